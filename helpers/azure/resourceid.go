@@ -174,3 +174,169 @@ func (id *ResourceID) ValidateNoEmptySegments(sourceId string) error {
 
 	return fmt.Errorf("ID contained more segments than required: %q, %v", sourceId, id.Path)
 }
+
+// ResourceIDSegment is a single key/value pair within a Resource ID, for example `resourceGroups/resGroup1`
+type ResourceIDSegment struct {
+	Key   string
+	Value string
+}
+
+// SplitAzureResourceID splits a long-form Azure Resource Manager ID into its key/value pairs. Unlike
+// ParseAzureResourceID the order of (and any repeated) keys is retained, so that the Resource ID can
+// be rebuilt using FormatAzureResourceID.
+func SplitAzureResourceID(id string) ([]ResourceIDSegment, error) {
+	idURL, err := url.ParseRequestURI(id)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot parse Azure ID: %s", err)
+	}
+
+	path := idURL.Path
+
+	path = strings.TrimPrefix(path, "/")
+	path = strings.TrimSuffix(path, "/")
+
+	components := strings.Split(path, "/")
+
+	// We should have an even number of key-value pairs.
+	if len(components)%2 != 0 {
+		return nil, fmt.Errorf("The number of path segments is not divisible by 2 in %q", path)
+	}
+
+	segments := make([]ResourceIDSegment, 0, len(components)/2)
+	for current := 0; current < len(components); current += 2 {
+		key := components[current]
+		value := components[current+1]
+
+		// Check key/value for empty strings.
+		if key == "" || value == "" {
+			return nil, fmt.Errorf("Key/Value cannot be empty strings. Key: '%s', Value: '%s'", key, value)
+		}
+
+		segments = append(segments, ResourceIDSegment{
+			Key:   key,
+			Value: value,
+		})
+	}
+
+	return segments, nil
+}
+
+// FormatAzureResourceID builds a long-form Azure Resource Manager ID from the ordered key/value pairs `segments`
+func FormatAzureResourceID(segments []ResourceIDSegment) string {
+	components := make([]string, 0, len(segments)*2)
+	for _, segment := range segments {
+		components = append(components, segment.Key, segment.Value)
+	}
+
+	return "/" + strings.Join(components, "/")
+}
+
+// ResourceIDNormaliser corrects the casing of the keys (and Resource Provider namespaces) within a
+// Resource ID, using the casing defined in a set of known Resource IDs.
+//
+// Only the casing of the keys and Resource Provider namespaces is corrected, since the values (e.g. the
+// name of a Resource Group) are user-specified - and keys which aren't known are left as-is.
+type ResourceIDNormaliser struct {
+	// keys is a map of `{lower-cased namespace}/{lower-cased key}` to the canonical casing of the key,
+	// where keys which appear prior to a Resource Provider have an empty namespace
+	keys map[string]string
+
+	// namespaces is a map of the lower-cased Resource Provider namespace to the canonical casing
+	namespaces map[string]string
+}
+
+// NewResourceIDNormaliser returns a ResourceIDNormaliser which uses the casing from the Resource IDs
+// `knownIds` - where a key is cased differently across these, the most common casing is used.
+func NewResourceIDNormaliser(knownIds []string) ResourceIDNormaliser {
+	keyCounts := make(map[string]map[string]int)
+	namespaceCounts := make(map[string]map[string]int)
+	increment := func(counts map[string]map[string]int, key, value string) {
+		if _, ok := counts[key]; !ok {
+			counts[key] = make(map[string]int)
+		}
+		counts[key][value]++
+	}
+
+	// these are present in every Resource ID, so are always cased consistently
+	for _, key := range []string{"subscriptions", "resourceGroups", "providers"} {
+		increment(keyCounts, "/"+strings.ToLower(key), key)
+	}
+
+	for _, id := range knownIds {
+		segments, err := SplitAzureResourceID(id)
+		if err != nil {
+			continue
+		}
+
+		namespace := ""
+		for _, segment := range segments {
+			if strings.EqualFold(segment.Key, "providers") {
+				namespace = segment.Value
+				increment(namespaceCounts, strings.ToLower(segment.Value), segment.Value)
+				continue
+			}
+			increment(keyCounts, strings.ToLower(namespace)+"/"+strings.ToLower(segment.Key), segment.Key)
+		}
+	}
+
+	mostCommon := func(input map[string]map[string]int) map[string]string {
+		output := make(map[string]string, len(input))
+		for key, casings := range input {
+			best := ""
+			for casing, count := range casings {
+				// ties are broken by the lexical ordering, so that the result is deterministic
+				if best == "" || count > casings[best] || (count == casings[best] && casing < best) {
+					best = casing
+				}
+			}
+			output[key] = best
+		}
+		return output
+	}
+
+	return ResourceIDNormaliser{
+		keys:       mostCommon(keyCounts),
+		namespaces: mostCommon(namespaceCounts),
+	}
+}
+
+// Normalise returns the Resource ID `id` with the keys and Resource Provider namespaces correctly cased
+func (n ResourceIDNormaliser) Normalise(id string) (string, error) {
+	segments, err := n.NormaliseSegments(id)
+	if err != nil {
+		return "", err
+	}
+
+	return FormatAzureResourceID(segments), nil
+}
+
+// NormaliseSegments splits the Resource ID `id` into its key/value pairs, with the keys and Resource
+// Provider namespaces correctly cased
+func (n ResourceIDNormaliser) NormaliseSegments(id string) ([]ResourceIDSegment, error) {
+	segments, err := SplitAzureResourceID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := ""
+	for i, segment := range segments {
+		if strings.EqualFold(segment.Key, "providers") {
+			segment.Key = "providers"
+			if v, ok := n.namespaces[strings.ToLower(segment.Value)]; ok {
+				segment.Value = v
+			}
+			namespace = segment.Value
+			segments[i] = segment
+			continue
+		}
+
+		if v, ok := n.keys[strings.ToLower(namespace)+"/"+strings.ToLower(segment.Key)]; ok {
+			segment.Key = v
+		} else if v, ok := n.keys["/"+strings.ToLower(segment.Key)]; ok {
+			segment.Key = v
+		}
+		segments[i] = segment
+	}
+
+	return segments, nil
+}
@@ -270,3 +270,115 @@ func TestParseAzureResourceIDWithoutSubscription(t *testing.T) {
 		}
 	}
 }
+
+func TestSplitAzureResourceID(t *testing.T) {
+	testCases := []struct {
+		id               string
+		expectedSegments []azure.ResourceIDSegment
+		expectError      bool
+	}{
+		{
+			id:          "",
+			expectError: true,
+		},
+		{
+			id:          "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups",
+			expectError: true,
+		},
+		{
+			id:          "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups//",
+			expectError: true,
+		},
+		{
+			id: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.ServiceBus/namespaces/namespace1/topics/topic1/subscriptions/subscription1",
+			expectedSegments: []azure.ResourceIDSegment{
+				{Key: "subscriptions", Value: "00000000-0000-0000-0000-000000000000"},
+				{Key: "resourceGroups", Value: "group1"},
+				{Key: "providers", Value: "Microsoft.ServiceBus"},
+				{Key: "namespaces", Value: "namespace1"},
+				{Key: "topics", Value: "topic1"},
+				{Key: "subscriptions", Value: "subscription1"},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Logf("[DEBUG] Testing %q", test.id)
+		segments, err := azure.SplitAzureResourceID(test.id)
+		if test.expectError {
+			if err == nil {
+				t.Fatalf("Expected an error but didn't get one")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error: %+v", err)
+		}
+
+		if !reflect.DeepEqual(test.expectedSegments, segments) {
+			t.Fatalf("Unexpected segments:\nExpected: %+v\nGot:      %+v\n", test.expectedSegments, segments)
+		}
+
+		if actual := azure.FormatAzureResourceID(segments); actual != test.id {
+			t.Fatalf("Expected the formatted Resource ID to be %q but got %q", test.id, actual)
+		}
+	}
+}
+
+func TestResourceIDNormaliser(t *testing.T) {
+	normaliser := azure.NewResourceIDNormaliser([]string{
+		"/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Network/virtualNetworks/network1/subnets/subnet1",
+		"/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Network/publicIPAddresses/address1",
+		"/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Network/publicIPAddresses/address2",
+		"/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Network/publicIpAddresses/address3",
+		"/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Storage/storageAccounts/account1/providers/Microsoft.Authorization/roleAssignments/assignment1",
+	})
+
+	testCases := []struct {
+		id          string
+		expected    string
+		expectError bool
+	}{
+		{
+			id:          "not-a-resource-id",
+			expectError: true,
+		},
+		{
+			// the values are user-specified so should be left as-is
+			id:       "/SUBSCRIPTIONS/12345678-1234-9876-4563-123456789012/RESOURCEGROUPS/ResGroup1/PROVIDERS/microsoft.network/VIRTUALNETWORKS/Network1/SUBNETS/Subnet1",
+			expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/ResGroup1/providers/Microsoft.Network/virtualNetworks/Network1/subnets/Subnet1",
+		},
+		{
+			// the most common casing should be used
+			id:       "/subscriptions/12345678-1234-9876-4563-123456789012/resourcegroups/resGroup1/providers/Microsoft.Network/publicipaddresses/address1",
+			expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Network/publicIPAddresses/address1",
+		},
+		{
+			id:       "/subscriptions/12345678-1234-9876-4563-123456789012/resourcegroups/resGroup1/providers/microsoft.storage/storageaccounts/account1/providers/microsoft.authorization/roleassignments/assignment1",
+			expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Storage/storageAccounts/account1/providers/Microsoft.Authorization/roleAssignments/assignment1",
+		},
+		{
+			// unknown keys and Resource Providers are left as-is
+			id:       "/subscriptions/12345678-1234-9876-4563-123456789012/resourcegroups/resGroup1/providers/Microsoft.Example/someThings/thing1",
+			expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Example/someThings/thing1",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Logf("[DEBUG] Testing %q", test.id)
+		actual, err := normaliser.Normalise(test.id)
+		if test.expectError {
+			if err == nil {
+				t.Fatalf("Expected an error but didn't get one")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error: %+v", err)
+		}
+
+		if actual != test.expected {
+			t.Fatalf("Expected %q but got %q", test.expected, actual)
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/recording"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 )
 
@@ -60,6 +61,9 @@ type TestData struct {
 
 	// resourceLabel is the local used for the resource - generally "test""
 	resourceLabel string
+
+	// recorder records (or replays) the requests sent for this test, when enabled using `ARM_TEST_RECORDING_MODE`
+	recorder *recording.Recorder
 }
 
 // BuildTestData generates some test data for the given resource
@@ -69,9 +73,12 @@ func BuildTestData(t *testing.T, resourceType string, resourceLabel string) Test
 		t.Fatalf("Error retrieving Environment: %+v", err)
 	}
 
+	recorder, err := newRecorder(t)
+	if err != nil {
+		t.Fatalf("building Recorder: %+v", err)
+	}
+
 	testData := TestData{
-		RandomInteger:   RandTimeInt(),
-		RandomString:    randString(5),
 		ResourceName:    fmt.Sprintf("%s.%s", resourceType, resourceLabel),
 		Environment:     *env,
 		EnvironmentName: EnvironmentName(),
@@ -79,21 +86,37 @@ func BuildTestData(t *testing.T, resourceType string, resourceLabel string) Test
 
 		ResourceType:  resourceType,
 		resourceLabel: resourceLabel,
+		recorder:      recorder,
 	}
 
+	// when recording/replaying, these values are taken from the recording so that the requests are identical
+	testData.RandomInteger = testData.nextRecordedInteger("random_integer", RandTimeInt)
+	testData.RandomString = testData.nextRecordedString("random_string", func() string {
+		return randString(5)
+	})
+	var locations Regions
 	if features.UseDynamicTestLocations() {
-		testData.Locations = availableLocations()
+		locations = availableLocations()
 	} else {
-		testData.Locations = Regions{
+		locations = Regions{
 			Primary:   os.Getenv("ARM_TEST_LOCATION"),
 			Secondary: os.Getenv("ARM_TEST_LOCATION_ALT"),
 			Ternary:   os.Getenv("ARM_TEST_LOCATION_ALT2"),
 		}
 	}
+	testData.Locations = Regions{
+		Primary:   testData.recordedString("location_primary", func() string { return locations.Primary }),
+		Secondary: testData.recordedString("location_secondary", func() string { return locations.Secondary }),
+		Ternary:   testData.recordedString("location_ternary", func() string { return locations.Ternary }),
+	}
 
 	testData.Subscriptions = Subscriptions{
-		Primary:   os.Getenv("ARM_SUBSCRIPTION_ID"),
-		Secondary: os.Getenv("ARM_TEST_SUBSCRIPTION_ID_ALT"),
+		Primary: testData.recordedString(recording.VariableSubscriptionId, func() string {
+			return os.Getenv("ARM_SUBSCRIPTION_ID")
+		}),
+		Secondary: testData.recordedString("subscription_id_secondary", func() string {
+			return os.Getenv("ARM_TEST_SUBSCRIPTION_ID_ALT")
+		}),
 	}
 
 	return testData
@@ -134,7 +157,9 @@ func (td *TestData) RandomStringOfLength(len int) string {
 		panic("Invalid Test: RandomStringOfLength: length argument must be between 1 and 1024 characters")
 	}
 
-	return randString(len)
+	return td.nextRecordedString(fmt.Sprintf("random_string_of_length_%d", len), func() string {
+		return randString(len)
+	})
 }
 
 // randString generates a random alphanumeric string of the length specified
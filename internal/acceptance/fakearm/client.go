@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fakearm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+)
+
+// Client returns the Provider's Client, where all requests sent by the API Clients are sent to the Server
+func (s *Server) Client(t *testing.T) *clients.Client {
+	t.Helper()
+
+	// Enhanced Validation retrieves the available Locations directly from Resource Manager
+	t.Setenv("ARM_PROVIDER_ENHANCED_VALIDATION", "false")
+
+	builder := clients.ClientBuilder{
+		AuthConfig: &auth.Credentials{
+			Environment: *environments.AzurePublic(),
+			ClientID:    s.ClientId,
+			TenantID:    s.TenantId,
+		},
+		Features:                 features.Default(),
+		SkipProviderRegistration: true,
+		SubscriptionID:           s.SubscriptionId,
+		Recorder:                 s,
+	}
+
+	client, err := clients.Build(context.Background(), builder)
+	if err != nil {
+		t.Fatalf("building Client: %+v", err)
+	}
+
+	return client
+}
@@ -0,0 +1,140 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fakearm
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/go-azure-sdk/sdk/claims"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"golang.org/x/oauth2"
+)
+
+// headerOriginalBaseUri contains the scheme and host the request was sent to, prior to being redirected to the Server
+const headerOriginalBaseUri = "X-Fakearm-Original-Base-Uri"
+
+type originalUrlKey struct{}
+
+var _ common.Recorder = &Server{}
+
+// RequestMiddleware redirects requests sent by clients based on hashicorp/go-azure-sdk to the Server
+func (s *Server) RequestMiddleware() client.RequestMiddleware {
+	return func(request *http.Request) (*http.Request, error) {
+		return s.redirect(request), nil
+	}
+}
+
+// ResponseMiddleware restores the original URL for the request, since Pollers use this to determine where to poll
+func (s *Server) ResponseMiddleware() client.ResponseMiddleware {
+	return func(_ *http.Request, response *http.Response) (*http.Response, error) {
+		if response != nil {
+			restoreOriginalUrl(response)
+		}
+		return response, nil
+	}
+}
+
+// SendDecorator redirects requests sent by clients based on Azure/go-autorest to the Server
+func (s *Server) SendDecorator() autorest.SendDecorator {
+	return func(sender autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(request *http.Request) (*http.Response, error) {
+			response, err := sender.Do(s.redirect(request))
+			if response != nil {
+				restoreOriginalUrl(response)
+			}
+			return response, err
+		})
+	}
+}
+
+// ReplayCredentials returns the credentials used in place of those configured, since requests are sent to the Server
+func (s *Server) ReplayCredentials() *common.ReplayCredentials {
+	return &common.ReplayCredentials{
+		Authorizer:     authorizer{server: s},
+		SubscriptionId: s.SubscriptionId,
+	}
+}
+
+func (s *Server) redirect(request *http.Request) *http.Request {
+	originalUrl := request.URL
+	request = request.WithContext(context.WithValue(request.Context(), originalUrlKey{}, originalUrl))
+
+	redirectedUrl := *request.URL
+	redirectedUrl.Scheme = s.serverUrl.Scheme
+	redirectedUrl.Host = s.serverUrl.Host
+	request.URL = &redirectedUrl
+	request.Host = ""
+	request.Header.Set(headerOriginalBaseUri, fmt.Sprintf("%s://%s", originalUrl.Scheme, originalUrl.Host))
+
+	return request
+}
+
+func restoreOriginalUrl(response *http.Response) {
+	if response.Request == nil {
+		return
+	}
+	originalUrl, ok := response.Request.Context().Value(originalUrlKey{}).(*url.URL)
+	if !ok {
+		return
+	}
+
+	originalRequest := response.Request.Clone(response.Request.Context())
+	originalRequest.URL = originalUrl
+	originalRequest.Header.Del(headerOriginalBaseUri)
+	response.Request = originalRequest
+}
+
+// originalBaseUri returns the scheme and host which the request was originally sent to
+func originalBaseUri(request *http.Request, fallback string) string {
+	if v := request.Header.Get(headerOriginalBaseUri); v != "" {
+		return v
+	}
+	return fallback
+}
+
+var _ auth.Authorizer = authorizer{}
+
+// authorizer issues an (unsigned) token containing the claims for the principal configured on the Server,
+// since the Provider inspects these claims to determine the authenticated principal
+type authorizer struct {
+	server *Server
+}
+
+func (a authorizer) Token(_ context.Context, _ *http.Request) (*oauth2.Token, error) {
+	header, err := json.Marshal(map[string]string{
+		"alg": "none",
+		"typ": "JWT",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(claims.Claims{
+		AppId:    a.server.ClientId,
+		ObjectId: a.server.ObjectId,
+		TenantId: a.server.TenantId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &oauth2.Token{
+		AccessToken: fmt.Sprintf("%s.%s.fakearm", base64.RawURLEncoding.EncodeToString(header), base64.RawURLEncoding.EncodeToString(payload)),
+		TokenType:   "Bearer",
+		Expiry:      time.Now().Add(time.Hour),
+	}, nil
+}
+
+func (a authorizer) AuxiliaryTokens(_ context.Context, _ *http.Request) ([]*oauth2.Token, error) {
+	return []*oauth2.Token{}, nil
+}
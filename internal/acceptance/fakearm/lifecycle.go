@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fakearm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// Lifecycle runs the Create, Read, Update and Delete functions for a typed Resource against a Server
+type Lifecycle struct {
+	client   *clients.Client
+	resource sdk.Resource
+	schema   *schema.Resource
+	t        *testing.T
+}
+
+// Lifecycle returns a Lifecycle for the typed Resource, using a Client which sends all requests to the Server
+func (s *Server) Lifecycle(t *testing.T, resource sdk.Resource) *Lifecycle {
+	t.Helper()
+
+	wrapper := sdk.NewResourceWrapper(resource)
+	resourceSchema, err := wrapper.Resource()
+	if err != nil {
+		t.Fatalf("building the Schema for %q: %+v", resource.ResourceType(), err)
+	}
+
+	return &Lifecycle{
+		client:   s.Client(t),
+		resource: resource,
+		schema:   resourceSchema,
+		t:        t,
+	}
+}
+
+// Create runs the Create (and then Read) functions for the Resource using the specified configuration,
+// returning the resulting state
+func (l *Lifecycle) Create(config map[string]interface{}) *pluginsdk.ResourceData {
+	l.t.Helper()
+
+	d := schema.TestResourceDataRaw(l.t, l.schema.Schema, config)
+	l.run("Create", l.resource.Create().Timeout, l.schema.CreateContext, d)
+	return d
+}
+
+// Read runs the Read function for the Resource with the specified ID, returning the resulting state - where
+// the ID is empty when the Resource no longer exists
+func (l *Lifecycle) Read(id string) *pluginsdk.ResourceData {
+	l.t.Helper()
+
+	d := schema.TestResourceDataRaw(l.t, l.schema.Schema, map[string]interface{}{})
+	d.SetId(id)
+	l.run("Read", l.resource.Read().Timeout, l.schema.ReadContext, d)
+	return d
+}
+
+// Update runs the Update (and then Read) functions for the Resource with the specified ID using the specified
+// configuration, returning the resulting state. Since the configuration is compared to an empty state, every
+// field within the configuration is considered to have changed.
+func (l *Lifecycle) Update(id string, config map[string]interface{}) *pluginsdk.ResourceData {
+	l.t.Helper()
+
+	v, ok := l.resource.(sdk.ResourceWithUpdate)
+	if !ok {
+		l.t.Fatalf("%q doesn't support being updated", l.resource.ResourceType())
+	}
+
+	d := schema.TestResourceDataRaw(l.t, l.schema.Schema, config)
+	d.SetId(id)
+	l.run("Update", v.Update().Timeout, l.schema.UpdateContext, d)
+	return d
+}
+
+// Delete runs the Delete function for the Resource with the specified ID
+func (l *Lifecycle) Delete(id string) {
+	l.t.Helper()
+
+	d := schema.TestResourceDataRaw(l.t, l.schema.Schema, map[string]interface{}{})
+	d.SetId(id)
+	l.run("Delete", l.resource.Delete().Timeout, l.schema.DeleteContext, d)
+}
+
+func (l *Lifecycle) run(name string, timeout time.Duration, f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics, d *pluginsdk.ResourceData) {
+	l.t.Helper()
+
+	// Pollers require a deadline, which Terraform would otherwise set based on the Timeout for the function
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	for _, diagnostic := range f(ctx, d, l.client) {
+		if diagnostic.Severity == diag.Error {
+			l.t.Fatalf("running %s for %q: %s: %s", name, l.resource.ResourceType(), diagnostic.Summary, diagnostic.Detail)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fakearm_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/fakearm"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/communication"
+)
+
+func TestLifecycle(t *testing.T) {
+	server := fakearm.NewServer(t, fakearm.Options{})
+	server.Seed("/subscriptions/"+server.SubscriptionId+"/resourceGroups/example", map[string]interface{}{
+		"location": "westeurope",
+	})
+	lifecycle := server.Lifecycle(t, communication.EmailCommunicationServiceResource{})
+
+	d := lifecycle.Create(map[string]interface{}{
+		"name":                "example",
+		"resource_group_name": "example",
+		"data_location":       "Europe",
+	})
+	id := d.Id()
+	if id == "" {
+		t.Fatalf("expected an ID to be set")
+	}
+	if !server.Exists(id) {
+		t.Fatalf("expected %q to exist", id)
+	}
+
+	d = lifecycle.Update(id, map[string]interface{}{
+		"name":                "example",
+		"resource_group_name": "example",
+		"data_location":       "Europe",
+		"tags": map[string]interface{}{
+			"hello": "world",
+		},
+	})
+	if v := d.Get("tags.hello").(string); v != "world" {
+		t.Fatalf("expected the tag `hello` to be `world` but got %q", v)
+	}
+
+	lifecycle.Delete(id)
+	if server.Exists(id) {
+		t.Fatalf("expected %q to have been deleted", id)
+	}
+	if d = lifecycle.Read(id); d.Id() != "" {
+		t.Fatalf("expected %q to have been removed from the state", id)
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fakearm
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/go-uuid"
+)
+
+const (
+	// operationsPathPrefix is the (lower-cased) prefix for the URIs used to poll operations
+	operationsPathPrefix = "/providers/fakearm/"
+
+	operationStatusInProgress = "InProgress"
+	operationStatusSucceeded  = "Succeeded"
+)
+
+type operationKind string
+
+const (
+	operationKindCreate operationKind = "Create"
+	operationKindDelete operationKind = "Delete"
+	operationKindUpdate operationKind = "Update"
+)
+
+type operation struct {
+	id             string
+	kind           operationKind
+	resourceId     string
+	remainingPolls int
+	status         string
+}
+
+func (s *Server) newOperation(kind operationKind, resourceId string) *operation {
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		// this is only used as an identifier, so the number of operations is sufficient
+		id = fmt.Sprintf("operation-%d", len(s.operations))
+	}
+
+	op := &operation{
+		id:             id,
+		kind:           kind,
+		resourceId:     resourceId,
+		remainingPolls: s.options.PollsUntilComplete,
+		status:         operationStatusInProgress,
+	}
+	s.operations[id] = op
+	return op
+}
+
+// pollOperation progresses the operation, completing it once it has been polled enough times
+func (s *Server) pollOperation(op *operation) {
+	if op.status != operationStatusInProgress {
+		return
+	}
+	if op.remainingPolls > 0 {
+		op.remainingPolls--
+		return
+	}
+
+	op.status = operationStatusSucceeded
+
+	key := resourceKey(op.resourceId)
+	existing, ok := s.resources[key]
+	if !ok || existing.operation != op {
+		// the Resource has since been replaced or removed
+		return
+	}
+
+	existing.operation = nil
+	if op.kind == operationKindDelete {
+		delete(s.resources, key)
+		return
+	}
+	existing.setProvisioningState(provisioningStateSucceeded)
+}
+
+// writeOperationHeaders writes the headers used by clients to poll the operation
+func (s *Server) writeOperationHeaders(w http.ResponseWriter, r *http.Request, op *operation) {
+	baseUri := originalBaseUri(r, s.server.URL)
+	query := ""
+	if apiVersion := r.URL.Query().Get("api-version"); apiVersion != "" {
+		query = fmt.Sprintf("?api-version=%s", apiVersion)
+	}
+
+	if s.options.LongRunningOperations == LongRunningOperationAzureAsyncOperation {
+		w.Header().Set("Azure-AsyncOperation", fmt.Sprintf("%s/providers/fakeArm/operations/%s%s", baseUri, op.id, query))
+	}
+	w.Header().Set("Location", fmt.Sprintf("%s/providers/fakeArm/operationResults/%s%s", baseUri, op.id, query))
+
+	// the Server is in-memory, so there's no need for clients to wait between polls
+	w.Header().Set("Retry-After", "0")
+}
+
+func (s *Server) serveOperation(w http.ResponseWriter, r *http.Request, path string) {
+	segments := segmentsFor(strings.TrimPrefix(strings.ToLower(path), operationsPathPrefix))
+	if r.Method != http.MethodGet || len(segments) != 2 {
+		writeError(w, http.StatusBadRequest, "InvalidOperation", "%s %q is not a valid operation", r.Method, path)
+		return
+	}
+
+	op, ok := s.operations[segments[1]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "OperationNotFound", "the operation %q was not found", segments[1])
+		return
+	}
+	s.pollOperation(op)
+
+	switch segments[0] {
+	case "operations":
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"id":     path,
+			"name":   op.id,
+			"status": op.status,
+		})
+
+	case "operationresults":
+		if op.status == operationStatusInProgress {
+			s.writeOperationHeaders(w, r, op)
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+
+		existing, ok := s.resources[resourceKey(op.resourceId)]
+		if op.kind == operationKindDelete || !ok {
+			// an empty 200 OK signifies that the operation has completed
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		writeJSON(w, http.StatusOK, existing.body)
+
+	default:
+		writeError(w, http.StatusNotFound, "OperationNotFound", "%q is not a valid operation", path)
+	}
+}
@@ -0,0 +1,335 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fakearm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const (
+	provisioningStateCreating  = "Creating"
+	provisioningStateDeleting  = "Deleting"
+	provisioningStateSucceeded = "Succeeded"
+	provisioningStateUpdating  = "Updating"
+)
+
+type resource struct {
+	id   string
+	body map[string]interface{}
+
+	// operation is the operation in progress for this Resource, if any
+	operation *operation
+}
+
+func (r *resource) copyBody() map[string]interface{} {
+	encoded, _ := json.Marshal(r.body)
+	out := make(map[string]interface{})
+	_ = json.Unmarshal(encoded, &out)
+	return out
+}
+
+func (r *resource) setProvisioningState(state string) {
+	properties, ok := r.body["properties"].(map[string]interface{})
+	if !ok {
+		properties = make(map[string]interface{})
+		r.body["properties"] = properties
+	}
+	properties["provisioningState"] = state
+}
+
+// resourceKey returns the key used to store the Resource, since Resource IDs are case-insensitive
+func resourceKey(id string) string {
+	return strings.ToLower(strings.TrimSuffix(id, "/"))
+}
+
+func segmentsFor(id string) []string {
+	trimmed := strings.Trim(id, "/")
+	if trimmed == "" {
+		return []string{}
+	}
+	return strings.Split(trimmed, "/")
+}
+
+// isCollection returns whether the path refers to a collection of Resources (e.g. `/subscriptions/{id}/resourceGroups`)
+// rather than to a single Resource, since each Resource is made up of key/value pairs
+func isCollection(path string) bool {
+	return len(segmentsFor(path))%2 == 1
+}
+
+// parentIdFor returns the ID of the Resource which must exist for the Resource to be created, if any
+func parentIdFor(id string) string {
+	segments := segmentsFor(id)
+	if len(segments) <= 2 {
+		return ""
+	}
+
+	segments = segments[:len(segments)-2]
+	if len(segments) >= 2 && strings.EqualFold(segments[len(segments)-2], "providers") {
+		segments = segments[:len(segments)-2]
+	}
+
+	// Resources at the Subscription (or Tenant) scope don't have a parent
+	if len(segments) <= 2 {
+		return ""
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// resourceTypeFor returns the Resource Type for the Resource ID, e.g. `Microsoft.Resources/resourceGroups`
+func resourceTypeFor(id string) string {
+	segments := segmentsFor(id)
+
+	namespace := "Microsoft.Resources"
+	types := make([]string, 0)
+	start := 2
+	for i := len(segments) - 2; i >= 0; i-- {
+		if strings.EqualFold(segments[i], "providers") {
+			namespace = segments[i+1]
+			start = i + 2
+			break
+		}
+	}
+	for i := start; i < len(segments); i += 2 {
+		types = append(types, segments[i])
+	}
+	if len(types) == 0 && len(segments) > 0 {
+		types = append(types, segments[0])
+	}
+
+	return fmt.Sprintf("%s/%s", namespace, strings.Join(types, "/"))
+}
+
+func (s *Server) putResource(id string, body map[string]interface{}, provisioningState string) *resource {
+	segments := segmentsFor(id)
+	id = "/" + strings.Join(segments, "/")
+
+	body["id"] = id
+	body["name"] = segments[len(segments)-1]
+	body["type"] = resourceTypeFor(id)
+
+	r := &resource{
+		id:   id,
+		body: body,
+	}
+	r.setProvisioningState(provisioningState)
+	s.resources[resourceKey(id)] = r
+	return r
+}
+
+func (s *Server) getResource(w http.ResponseWriter, path string) {
+	if isCollection(path) {
+		s.listResources(w, path)
+		return
+	}
+
+	existing, ok := s.resources[resourceKey(path)]
+	if ok && existing.operation != nil {
+		// retrieving the Resource counts as polling the operation, since the Delete poller polls the Resource
+		s.pollOperation(existing.operation)
+		existing, ok = s.resources[resourceKey(path)]
+	}
+	if !ok {
+		writeNotFound(w, path)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, existing.body)
+}
+
+func (s *Server) listResources(w http.ResponseWriter, path string) {
+	collection := resourceKey(path)
+
+	ids := make([]string, 0)
+	for key := range s.resources {
+		if key[:strings.LastIndex(key, "/")] == collection {
+			ids = append(ids, key)
+		}
+	}
+	sort.Strings(ids)
+
+	values := make([]interface{}, 0)
+	for _, key := range ids {
+		values = append(values, s.resources[key].body)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"value": values,
+	})
+}
+
+func (s *Server) headResource(w http.ResponseWriter, path string) {
+	if _, ok := s.resources[resourceKey(path)]; !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) createOrUpdateResource(w http.ResponseWriter, r *http.Request, path string) {
+	if isCollection(path) {
+		writeError(w, http.StatusBadRequest, "InvalidResourceId", "%q is not a valid Resource ID", path)
+		return
+	}
+
+	body, err := readObject(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequestContent", "parsing the request body: %+v", err)
+		return
+	}
+
+	if parentId := parentIdFor(path); parentId != "" {
+		if _, ok := s.resources[resourceKey(parentId)]; !ok {
+			code := "ParentResourceNotFound"
+			if strings.EqualFold(resourceTypeFor(parentId), "Microsoft.Resources/resourceGroups") {
+				code = "ResourceGroupNotFound"
+			}
+			writeError(w, http.StatusNotFound, code, "the parent Resource %q was not found", parentId)
+			return
+		}
+	}
+
+	_, exists := s.resources[resourceKey(path)]
+	statusCode := http.StatusCreated
+	kind := operationKindCreate
+	provisioningState := provisioningStateCreating
+	if exists {
+		statusCode = http.StatusOK
+		kind = operationKindUpdate
+		provisioningState = provisioningStateUpdating
+	}
+
+	if s.options.LongRunningOperations == LongRunningOperationNone {
+		updated := s.putResource(path, body, provisioningStateSucceeded)
+		writeJSON(w, statusCode, updated.body)
+		return
+	}
+
+	updated := s.putResource(path, body, provisioningState)
+	updated.operation = s.newOperation(kind, updated.id)
+	s.writeOperationHeaders(w, r, updated.operation)
+	writeJSON(w, statusCode, updated.body)
+}
+
+func (s *Server) patchResource(w http.ResponseWriter, r *http.Request, path string) {
+	existing, ok := s.resources[resourceKey(path)]
+	if !ok {
+		writeNotFound(w, path)
+		return
+	}
+
+	patch, err := readObject(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequestContent", "parsing the request body: %+v", err)
+		return
+	}
+
+	// the fields computed by Resource Manager can't be changed
+	delete(patch, "id")
+	delete(patch, "name")
+	delete(patch, "type")
+	mergePatch(existing.body, patch)
+
+	if s.options.LongRunningOperations == LongRunningOperationNone {
+		existing.setProvisioningState(provisioningStateSucceeded)
+		writeJSON(w, http.StatusOK, existing.body)
+		return
+	}
+
+	existing.setProvisioningState(provisioningStateUpdating)
+	existing.operation = s.newOperation(operationKindUpdate, existing.id)
+	s.writeOperationHeaders(w, r, existing.operation)
+	writeJSON(w, http.StatusOK, existing.body)
+}
+
+func (s *Server) deleteResource(w http.ResponseWriter, r *http.Request, path string) {
+	existing, ok := s.resources[resourceKey(path)]
+	if !ok {
+		// Resource Manager returns a 204 No Content when the Resource doesn't exist
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if s.options.LongRunningOperations == LongRunningOperationNone {
+		delete(s.resources, resourceKey(path))
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	existing.setProvisioningState(provisioningStateDeleting)
+	existing.operation = s.newOperation(operationKindDelete, existing.id)
+	s.writeOperationHeaders(w, r, existing.operation)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// mergePatch applies the patch to the target as a JSON Merge Patch (RFC 7386)
+func mergePatch(target map[string]interface{}, patch map[string]interface{}) {
+	for k, v := range patch {
+		if v == nil {
+			delete(target, k)
+			continue
+		}
+
+		patchValue, isObject := v.(map[string]interface{})
+		targetValue, targetIsObject := target[k].(map[string]interface{})
+		if isObject && targetIsObject {
+			mergePatch(targetValue, patchValue)
+			continue
+		}
+
+		target[k] = v
+	}
+}
+
+func readObject(r *http.Request) (map[string]interface{}, error) {
+	out := make(map[string]interface{})
+	if r.Body == nil {
+		return out, nil
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) == 0 {
+		return out, nil
+	}
+
+	if err := json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "InternalServerError", "marshaling the response: %+v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(encoded)
+}
+
+func writeNotFound(w http.ResponseWriter, id string) {
+	writeError(w, http.StatusNotFound, "ResourceNotFound", "the Resource %q was not found", id)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, code string, format string, args ...interface{}) {
+	encoded, _ := json.Marshal(map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": fmt.Sprintf(format, args...),
+		},
+	})
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(encoded)
+}
@@ -0,0 +1,170 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fakearm
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// LongRunningOperationStyle specifies how the Server reports the progress of a Create, Update or Delete
+type LongRunningOperationStyle string
+
+const (
+	// LongRunningOperationAzureAsyncOperation returns both the `Azure-AsyncOperation` and `Location` headers, where
+	// clients poll the `Azure-AsyncOperation` URI for the status of the operation
+	LongRunningOperationAzureAsyncOperation LongRunningOperationStyle = "Azure-AsyncOperation"
+
+	// LongRunningOperationLocation returns only the `Location` header, which returns a 202 Accepted whilst the
+	// operation is in progress - and the Resource (or an empty 200 OK for a Delete) once it has completed
+	LongRunningOperationLocation LongRunningOperationStyle = "Location"
+
+	// LongRunningOperationNone completes each operation immediately, returning the `provisioningState` of the Resource
+	LongRunningOperationNone LongRunningOperationStyle = "None"
+)
+
+// Options configures the behaviour of a Server
+type Options struct {
+	// LongRunningOperations specifies how the progress of an operation is reported, defaulting to
+	// LongRunningOperationAzureAsyncOperation
+	LongRunningOperations LongRunningOperationStyle
+
+	// PollsUntilComplete is the number of times an operation is reported as in progress before it completes.
+	// Both polling the operation and retrieving the Resource count as polling the operation.
+	PollsUntilComplete int
+}
+
+// Server is an in-memory emulation of Azure Resource Manager, which stores each Resource which is
+// created (using a PUT) so that this can be retrieved (GET), updated (PATCH) and deleted (DELETE).
+//
+// Requests sent by the Provider's API Clients are sent to the Server in place of Azure by using the
+// Server as the Recorder for the Client (see Client), allowing the Create, Read, Update and Delete
+// functions for a Resource to be run under `go test` without access to Azure.
+type Server struct {
+	ClientId       string
+	ObjectId       string
+	SubscriptionId string
+	TenantId       string
+
+	options   Options
+	server    *httptest.Server
+	serverUrl *url.URL
+	t         *testing.T
+
+	lock       sync.Mutex
+	operations map[string]*operation
+	resources  map[string]*resource
+}
+
+// NewServer starts a new Server, which is stopped once the test completes
+func NewServer(t *testing.T, options Options) *Server {
+	t.Helper()
+
+	if options.LongRunningOperations == "" {
+		options.LongRunningOperations = LongRunningOperationAzureAsyncOperation
+	}
+
+	s := &Server{
+		ClientId:       "11111111-1111-1111-1111-111111111111",
+		ObjectId:       "22222222-2222-2222-2222-222222222222",
+		SubscriptionId: "33333333-3333-3333-3333-333333333333",
+		TenantId:       "44444444-4444-4444-4444-444444444444",
+
+		options:    options,
+		operations: make(map[string]*operation),
+		resources:  make(map[string]*resource),
+		t:          t,
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.server.Close)
+
+	serverUrl, err := url.Parse(s.server.URL)
+	if err != nil {
+		t.Fatalf("parsing the URL for the Server %q: %+v", s.server.URL, err)
+	}
+	s.serverUrl = serverUrl
+
+	return s
+}
+
+// Seed stores the Resource with the specified ID, for example to create a Resource Group which the
+// Resource being tested can be created within
+func (s *Server) Seed(id string, model interface{}) {
+	s.t.Helper()
+
+	body, err := toObject(model)
+	if err != nil {
+		s.t.Fatalf("seeding %q: %+v", id, err)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.putResource(id, body, provisioningStateSucceeded)
+}
+
+// Get returns the (JSON) representation of the Resource with the specified ID, and whether it exists
+func (s *Server) Get(id string) (map[string]interface{}, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	existing, ok := s.resources[resourceKey(id)]
+	if !ok {
+		return nil, false
+	}
+	return existing.copyBody(), true
+}
+
+// Exists returns whether the Resource with the specified ID exists
+func (s *Server) Exists(id string) bool {
+	_, ok := s.Get(id)
+	return ok
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	if strings.HasPrefix(strings.ToLower(path), operationsPathPrefix) {
+		s.serveOperation(w, r, path)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.getResource(w, path)
+	case http.MethodHead:
+		s.headResource(w, path)
+	case http.MethodPut:
+		s.createOrUpdateResource(w, r, path)
+	case http.MethodPatch:
+		s.patchResource(w, r, path)
+	case http.MethodDelete:
+		s.deleteResource(w, r, path)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "the method %q is not supported for %q", r.Method, path)
+	}
+}
+
+// toObject converts the model into its (JSON) representation
+func toObject(model interface{}) (map[string]interface{}, error) {
+	if model == nil {
+		return map[string]interface{}{}, nil
+	}
+
+	encoded, err := json.Marshal(model)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]interface{})
+	if err := json.Unmarshal(encoded, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
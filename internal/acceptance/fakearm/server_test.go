@@ -0,0 +1,193 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fakearm
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+const (
+	testResourceGroupId = "/subscriptions/33333333-3333-3333-3333-333333333333/resourceGroups/example"
+	testResourceId      = testResourceGroupId + "/providers/Microsoft.Example/things/thing1"
+)
+
+func sendRequest(t *testing.T, s *Server, method, path string, body interface{}) (*http.Response, map[string]interface{}) {
+	t.Helper()
+
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			t.Fatalf("marshaling request: %+v", err)
+		}
+	}
+
+	req, err := http.NewRequest(method, s.server.URL+path+"?api-version=2020-01-01", bytes.NewReader(payload))
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	out := make(map[string]interface{})
+	_ = json.NewDecoder(resp.Body).Decode(&out)
+	return resp, out
+}
+
+func provisioningStateOf(body map[string]interface{}) string {
+	properties, _ := body["properties"].(map[string]interface{})
+	v, _ := properties["provisioningState"].(string)
+	return v
+}
+
+func TestServerLifecycle(t *testing.T) {
+	s := NewServer(t, Options{
+		LongRunningOperations: LongRunningOperationNone,
+	})
+
+	resp, body := sendRequest(t, s, http.MethodPut, testResourceId, map[string]interface{}{})
+	if resp.StatusCode != http.StatusNotFound || body["error"].(map[string]interface{})["code"] != "ResourceGroupNotFound" {
+		t.Fatalf("expected a 404 ResourceGroupNotFound but got %d: %+v", resp.StatusCode, body)
+	}
+
+	s.Seed(testResourceGroupId, map[string]interface{}{
+		"location": "westeurope",
+	})
+
+	resp, body = sendRequest(t, s, http.MethodPut, testResourceId, map[string]interface{}{
+		"location": "westeurope",
+		"properties": map[string]interface{}{
+			"size": "Small",
+		},
+	})
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected a 201 but got %d: %+v", resp.StatusCode, body)
+	}
+	if body["type"] != "Microsoft.Example/things" || body["name"] != "thing1" || provisioningStateOf(body) != "Succeeded" {
+		t.Fatalf("unexpected response: %+v", body)
+	}
+
+	resp, body = sendRequest(t, s, http.MethodPatch, testResourceId, map[string]interface{}{
+		"tags": map[string]interface{}{
+			"hello": "world",
+		},
+	})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected a 200 but got %d: %+v", resp.StatusCode, body)
+	}
+
+	// Resource IDs are case-insensitive
+	resp, body = sendRequest(t, s, http.MethodGet, "/SUBSCRIPTIONS/33333333-3333-3333-3333-333333333333/resourcegroups/EXAMPLE/providers/microsoft.example/things/THING1", nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected a 200 but got %d: %+v", resp.StatusCode, body)
+	}
+	if body["properties"].(map[string]interface{})["size"] != "Small" || body["tags"].(map[string]interface{})["hello"] != "world" {
+		t.Fatalf("expected the PATCH to be merged but got: %+v", body)
+	}
+
+	resp, body = sendRequest(t, s, http.MethodGet, testResourceGroupId+"/providers/Microsoft.Example/things", nil)
+	if values, _ := body["value"].([]interface{}); resp.StatusCode != http.StatusOK || len(values) != 1 {
+		t.Fatalf("expected a 200 listing a single Resource but got %d: %+v", resp.StatusCode, body)
+	}
+
+	if resp, _ = sendRequest(t, s, http.MethodDelete, testResourceId, nil); resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected a 200 but got %d", resp.StatusCode)
+	}
+	if resp, _ = sendRequest(t, s, http.MethodGet, testResourceId, nil); resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected a 404 after deletion but got %d", resp.StatusCode)
+	}
+	if resp, _ = sendRequest(t, s, http.MethodDelete, testResourceId, nil); resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("expected a 204 when deleting a Resource which doesn't exist but got %d", resp.StatusCode)
+	}
+}
+
+func TestServerLongRunningOperations(t *testing.T) {
+	testData := []struct {
+		style          LongRunningOperationStyle
+		pollingHeader  string
+		inProgressCode int
+	}{
+		{
+			style:          LongRunningOperationAzureAsyncOperation,
+			pollingHeader:  "Azure-AsyncOperation",
+			inProgressCode: http.StatusOK,
+		},
+		{
+			style:          LongRunningOperationLocation,
+			pollingHeader:  "Location",
+			inProgressCode: http.StatusAccepted,
+		},
+	}
+
+	for _, v := range testData {
+		t.Run(string(v.style), func(t *testing.T) {
+			s := NewServer(t, Options{
+				LongRunningOperations: v.style,
+				PollsUntilComplete:    1,
+			})
+			s.Seed(testResourceGroupId, nil)
+
+			resp, body := sendRequest(t, s, http.MethodPut, testResourceId, map[string]interface{}{})
+			if resp.StatusCode != http.StatusCreated || provisioningStateOf(body) != "Creating" {
+				t.Fatalf("expected a 201 with the provisioningState `Creating` but got %d: %+v", resp.StatusCode, body)
+			}
+			pollingUri := resp.Header.Get(v.pollingHeader)
+			if pollingUri == "" || resp.Header.Get("Retry-After") != "0" {
+				t.Fatalf("expected the %q and `Retry-After` headers to be returned but got %+v", v.pollingHeader, resp.Header)
+			}
+			req, _ := http.NewRequest(http.MethodGet, pollingUri, nil)
+			pollingPath := req.URL.Path
+
+			// the first poll is in progress..
+			resp, body = sendRequest(t, s, http.MethodGet, pollingPath, nil)
+			if resp.StatusCode != v.inProgressCode || (v.style == LongRunningOperationAzureAsyncOperation && body["status"] != "InProgress") {
+				t.Fatalf("expected the operation to be in progress but got %d: %+v", resp.StatusCode, body)
+			}
+
+			// ..and the second completes
+			resp, body = sendRequest(t, s, http.MethodGet, pollingPath, nil)
+			if resp.StatusCode != http.StatusOK || (v.style == LongRunningOperationAzureAsyncOperation && body["status"] != "Succeeded") {
+				t.Fatalf("expected the operation to have succeeded but got %d: %+v", resp.StatusCode, body)
+			}
+			if body, _ := s.Get(testResourceId); provisioningStateOf(body) != "Succeeded" {
+				t.Fatalf("expected the provisioningState to be `Succeeded` but got %+v", body)
+			}
+
+			// retrieving the Resource also counts as polling the operation
+			resp, _ = sendRequest(t, s, http.MethodDelete, testResourceId, nil)
+			if resp.StatusCode != http.StatusAccepted {
+				t.Fatalf("expected a 202 but got %d", resp.StatusCode)
+			}
+			if resp, _ = sendRequest(t, s, http.MethodGet, testResourceId, nil); resp.StatusCode != http.StatusOK {
+				t.Fatalf("expected a 200 whilst the Resource is being deleted but got %d", resp.StatusCode)
+			}
+			if resp, _ = sendRequest(t, s, http.MethodGet, testResourceId, nil); resp.StatusCode != http.StatusNotFound {
+				t.Fatalf("expected a 404 once the Resource has been deleted but got %d", resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestParentIdFor(t *testing.T) {
+	testData := map[string]string{
+		"/subscriptions/00000000-0000-0000-0000-000000000000":                                                                    "",
+		"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example":                                             "",
+		"/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Example/things/thing1":                          "",
+		"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Example/things/thing1":   "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example",
+		"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Example/things/t/bits/b": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Example/things/t",
+		"/providers/Microsoft.Management/managementGroups/example":                                                               "",
+	}
+
+	for input, expected := range testData {
+		if actual := parentIdFor(input); actual != expected {
+			t.Fatalf("expected the parent of %q to be %q but got %q", input, expected, actual)
+		}
+	}
+}
@@ -4,6 +4,7 @@
 package acceptance
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -37,3 +38,44 @@ func RandString(strlen int) string {
 func RandStringFromCharSet(strlen int, charSet string) string {
 	return acctest.RandStringFromCharSet(strlen, charSet)
 }
+
+// recordedString returns the value of the variable `name` from the recording for this test when requests are being
+// recorded or replayed (so that the same value is used when the test is replayed), otherwise calling `generate`
+func (td *TestData) recordedString(name string, generate func() string) string {
+	if td.recorder == nil {
+		return generate()
+	}
+
+	value, err := td.recorder.Variable(name, generate)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid Test: %+v", err))
+	}
+	return value
+}
+
+// nextRecordedString is like recordedString for values which are expected to differ each time they're generated
+func (td *TestData) nextRecordedString(prefix string, generate func() string) string {
+	if td.recorder == nil {
+		return generate()
+	}
+
+	value, err := td.recorder.NextVariable(prefix, generate)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid Test: %+v", err))
+	}
+	td.recorder.AddIdentifier(value)
+	return value
+}
+
+// nextRecordedInteger is like nextRecordedString for integers
+func (td *TestData) nextRecordedInteger(prefix string, generate func() int) int {
+	value := td.nextRecordedString(prefix, func() string {
+		return strconv.Itoa(generate())
+	})
+
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid Test: parsing the recorded value %q for %q as an integer: %+v", value, prefix, err))
+	}
+	return i
+}
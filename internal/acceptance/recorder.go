@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package acceptance
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/recording"
+)
+
+// recorders is a map of the test to the Recorder for that test, since BuildTestData can be called multiple times within a test
+var recorders = &sync.Map{}
+
+// newRecorder returns the Recorder for this test when requests are being recorded or replayed (as specified
+// in the `ARM_TEST_RECORDING_MODE` Environment Variable) - or nil when requests are sent to Azure
+func newRecorder(t *testing.T) (*recording.Recorder, error) {
+	mode := recording.ModeFromEnvironment()
+	if mode == recording.ModeLive {
+		return nil, nil
+	}
+
+	if v, ok := recorders.Load(t); ok {
+		return v.(*recording.Recorder), nil
+	}
+
+	recorder, err := recording.NewRecorder(mode, recording.CassettePath(t.Name()))
+	if err != nil {
+		return nil, err
+	}
+	recorders.Store(t, recorder)
+	recording.DefaultRouter().Register(recorder)
+
+	t.Cleanup(func() {
+		recording.DefaultRouter().Deregister(recorder)
+		recorders.Delete(t)
+
+		// a failed test may not have completed, so shouldn't replace an existing recording
+		if t.Failed() && mode == recording.ModeRecord {
+			t.Logf("[DEBUG] Not saving the recording for %q since the test failed", t.Name())
+		}
+		if err := recorder.Stop(t.Failed()); err != nil {
+			t.Errorf("saving the recording for %q: %+v", t.Name(), err)
+		}
+	})
+
+	return recorder, nil
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recording
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/go-azure-sdk/sdk/claims"
+	"golang.org/x/oauth2"
+)
+
+var _ auth.Authorizer = replayAuthorizer{}
+
+// replayAuthorizer issues an (unsigned) token containing the claims recorded from the token used when
+// recording, since the Provider inspects these claims to determine the authenticated principal
+type replayAuthorizer struct {
+	clientId string
+	objectId string
+	tenantId string
+}
+
+func (a replayAuthorizer) Token(_ context.Context, _ *http.Request) (*oauth2.Token, error) {
+	header, err := json.Marshal(map[string]string{
+		"alg": "none",
+		"typ": "JWT",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(claims.Claims{
+		AppId:    a.clientId,
+		ObjectId: a.objectId,
+		TenantId: a.tenantId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken := fmt.Sprintf("%s.%s.replay", base64.RawURLEncoding.EncodeToString(header), base64.RawURLEncoding.EncodeToString(payload))
+	return &oauth2.Token{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		Expiry:      time.Now().Add(time.Hour),
+	}, nil
+}
+
+func (a replayAuthorizer) AuxiliaryTokens(_ context.Context, _ *http.Request) ([]*oauth2.Token, error) {
+	return []*oauth2.Token{}, nil
+}
+
+// recordTokenClaims records the claims from the bearer token used to authorize the request (the first time
+// one is seen) so that these can be reissued when replaying
+func (r *Recorder) recordTokenClaims(request *http.Request) {
+	r.lock.Lock()
+	_, recorded := r.cassette.Variables[variableTokenTenantId]
+	r.lock.Unlock()
+	if recorded {
+		return
+	}
+
+	value := request.Header.Get("Authorization")
+	if !strings.HasPrefix(value, "Bearer ") {
+		return
+	}
+
+	parsed, err := claims.ParseClaims(&oauth2.Token{AccessToken: strings.TrimPrefix(value, "Bearer ")})
+	if err != nil || parsed.TenantId == "" {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.cassette.Variables[variableTokenClientId] = parsed.AppId
+	r.cassette.Variables[variableTokenObjectId] = parsed.ObjectId
+	r.cassette.Variables[variableTokenTenantId] = parsed.TenantId
+}
@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recording
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+)
+
+// Cassette is the set of interactions (and variables) recorded for a single test
+type Cassette struct {
+	// Variables contains the values generated for this test (for example random integers/strings and the
+	// Subscription ID) which must be reused when replaying the test, so that the requests match
+	Variables map[string]string `json:"variables"`
+
+	// Interactions is the list of requests sent and responses received, in the order the responses were received
+	Interactions []Interaction `json:"interactions"`
+}
+
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Request struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    Body        `json:"body,omitempty"`
+
+	// BodyHash is the SHA256 hash of the (sanitized) body, which is used to match requests when replaying
+	BodyHash string `json:"body_hash,omitempty"`
+}
+
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       Body        `json:"body,omitempty"`
+}
+
+// Body is the body of a request/response, which is stored as a string when it's valid UTF-8 (to keep Cassettes
+// readable) - and base64 encoded otherwise
+type Body struct {
+	Value    string `json:"value,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+const bodyEncodingBase64 = "base64"
+
+func newBody(input []byte) Body {
+	if utf8.Valid(input) {
+		return Body{
+			Value: string(input),
+		}
+	}
+
+	return Body{
+		Value:    base64.StdEncoding.EncodeToString(input),
+		Encoding: bodyEncodingBase64,
+	}
+}
+
+func (b Body) Bytes() ([]byte, error) {
+	if b.Encoding == bodyEncodingBase64 {
+		return base64.StdEncoding.DecodeString(b.Value)
+	}
+	return []byte(b.Value), nil
+}
+
+// redactor redacts any secrets (within the headers, query string and JSON bodies) from the requests and responses
+// written to a Cassette - using the same defaults as the Provider uses when logging requests
+var redactor = common.NewHTTPRedactor(nil, nil)
+
+func sanitizeHeaders(input http.Header) http.Header {
+	return redactor.RedactHeaders(input)
+}
+
+// sanitizeURL returns the URL with any sensitive query string parameters redacted - this is used both when
+// writing a request to a Cassette and when matching a request against those within a Cassette
+func sanitizeURL(input *url.URL) string {
+	return redactor.RedactURL(input)
+}
+
+// sanitizeBody returns the body of a request to (or a response from) `requestUrl` with any secrets redacted - this
+// is used both when writing a request/response to a Cassette and (via bodyHash) when matching a request against
+// those within a Cassette
+func sanitizeBody(requestUrl *url.URL, input []byte) []byte {
+	if len(input) == 0 {
+		return input
+	}
+	return redactor.RedactBody(requestUrl, input)
+}
+
+// bodyHash returns the SHA256 hash of the sanitized request body, used to match requests against a Cassette
+func bodyHash(requestUrl *url.URL, input []byte) string {
+	if len(input) == 0 {
+		return ""
+	}
+	hash := sha256.Sum256(sanitizeBody(requestUrl, input))
+	return hex.EncodeToString(hash[:])
+}
+
+// LoadCassette loads the Cassette from the file at `path`
+func LoadCassette(path string) (*Cassette, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading the Cassette %q: %+v", path, err)
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(contents, &cassette); err != nil {
+		return nil, fmt.Errorf("parsing the Cassette %q: %+v", path, err)
+	}
+	if cassette.Variables == nil {
+		cassette.Variables = make(map[string]string)
+	}
+
+	return &cassette, nil
+}
+
+// Save writes the Cassette to the file at `path`, creating any parent directories as required
+func (c Cassette) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating the directory for the Cassette %q: %+v", path, err)
+	}
+
+	contents, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("serializing the Cassette %q: %+v", path, err)
+	}
+
+	if err := os.WriteFile(path, contents, 0644); err != nil {
+		return fmt.Errorf("writing the Cassette %q: %+v", path, err)
+	}
+
+	return nil
+}
+
+var invalidCassetteNameCharacters = regexp.MustCompile("[^a-zA-Z0-9_-]+")
+
+// CassettePath returns the path to the Cassette used for the test named `testName`
+func CassettePath(testName string) string {
+	name := invalidCassetteNameCharacters.ReplaceAllString(testName, "_")
+	return filepath.Join(Directory(), fmt.Sprintf("%s.json", name))
+}
@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recording
+
+import (
+	"os"
+	"strings"
+)
+
+// Mode specifies whether the requests sent by the Provider are sent to Azure, recorded or replayed
+type Mode string
+
+const (
+	// ModeLive sends requests to Azure without recording them
+	ModeLive Mode = "live"
+
+	// ModeRecord sends requests to Azure and records each interaction into a Cassette
+	ModeRecord Mode = "record"
+
+	// ModeReplay serves each request from a previously recorded Cassette, without sending anything to Azure
+	ModeReplay Mode = "replay"
+)
+
+// ModeFromEnvironment returns the Mode specified in the `ARM_TEST_RECORDING_MODE` Environment Variable,
+// defaulting to ModeLive
+func ModeFromEnvironment() Mode {
+	switch Mode(strings.ToLower(os.Getenv("ARM_TEST_RECORDING_MODE"))) {
+	case ModeRecord:
+		return ModeRecord
+	case ModeReplay:
+		return ModeReplay
+	}
+
+	return ModeLive
+}
+
+// Directory returns the directory containing the Cassettes, which is specified in the `ARM_TEST_RECORDING_DIR`
+// Environment Variable - defaulting to `testdata/recordings` within the package being tested
+func Directory() string {
+	if v := os.Getenv("ARM_TEST_RECORDING_DIR"); v != "" {
+		return v
+	}
+
+	return "testdata/recordings"
+}
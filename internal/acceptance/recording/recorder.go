@@ -0,0 +1,471 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recording
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+)
+
+const (
+	// VariableSubscriptionId is the name of the variable containing the Subscription ID used when recording
+	VariableSubscriptionId = "subscription_id"
+
+	variableTokenClientId = "token_client_id"
+	variableTokenObjectId = "token_object_id"
+	variableTokenTenantId = "token_tenant_id"
+
+	// minimumIdentifierLength is the minimum length of a value which can be used to identify the requests for a test
+	minimumIdentifierLength = 5
+
+	// headerInteraction is the header used to tell the replay server which interaction to serve
+	headerInteraction = "X-Recording-Interaction"
+)
+
+var _ common.Recorder = &Recorder{}
+
+// Recorder records the requests sent by the Provider into a Cassette, or replays them from a Cassette
+type Recorder struct {
+	mode Mode
+	path string
+
+	lock      sync.Mutex
+	cassette  *Cassette
+	used      []bool
+	sequences map[string]int
+
+	// identifiers are values unique to this test, used to route requests from shared clients to this Recorder
+	identifiers []string
+
+	// server serves the recorded responses when replaying requests sent by clients based on hashicorp/go-azure-sdk
+	server    *httptest.Server
+	serverUrl *url.URL
+}
+
+// NewRecorder returns a Recorder which either records interactions into (or replays interactions from) the Cassette at `path`
+func NewRecorder(mode Mode, path string) (*Recorder, error) {
+	recorder := &Recorder{
+		mode:      mode,
+		path:      path,
+		sequences: make(map[string]int),
+	}
+
+	switch mode {
+	case ModeRecord:
+		recorder.cassette = &Cassette{
+			Variables:    make(map[string]string),
+			Interactions: make([]Interaction, 0),
+		}
+
+	case ModeReplay:
+		cassette, err := LoadCassette(path)
+		if err != nil {
+			return nil, err
+		}
+		recorder.cassette = cassette
+		recorder.used = make([]bool, len(cassette.Interactions))
+
+		recorder.server = httptest.NewServer(http.HandlerFunc(recorder.serveInteraction))
+		serverUrl, err := url.Parse(recorder.server.URL)
+		if err != nil {
+			recorder.server.Close()
+			return nil, fmt.Errorf("parsing the URL for the replay server %q: %+v", recorder.server.URL, err)
+		}
+		recorder.serverUrl = serverUrl
+
+	default:
+		return nil, fmt.Errorf("a Recorder can only be used when recording or replaying, got %q", mode)
+	}
+
+	return recorder, nil
+}
+
+// Mode returns whether this Recorder is recording or replaying requests
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Stop writes the Cassette when recording (unless `discard` is set, for example when the test failed) and
+// stops the replay server when replaying
+func (r *Recorder) Stop(discard bool) error {
+	if r.mode == ModeReplay {
+		r.server.Close()
+		return nil
+	}
+
+	if discard {
+		return nil
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.cassette.Save(r.path)
+}
+
+// Variable returns the value of the variable `name` - when recording the value is obtained by calling `generate`
+// (the first time this is called) and recorded, when replaying the recorded value is returned
+func (r *Recorder) Variable(name string, generate func() string) (string, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if v, ok := r.cassette.Variables[name]; ok {
+		return v, nil
+	}
+
+	if r.mode == ModeReplay {
+		return "", fmt.Errorf("the variable %q was not recorded in the Cassette %q", name, r.path)
+	}
+
+	value := generate()
+	r.cassette.Variables[name] = value
+	return value, nil
+}
+
+// NextVariable returns the value of a new variable prefixed with `prefix` each time it's called, for values
+// which are expected to differ each time they're generated - see Variable for more information
+func (r *Recorder) NextVariable(prefix string, generate func() string) (string, error) {
+	r.lock.Lock()
+	r.sequences[prefix]++
+	name := fmt.Sprintf("%s_%d", prefix, r.sequences[prefix])
+	r.lock.Unlock()
+
+	return r.Variable(name, generate)
+}
+
+// AddIdentifier registers a value which is unique to this test, such that requests sent by shared clients
+// (for example the clients used to check that a resource exists) containing this value can be routed to
+// this Recorder by a Router
+func (r *Recorder) AddIdentifier(value string) {
+	// shorter values aren't unique enough to identify the requests for a test
+	if len(value) < minimumIdentifierLength {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.identifiers = append(r.identifiers, value)
+}
+
+func (r *Recorder) ReplayCredentials() *common.ReplayCredentials {
+	if r.mode != ModeReplay {
+		return nil
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return &common.ReplayCredentials{
+		Authorizer: replayAuthorizer{
+			clientId: r.cassette.Variables[variableTokenClientId],
+			objectId: r.cassette.Variables[variableTokenObjectId],
+			tenantId: r.cassette.Variables[variableTokenTenantId],
+		},
+		SubscriptionId: r.cassette.Variables[VariableSubscriptionId],
+	}
+}
+
+func (r *Recorder) RequestMiddleware() client.RequestMiddleware {
+	return r.handleRequest
+}
+
+func (r *Recorder) ResponseMiddleware() client.ResponseMiddleware {
+	return handleResponse
+}
+
+func (r *Recorder) SendDecorator() autorest.SendDecorator {
+	return func(sender autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(request *http.Request) (*http.Response, error) {
+			return r.send(sender, request)
+		})
+	}
+}
+
+// pendingInteractionKey is the key used to store a pendingInteraction within the request context
+type pendingInteractionKey struct{}
+
+// pendingInteraction tracks a request which has been sent, until the response is received
+type pendingInteraction struct {
+	recorder *Recorder
+
+	// request is the request being recorded
+	request *Request
+
+	// originalUrl is the URL the request was sent to, prior to being redirected to the replay server
+	originalUrl *url.URL
+}
+
+func (r *Recorder) handleRequest(request *http.Request) (*http.Request, error) {
+	switch r.mode {
+	case ModeRecord:
+		r.recordTokenClaims(request)
+
+		recorded, err := newRecordedRequest(request)
+		if err != nil {
+			return nil, err
+		}
+		pending := &pendingInteraction{
+			recorder: r,
+			request:  recorded,
+		}
+		return request.WithContext(context.WithValue(request.Context(), pendingInteractionKey{}, pending)), nil
+
+	case ModeReplay:
+		index, err := r.findInteraction(request)
+		if err != nil {
+			return nil, err
+		}
+
+		pending := &pendingInteraction{
+			recorder:    r,
+			originalUrl: request.URL,
+		}
+		request = request.WithContext(context.WithValue(request.Context(), pendingInteractionKey{}, pending))
+
+		// send the request to the replay server, which serves the interaction specified in the header
+		replayUrl := *request.URL
+		replayUrl.Scheme = r.serverUrl.Scheme
+		replayUrl.Host = r.serverUrl.Host
+		request.URL = &replayUrl
+		request.Host = ""
+		request.Header.Set(headerInteraction, strconv.Itoa(index))
+		return request, nil
+	}
+
+	return request, nil
+}
+
+func handleResponse(request *http.Request, response *http.Response) (*http.Response, error) {
+	pending, ok := request.Context().Value(pendingInteractionKey{}).(*pendingInteraction)
+	if !ok || response == nil {
+		return response, nil
+	}
+
+	switch pending.recorder.mode {
+	case ModeRecord:
+		if err := pending.recorder.recordResponse(*pending.request, response); err != nil {
+			return nil, err
+		}
+
+	case ModeReplay:
+		// Pollers use the URL of the request to determine where to poll, so this needs to be the original URL
+		if response.Request != nil && pending.originalUrl != nil {
+			originalRequest := response.Request.Clone(response.Request.Context())
+			originalRequest.URL = pending.originalUrl
+			originalRequest.Header.Del(headerInteraction)
+			response.Request = originalRequest
+		}
+	}
+
+	return response, nil
+}
+
+func (r *Recorder) send(sender autorest.Sender, request *http.Request) (*http.Response, error) {
+	switch r.mode {
+	case ModeRecord:
+		r.recordTokenClaims(request)
+
+		recorded, err := newRecordedRequest(request)
+		if err != nil {
+			return nil, err
+		}
+		response, err := sender.Do(request)
+		if err != nil {
+			return response, err
+		}
+		if err := r.recordResponse(*recorded, response); err != nil {
+			return nil, err
+		}
+		return response, nil
+
+	case ModeReplay:
+		index, err := r.findInteraction(request)
+		if err != nil {
+			return nil, err
+		}
+
+		// drain the request body as the transport would
+		if request.Body != nil {
+			_, _ = io.Copy(io.Discard, request.Body)
+			request.Body.Close()
+		}
+
+		r.lock.Lock()
+		interaction := r.cassette.Interactions[index]
+		r.lock.Unlock()
+		return interaction.Response.httpResponse(request)
+	}
+
+	return sender.Do(request)
+}
+
+func newRecordedRequest(request *http.Request) (*Request, error) {
+	recorded := Request{
+		Method:  request.Method,
+		URL:     sanitizeURL(request.URL),
+		Headers: sanitizeHeaders(request.Header),
+	}
+
+	contents, err := readRequestBody(request)
+	if err != nil {
+		return nil, fmt.Errorf("reading the request body to record: %+v", err)
+	}
+	if len(contents) > 0 {
+		recorded.Body = newBody(sanitizeBody(request.URL, contents))
+		recorded.BodyHash = bodyHash(request.URL, contents)
+	}
+
+	return &recorded, nil
+}
+
+// readRequestBody returns the body of the request, ensuring that the request body can still be sent afterwards
+func readRequestBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil, nil
+	}
+
+	contents, err := io.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	request.Body.Close()
+	request.Body = io.NopCloser(bytes.NewReader(contents))
+	request.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(contents)), nil
+	}
+	return contents, nil
+}
+
+func (r *Recorder) recordResponse(request Request, response *http.Response) error {
+	recorded := Response{
+		StatusCode: response.StatusCode,
+		Headers:    sanitizeHeaders(response.Header),
+	}
+
+	if response.Body != nil && response.Body != http.NoBody {
+		contents, err := io.ReadAll(response.Body)
+		if err != nil {
+			return fmt.Errorf("reading the response body to record: %+v", err)
+		}
+		response.Body.Close()
+		response.Body = io.NopCloser(bytes.NewReader(contents))
+
+		// the URL has already been sanitized, which doesn't affect the host used to determine what's redacted
+		requestUrl, err := url.Parse(request.URL)
+		if err != nil {
+			return fmt.Errorf("parsing the recorded request URL %q: %+v", request.URL, err)
+		}
+		recorded.Body = newBody(sanitizeBody(requestUrl, contents))
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request:  request,
+		Response: recorded,
+	})
+	return nil
+}
+
+// findInteraction returns the index of the first unused interaction matching the request (by the method, URL and a
+// hash of the body) - falling back to the last matching interaction, for requests (such as polling) which may be
+// repeated more times than were recorded
+func (r *Recorder) findInteraction(request *http.Request) (int, error) {
+	requestUrl := sanitizeURL(request.URL)
+
+	contents, err := readRequestBody(request)
+	if err != nil {
+		return -1, fmt.Errorf("reading the request body to match: %+v", err)
+	}
+	requestBodyHash := bodyHash(request.URL, contents)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	lastMatch := -1
+	for i, interaction := range r.cassette.Interactions {
+		if !strings.EqualFold(interaction.Request.Method, request.Method) || interaction.Request.URL != requestUrl {
+			continue
+		}
+		if interaction.Request.BodyHash != requestBodyHash {
+			continue
+		}
+
+		if !r.used[i] {
+			r.used[i] = true
+			return i, nil
+		}
+		lastMatch = i
+	}
+
+	if lastMatch == -1 {
+		return -1, fmt.Errorf("no interaction was recorded in the Cassette %q for the request %s %s (with the body hash %q)", r.path, request.Method, requestUrl, requestBodyHash)
+	}
+
+	return lastMatch, nil
+}
+
+func (r *Recorder) serveInteraction(w http.ResponseWriter, request *http.Request) {
+	index, err := strconv.Atoi(request.Header.Get(headerInteraction))
+
+	r.lock.Lock()
+	valid := err == nil && index >= 0 && index < len(r.cassette.Interactions)
+	var interaction Interaction
+	if valid {
+		interaction = r.cassette.Interactions[index]
+	}
+	r.lock.Unlock()
+
+	if !valid {
+		http.Error(w, fmt.Sprintf("the `%s` header was missing or invalid", headerInteraction), http.StatusBadRequest)
+		return
+	}
+
+	body, err := interaction.Response.Body.Bytes()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("decoding the recorded response body: %+v", err), http.StatusInternalServerError)
+		return
+	}
+
+	for key, values := range interaction.Response.Headers {
+		// the length of the body is set by the server
+		if strings.EqualFold(key, "Content-Length") {
+			continue
+		}
+		for _, v := range values {
+			w.Header().Add(key, v)
+		}
+	}
+	w.WriteHeader(interaction.Response.StatusCode)
+	_, _ = w.Write(body)
+}
+
+func (r Response) httpResponse(request *http.Request) (*http.Response, error) {
+	body, err := r.Body.Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("decoding the recorded response body: %+v", err)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Headers.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       request,
+	}, nil
+}
@@ -0,0 +1,256 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recording
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+)
+
+type recorderTestModel struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func recorderTestClient(baseUri string, recorder *Recorder) *client.Client {
+	c := client.NewClient(baseUri, "recording", "2020-01-01")
+	c.DisableRetries = true
+	c.RequestMiddlewares = &[]client.RequestMiddleware{recorder.RequestMiddleware()}
+	c.ResponseMiddlewares = &[]client.ResponseMiddleware{recorder.ResponseMiddleware()}
+	return c
+}
+
+func recorderTestPut(ctx context.Context, c *client.Client, name string) (*recorderTestModel, error) {
+	req, err := c.NewRequest(ctx, client.RequestOptions{
+		ContentType:         "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{http.StatusOK},
+		HttpMethod:          http.MethodPut,
+		Path:                fmt.Sprintf("/things/%s", name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("building request: %+v", err)
+	}
+	if err := req.Marshal(recorderTestModel{Name: name}); err != nil {
+		return nil, fmt.Errorf("marshaling request: %+v", err)
+	}
+
+	resp, err := req.Execute(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %+v", err)
+	}
+
+	var model recorderTestModel
+	if err := resp.Unmarshal(&model); err != nil {
+		return nil, fmt.Errorf("unmarshaling response: %+v", err)
+	}
+	return &model, nil
+}
+
+func TestRecorderRecordAndReplay(t *testing.T) {
+	ctx := context.TODO()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"name":%q,"value":"call-%d"}`, r.URL.Path, calls)
+	}))
+	baseUri := server.URL
+
+	// first record the requests..
+	recorder, err := NewRecorder(ModeRecord, path)
+	if err != nil {
+		t.Fatalf("building Recorder: %+v", err)
+	}
+	randomValue, err := recorder.Variable("random", func() string {
+		return "recorded"
+	})
+	if err != nil {
+		t.Fatalf("retrieving variable: %+v", err)
+	}
+
+	c := recorderTestClient(baseUri, recorder)
+	for _, name := range []string{"first", "second", "first"} {
+		if _, err := recorderTestPut(ctx, c, name); err != nil {
+			t.Fatalf("recording %q: %+v", name, err)
+		}
+	}
+	if err := recorder.Stop(false); err != nil {
+		t.Fatalf("stopping Recorder: %+v", err)
+	}
+	server.Close()
+
+	// ..then replay them, without the server
+	recorder, err = NewRecorder(ModeReplay, path)
+	if err != nil {
+		t.Fatalf("building Recorder: %+v", err)
+	}
+	defer recorder.Stop(false)
+
+	replayedValue, err := recorder.Variable("random", func() string {
+		return "replayed"
+	})
+	if err != nil {
+		t.Fatalf("retrieving variable: %+v", err)
+	}
+	if replayedValue != randomValue {
+		t.Fatalf("expected the variable to be %q but got %q", randomValue, replayedValue)
+	}
+	if _, err := recorder.Variable("unknown", func() string { return "" }); err == nil {
+		t.Fatalf("expected an error for a variable which wasn't recorded")
+	}
+
+	c = recorderTestClient(baseUri, recorder)
+	expected := map[string]string{
+		"first":  "call-1",
+		"second": "call-2",
+	}
+	for _, name := range []string{"first", "second"} {
+		model, err := recorderTestPut(ctx, c, name)
+		if err != nil {
+			t.Fatalf("replaying %q: %+v", name, err)
+		}
+		if model.Value != expected[name] {
+			t.Fatalf("expected the value for %q to be %q but got %q", name, expected[name], model.Value)
+		}
+	}
+
+	// the third request is served from the next matching interaction
+	model, err := recorderTestPut(ctx, c, "first")
+	if err != nil {
+		t.Fatalf("replaying %q: %+v", "first", err)
+	}
+	if model.Value != "call-3" {
+		t.Fatalf("expected the value for %q to be %q but got %q", "first", "call-3", model.Value)
+	}
+
+	if _, err := recorderTestPut(ctx, c, "third"); err == nil {
+		t.Fatalf("expected an error for a request which wasn't recorded")
+	}
+}
+
+func TestRecorderSendDecoratorReplay(t *testing.T) {
+	recorder := &Recorder{
+		mode: ModeReplay,
+		path: "example.json",
+		cassette: &Cassette{
+			Interactions: []Interaction{
+				{
+					Request: Request{
+						Method: http.MethodGet,
+						URL:    "https://example.com/things/first?sig=%2A%2A%2A",
+					},
+					Response: Response{
+						StatusCode: http.StatusOK,
+						Body:       newBody([]byte("hello")),
+					},
+				},
+			},
+		},
+		used: make([]bool, 1),
+	}
+
+	sender := autorest.DecorateSender(autorest.SenderFunc(func(*http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("requests shouldn't be sent when replaying")
+	}), recorder.SendDecorator())
+
+	req, err := http.NewRequest(http.MethodGet, "https://example.com/things/first?sig=abc123", nil)
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	resp, err := sender.Do(req)
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading response body: %+v", err)
+	}
+	if resp.StatusCode != http.StatusOK || string(body) != "hello" {
+		t.Fatalf("expected a 200 with the body %q but got %d with %q", "hello", resp.StatusCode, string(body))
+	}
+}
+
+func TestRecorderRedactsAndMatchesOnBody(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=cookie-secret")
+		_, _ = w.Write([]byte(`{"properties":{"primaryKey":"response-secret"}}`))
+	}))
+	defer server.Close()
+
+	recorder, err := NewRecorder(ModeRecord, path)
+	if err != nil {
+		t.Fatalf("building Recorder: %+v", err)
+	}
+	sender := autorest.DecorateSender(server.Client(), recorder.SendDecorator())
+
+	send := func(body string) (*http.Response, error) {
+		req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/things/first/listKeys", server.URL), bytes.NewBufferString(body))
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+		req.Header.Set("Authorization", "Bearer header-secret")
+		return sender.Do(req)
+	}
+
+	resp, err := send(`{"name":"first","password":"request-secret"}`)
+	if err != nil {
+		t.Fatalf("recording request: %+v", err)
+	}
+	// the response returned to the caller mustn't be redacted
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading response body: %+v", err)
+	}
+	if !strings.Contains(string(body), "response-secret") {
+		t.Fatalf("expected the response body to be unmodified but got %q", string(body))
+	}
+	if err := recorder.Stop(false); err != nil {
+		t.Fatalf("stopping Recorder: %+v", err)
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading Cassette: %+v", err)
+	}
+	for _, secret := range []string{"request-secret", "response-secret", "header-secret", "cookie-secret"} {
+		if strings.Contains(string(contents), secret) {
+			t.Fatalf("expected %q to be redacted from the Cassette but got %s", secret, string(contents))
+		}
+	}
+
+	recorder, err = NewRecorder(ModeReplay, path)
+	if err != nil {
+		t.Fatalf("building Recorder: %+v", err)
+	}
+	defer recorder.Stop(false)
+	sender = autorest.DecorateSender(autorest.SenderFunc(func(*http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("requests shouldn't be sent when replaying")
+	}), recorder.SendDecorator())
+
+	// the same body (with the keys in a different order) matches the recorded interaction..
+	if _, err := send(`{"password":"request-secret","name":"first"}`); err != nil {
+		t.Fatalf("replaying request: %+v", err)
+	}
+
+	// ..whereas a different body doesn't
+	if _, err := send(`{"name":"second","password":"request-secret"}`); err == nil {
+		t.Fatalf("expected an error for a request with a body which wasn't recorded")
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recording
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"golang.org/x/oauth2"
+)
+
+var _ common.Recorder = &Router{}
+
+// Router routes the requests sent by a client which is shared between tests (for example the client used to
+// check that a resource exists) to the Recorder for the test which sent them - by looking for a value unique
+// to that test (see Recorder.AddIdentifier) within the URL of the request.
+//
+// Requests which can't be routed to a Recorder are sent to Azure (without being recorded) when recording and
+// fail when replaying.
+type Router struct {
+	mode Mode
+
+	lock      sync.Mutex
+	recorders []*Recorder
+}
+
+var (
+	defaultRouter     *Router
+	defaultRouterOnce sync.Once
+)
+
+// DefaultRouter returns the Router used by the shared test client, using the Mode specified in the Environment
+func DefaultRouter() *Router {
+	defaultRouterOnce.Do(func() {
+		defaultRouter = &Router{
+			mode: ModeFromEnvironment(),
+		}
+	})
+	return defaultRouter
+}
+
+// Register adds the Recorder to the list of Recorders which requests can be routed to
+func (r *Router) Register(recorder *Recorder) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.recorders = append(r.recorders, recorder)
+}
+
+// Deregister removes the Recorder from the list of Recorders which requests can be routed to
+func (r *Router) Deregister(recorder *Recorder) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for i, v := range r.recorders {
+		if v == recorder {
+			r.recorders = append(r.recorders[:i], r.recorders[i+1:]...)
+			return
+		}
+	}
+}
+
+// recorderFor returns the Recorder with the longest identifier found within the URL of the request, if any
+func (r *Router) recorderFor(request *http.Request) *Recorder {
+	if request == nil || request.URL == nil {
+		return nil
+	}
+	requestUrl := strings.ToLower(request.URL.String())
+
+	r.lock.Lock()
+	recorders := append([]*Recorder{}, r.recorders...)
+	r.lock.Unlock()
+
+	var match *Recorder
+	longest := 0
+	for _, recorder := range recorders {
+		recorder.lock.Lock()
+		for _, identifier := range recorder.identifiers {
+			if len(identifier) > longest && strings.Contains(requestUrl, strings.ToLower(identifier)) {
+				match = recorder
+				longest = len(identifier)
+			}
+		}
+		recorder.lock.Unlock()
+	}
+	return match
+}
+
+func (r *Router) RequestMiddleware() client.RequestMiddleware {
+	return func(request *http.Request) (*http.Request, error) {
+		recorder := r.recorderFor(request)
+		if recorder == nil {
+			if r.mode == ModeReplay {
+				return nil, fmt.Errorf("no Recorder was found for the request %s %s", request.Method, sanitizeURL(request.URL))
+			}
+			return request, nil
+		}
+		return recorder.handleRequest(request)
+	}
+}
+
+func (r *Router) ResponseMiddleware() client.ResponseMiddleware {
+	return handleResponse
+}
+
+func (r *Router) SendDecorator() autorest.SendDecorator {
+	return func(sender autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(request *http.Request) (*http.Response, error) {
+			recorder := r.recorderFor(request)
+			if recorder == nil {
+				if r.mode == ModeReplay {
+					return nil, fmt.Errorf("no Recorder was found for the request %s %s", request.Method, sanitizeURL(request.URL))
+				}
+				return sender.Do(request)
+			}
+			return recorder.send(sender, request)
+		})
+	}
+}
+
+func (r *Router) ReplayCredentials() *common.ReplayCredentials {
+	if r.mode != ModeReplay {
+		return nil
+	}
+
+	// the Subscription ID is the same for every test, so can be taken from any Recorder
+	subscriptionId := ""
+	r.lock.Lock()
+	if len(r.recorders) > 0 {
+		if credentials := r.recorders[0].ReplayCredentials(); credentials != nil {
+			subscriptionId = credentials.SubscriptionId
+		}
+	}
+	r.lock.Unlock()
+
+	return &common.ReplayCredentials{
+		Authorizer:     routerAuthorizer{router: r},
+		SubscriptionId: subscriptionId,
+	}
+}
+
+var _ auth.Authorizer = routerAuthorizer{}
+
+// routerAuthorizer issues the token for the Recorder which the request is routed to
+type routerAuthorizer struct {
+	router *Router
+}
+
+func (a routerAuthorizer) Token(ctx context.Context, request *http.Request) (*oauth2.Token, error) {
+	recorder := a.router.recorderFor(request)
+	if recorder == nil {
+		// the Provider requests a token without a request to inspect the claims, which are the same for every test
+		a.router.lock.Lock()
+		if len(a.router.recorders) > 0 {
+			recorder = a.router.recorders[0]
+		}
+		a.router.lock.Unlock()
+	}
+	if recorder == nil {
+		return nil, fmt.Errorf("no Recorder was found to issue a token")
+	}
+
+	return recorder.ReplayCredentials().Authorizer.Token(ctx, request)
+}
+
+func (a routerAuthorizer) AuxiliaryTokens(_ context.Context, _ *http.Request) ([]*oauth2.Token, error) {
+	return []*oauth2.Token{}, nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package stateupgrade
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// TestCase is a single test case for a State Upgrade, see RunTestCases
+type TestCase struct {
+	// Name is a human-readable name for this test case
+	Name string
+
+	// Input is the Raw State prior to the State Upgrade
+	Input map[string]interface{}
+
+	// Expected is the Raw State after the State Upgrade - or nil when the State Upgrade is expected to fail
+	Expected map[string]interface{}
+}
+
+// RunTestCases runs each of the test cases `testCases` against the State Upgrade `upgrade`, which allows
+// a State Upgrade (such as an sdk.ResourceIDStateUpgrade) to be tested using only a table of test cases:
+//
+//	func TestExampleV0ToV1(t *testing.T) {
+//		stateupgrade.RunTestCases(t, ExampleV0ToV1{}, []stateupgrade.TestCase{
+//			{
+//				Name:     "old id",
+//				Input:    map[string]interface{}{"id": "/subscriptions/.../Examples/example1"},
+//				Expected: map[string]interface{}{"id": "/subscriptions/.../examples/example1"},
+//			},
+//		})
+//	}
+func RunTestCases(t *testing.T, upgrade pluginsdk.StateUpgrade, testCases []TestCase) {
+	t.Helper()
+
+	if len(upgrade.Schema()) == 0 {
+		t.Fatalf("the State Upgrade must define a point-in-time Schema")
+	}
+
+	for _, test := range testCases {
+		t.Logf("[DEBUG] Testing %q..", test.Name)
+
+		// the upgrade functions can modify the input, so we pass a copy to avoid polluting other test cases
+		input := copyRawState(test.Input)
+		actual, err := upgrade.UpgradeFunc()(context.TODO(), input, nil)
+		if err != nil {
+			if test.Expected == nil {
+				continue
+			}
+			t.Fatalf("%s: expected no error but got: %+v", test.Name, err)
+		}
+		if test.Expected == nil {
+			t.Fatalf("%s: expected an error but didn't get one", test.Name)
+		}
+
+		if !reflect.DeepEqual(test.Expected, actual) {
+			t.Fatalf("%s: expected %+v but got %+v", test.Name, test.Expected, actual)
+		}
+	}
+}
+
+func copyRawState(input map[string]interface{}) map[string]interface{} {
+	if input == nil {
+		return nil
+	}
+
+	var copyValue func(interface{}) interface{}
+	copyValue = func(value interface{}) interface{} {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			output := make(map[string]interface{}, len(v))
+			for key, item := range v {
+				output[key] = copyValue(item)
+			}
+			return output
+		case []interface{}:
+			output := make([]interface{}, 0, len(v))
+			for _, item := range v {
+				output = append(output, copyValue(item))
+			}
+			return output
+		}
+		return value
+	}
+
+	return copyValue(input).(map[string]interface{})
+}
@@ -4,15 +4,17 @@
 package acceptance
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/helpers"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/testclient"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/types"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
 )
 
@@ -108,28 +110,35 @@ func RunTestsInSequence(t *testing.T, tests map[string]map[string]func(t *testin
 
 func (td TestData) runAcceptanceTest(t *testing.T, testCase resource.TestCase) {
 	testCase.ExternalProviders = td.externalProviders()
-	testCase.ProviderFactories = td.providers()
+	testCase.ProtoV5ProviderFactories = td.providers()
 
 	resource.ParallelTest(t, testCase)
 }
 
 func (td TestData) runAcceptanceSequentialTest(t *testing.T, testCase resource.TestCase) {
 	testCase.ExternalProviders = td.externalProviders()
-	testCase.ProviderFactories = td.providers()
+	testCase.ProtoV5ProviderFactories = td.providers()
 
 	resource.Test(t, testCase)
 }
 
-func (td TestData) providers() map[string]func() (*schema.Provider, error) {
-	return map[string]func() (*schema.Provider, error){
-		"azurerm": func() (*schema.Provider, error) { //nolint:unparam
-			azurerm := provider.TestAzureProvider()
-			return azurerm, nil
-		},
-		"azurerm-alt": func() (*schema.Provider, error) { //nolint:unparam
-			azurerm := provider.TestAzureProvider()
-			return azurerm, nil
-		},
+func (td TestData) providers() map[string]func() (tfprotov5.ProviderServer, error) {
+	factory := func() (tfprotov5.ProviderServer, error) {
+		// when recording/replaying the requests sent by the Provider are recorded into/replayed from the recording for this test
+		var recorder common.Recorder
+		if td.recorder != nil {
+			recorder = td.recorder
+		}
+		azurerm, err := provider.TestProtoV5ProviderServerFactoryWithRecorder(context.Background(), recorder)
+		if err != nil {
+			return nil, err
+		}
+		return azurerm(), nil
+	}
+
+	return map[string]func() (tfprotov5.ProviderServer, error){
+		"azurerm":     factory,
+		"azurerm-alt": factory,
 	}
 }
 
@@ -11,6 +11,7 @@ import (
 
 	"github.com/hashicorp/go-azure-sdk/sdk/auth"
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/recording"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 )
@@ -73,6 +74,11 @@ func Build() (*clients.Client, error) {
 			SubscriptionID:           os.Getenv("ARM_SUBSCRIPTION_ID"),
 		}
 
+		// this client is shared between tests, so requests are routed to the recording for the test which sent them
+		if recording.ModeFromEnvironment() != recording.ModeLive {
+			clientBuilder.Recorder = recording.DefaultRouter()
+		}
+
 		client, err := clients.Build(ctx, clientBuilder)
 		if err != nil {
 			return nil, fmt.Errorf("building test client: %+v", err)
@@ -38,6 +38,10 @@ func NewResourceManagerAccount(ctx context.Context, config auth.Credentials, sub
 		return nil, fmt.Errorf("unable to build authorizer for Microsoft Graph API: %+v", err)
 	}
 
+	return newResourceManagerAccount(ctx, config, authorizer, subscriptionId, skipResourceProviderRegistration, azureEnvironment)
+}
+
+func newResourceManagerAccount(ctx context.Context, config auth.Credentials, authorizer auth.Authorizer, subscriptionId string, skipResourceProviderRegistration bool, azureEnvironment azure.Environment) (*ResourceManagerAccount, error) {
 	// Acquire an access token so we can inspect the claims
 	token, err := authorizer.Token(ctx, &http.Request{})
 	if err != nil {
@@ -145,6 +145,7 @@ import (
 	vmware "github.com/hashicorp/terraform-provider-azurerm/internal/services/vmware/client"
 	voiceServices "github.com/hashicorp/terraform-provider-azurerm/internal/services/voiceservices/client"
 	web "github.com/hashicorp/terraform-provider-azurerm/internal/services/web/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
 )
 
 type Client struct {
@@ -156,6 +157,12 @@ type Client struct {
 	Account  *ResourceManagerAccount
 	Features features.UserFeatures
 
+	// ResourceProviderRegistrations specifies how the Resource Providers used by each Service should be registered
+	ResourceProviderRegistrations string
+
+	// ProviderTags are the `default_tags` and `ignore_tags` configured within the Provider block
+	ProviderTags tags.ProviderTags
+
 	AadB2c                       *aadb2c_v2021_04_01_preview.Client
 	Advisor                      *advisor.Client
 	AnalysisServices             *analysisservices_v2017_08_01.Client
@@ -10,7 +10,6 @@ import (
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
-	"github.com/hashicorp/go-azure-helpers/sender"
 	"github.com/hashicorp/go-azure-sdk/sdk/auth"
 	"github.com/hashicorp/go-azure-sdk/sdk/client"
 	"github.com/hashicorp/go-azure-sdk/sdk/client/resourcemanager"
@@ -48,7 +47,11 @@ type ClientOptions struct {
 	DisableCorrelationRequestID bool
 
 	DisableTerraformPartnerID bool
+	HTTPLogging               HTTPLoggingOptions
+	Recorder                  Recorder
+	Retry                     RetryOptions
 	SkipProviderReg           bool
+	StorageDataPlane          StorageDataPlaneOptions
 	StorageUseAzureAD         bool
 
 	// Keep these around for convenience with Autorest based clients, remove when we are no longer using autorest
@@ -78,12 +81,24 @@ func (o ClientOptions) Configure(c *resourcemanager.Client, authorizer auth.Auth
 		}
 		requestMiddlewares = append(requestMiddlewares, correlationRequestIDMiddleware(id))
 	}
-	requestMiddlewares = append(requestMiddlewares, requestLoggerMiddleware("AzureRM"))
-	c.RequestMiddlewares = &requestMiddlewares
+	logger := newHTTPLogger("AzureRM", o.HTTPLogging)
+	requestMiddlewares = append(requestMiddlewares, requestLoggerMiddleware(logger))
+	responseMiddlewares := []client.ResponseMiddleware{
+		responseLoggerMiddleware(logger),
+	}
 
-	c.ResponseMiddlewares = &[]client.ResponseMiddleware{
-		responseLoggerMiddleware("AzureRM"),
+	// the recorder must run last, so that the request has been fully prepared before it's recorded (or replayed)
+	if o.Recorder != nil {
+		requestMiddlewares = append(requestMiddlewares, o.Recorder.RequestMiddleware())
+		responseMiddlewares = append([]client.ResponseMiddleware{o.Recorder.ResponseMiddleware()}, responseMiddlewares...)
 	}
+
+	// the SDK retries requests itself using a policy which can't be configured, so only a policy which disables
+	// retries can be honoured - other settings are warned about when configuring the Provider
+	c.DisableRetries = o.Retry.MaxAttempts == 1
+
+	c.RequestMiddlewares = &requestMiddlewares
+	c.ResponseMiddlewares = &responseMiddlewares
 }
 
 // ConfigureClient sets up an autorest.Client using an autorest.Authorizer
@@ -91,8 +106,15 @@ func (o ClientOptions) ConfigureClient(c *autorest.Client, authorizer autorest.A
 	c.UserAgent = userAgent(c.UserAgent, o.TerraformVersion, o.PartnerId, o.DisableTerraformPartnerID)
 
 	c.Authorizer = authorizer
-	c.Sender = sender.BuildSender("AzureRM")
 	c.SkipResourceProviderRegistration = o.SkipProviderReg
+
+	// transient errors are retried by the Sender, so the retries performed by the SDK are reduced to the minimum - since
+	// `azure.DoRetryWithRegistration` doesn't send the request at all when this is 0
+	c.RetryAttempts = 1
+	c.Sender = autorest.DecorateSender(buildSender(newHTTPLogger("AzureRM", o.HTTPLogging)), retrySendDecorator(o.Retry, *c))
+	if o.Recorder != nil {
+		c.Sender = autorest.DecorateSender(c.Sender, o.Recorder.SendDecorator())
+	}
 	if !o.DisableCorrelationRequestID {
 		id := o.CustomCorrelationRequestID
 		if id == "" {
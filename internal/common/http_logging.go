@@ -0,0 +1,501 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	// defaultHTTPLogMaxBodySize is the default number of bytes of a request/response body which are logged
+	defaultHTTPLogMaxBodySize = 32 * 1024
+
+	// redactedValue is the value used in place of any redacted header, query string parameter or field
+	// NOTE: this matches the value used by terraform-plugin-log when masking fields
+	redactedValue = "***"
+)
+
+// defaultRedactedHeaders is the list of HTTP Headers (matched insensitively) whose values are always redacted
+var defaultRedactedHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Proxy-Authorization",
+	"Set-Cookie",
+	"x-ms-authorization-auxiliary",
+	"x-ms-copy-source-authorization",
+	"x-ms-encryption-key",
+	"x-ms-source-encryption-key",
+}
+
+// defaultRedactedQueryParameters is the list of Query String parameters (matched insensitively) whose values are always redacted
+var defaultRedactedQueryParameters = []string{
+	// the signature of a SAS Token
+	"sig",
+}
+
+// defaultRedactedJSONPaths is the list of paths within JSON bodies whose values are always redacted - see
+// HTTPLoggingOptions.RedactedJSONPaths for the format of these.
+var defaultRedactedJSONPaths = []string{
+	"**.accessKey",
+	"**.accountSasToken",
+	"**.adminPassword",
+	"**.administratorLoginPassword",
+	"**.clientSecret",
+	"**.connectionString",
+	"**.password",
+	"**.primaryConnectionString",
+	"**.primaryKey",
+	"**.primaryMasterKey",
+	"**.primaryReadonlyMasterKey",
+	"**.sasToken",
+	"**.secondaryConnectionString",
+	"**.secondaryKey",
+	"**.secondaryMasterKey",
+	"**.secondaryReadonlyMasterKey",
+	"**.serviceSasToken",
+	"keys.*.value",
+}
+
+// defaultRedactedJSONPathsForDataPlanes is the list of paths within JSON bodies whose values are always redacted for
+// requests to (and responses from) the Data Plane APIs matched by each function. Unlike Resource Manager, these can
+// return secrets at the top-level of the body - which can't be redacted for all requests, since Resource Manager uses
+// the top-level `value` field for the items in a list.
+var defaultRedactedJSONPathsForDataPlanes = []struct {
+	matches func(host string) bool
+	paths   []string
+}{
+	{
+		// Key Vault and Managed HSM: the value of a Secret, a Certificate being imported (and its password),
+		// the private key material of a Key being imported and the backup of a Secret, Certificate or Key
+		matches: func(host string) bool {
+			return strings.Contains(host, ".vault.") || strings.Contains(host, ".managedhsm.")
+		},
+		paths: []string{
+			"value",
+			"pwd",
+			"**.key.d",
+			"**.key.dp",
+			"**.key.dq",
+			"**.key.k",
+			"**.key.p",
+			"**.key.q",
+			"**.key.qi",
+			"**.key.t",
+		},
+	},
+}
+
+// HTTPLoggingOptions configures how the requests sent to (and the responses returned from) the Azure APIs are logged
+type HTTPLoggingOptions struct {
+	// FailedRequestsOnly specifies that only requests which failed (that is, returned a status code of 400 or above)
+	// should be logged, in which case both the request and the response are logged once the response is received
+	FailedRequestsOnly bool
+
+	// MaxBodySize is the maximum number of bytes of the request/response body to log, bodies larger than this are truncated.
+	// A value of 0 uses the default size, a negative value disables logging of bodies entirely.
+	MaxBodySize int
+
+	// RedactedHeaders is a list of HTTP Headers (matched insensitively) whose values should be redacted,
+	// in addition to the default list
+	RedactedHeaders []string
+
+	// RedactedJSONPaths is a list of paths within JSON bodies whose values should be redacted, in addition to the
+	// default list. Each path is a `.` separated list of keys (matched insensitively) - where `*` matches any single
+	// key or list index and `**` matches any number of levels, for example `properties.secrets.*.value` or `**.primaryKey`.
+	RedactedJSONPaths []string
+
+	// Services optionally limits logging to requests for the specified Resource Provider namespaces (matched
+	// insensitively), e.g. `Microsoft.Storage`. Requests which don't target a Resource Provider aren't logged when set.
+	Services []string
+
+	// APIVersions optionally limits logging to requests using the specified API Versions
+	APIVersions []string
+}
+
+// HTTPLoggingOptionsFromEnvironment returns the HTTPLoggingOptions configured using Environment Variables
+// NOTE: FailedRequestsOnly is exposed in the Provider block, so is configured separately
+func HTTPLoggingOptionsFromEnvironment() (*HTTPLoggingOptions, error) {
+	options := HTTPLoggingOptions{
+		RedactedHeaders:   splitEnvironmentVariable("ARM_HTTP_LOG_REDACTED_HEADERS"),
+		RedactedJSONPaths: splitEnvironmentVariable("ARM_HTTP_LOG_REDACTED_JSON_PATHS"),
+		Services:          splitEnvironmentVariable("ARM_HTTP_LOG_SERVICES"),
+		APIVersions:       splitEnvironmentVariable("ARM_HTTP_LOG_API_VERSIONS"),
+	}
+
+	if v := os.Getenv("ARM_HTTP_LOG_MAX_BODY_SIZE"); v != "" {
+		maxBodySize, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("parsing `ARM_HTTP_LOG_MAX_BODY_SIZE` (%q) as an integer: %+v", v, err)
+		}
+		options.MaxBodySize = maxBodySize
+	}
+
+	return &options, nil
+}
+
+func splitEnvironmentVariable(name string) []string {
+	output := make([]string, 0)
+	for _, v := range strings.Split(os.Getenv(name), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			output = append(output, v)
+		}
+	}
+	return output
+}
+
+// httpLogLevelEnvironmentVariables are the Environment Variables (in order of precedence) which configure the log level of the Provider
+var httpLogLevelEnvironmentVariables = []string{
+	"TF_LOG_PROVIDER_AZURERM",
+	"TF_LOG_PROVIDER",
+	"TF_LOG",
+}
+
+// httpLoggingEnabled returns whether the Provider is logging at the DEBUG level (or above), which is required for
+// requests to be logged - checking this up-front avoids reading and parsing every request/response body needlessly
+func httpLoggingEnabled() bool {
+	for _, name := range httpLogLevelEnvironmentVariables {
+		v := os.Getenv(name)
+		if v == "" {
+			continue
+		}
+
+		switch strings.ToUpper(v) {
+		case "INFO", "WARN", "ERROR", "OFF":
+			return false
+		}
+
+		// `DEBUG`, `TRACE` and `JSON` all log at the DEBUG level (or above) - and Terraform treats unknown values as `TRACE`
+		return true
+	}
+
+	return false
+}
+
+// httpLogger builds the structured log fields for requests and responses, applying the configured filtering and redaction
+type httpLogger struct {
+	providerName string
+	options      HTTPLoggingOptions
+
+	// enabled specifies whether requests are logged at all, see httpLoggingEnabled
+	enabled bool
+
+	redactor *HTTPRedactor
+}
+
+func newHTTPLogger(providerName string, options HTTPLoggingOptions) *httpLogger {
+	return &httpLogger{
+		providerName: providerName,
+		options:      options,
+		enabled:      httpLoggingEnabled(),
+		redactor:     NewHTTPRedactor(options.RedactedHeaders, options.RedactedJSONPaths),
+	}
+}
+
+// shouldLog returns whether logging is enabled and the request matches the configured Service and API Version filters
+func (l *httpLogger) shouldLog(request *http.Request) bool {
+	if !l.enabled || request == nil || request.URL == nil {
+		return false
+	}
+
+	if len(l.options.Services) > 0 {
+		namespace := resourceProviderNamespaceFromPath(request.URL.Path)
+		if !containsInsensitively(l.options.Services, namespace) {
+			return false
+		}
+	}
+
+	if len(l.options.APIVersions) > 0 {
+		if !containsInsensitively(l.options.APIVersions, request.URL.Query().Get("api-version")) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (l *httpLogger) requestFields(request *http.Request) map[string]interface{} {
+	fields := map[string]interface{}{
+		"tf_http_op_type":      "request",
+		"tf_http_req_method":   request.Method,
+		"tf_http_req_uri":      l.redactURL(request.URL),
+		"tf_http_req_version":  request.Proto,
+		"tf_http_req_headers":  l.redactHeaders(request.Header),
+		"tf_http_service":      resourceProviderNamespaceFromPath(request.URL.Path),
+		"tf_http_api_version":  request.URL.Query().Get("api-version"),
+		"tf_http_req_body_len": request.ContentLength,
+	}
+
+	if l.options.MaxBodySize >= 0 {
+		body, err := readRequestBody(request)
+		if err != nil {
+			fields["tf_http_req_body"] = fmt.Sprintf("<unable to read the request body: %+v>", err)
+		} else if len(body) > 0 {
+			fields["tf_http_req_body"] = l.redactBody(request.URL, body)
+		}
+	}
+
+	return fields
+}
+
+func (l *httpLogger) responseFields(response *http.Response) map[string]interface{} {
+	fields := map[string]interface{}{
+		"tf_http_op_type":           "response",
+		"tf_http_res_status_code":   response.StatusCode,
+		"tf_http_res_status_reason": response.Status,
+		"tf_http_res_version":       response.Proto,
+		"tf_http_res_headers":       l.redactHeaders(response.Header),
+	}
+
+	if l.options.MaxBodySize >= 0 {
+		body, err := readResponseBody(response)
+		if err != nil {
+			fields["tf_http_res_body"] = fmt.Sprintf("<unable to read the response body: %+v>", err)
+		} else if len(body) > 0 {
+			var requestUrl *url.URL
+			if response.Request != nil {
+				requestUrl = response.Request.URL
+			}
+			fields["tf_http_res_body"] = l.redactBody(requestUrl, body)
+		}
+	}
+
+	return fields
+}
+
+func (l *httpLogger) redactURL(input *url.URL) string {
+	return l.redactor.RedactURL(input)
+}
+
+func (l *httpLogger) redactHeaders(input http.Header) map[string]string {
+	output := make(map[string]string, len(input))
+	for key, values := range l.redactor.RedactHeaders(input) {
+		output[key] = strings.Join(values, ", ")
+	}
+	return output
+}
+
+// redactBody redacts any configured paths within a JSON body and then truncates the body to the configured size.
+// Bodies which aren't JSON (for example the XML used by the Storage Data Plane APIs) can't be redacted, so are omitted.
+func (l *httpLogger) redactBody(requestUrl *url.URL, input []byte) string {
+	if !json.Valid(input) {
+		return fmt.Sprintf("<%d bytes omitted since the body isn't JSON>", len(input))
+	}
+
+	body := string(l.redactor.RedactBody(requestUrl, input))
+
+	maxBodySize := l.options.MaxBodySize
+	if maxBodySize == 0 {
+		maxBodySize = defaultHTTPLogMaxBodySize
+	}
+	if len(body) > maxBodySize {
+		body = fmt.Sprintf("%s... (truncated %d bytes)", body[0:maxBodySize], len(body)-maxBodySize)
+	}
+
+	return body
+}
+
+// HTTPRedactor redacts secrets from HTTP requests and responses, such that these can be written out (for example
+// to the logs, or to a recording of the requests) - using the default lists of headers, query string parameters
+// and JSON paths, in addition to any which are specified
+type HTTPRedactor struct {
+	headers map[string]struct{}
+	paths   [][]string
+}
+
+// NewHTTPRedactor returns a HTTPRedactor which redacts the default headers and JSON paths, in addition to the
+// headers and JSON paths specified - see HTTPLoggingOptions.RedactedJSONPaths for the format of these paths.
+func NewHTTPRedactor(headers []string, jsonPaths []string) *HTTPRedactor {
+	redactor := &HTTPRedactor{
+		headers: make(map[string]struct{}),
+		paths:   make([][]string, 0),
+	}
+
+	for _, v := range append(append([]string{}, defaultRedactedHeaders...), headers...) {
+		redactor.headers[http.CanonicalHeaderKey(v)] = struct{}{}
+	}
+	for _, v := range append(append([]string{}, defaultRedactedJSONPaths...), jsonPaths...) {
+		redactor.paths = append(redactor.paths, strings.Split(v, "."))
+	}
+
+	return redactor
+}
+
+// RedactURL returns the URL with any credentials and sensitive query string parameters redacted
+func (r *HTTPRedactor) RedactURL(input *url.URL) string {
+	if input == nil {
+		return ""
+	}
+
+	output := *input
+	output.User = nil
+	query := output.Query()
+	redacted := false
+	for key := range query {
+		if containsInsensitively(defaultRedactedQueryParameters, key) {
+			query.Set(key, redactedValue)
+			redacted = true
+		}
+	}
+	if redacted {
+		output.RawQuery = query.Encode()
+	}
+	return output.String()
+}
+
+// RedactHeaders returns a copy of the headers with the values of any sensitive headers redacted
+func (r *HTTPRedactor) RedactHeaders(input http.Header) http.Header {
+	output := make(http.Header, len(input))
+	for key, values := range input {
+		if _, ok := r.headers[http.CanonicalHeaderKey(key)]; ok {
+			output[key] = []string{redactedValue}
+			continue
+		}
+		output[key] = append([]string{}, values...)
+	}
+	return output
+}
+
+// RedactBody returns the body of a request to (or a response from) `requestUrl` with the values at any sensitive
+// paths redacted, including those specific to the Data Plane API being called - when the body is JSON it's
+// re-encoded (with the keys sorted), otherwise the body is returned as-is
+func (r *HTTPRedactor) RedactBody(requestUrl *url.URL, input []byte) []byte {
+	var decoded interface{}
+	decoder := json.NewDecoder(bytes.NewReader(input))
+	decoder.UseNumber()
+	if err := decoder.Decode(&decoded); err != nil {
+		return input
+	}
+
+	for _, path := range r.paths {
+		decoded = redactJSONPath(decoded, path)
+	}
+	if requestUrl != nil {
+		host := strings.ToLower(requestUrl.Hostname())
+		for _, dataPlane := range defaultRedactedJSONPathsForDataPlanes {
+			if !dataPlane.matches(host) {
+				continue
+			}
+			for _, path := range dataPlane.paths {
+				decoded = redactJSONPath(decoded, strings.Split(path, "."))
+			}
+		}
+	}
+	encoded, err := json.Marshal(decoded)
+	if err != nil {
+		return input
+	}
+	return encoded
+}
+
+// redactJSONPath replaces the value(s) found at the path `path` within `input` with the redacted value
+func redactJSONPath(input interface{}, path []string) interface{} {
+	if len(path) == 0 {
+		return redactedValue
+	}
+
+	key := path[0]
+	if key == "**" {
+		// `**` matches zero levels..
+		input = redactJSONPath(input, path[1:])
+
+		// ..or one or more levels
+		switch v := input.(type) {
+		case map[string]interface{}:
+			for k, item := range v {
+				v[k] = redactJSONPath(item, path)
+			}
+		case []interface{}:
+			for i, item := range v {
+				v[i] = redactJSONPath(item, path)
+			}
+		}
+		return input
+	}
+
+	switch v := input.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			if key == "*" || strings.EqualFold(k, key) {
+				v[k] = redactJSONPath(item, path[1:])
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			if key == "*" || key == strconv.Itoa(i) {
+				v[i] = redactJSONPath(item, path[1:])
+			}
+		}
+	}
+	return input
+}
+
+// resourceProviderNamespaceFromPath returns the last Resource Provider namespace within the URI Path, if any
+func resourceProviderNamespaceFromPath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := len(segments) - 2; i >= 0; i-- {
+		if strings.EqualFold(segments[i], "providers") {
+			return segments[i+1]
+		}
+	}
+	return ""
+}
+
+func containsInsensitively(input []string, value string) bool {
+	for _, v := range input {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// readRequestBody returns the body of the request, ensuring that the request body can still be sent afterwards
+func readRequestBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil, nil
+	}
+
+	if request.GetBody != nil {
+		body, err := request.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return io.ReadAll(body)
+	}
+
+	contents, err := io.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	request.Body.Close()
+	request.Body = io.NopCloser(bytes.NewReader(contents))
+	request.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(contents)), nil
+	}
+	return contents, nil
+}
+
+// readResponseBody returns the body of the response, ensuring that the response body can still be read afterwards
+func readResponseBody(response *http.Response) ([]byte, error) {
+	if response.Body == nil || response.Body == http.NoBody {
+		return nil, nil
+	}
+
+	contents, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	response.Body.Close()
+	response.Body = io.NopCloser(bytes.NewReader(contents))
+	return contents, nil
+}
@@ -0,0 +1,392 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestHTTPLoggerRedactBody(t *testing.T) {
+	testData := []struct {
+		name     string
+		options  HTTPLoggingOptions
+		input    string
+		expected string
+	}{
+		{
+			name:     "not json",
+			input:    `<?xml version="1.0" encoding="utf-8"?><UserDelegationKey><Value>secret</Value></UserDelegationKey>`,
+			expected: "<98 bytes omitted since the body isn't JSON>",
+		},
+		{
+			name:     "nothing to redact",
+			input:    `{"name":"example","properties":{"enabled":true,"count":12345678901234567890}}`,
+			expected: `{"name":"example","properties":{"count":12345678901234567890,"enabled":true}}`,
+		},
+		{
+			name:     "default paths",
+			input:    `{"properties":{"primaryKey":"abc","nested":[{"PrimaryConnectionString":"def"}]},"keys":[{"keyName":"key1","value":"ghi"}]}`,
+			expected: `{"keys":[{"keyName":"key1","value":"***"}],"properties":{"nested":[{"PrimaryConnectionString":"***"}],"primaryKey":"***"}}`,
+		},
+		{
+			name: "custom paths",
+			options: HTTPLoggingOptions{
+				RedactedJSONPaths: []string{"properties.secrets.*.value", "properties.list.1"},
+			},
+			input:    `{"properties":{"secrets":[{"name":"first","value":"abc"},{"name":"second","value":"def"}],"list":["a","b","c"]}}`,
+			expected: `{"properties":{"list":["a","***","c"],"secrets":[{"name":"first","value":"***"},{"name":"second","value":"***"}]}}`,
+		},
+		{
+			name: "truncated",
+			options: HTTPLoggingOptions{
+				MaxBodySize: 5,
+			},
+			input:    `"hello world"`,
+			expected: `"hell... (truncated 8 bytes)`,
+		},
+		{
+			name:     "list of resources",
+			input:    `{"value":[{"name":"first"},{"name":"second"}]}`,
+			expected: `{"value":[{"name":"first"},{"name":"second"}]}`,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual := newHTTPLogger("AzureRM", v.options).redactBody(nil, []byte(v.input))
+		if actual != v.expected {
+			t.Fatalf("expected %q but got %q", v.expected, actual)
+		}
+	}
+}
+
+func TestHTTPLoggerRequestFields(t *testing.T) {
+	body := `{"properties":{"password":"secret"}}`
+	request, err := http.NewRequest(http.MethodPut, "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1?api-version=2023-01-01&sig=abc", bytes.NewBufferString(body))
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	request.Header.Set("Authorization", "Bearer abc")
+	request.Header.Set("X-Custom-Secret", "def")
+	request.Header.Set("Content-Type", "application/json")
+
+	logger := newHTTPLogger("AzureRM", HTTPLoggingOptions{
+		RedactedHeaders: []string{"x-custom-secret"},
+	})
+	fields := logger.requestFields(request)
+
+	if v := fields["tf_http_req_uri"].(string); strings.Contains(v, "sig=abc") {
+		t.Fatalf("expected the `sig` query string parameter to be redacted but got %q", v)
+	}
+	headers := fields["tf_http_req_headers"].(map[string]string)
+	if headers["Authorization"] != redactedValue || headers["X-Custom-Secret"] != redactedValue {
+		t.Fatalf("expected the `Authorization` and `X-Custom-Secret` headers to be redacted but got %+v", headers)
+	}
+	if headers["Content-Type"] != "application/json" {
+		t.Fatalf("expected the `Content-Type` header to be logged but got %+v", headers)
+	}
+	if v := fields["tf_http_req_body"].(string); v != `{"properties":{"password":"***"}}` {
+		t.Fatalf("expected the `password` field to be redacted but got %q", v)
+	}
+	if v := fields["tf_http_service"].(string); v != "Microsoft.Storage" {
+		t.Fatalf("expected the service to be `Microsoft.Storage` but got %q", v)
+	}
+
+	// the request body must still be readable once it's been logged
+	contents, err := io.ReadAll(request.Body)
+	if err != nil {
+		t.Fatalf("reading request body: %+v", err)
+	}
+	if string(contents) != body {
+		t.Fatalf("expected the request body to be %q but got %q", body, string(contents))
+	}
+}
+
+func TestHTTPLoggerKeyVaultSecret(t *testing.T) {
+	logger := newHTTPLogger("AzureRM", HTTPLoggingOptions{})
+
+	for _, method := range []string{http.MethodPut, http.MethodGet} {
+		t.Logf("[DEBUG] Testing %s..", method)
+
+		var requestBody io.Reader
+		if method == http.MethodPut {
+			requestBody = bytes.NewBufferString(`{"value":"request-secret","contentType":"text/plain"}`)
+		}
+		request, err := http.NewRequest(method, "https://example.vault.azure.net/secrets/secret1?api-version=7.4", requestBody)
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+		response := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(bytes.NewBufferString(`{"value":"response-secret","id":"https://example.vault.azure.net/secrets/secret1/abc123","attributes":{"enabled":true}}`)),
+			Request:    request,
+		}
+
+		logged := fmt.Sprintf("%+v %+v", logger.requestFields(request), logger.responseFields(response))
+		for _, secret := range []string{"request-secret", "response-secret"} {
+			if strings.Contains(logged, secret) {
+				t.Fatalf("expected %q to be redacted but got %s", secret, logged)
+			}
+		}
+		if !strings.Contains(logged, "https://example.vault.azure.net/secrets/secret1/abc123") {
+			t.Fatalf("expected the ID of the Secret to be logged but got %s", logged)
+		}
+	}
+}
+
+func TestHTTPLoggerKeyVaultCertificateImport(t *testing.T) {
+	body := `{"value":"MIIJ...","pwd":"certificate-password","policy":{"key_props":{"exportable":true}}}`
+	request, err := http.NewRequest(http.MethodPost, "https://example.vault.azure.net/certificates/cert1/import?api-version=7.4", bytes.NewBufferString(body))
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+
+	fields := newHTTPLogger("AzureRM", HTTPLoggingOptions{}).requestFields(request)
+	expected := `{"policy":{"key_props":{"exportable":true}},"pwd":"***","value":"***"}`
+	if v := fields["tf_http_req_body"].(string); v != expected {
+		t.Fatalf("expected %q but got %q", expected, v)
+	}
+}
+
+func TestHTTPLoggerShouldLog(t *testing.T) {
+	testData := []struct {
+		name     string
+		options  HTTPLoggingOptions
+		uri      string
+		expected bool
+	}{
+		{
+			name:     "no filters",
+			uri:      "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000?api-version=2022-12-01",
+			expected: true,
+		},
+		{
+			name: "matching service",
+			options: HTTPLoggingOptions{
+				Services: []string{"microsoft.storage"},
+			},
+			uri:      "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1?api-version=2023-01-01",
+			expected: true,
+		},
+		{
+			name: "different service",
+			options: HTTPLoggingOptions{
+				Services: []string{"Microsoft.Network"},
+			},
+			uri:      "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1?api-version=2023-01-01",
+			expected: false,
+		},
+		{
+			name: "nested service",
+			options: HTTPLoggingOptions{
+				Services: []string{"Microsoft.Authorization"},
+			},
+			uri:      "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1/providers/Microsoft.Authorization/locks/lock1?api-version=2020-05-01",
+			expected: true,
+		},
+		{
+			name: "no service",
+			options: HTTPLoggingOptions{
+				Services: []string{"Microsoft.Storage"},
+			},
+			uri:      "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000?api-version=2022-12-01",
+			expected: false,
+		},
+		{
+			name: "matching api version",
+			options: HTTPLoggingOptions{
+				APIVersions: []string{"2023-01-01"},
+			},
+			uri:      "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1?api-version=2023-01-01",
+			expected: true,
+		},
+		{
+			name: "different api version",
+			options: HTTPLoggingOptions{
+				APIVersions: []string{"2022-09-01"},
+			},
+			uri:      "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1?api-version=2023-01-01",
+			expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		request, err := http.NewRequest(http.MethodGet, v.uri, nil)
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+
+		logger := newHTTPLogger("AzureRM", v.options)
+		logger.enabled = true
+		actual := logger.shouldLog(request)
+		if actual != v.expected {
+			t.Fatalf("expected %t but got %t", v.expected, actual)
+		}
+	}
+}
+
+func TestHTTPLoggingEnabled(t *testing.T) {
+	testData := []struct {
+		name        string
+		environment map[string]string
+		expected    bool
+	}{
+		{
+			name:     "not set",
+			expected: false,
+		},
+		{
+			name: "debug",
+			environment: map[string]string{
+				"TF_LOG": "debug",
+			},
+			expected: true,
+		},
+		{
+			name: "info",
+			environment: map[string]string{
+				"TF_LOG": "INFO",
+			},
+			expected: false,
+		},
+		{
+			name: "provider overrides core",
+			environment: map[string]string{
+				"TF_LOG":          "TRACE",
+				"TF_LOG_PROVIDER": "WARN",
+			},
+			expected: false,
+		},
+		{
+			name: "azurerm overrides provider",
+			environment: map[string]string{
+				"TF_LOG_PROVIDER":         "ERROR",
+				"TF_LOG_PROVIDER_AZURERM": "DEBUG",
+			},
+			expected: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		for _, name := range httpLogLevelEnvironmentVariables {
+			t.Setenv(name, v.environment[name])
+		}
+
+		if actual := httpLoggingEnabled(); actual != v.expected {
+			t.Fatalf("expected %t but got %t", v.expected, actual)
+		}
+	}
+}
+
+func TestHTTPLoggerAutorestSender(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"keys":[{"keyName":"key1","value":"response-secret"}]}`))
+	}))
+	defer server.Close()
+
+	logger := newHTTPLogger("AzureRM", HTTPLoggingOptions{})
+	logger.enabled = true
+
+	c := autorest.NewClientWithUserAgent("example")
+	c.Sender = buildSender(logger)
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	request, err := autorest.Prepare((&http.Request{}).WithContext(ctx),
+		autorest.AsPost(),
+		autorest.WithBaseURL(server.URL),
+		autorest.WithPath("/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Storage/storageAccounts/account1/listKeys"),
+		autorest.WithQueryParameters(map[string]interface{}{"sig": "query-secret"}),
+		autorest.WithHeader("Authorization", "Bearer header-secret"),
+		autorest.WithJSON(map[string]interface{}{"password": "request-secret"}))
+	if err != nil {
+		t.Fatalf("preparing request: %+v", err)
+	}
+
+	resp, err := autorest.SendWithSender(c, request)
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+
+	// the response body must still be readable once it's been logged
+	contents, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading response body: %+v", err)
+	}
+	if !strings.Contains(string(contents), "response-secret") {
+		t.Fatalf("expected the response body to be unmodified but got %q", string(contents))
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("decoding log entries: %+v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 log entries but got %d: %s", len(entries), output.String())
+	}
+	if entries[0]["tf_http_op_type"] != "request" || entries[1]["tf_http_op_type"] != "response" {
+		t.Fatalf("expected a request and a response to be logged but got %+v", entries)
+	}
+	if entries[0]["tf_http_trans_id"] != entries[1]["tf_http_trans_id"] {
+		t.Fatalf("expected the request and response to share a transaction ID but got %+v", entries)
+	}
+
+	for _, entry := range entries {
+		logged := fmt.Sprintf("%+v", entry)
+		for _, secret := range []string{"query-secret", "header-secret", "request-secret", "response-secret"} {
+			if strings.Contains(logged, secret) {
+				t.Fatalf("expected %q to be redacted but got %s", secret, logged)
+			}
+		}
+	}
+}
+
+func TestHTTPLoggerAutorestSenderDisabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	logger := newHTTPLogger("AzureRM", HTTPLoggingOptions{})
+	logger.enabled = false
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, server.URL, bytes.NewBufferString(`{"password":"secret"}`))
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	// the request body mustn't be read (or buffered) when logging is disabled
+	request.GetBody = nil
+
+	if _, err := buildSender(logger).Do(request); err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+	if output.Len() > 0 {
+		t.Fatalf("expected nothing to be logged but got %s", output.String())
+	}
+	if request.GetBody != nil {
+		t.Fatalf("expected the request body not to be read when logging is disabled")
+	}
+}
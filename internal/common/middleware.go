@@ -4,11 +4,14 @@
 package common
 
 import (
-	"log"
+	"context"
+	"fmt"
 	"net/http"
-	"net/http/httputil"
 
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 func correlationRequestIDMiddleware(id string) client.RequestMiddleware {
@@ -19,41 +22,107 @@ func correlationRequestIDMiddleware(id string) client.RequestMiddleware {
 	}
 }
 
-func requestLoggerMiddleware(providerName string) client.RequestMiddleware {
+// httpLogEntryKey is the key used to store the httpLogEntry for a request within the request context
+type httpLogEntryKey struct{}
+
+// httpLogEntry contains the fields logged for a request, so that the response can be correlated with
+// the request - and so that the request can be logged once the response is known to have failed
+type httpLogEntry struct {
+	transactionId string
+	fields        map[string]interface{}
+}
+
+func requestLoggerMiddleware(logger *httpLogger) client.RequestMiddleware {
 	return func(request *http.Request) (*http.Request, error) {
-		// strip the authorization header prior to printing
-		authHeaderName := "Authorization"
-		auth := request.Header.Get(authHeaderName)
-		if auth != "" {
-			request.Header.Del(authHeaderName)
+		if !logger.shouldLog(request) {
+			return request, nil
 		}
 
-		// dump request to wire format
-		if dump, err := httputil.DumpRequestOut(request, true); err == nil {
-			log.Printf("[DEBUG] %s Request: \n%s\n", providerName, dump)
-		} else {
-			// fallback to basic message
-			log.Printf("[DEBUG] %s Request: %s to %s\n", providerName, request.Method, request.URL)
+		// the transaction ID is only used to correlate the request and response, so an error here isn't fatal
+		transactionId, _ := uuid.GenerateUUID()
+
+		fields := logger.requestFields(request)
+		fields["tf_http_trans_id"] = transactionId
+		entry := &httpLogEntry{
+			transactionId: transactionId,
+			fields:        fields,
 		}
+		request = request.WithContext(context.WithValue(request.Context(), httpLogEntryKey{}, entry))
 
-		// add the auth header back
-		if auth != "" {
-			request.Header.Add(authHeaderName, auth)
+		// when only logging failed requests, the request is logged alongside the response
+		if !logger.options.FailedRequestsOnly {
+			tflog.Debug(request.Context(), fmt.Sprintf("%s Request", logger.providerName), fields)
 		}
 
 		return request, nil
 	}
 }
 
-func responseLoggerMiddleware(providerName string) client.ResponseMiddleware {
+func responseLoggerMiddleware(logger *httpLogger) client.ResponseMiddleware {
 	return func(request *http.Request, response *http.Response) (*http.Response, error) {
-		// dump response to wire format
-		if dump, err2 := httputil.DumpResponse(response, true); err2 == nil {
-			log.Printf("[DEBUG] %s Response for %s: \n%s\n", providerName, request.URL, dump)
-		} else {
-			// fallback to basic message
-			log.Printf("[DEBUG] %s Response: %s for %s\n", providerName, response.Status, request.URL)
+		// requests which have been filtered out won't have a log entry
+		entry, ok := request.Context().Value(httpLogEntryKey{}).(*httpLogEntry)
+		if !ok || response == nil {
+			return response, nil
 		}
+
+		failed := response.StatusCode >= http.StatusBadRequest
+		if logger.options.FailedRequestsOnly {
+			if !failed {
+				return response, nil
+			}
+			tflog.Debug(request.Context(), fmt.Sprintf("%s Request", logger.providerName), entry.fields)
+		}
+
+		fields := logger.responseFields(response)
+		fields["tf_http_trans_id"] = entry.transactionId
+		fields["tf_http_req_method"] = request.Method
+		fields["tf_http_req_uri"] = logger.redactURL(request.URL)
+		tflog.Debug(request.Context(), fmt.Sprintf("%s Response", logger.providerName), fields)
+
 		return response, nil
 	}
 }
+
+// buildSender returns the autorest.Sender used by autorest based clients, which logs requests and responses
+// in the same (redacted) manner as the clients based on hashicorp/go-azure-sdk
+func buildSender(logger *httpLogger) autorest.Sender {
+	return autorest.DecorateSender(&http.Client{
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+		},
+	}, loggingSendDecorator(logger))
+}
+
+func loggingSendDecorator(logger *httpLogger) autorest.SendDecorator {
+	requestLogger := requestLoggerMiddleware(logger)
+	responseLogger := responseLoggerMiddleware(logger)
+
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			request, err := requestLogger(r)
+			if err != nil {
+				return nil, err
+			}
+
+			resp, err := s.Do(request)
+			if resp != nil {
+				return responseLogger(request, resp)
+			}
+
+			if entry, ok := request.Context().Value(httpLogEntryKey{}).(*httpLogEntry); ok && err != nil {
+				if logger.options.FailedRequestsOnly {
+					tflog.Debug(request.Context(), fmt.Sprintf("%s Request", logger.providerName), entry.fields)
+				}
+				tflog.Debug(request.Context(), fmt.Sprintf("%s Response Error", logger.providerName), map[string]interface{}{
+					"tf_http_op_type":    "response",
+					"tf_http_trans_id":   entry.transactionId,
+					"tf_http_req_method": request.Method,
+					"tf_http_req_uri":    logger.redactURL(request.URL),
+					"tf_http_res_error":  err.Error(),
+				})
+			}
+			return resp, err
+		})
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+)
+
+// Recorder intercepts the requests sent by the API Clients, allowing these to be recorded and then replayed
+// (without access to Azure) in the Acceptance Tests - or to be served by a fake implementation of Resource Manager
+type Recorder interface {
+	// RequestMiddleware returns the Request Middleware used for clients based on hashicorp/go-azure-sdk
+	RequestMiddleware() client.RequestMiddleware
+
+	// ResponseMiddleware returns the Response Middleware used for clients based on hashicorp/go-azure-sdk
+	ResponseMiddleware() client.ResponseMiddleware
+
+	// SendDecorator returns the Send Decorator used for clients based on Azure/go-autorest
+	SendDecorator() autorest.SendDecorator
+
+	// ReplayCredentials returns the credentials to use in place of those configured when requests are
+	// being replayed, or nil when requests are sent to Azure
+	ReplayCredentials() *ReplayCredentials
+}
+
+// ReplayCredentials are used in place of the configured credentials when requests are being replayed
+type ReplayCredentials struct {
+	// Authorizer is used to authorize requests for all APIs
+	Authorizer auth.Authorizer
+
+	// SubscriptionId is the Subscription ID which was used when the requests were recorded
+	SubscriptionId string
+}
@@ -0,0 +1,474 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+const (
+	// DefaultRetryMaxAttempts is the default number of times a request which failed with a transient error is sent
+	DefaultRetryMaxAttempts = 4
+
+	// DefaultRetryMaxBackoff is the default maximum duration to wait before retrying a request
+	DefaultRetryMaxBackoff = 2 * time.Minute
+
+	// retryMinBackoff is the duration to wait before the first retry, which is doubled for each subsequent attempt
+	retryMinBackoff = 5 * time.Second
+)
+
+// retryableErrorCodes are the error codes returned by Azure when a request conflicts with another operation in progress
+// (for example on the parent resource) - which succeed when retried once that operation has completed
+var retryableErrorCodes = []string{
+	"AnotherOperationInProgress",
+	"OngoingOperationInProgress",
+	"ReferencedResourceNotProvisioned",
+	"RetryableError",
+}
+
+// RetryPolicy determines how many times (and how often) a request which failed with a transient error is retried
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a request is sent, including the first attempt.
+	// A value of 1 disables retries.
+	MaxAttempts int
+
+	// MaxBackoff is the maximum duration to wait before retrying a request, including the duration specified
+	// by the `Retry-After` header
+	MaxBackoff time.Duration
+}
+
+// RetryOptions configures the retries for requests which failed with a transient error, that is:
+//
+// * throttled requests (429), honouring the `Retry-After` header
+// * requests which conflict with another operation in progress (e.g. on the parent resource)
+// * requests which timed out (408), couldn't be sent or failed with a server error (5xx, other than 501 and 505) - unless the
+// request is a POST which isn't idempotent (a POST for an action which only reads data, such as `listKeys`, is retried)
+//
+// These replace the retries performed by clients based on Azure/go-autorest. Clients based on hashicorp/go-azure-sdk
+// retry requests using the SDK's own retry policy, which can't be configured - as such only a MaxAttempts of 1 is
+// honoured by these clients (disabling the retries which the SDK performs to work around eventual consistency), and
+// the MaxBackoff and ServiceOverrides are ignored.
+type RetryOptions struct {
+	RetryPolicy
+
+	// ServiceOverrides are the Retry Policies for requests to specific Resource Providers, keyed by
+	// the namespace of the Resource Provider (e.g. `Microsoft.Storage`) - matched case-insensitively
+	ServiceOverrides map[string]RetryPolicy
+}
+
+// DefaultRetryOptions returns the RetryOptions used when none are configured
+func DefaultRetryOptions() RetryOptions {
+	return RetryOptions{
+		RetryPolicy: RetryPolicy{
+			MaxAttempts: DefaultRetryMaxAttempts,
+			MaxBackoff:  DefaultRetryMaxBackoff,
+		},
+		ServiceOverrides: map[string]RetryPolicy{},
+	}
+}
+
+// policyFor returns the Retry Policy for the request, taking into account any override for the Resource Provider
+func (o RetryOptions) policyFor(request *http.Request) RetryPolicy {
+	if namespace := resourceProviderNamespace(request); namespace != "" {
+		for k, v := range o.ServiceOverrides {
+			if strings.EqualFold(k, namespace) {
+				return v
+			}
+		}
+	}
+	return o.RetryPolicy
+}
+
+// backoff returns the duration to wait before sending the request again, honouring the `Retry-After` header
+func (p RetryPolicy) backoff(attempt int, response *http.Response) time.Duration {
+	wait := retryMinBackoff << (attempt - 1)
+	if retryAfter, ok := parseRetryAfter(response); ok {
+		wait = retryAfter
+	}
+
+	if wait > p.MaxBackoff || wait < 0 {
+		wait = p.MaxBackoff
+	}
+	return wait
+}
+
+// send sends the request (using `send`) until either the response doesn't indicate a transient error or the Retry Policy
+// for the request is exhausted - returning the last response received. When `register` is specified it's used to register
+// the Resource Provider when the Subscription isn't registered for it, before the request is sent again.
+func (o RetryOptions) send(request *http.Request, send func(*http.Request) (*http.Response, error), register func(*http.Request, string) error) (*http.Response, error) {
+	policy := o.policyFor(request)
+
+	if err := bufferRequestBody(request); err != nil {
+		return nil, err
+	}
+
+	attemptRequest := request
+	for attempt := 1; ; attempt++ {
+		response, err := send(attemptRequest)
+
+		reason := ""
+		if err != nil {
+			reason = retryReasonForError(request, err)
+		} else if response == nil {
+			return nil, fmt.Errorf("HTTP response was nil when sending %s %s; connection may have been reset", request.Method, redactedRequestURL(request))
+		} else if register != nil && retryNamespaceToRegister(response) != "" {
+			namespace := retryNamespaceToRegister(response)
+			if regErr := register(request, namespace); regErr != nil {
+				return response, fmt.Errorf("failed auto registering Resource Provider %q: %+v", namespace, regErr)
+			}
+			reason = fmt.Sprintf("the Resource Provider %q has been registered", namespace)
+		} else {
+			reason = retryReason(request, response)
+		}
+
+		if reason == "" {
+			return response, err
+		}
+
+		if attempt >= policy.MaxAttempts {
+			if policy.MaxAttempts > 1 {
+				log.Printf("[WARN] AzureRM: giving up on %s %s after %d attempts since %s", request.Method, redactedRequestURL(request), attempt, reason)
+			}
+			return response, err
+		}
+
+		wait := policy.backoff(attempt, response)
+		log.Printf("[WARN] AzureRM: retrying %s %s (attempt %d of %d) in %s since %s", request.Method, redactedRequestURL(request), attempt+1, policy.MaxAttempts, wait, reason)
+
+		// the response is being discarded, so the connection can be reused
+		if response != nil && response.Body != nil {
+			_, _ = io.Copy(io.Discard, response.Body)
+			response.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-request.Context().Done():
+			timer.Stop()
+			return nil, fmt.Errorf("waiting to retry %s %s: %+v", request.Method, redactedRequestURL(request), request.Context().Err())
+		case <-timer.C:
+		}
+
+		attemptRequest = request.Clone(request.Context())
+		if request.GetBody != nil {
+			body, err := request.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("retrieving the body to retry %s %s: %+v", request.Method, redactedRequestURL(request), err)
+			}
+			attemptRequest.Body = body
+		}
+	}
+}
+
+// retrySendDecorator retries requests sent by clients based on Azure/go-autorest which failed with a transient error,
+// replacing the retries performed by `autorest.DoRetryForStatusCodes` and `azure.DoRetryWithRegistration` - including
+// the registration of the Resource Provider when the Subscription isn't registered for it, unless this is skipped
+func retrySendDecorator(options RetryOptions, client autorest.Client) autorest.SendDecorator {
+	return func(sender autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(request *http.Request) (*http.Response, error) {
+			var register func(*http.Request, string) error
+			if !client.SkipResourceProviderRegistration {
+				register = func(request *http.Request, namespace string) error {
+					return registerResourceProvider(options, client, sender, request, namespace)
+				}
+			}
+			return options.send(request, sender.Do, register)
+		})
+	}
+}
+
+// registerResourceProvider registers the Subscription which the request is for with the Resource Provider `namespace`,
+// then waits for the registration to complete - as `azure.DoRetryWithRegistration` does. These requests are sent using
+// `sender` (which isn't decorated) so that a failure to register isn't itself handled by registering.
+func registerResourceProvider(options RetryOptions, client autorest.Client, sender autorest.Sender, originalRequest *http.Request, namespace string) error {
+	subscriptionId := ""
+	segments := strings.Split(originalRequest.URL.Path, "/")
+	for i := 0; i < len(segments)-1; i++ {
+		if strings.EqualFold(segments[i], "subscriptions") {
+			subscriptionId = segments[i+1]
+			break
+		}
+	}
+	if subscriptionId == "" {
+		return fmt.Errorf("the Subscription ID couldn't be determined from the request")
+	}
+
+	ctx := originalRequest.Context()
+	baseUri := fmt.Sprintf("%s://%s", originalRequest.URL.Scheme, originalRequest.URL.Host)
+	pathParameters := map[string]interface{}{
+		"resourceProviderNamespace": autorest.Encode("path", namespace),
+		"subscriptionId":            autorest.Encode("path", subscriptionId),
+	}
+	queryParameters := map[string]interface{}{
+		"api-version": "2016-09-01",
+	}
+
+	sendAndUnmarshal := func(decorator autorest.PrepareDecorator, path string) (*http.Response, *string, error) {
+		request, err := autorest.Prepare((&http.Request{}).WithContext(ctx),
+			decorator,
+			autorest.WithBaseURL(baseUri),
+			autorest.WithPathParameters(path, pathParameters),
+			autorest.WithQueryParameters(queryParameters),
+			autorest.WithUserAgent(client.UserAgent),
+			client.WithAuthorization())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		response, err := options.send(request, sender.Do, nil)
+		if err != nil {
+			return response, nil, err
+		}
+
+		var provider struct {
+			RegistrationState *string `json:"registrationState,omitempty"`
+		}
+		err = autorest.Respond(response,
+			azure.WithErrorUnlessStatusCode(http.StatusOK),
+			autorest.ByUnmarshallingJSON(&provider),
+			autorest.ByClosing())
+		return response, provider.RegistrationState, err
+	}
+
+	if _, _, err := sendAndUnmarshal(autorest.AsPost(), "/subscriptions/{subscriptionId}/providers/{resourceProviderNamespace}/register"); err != nil {
+		return fmt.Errorf("registering: %+v", err)
+	}
+
+	registrationStartTime := time.Now()
+	for client.PollingDuration == 0 || time.Since(registrationStartTime) < client.PollingDuration {
+		response, registrationState, err := sendAndUnmarshal(autorest.AsGet(), "/subscriptions/{subscriptionId}/providers/{resourceProviderNamespace}")
+		if err != nil {
+			return fmt.Errorf("polling for the registration state: %+v", err)
+		}
+		if registrationState != nil && strings.EqualFold(*registrationState, "Registered") {
+			return nil
+		}
+
+		if !autorest.DelayWithRetryAfter(response, ctx.Done()) && !autorest.DelayForBackoff(client.PollingDelay, 0, ctx.Done()) {
+			return ctx.Err()
+		}
+	}
+
+	return fmt.Errorf("polling for the registration state exceeded the polling duration of %s", client.PollingDuration)
+}
+
+func bufferRequestBody(request *http.Request) error {
+	if request.Body == nil || request.Body == http.NoBody || request.GetBody != nil {
+		return nil
+	}
+
+	body, err := io.ReadAll(request.Body)
+	if err != nil {
+		return fmt.Errorf("reading request body: %+v", err)
+	}
+	request.Body.Close()
+
+	request.Body = io.NopCloser(bytes.NewReader(body))
+	request.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return nil
+}
+
+// retryReason returns why the request should be retried based on the response, or an empty string if it shouldn't be
+func retryReason(request *http.Request, response *http.Response) string {
+	if response == nil {
+		return ""
+	}
+
+	if response.StatusCode == http.StatusTooManyRequests {
+		return "the request was throttled (429)"
+	}
+
+	if response.StatusCode >= http.StatusBadRequest {
+		if code := parseErrorResponse(response).code; code != "" {
+			for _, v := range retryableErrorCodes {
+				if strings.EqualFold(v, code) {
+					return fmt.Sprintf("the request conflicted with another operation (%d %s)", response.StatusCode, code)
+				}
+			}
+		}
+	}
+
+	// a POST which isn't idempotent may have been (partially) processed before the server error was returned
+	if !isIdempotent(request) {
+		return ""
+	}
+
+	if response.StatusCode == http.StatusRequestTimeout {
+		return "the request timed out (408)"
+	}
+
+	if response.StatusCode >= http.StatusInternalServerError && response.StatusCode != http.StatusNotImplemented && response.StatusCode != http.StatusHTTPVersionNotSupported {
+		return fmt.Sprintf("the server returned a transient error (%d)", response.StatusCode)
+	}
+
+	return ""
+}
+
+// retryReasonForError returns why the request should be retried when it couldn't be sent, or an empty string if it shouldn't be
+func retryReasonForError(request *http.Request, err error) string {
+	// the request may have been (partially) processed before the connection failed
+	if !isIdempotent(request) {
+		return ""
+	}
+
+	if request.Context().Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return ""
+	}
+
+	return fmt.Sprintf("the request failed: %+v", err)
+}
+
+// idempotentPostActionPrefixes are the prefixes of the actions which are sent as a POST but only read data
+// (e.g. `listKeys`), which can be safely sent again
+var idempotentPostActionPrefixes = []string{
+	"checkNameAvailability",
+	"get",
+	"list",
+}
+
+// isIdempotent returns whether the request can be safely sent again, which is the case for all requests other than
+// a POST - unless the POST is for an action which only reads data (e.g. `listKeys`)
+func isIdempotent(request *http.Request) bool {
+	if request.Method != http.MethodPost {
+		return true
+	}
+	if request.URL == nil {
+		return false
+	}
+
+	segments := strings.Split(strings.Trim(request.URL.Path, "/"), "/")
+	action := strings.ToLower(segments[len(segments)-1])
+	for _, prefix := range idempotentPostActionPrefixes {
+		if strings.HasPrefix(action, strings.ToLower(prefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryNamespaceToRegister returns the namespace of the Resource Provider which the Subscription needs to be registered
+// with before the request can succeed, or an empty string if the response doesn't indicate this
+func retryNamespaceToRegister(response *http.Response) string {
+	if response.StatusCode != http.StatusConflict {
+		return ""
+	}
+
+	parsed := parseErrorResponse(response)
+	if !strings.EqualFold(parsed.code, "MissingSubscriptionRegistration") {
+		return ""
+	}
+	return parsed.target
+}
+
+type errorResponse struct {
+	code string
+
+	// target is the target of the first error detail, which is the namespace of the Resource Provider for the
+	// error code `MissingSubscriptionRegistration`
+	target string
+}
+
+// parseErrorResponse returns the error code (and target) from an Azure error response, restoring the response body once it's been read
+func parseErrorResponse(response *http.Response) errorResponse {
+	if response.Body == nil || response.Body == http.NoBody {
+		return errorResponse{}
+	}
+
+	body, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	response.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return errorResponse{}
+	}
+
+	type errorDetail struct {
+		Code    string `json:"code"`
+		Details []struct {
+			Target string `json:"target"`
+		} `json:"details"`
+	}
+	var payload struct {
+		errorDetail
+		Error *errorDetail `json:"error"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return errorResponse{}
+	}
+
+	detail := payload.errorDetail
+	if payload.Error != nil && payload.Error.Code != "" {
+		detail = *payload.Error
+	}
+	output := errorResponse{
+		code: detail.Code,
+	}
+	if len(detail.Details) > 0 {
+		output.target = detail.Details[0].Target
+	}
+	return output
+}
+
+// parseRetryAfter parses the `Retry-After` header, which is either a number of seconds or an HTTP date
+func parseRetryAfter(response *http.Response) (time.Duration, bool) {
+	if response == nil {
+		return 0, false
+	}
+
+	value := strings.TrimSpace(response.Header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// resourceProviderNamespace returns the namespace of the Resource Provider which the request is for (e.g.
+// `Microsoft.Storage`), or an empty string when the request isn't for a Resource Manager API
+func resourceProviderNamespace(request *http.Request) string {
+	if request.URL == nil {
+		return ""
+	}
+
+	// the last Resource Provider within the URI is used, since this is the one for Extension Resources
+	segments := strings.Split(strings.Trim(request.URL.Path, "/"), "/")
+	for i := len(segments) - 2; i >= 0; i-- {
+		if strings.EqualFold(segments[i], "providers") {
+			return segments[i+1]
+		}
+	}
+	return ""
+}
+
+// redactedRequestURL returns the URL for the request without the query string, which can contain SAS Tokens
+func redactedRequestURL(request *http.Request) string {
+	if request.URL == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s://%s%s", request.URL.Scheme, request.URL.Host, request.URL.Path)
+}
@@ -0,0 +1,307 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newRetryTestResponse(statusCode int, body string, headers map[string]string) *http.Response {
+	response := &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+	for k, v := range headers {
+		response.Header.Set(k, v)
+	}
+	return response
+}
+
+func TestRetryReason(t *testing.T) {
+	testData := []struct {
+		name     string
+		method   string
+		path     string
+		response *http.Response
+		retry    bool
+	}{
+		{
+			name:     "ok",
+			response: newRetryTestResponse(http.StatusOK, `{}`, nil),
+		},
+		{
+			name:     "throttled",
+			response: newRetryTestResponse(http.StatusTooManyRequests, ``, nil),
+			retry:    true,
+		},
+		{
+			name:     "conflict with another operation",
+			response: newRetryTestResponse(http.StatusConflict, `{"error":{"code":"AnotherOperationInProgress","message":"..."}}`, nil),
+			retry:    true,
+		},
+		{
+			name:     "conflict",
+			response: newRetryTestResponse(http.StatusConflict, `{"error":{"code":"Conflict","message":"..."}}`, nil),
+		},
+		{
+			name:     "bad request",
+			response: newRetryTestResponse(http.StatusBadRequest, `{"code":"InvalidParameter"}`, nil),
+		},
+		{
+			name:     "service unavailable",
+			response: newRetryTestResponse(http.StatusServiceUnavailable, `not json`, nil),
+			retry:    true,
+		},
+		{
+			name:     "service unavailable for a post",
+			method:   http.MethodPost,
+			response: newRetryTestResponse(http.StatusServiceUnavailable, ``, nil),
+		},
+		{
+			// unlike `autorest.DoRetryForStatusCodes`, a POST for an action which changes data isn't sent again
+			name:     "service unavailable for a post regenerating keys",
+			method:   http.MethodPost,
+			path:     "/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example/regenerateKey",
+			response: newRetryTestResponse(http.StatusServiceUnavailable, ``, nil),
+		},
+		{
+			name:     "service unavailable for a post listing keys",
+			method:   http.MethodPost,
+			path:     "/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example/listKeys",
+			response: newRetryTestResponse(http.StatusServiceUnavailable, ``, nil),
+			retry:    true,
+		},
+		{
+			name:     "request timeout for a post listing keys",
+			method:   http.MethodPost,
+			path:     "/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example/listKeys",
+			response: newRetryTestResponse(http.StatusRequestTimeout, ``, nil),
+			retry:    true,
+		},
+		{
+			name:     "throttled for a post",
+			method:   http.MethodPost,
+			response: newRetryTestResponse(http.StatusTooManyRequests, ``, nil),
+			retry:    true,
+		},
+		{
+			name:     "request timeout",
+			response: newRetryTestResponse(http.StatusRequestTimeout, ``, nil),
+			retry:    true,
+		},
+		{
+			name:     "not implemented",
+			response: newRetryTestResponse(http.StatusNotImplemented, ``, nil),
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		method := v.method
+		if method == "" {
+			method = http.MethodGet
+		}
+		request, err := http.NewRequest(method, "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000"+v.path, nil)
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+
+		if actual := retryReason(request, v.response) != ""; actual != v.retry {
+			t.Fatalf("expected retry to be %t but got %t", v.retry, actual)
+		}
+
+		// the body must still be readable once the error code has been checked
+		if _, err := io.ReadAll(v.response.Body); err != nil {
+			t.Fatalf("reading body: %+v", err)
+		}
+	}
+}
+
+func TestRetryReasonForError(t *testing.T) {
+	testData := []struct {
+		method string
+		path   string
+		retry  bool
+	}{
+		{
+			method: http.MethodGet,
+			path:   "/resourceGroups/example",
+			retry:  true,
+		},
+		{
+			method: http.MethodPut,
+			path:   "/resourceGroups/example",
+			retry:  true,
+		},
+		{
+			method: http.MethodPost,
+			path:   "/resourceGroups/example/providers/Microsoft.Web/sites/example/restart",
+		},
+		{
+			method: http.MethodPost,
+			path:   "/resourceGroups/example/providers/Microsoft.Web/sites/example/config/appsettings/list",
+			retry:  true,
+		},
+		{
+			method: http.MethodPost,
+			path:   "/providers/Microsoft.Storage/checkNameAvailability",
+			retry:  true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %s %s", v.method, v.path)
+
+		request, err := http.NewRequest(v.method, "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000"+v.path, nil)
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+
+		if actual := retryReasonForError(request, fmt.Errorf("connection reset by peer")) != ""; actual != v.retry {
+			t.Fatalf("expected retry to be %t but got %t", v.retry, actual)
+		}
+	}
+}
+
+func TestRetryOptionsSend(t *testing.T) {
+	options := RetryOptions{
+		RetryPolicy: RetryPolicy{
+			MaxAttempts: 4,
+			MaxBackoff:  time.Millisecond,
+		},
+		ServiceOverrides: map[string]RetryPolicy{
+			"Microsoft.Storage": {
+				MaxAttempts: 2,
+				MaxBackoff:  time.Millisecond,
+			},
+		},
+	}
+
+	newRequest := func(uri string) *http.Request {
+		request, err := http.NewRequest(http.MethodPut, uri, strings.NewReader(`{"name":"example"}`))
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+		request.GetBody = nil
+		return request
+	}
+
+	var responses []*http.Response
+	attempts := 0
+	send := func(request *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(request.Body)
+		if err != nil {
+			return nil, err
+		}
+		if string(body) != `{"name":"example"}` {
+			t.Fatalf("expected the request body to be sent again but got %q", string(body))
+		}
+
+		response := responses[attempts]
+		attempts++
+		if response == nil {
+			return nil, fmt.Errorf("connection reset by peer")
+		}
+		return response, nil
+	}
+
+	responses = []*http.Response{
+		newRetryTestResponse(http.StatusTooManyRequests, ``, map[string]string{"Retry-After": "0"}),
+		nil,
+		newRetryTestResponse(http.StatusConflict, `{"error":{"code":"AnotherOperationInProgress"}}`, nil),
+		newRetryTestResponse(http.StatusOK, `{"name":"example"}`, nil),
+	}
+	request := newRequest("https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example")
+	response, err := options.send(request, send, nil)
+	if err != nil {
+		t.Fatalf("sending: %+v", err)
+	}
+	if response.StatusCode != http.StatusOK || attempts != 4 {
+		t.Fatalf("expected a 200 after 4 attempts but got a %d after %d attempts", response.StatusCode, attempts)
+	}
+
+	// the override for the Resource Provider only allows a single retry
+	attempts = 0
+	responses = []*http.Response{
+		newRetryTestResponse(http.StatusTooManyRequests, ``, map[string]string{"Retry-After": "0"}),
+		newRetryTestResponse(http.StatusConflict, `{"error":{"code":"AnotherOperationInProgress"}}`, nil),
+	}
+	request = newRequest("https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/microsoft.storage/storageAccounts/example")
+	response, err = options.send(request, send, nil)
+	if err != nil {
+		t.Fatalf("sending: %+v", err)
+	}
+	if response.StatusCode != http.StatusConflict || attempts != 2 {
+		t.Fatalf("expected a 409 after 2 attempts but got a %d after %d attempts", response.StatusCode, attempts)
+	}
+
+	// the Resource Provider is registered when the Subscription isn't registered for it, before the request is sent again
+	attempts = 0
+	responses = []*http.Response{
+		newRetryTestResponse(http.StatusConflict, `{"error":{"code":"MissingSubscriptionRegistration","details":[{"target":"Microsoft.Network"}]}}`, nil),
+		newRetryTestResponse(http.StatusOK, `{"name":"example"}`, nil),
+	}
+	registered := ""
+	register := func(_ *http.Request, namespace string) error {
+		registered = namespace
+		return nil
+	}
+	request = newRequest("https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example")
+	response, err = options.send(request, send, register)
+	if err != nil {
+		t.Fatalf("sending: %+v", err)
+	}
+	if response.StatusCode != http.StatusOK || attempts != 2 || registered != "Microsoft.Network" {
+		t.Fatalf("expected a 200 after 2 attempts having registered Microsoft.Network but got a %d after %d attempts having registered %q", response.StatusCode, attempts, registered)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		MaxBackoff: 30 * time.Second,
+	}
+
+	testData := []struct {
+		attempt  int
+		headers  map[string]string
+		expected time.Duration
+	}{
+		{
+			attempt:  1,
+			expected: 5 * time.Second,
+		},
+		{
+			attempt:  2,
+			expected: 10 * time.Second,
+		},
+		{
+			attempt:  4,
+			expected: 30 * time.Second,
+		},
+		{
+			attempt:  1,
+			headers:  map[string]string{"Retry-After": "12"},
+			expected: 12 * time.Second,
+		},
+		{
+			attempt:  1,
+			headers:  map[string]string{"Retry-After": "3600"},
+			expected: 30 * time.Second,
+		},
+	}
+
+	for _, v := range testData {
+		response := newRetryTestResponse(http.StatusTooManyRequests, ``, v.headers)
+		if actual := policy.backoff(v.attempt, response); actual != v.expected {
+			t.Fatalf("expected a backoff of %s for attempt %d with %+v but got %s", v.expected, v.attempt, v.headers, actual)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+// StorageDataPlaneOptions configures where requests to the Storage Data Plane API's are sent, for example
+// when the Storage Accounts are only reachable using a Private Endpoint
+type StorageDataPlaneOptions struct {
+	// DomainSuffix overrides the Storage Endpoint Suffix for the Environment (e.g. `core.windows.net`),
+	// such that requests are sent to `https://{accountName}.{service}.{DomainSuffix}`
+	DomainSuffix string
+
+	// AccountEndpoints are the Data Plane Endpoints to use for a specific Storage Account, keyed by the
+	// name of the Storage Account - which take precedence over the DomainSuffix
+	AccountEndpoints map[string]StorageAccountEndpoints
+}
+
+// StorageAccountEndpoints are the (optional) Data Plane Endpoints for each service within a Storage Account
+type StorageAccountEndpoints struct {
+	Blob  string
+	Dfs   string
+	File  string
+	Queue string
+	Table string
+}
@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package features
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DeletionProtectionFeatures specifies the resources which the Provider should refuse to delete (or replace)
+type DeletionProtectionFeatures struct {
+	// ResourceTypes is a list of Terraform Resource Types (e.g. `azurerm_storage_account`) which are protected
+	ResourceTypes []string
+
+	// ResourceIdPatterns is a list of patterns (where `*` matches any characters) for the Resource IDs which are protected
+	ResourceIdPatterns []string
+
+	// ExcludedResourceIdPatterns is a list of patterns for the Resource IDs which aren't protected, which takes
+	// precedence over the Resource Types, Resource ID Patterns and Tags
+	ExcludedResourceIdPatterns []string
+
+	// Tags is a map of tag keys to values which, when applied to a resource, protects it - where a value
+	// of `*` matches any value
+	Tags map[string]string
+
+	// PreventReplacement specifies whether protected resources should also be prevented from being replaced
+	PreventReplacement bool
+}
+
+// IsProtected returns whether the resource with the specified type, ID and tags is protected from deletion -
+// and if so, the reason why
+func (f DeletionProtectionFeatures) IsProtected(resourceType string, id string, tags map[string]string) (bool, string) {
+	for _, pattern := range f.ExcludedResourceIdPatterns {
+		if resourceIdMatchesPattern(id, pattern) {
+			return false, ""
+		}
+	}
+
+	for _, v := range f.ResourceTypes {
+		if strings.EqualFold(v, resourceType) {
+			return true, fmt.Sprintf("the Resource Type %q is protected", resourceType)
+		}
+	}
+
+	for _, pattern := range f.ResourceIdPatterns {
+		if resourceIdMatchesPattern(id, pattern) {
+			return true, fmt.Sprintf("the Resource ID matches the protected pattern %q", pattern)
+		}
+	}
+
+	// Tag keys are case-insensitive in Azure, however values are case-sensitive
+	for protectedKey, protectedValue := range f.Tags {
+		for key, value := range tags {
+			if !strings.EqualFold(key, protectedKey) {
+				continue
+			}
+			if protectedValue == "*" || value == protectedValue {
+				return true, fmt.Sprintf("the tag %q has the protected value %q", key, value)
+			}
+		}
+	}
+
+	return false, ""
+}
+
+// resourceIdMatchesPattern returns whether the Resource ID matches the pattern, where `*` matches any characters
+// and the comparison is case-insensitive (since Resource IDs are case-insensitive)
+func resourceIdMatchesPattern(id string, pattern string) bool {
+	if id == "" || pattern == "" {
+		return false
+	}
+
+	expression := strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*")
+	return regexp.MustCompile(fmt.Sprintf("(?i)^%s$", expression)).MatchString(id)
+}
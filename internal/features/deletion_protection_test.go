@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package features
+
+import "testing"
+
+func TestDeletionProtectionIsProtected(t *testing.T) {
+	id := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/production-web/providers/Microsoft.Storage/storageAccounts/example"
+
+	testData := []struct {
+		Name         string
+		Features     DeletionProtectionFeatures
+		ResourceType string
+		Tags         map[string]string
+		Expected     bool
+	}{
+		{
+			Name:         "Nothing Protected",
+			Features:     DeletionProtectionFeatures{},
+			ResourceType: "azurerm_storage_account",
+			Expected:     false,
+		},
+		{
+			Name: "Resource Type Protected",
+			Features: DeletionProtectionFeatures{
+				ResourceTypes: []string{"azurerm_storage_account"},
+			},
+			ResourceType: "azurerm_storage_account",
+			Expected:     true,
+		},
+		{
+			Name: "Different Resource Type Protected",
+			Features: DeletionProtectionFeatures{
+				ResourceTypes: []string{"azurerm_mssql_database"},
+			},
+			ResourceType: "azurerm_storage_account",
+			Expected:     false,
+		},
+		{
+			Name: "Resource ID Pattern Matches",
+			Features: DeletionProtectionFeatures{
+				ResourceIdPatterns: []string{"/subscriptions/*/resourcegroups/PRODUCTION-*"},
+			},
+			ResourceType: "azurerm_storage_account",
+			Expected:     true,
+		},
+		{
+			Name: "Resource ID Pattern Doesn't Match",
+			Features: DeletionProtectionFeatures{
+				ResourceIdPatterns: []string{"/subscriptions/*/resourceGroups/staging-*"},
+			},
+			ResourceType: "azurerm_storage_account",
+			Expected:     false,
+		},
+		{
+			Name: "Tag Value Matches",
+			Features: DeletionProtectionFeatures{
+				Tags: map[string]string{"environment": "production"},
+			},
+			ResourceType: "azurerm_storage_account",
+			Tags:         map[string]string{"Environment": "production"},
+			Expected:     true,
+		},
+		{
+			Name: "Tag Value Doesn't Match",
+			Features: DeletionProtectionFeatures{
+				Tags: map[string]string{"environment": "production"},
+			},
+			ResourceType: "azurerm_storage_account",
+			Tags:         map[string]string{"environment": "Production"},
+			Expected:     false,
+		},
+		{
+			Name: "Tag Wildcard Value",
+			Features: DeletionProtectionFeatures{
+				Tags: map[string]string{"protected": "*"},
+			},
+			ResourceType: "azurerm_storage_account",
+			Tags:         map[string]string{"protected": "anything"},
+			Expected:     true,
+		},
+		{
+			Name: "Excluded Resource ID Pattern Takes Precedence",
+			Features: DeletionProtectionFeatures{
+				ResourceTypes:              []string{"azurerm_storage_account"},
+				ExcludedResourceIdPatterns: []string{"*/storageAccounts/example"},
+			},
+			ResourceType: "azurerm_storage_account",
+			Expected:     false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual, reason := v.Features.IsProtected(v.ResourceType, id, v.Tags)
+		if actual != v.Expected {
+			t.Fatalf("expected %t but got %t", v.Expected, actual)
+		}
+		if actual && reason == "" {
+			t.Fatalf("expected a reason when the resource is protected")
+		}
+	}
+}
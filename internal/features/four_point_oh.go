@@ -24,7 +24,7 @@ func DeprecatedInFourPointOh(deprecationMessage string) string {
 // This exists to allow breaking changes to be piped through the provider
 // during the development of 3.x until 4.0 is ready.
 func FourPointOh() bool {
-	return false
+	return fourPointOh
 }
 
 // FourPointOhBeta returns whether this provider is running in 4.0 mode
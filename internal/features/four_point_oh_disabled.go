@@ -0,0 +1,9 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !fourpointoh
+
+package features
+
+// fourPointOh is always false within the provider - see four_point_oh_enabled.go
+const fourPointOh = false
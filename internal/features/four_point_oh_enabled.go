@@ -0,0 +1,11 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build fourpointoh
+
+package features
+
+// fourPointOh builds the provider in 4.0 mode when the `fourpointoh` build tag is specified - this allows
+// tooling (such as the schema-api) to determine the impact of the changes coming in 4.0, and is never used
+// when building the provider itself.
+const fourPointOh = true
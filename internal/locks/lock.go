@@ -3,40 +3,194 @@
 
 package locks
 
-// armMutexKV is the instance of MutexKV for ARM resources
-var armMutexKV = newMutexKV()
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// armLocks is the instance of the lockManager for ARM resources
+var armLocks = newLockManager()
 
 func ByID(id string) {
-	armMutexKV.Lock(id)
+	_ = ByIDWithContext(context.Background(), id)
+}
+
+// ByIDWithContext locks the resource with the specified ID, waiting until the lock is available or the context
+// is done - in which case an error is returned and the lock isn't held
+func ByIDWithContext(ctx context.Context, id string) error {
+	return armLocks.Lock(ctx, id)
+}
+
+// SharedByIDWithContext acquires a shared lock for the resource with the specified ID - which can be used
+// when operating on a child of the resource, allowing multiple children to be changed at once whilst
+// preventing changes to the resource itself
+func SharedByIDWithContext(ctx context.Context, id string) error {
+	return armLocks.RLock(ctx, id)
 }
 
 // handle the case of using the same name for different kinds of resources
 func ByName(name string, resourceType string) {
-	updatedName := resourceType + "." + name
-	armMutexKV.Lock(updatedName)
+	_ = ByNameWithContext(context.Background(), name, resourceType)
+}
+
+// ByNameWithContext locks the resource with the specified name and type, waiting until the lock is available
+// or the context is done - in which case an error is returned and the lock isn't held
+func ByNameWithContext(ctx context.Context, name string, resourceType string) error {
+	return armLocks.Lock(ctx, keyForName(name, resourceType))
+}
+
+// SharedByNameWithContext acquires a shared lock for the resource with the specified name and type - see
+// SharedByIDWithContext
+func SharedByNameWithContext(ctx context.Context, name string, resourceType string) error {
+	return armLocks.RLock(ctx, keyForName(name, resourceType))
 }
 
 func MultipleByName(names *[]string, resourceType string) {
-	newSlice := removeDuplicatesFromStringArray(*names)
+	_ = MultipleByNameWithContext(context.Background(), names, resourceType)
+}
+
+// MultipleByNameWithContext locks each of the resources with the specified names and type. The locks are
+// acquired in a canonical (sorted) order, so that callers locking the same resources in a different order
+// can't deadlock one another. Should the context be done before all of the locks are acquired, any locks
+// which were acquired are released and an error is returned.
+func MultipleByNameWithContext(ctx context.Context, names *[]string, resourceType string) error {
+	acquired := make([]string, 0)
+	for _, name := range canonicalNames(names) {
+		if err := ByNameWithContext(ctx, name, resourceType); err != nil {
+			for i := len(acquired) - 1; i >= 0; i-- {
+				UnlockByName(acquired[i], resourceType)
+			}
+			return fmt.Errorf("locking %d %s: %+v", len(*names), resourceType, err)
+		}
+		acquired = append(acquired, name)
+	}
+
+	return nil
+}
+
+// Lock is a lock for a resource with the specified name and type, acquired using MultipleWithContext
+type Lock struct {
+	Name         string
+	ResourceType string
+
+	// Shared specifies that a shared lock is acquired for this resource (see SharedByNameWithContext) - which
+	// should only be used where Azure allows the operations to run concurrently. For example changing a Subnet
+	// updates the Virtual Network, which Azure doesn't allow concurrently (returning `AnotherOperationInProgress`),
+	// so the Virtual Network must be locked exclusively
+	Shared bool
+}
+
+// resourceTypeLockOrder is the order in which the locks for these resource types are acquired, after those for
+// any other resource type (e.g. a Network Security Group associated with a Subnet) - matching the order used
+// when locking these individually, so that callers locking the same resources can't deadlock one another
+var resourceTypeLockOrder = []string{
+	"azurerm_virtual_network",
+	"azurerm_subnet",
+}
 
-	for _, name := range newSlice {
-		ByName(name, resourceType)
+// MultipleWithContext acquires each of the specified locks in the canonical order (see resourceTypeLockOrder),
+// regardless of the order in which they're specified. Should the context be done before all of the locks are
+// acquired, any locks which were acquired are released and an error is returned.
+func MultipleWithContext(ctx context.Context, toLock ...Lock) error {
+	acquired := make([]Lock, 0)
+	for _, lock := range canonicalLocks(toLock) {
+		acquire := ByNameWithContext
+		if lock.Shared {
+			acquire = SharedByNameWithContext
+		}
+
+		if err := acquire(ctx, lock.Name, lock.ResourceType); err != nil {
+			UnlockMultiple(acquired...)
+			return fmt.Errorf("locking %s %q: %+v", lock.ResourceType, lock.Name, err)
+		}
+		acquired = append(acquired, lock)
 	}
+
+	return nil
 }
 
 func UnlockByID(id string) {
-	armMutexKV.Unlock(id)
+	armLocks.Unlock(id)
+}
+
+// UnlockSharedByID releases a shared lock acquired using SharedByIDWithContext
+func UnlockSharedByID(id string) {
+	armLocks.RUnlock(id)
 }
 
 func UnlockByName(name string, resourceType string) {
-	updatedName := resourceType + "." + name
-	armMutexKV.Unlock(updatedName)
+	armLocks.Unlock(keyForName(name, resourceType))
+}
+
+// UnlockSharedByName releases a shared lock acquired using SharedByNameWithContext
+func UnlockSharedByName(name string, resourceType string) {
+	armLocks.RUnlock(keyForName(name, resourceType))
 }
 
 func UnlockMultipleByName(names *[]string, resourceType string) {
+	newSlice := canonicalNames(names)
+
+	// release the locks in the reverse order to which they were acquired
+	for i := len(newSlice) - 1; i >= 0; i-- {
+		UnlockByName(newSlice[i], resourceType)
+	}
+}
+
+// UnlockMultiple releases the locks acquired using MultipleWithContext
+func UnlockMultiple(toUnlock ...Lock) {
+	canonical := canonicalLocks(toUnlock)
+
+	// release the locks in the reverse order to which they were acquired
+	for i := len(canonical) - 1; i >= 0; i-- {
+		if canonical[i].Shared {
+			UnlockSharedByName(canonical[i].Name, canonical[i].ResourceType)
+			continue
+		}
+		UnlockByName(canonical[i].Name, canonical[i].ResourceType)
+	}
+}
+
+func keyForName(name string, resourceType string) string {
+	return resourceType + "." + name
+}
+
+// canonicalNames returns the unique names in the order in which they should be locked
+func canonicalNames(names *[]string) []string {
 	newSlice := removeDuplicatesFromStringArray(*names)
+	sort.Strings(newSlice)
+	return newSlice
+}
 
-	for _, name := range newSlice {
-		UnlockByName(name, resourceType)
+// canonicalLocks returns the unique locks in the order in which they should be acquired - where the same resource
+// is specified more than once, it's locked exclusively if any of these are exclusive
+func canonicalLocks(input []Lock) []Lock {
+	output := make([]Lock, 0)
+	indexes := make(map[string]int)
+	for _, lock := range input {
+		key := keyForName(lock.Name, lock.ResourceType)
+		if i, ok := indexes[key]; ok {
+			output[i].Shared = output[i].Shared && lock.Shared
+			continue
+		}
+		indexes[key] = len(output)
+		output = append(output, lock)
 	}
+
+	rank := func(resourceType string) int {
+		for i, v := range resourceTypeLockOrder {
+			if v == resourceType {
+				return i + 1
+			}
+		}
+		return 0
+	}
+	sort.SliceStable(output, func(i, j int) bool {
+		if rank(output[i].ResourceType) != rank(output[j].ResourceType) {
+			return rank(output[i].ResourceType) < rank(output[j].ResourceType)
+		}
+		return keyForName(output[i].Name, output[i].ResourceType) < keyForName(output[j].Name, output[j].ResourceType)
+	})
+
+	return output
 }
@@ -0,0 +1,198 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// waitWarningInterval is how often a warning is logged whilst waiting to acquire a lock, since a long wait
+// can indicate a deadlock (or an operation which is stuck)
+var waitWarningInterval = 5 * time.Minute
+
+// lockManager is a key/value store of read/write locks, which is used to serialize changes across arbitrary
+// collaborators that share knowledge of the keys they must serialize on.
+//
+// Unlike a sync.RWMutex, acquiring a lock can be cancelled using a context - and the time spent waiting to
+// acquire each lock is logged.
+type lockManager struct {
+	lock  sync.Mutex
+	store map[string]*rwLock
+}
+
+// newLockManager returns a properly initialized lockManager
+func newLockManager() *lockManager {
+	return &lockManager{
+		store: make(map[string]*rwLock),
+	}
+}
+
+// Lock acquires the lock for the given key exclusively, waiting until it's available or the context is done.
+// Callers are responsible for calling Unlock for the same key if (and only if) this returns no error.
+func (m *lockManager) Lock(ctx context.Context, key string) error {
+	return m.acquire(ctx, key, false)
+}
+
+// RLock acquires a shared lock for the given key, which can be held by multiple callers at the same time but not
+// whilst the lock is held exclusively. Callers are responsible for calling RUnlock for the same key if (and only if)
+// this returns no error.
+func (m *lockManager) RLock(ctx context.Context, key string) error {
+	return m.acquire(ctx, key, true)
+}
+
+// Unlock releases the exclusive lock for the given key
+func (m *lockManager) Unlock(key string) {
+	log.Printf("[DEBUG] Unlocking %q", key)
+	m.get(key).release(key, false)
+	log.Printf("[DEBUG] Unlocked %q", key)
+}
+
+// RUnlock releases a shared lock for the given key
+func (m *lockManager) RUnlock(key string) {
+	log.Printf("[DEBUG] Unlocking (shared) %q", key)
+	m.get(key).release(key, true)
+	log.Printf("[DEBUG] Unlocked (shared) %q", key)
+}
+
+func (m *lockManager) acquire(ctx context.Context, key string, shared bool) error {
+	mode := ""
+	if shared {
+		mode = " (shared)"
+	}
+
+	log.Printf("[DEBUG] Locking%s %q", mode, key)
+	started := time.Now()
+	if err := m.get(key).acquire(ctx, key, shared); err != nil {
+		log.Printf("[DEBUG] Unable to lock%s %q after waiting %s: %+v", mode, key, time.Since(started).Round(time.Millisecond), err)
+		return fmt.Errorf("waiting to lock %q: %+v", key, err)
+	}
+	log.Printf("[DEBUG] Locked%s %q after waiting %s", mode, key, time.Since(started).Round(time.Millisecond))
+
+	return nil
+}
+
+// get returns the lock for the given key, no guarantee of its lock status
+func (m *lockManager) get(key string) *rwLock {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	l, ok := m.store[key]
+	if !ok {
+		l = &rwLock{
+			changed: make(chan struct{}),
+		}
+		m.store[key] = l
+	}
+	return l
+}
+
+// rwLock is a read/write lock which can be acquired using a context. Once a writer is waiting for the lock,
+// new readers wait until the writer has acquired (and released) the lock so that writers aren't starved.
+type rwLock struct {
+	lock sync.Mutex
+
+	readers        int
+	writer         bool
+	writersWaiting int
+	heldSince      time.Time
+
+	// changed is closed (and replaced) whenever the state of the lock changes, to notify any waiters
+	changed chan struct{}
+}
+
+func (l *rwLock) acquire(ctx context.Context, key string, shared bool) error {
+	started := time.Now()
+	warning := time.NewTicker(waitWarningInterval)
+	defer warning.Stop()
+
+	waiting := false
+	for {
+		l.lock.Lock()
+		if l.available(shared, waiting) {
+			if waiting && !shared {
+				l.writersWaiting--
+			}
+			if shared {
+				l.readers++
+			} else {
+				l.writer = true
+			}
+			if l.readers <= 1 {
+				l.heldSince = time.Now()
+			}
+			l.lock.Unlock()
+			return nil
+		}
+
+		if !waiting && !shared {
+			l.writersWaiting++
+		}
+		waiting = true
+		changed := l.changed
+		heldSince := l.heldSince
+		l.lock.Unlock()
+
+		select {
+		case <-changed:
+			continue
+
+		case <-warning.C:
+			log.Printf("[WARN] Still waiting to lock %q after %s (held since %s) - this could indicate an operation which is stuck or a deadlock", key, time.Since(started).Round(time.Second), heldSince.Format(time.RFC3339))
+
+		case <-ctx.Done():
+			if !shared {
+				l.lock.Lock()
+				l.writersWaiting--
+				l.notify()
+				l.lock.Unlock()
+			}
+			return ctx.Err()
+		}
+	}
+}
+
+// available returns whether the lock can be acquired - and must be called whilst holding `l.lock`
+func (l *rwLock) available(shared bool, waitingWriter bool) bool {
+	if l.writer {
+		return false
+	}
+	if shared {
+		return l.writersWaiting == 0
+	}
+	if l.readers > 0 {
+		return false
+	}
+
+	// a writer which isn't already waiting can't skip ahead of those that are
+	return waitingWriter || l.writersWaiting == 0
+}
+
+func (l *rwLock) release(key string, shared bool) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if shared {
+		if l.readers == 0 {
+			panic(fmt.Sprintf("unlocking %q which isn't locked (shared)", key))
+		}
+		l.readers--
+	} else {
+		if !l.writer {
+			panic(fmt.Sprintf("unlocking %q which isn't locked", key))
+		}
+		l.writer = false
+	}
+
+	l.notify()
+}
+
+// notify wakes up any waiters - and must be called whilst holding `l.lock`
+func (l *rwLock) notify() {
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
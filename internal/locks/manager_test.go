@@ -0,0 +1,239 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLockManagerExclusive(t *testing.T) {
+	m := newLockManager()
+	if err := m.Lock(context.Background(), "example"); err != nil {
+		t.Fatalf("locking: %+v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := m.Lock(ctx, "example"); err == nil {
+		t.Fatalf("expected an error when the lock is held and the context is done")
+	}
+	if err := m.RLock(ctx, "example"); err == nil {
+		t.Fatalf("expected an error acquiring a shared lock when the lock is held exclusively")
+	}
+
+	// other keys aren't affected
+	if err := m.Lock(context.Background(), "other"); err != nil {
+		t.Fatalf("locking: %+v", err)
+	}
+
+	m.Unlock("example")
+	if err := m.Lock(context.Background(), "example"); err != nil {
+		t.Fatalf("expected the lock to be available once released: %+v", err)
+	}
+}
+
+func TestLockManagerShared(t *testing.T) {
+	m := newLockManager()
+	for i := 0; i < 3; i++ {
+		if err := m.RLock(context.Background(), "example"); err != nil {
+			t.Fatalf("acquiring shared lock %d: %+v", i, err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := m.Lock(ctx, "example"); err == nil {
+		t.Fatalf("expected an error acquiring the lock exclusively whilst shared locks are held")
+	}
+
+	// the cancelled writer no longer blocks further readers
+	if err := m.RLock(context.Background(), "example"); err != nil {
+		t.Fatalf("acquiring shared lock: %+v", err)
+	}
+
+	acquired := make(chan struct{})
+	go func() {
+		if err := m.Lock(context.Background(), "example"); err == nil {
+			close(acquired)
+		}
+	}()
+
+	// once a writer is waiting, new readers wait for it
+	time.Sleep(20 * time.Millisecond)
+	readerCtx, readerCancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer readerCancel()
+	if err := m.RLock(readerCtx, "example"); err == nil {
+		t.Fatalf("expected an error acquiring a shared lock whilst a writer is waiting")
+	}
+
+	for i := 0; i < 4; i++ {
+		m.RUnlock("example")
+	}
+	select {
+	case <-acquired:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected the writer to acquire the lock once all shared locks were released")
+	}
+}
+
+func TestMultipleByNameCanonicalOrder(t *testing.T) {
+	// locking the same names in a different order at the same time mustn't deadlock
+	var wait sync.WaitGroup
+	errs := make(chan error, 100)
+	for i := 0; i < 50; i++ {
+		names := []string{"first", "second", "third"}
+		if i%2 == 0 {
+			names = []string{"third", "second", "first", "second"}
+		}
+
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			if err := MultipleByNameWithContext(ctx, &names, "azurerm_example"); err != nil {
+				errs <- err
+				return
+			}
+			UnlockMultipleByName(&names, "azurerm_example")
+		}()
+	}
+	wait.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatalf("locking multiple names: %+v", err)
+	}
+}
+
+func TestMultipleByNameReleasesOnError(t *testing.T) {
+	if err := ByNameWithContext(context.Background(), "b", "azurerm_release"); err != nil {
+		t.Fatalf("locking: %+v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	names := []string{"c", "b", "a"}
+	if err := MultipleByNameWithContext(ctx, &names, "azurerm_release"); err == nil {
+		t.Fatalf("expected an error since `b` is locked")
+	}
+
+	// `a` was acquired before `b`, so must have been released
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := ByNameWithContext(ctx, "a", "azurerm_release"); err != nil {
+		t.Fatalf("expected `a` to have been released: %+v", err)
+	}
+	UnlockByName("a", "azurerm_release")
+	UnlockByName("b", "azurerm_release")
+}
+
+func TestMultipleCanonicalOrder(t *testing.T) {
+	expected := []Lock{
+		{Name: "nsg1", ResourceType: "azurerm_network_security_group"},
+		{Name: "vnet1", ResourceType: "azurerm_virtual_network", Shared: true},
+		{Name: "subnet1", ResourceType: "azurerm_subnet"},
+	}
+	testData := [][]Lock{
+		{expected[0], expected[1], expected[2]},
+		{expected[2], expected[1], expected[0]},
+		{expected[1], expected[2], expected[0], expected[1]},
+	}
+	for _, v := range testData {
+		actual := canonicalLocks(v)
+		if len(actual) != len(expected) {
+			t.Fatalf("expected %d locks but got %d: %+v", len(expected), len(actual), actual)
+		}
+		for i := range expected {
+			if actual[i] != expected[i] {
+				t.Fatalf("expected lock %d to be %+v but got %+v", i, expected[i], actual[i])
+			}
+		}
+	}
+
+	// a resource which is locked both shared and exclusively is locked exclusively
+	actual := canonicalLocks([]Lock{
+		{Name: "vnet1", ResourceType: "azurerm_virtual_network", Shared: true},
+		{Name: "vnet1", ResourceType: "azurerm_virtual_network"},
+	})
+	if len(actual) != 1 || actual[0].Shared {
+		t.Fatalf("expected a single exclusive lock but got %+v", actual)
+	}
+}
+
+func TestMultipleSharedParentConcurrently(t *testing.T) {
+	child := func(name string) []Lock {
+		return []Lock{
+			{Name: "concurrent", ResourceType: "azurerm_shared_parent", Shared: true},
+			{Name: name, ResourceType: "azurerm_shared_child"},
+		}
+	}
+
+	if err := MultipleWithContext(context.Background(), child("first")...); err != nil {
+		t.Fatalf("locking the first child: %+v", err)
+	}
+
+	// a second child of the same parent can be changed whilst the first is locked
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := MultipleWithContext(ctx, child("second")...); err != nil {
+		t.Fatalf("expected the second child to be locked whilst the first is locked: %+v", err)
+	}
+
+	// but the same child, or the parent itself, can't be
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := MultipleWithContext(ctx, child("first")...); err == nil {
+		t.Fatalf("expected an error since the first child is locked")
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := ByNameWithContext(ctx, "concurrent", "azurerm_shared_parent"); err == nil {
+		t.Fatalf("expected an error since the children of the parent are locked")
+	}
+
+	UnlockMultiple(child("first")...)
+	UnlockMultiple(child("second")...)
+
+	// once both are released the parent can be locked exclusively
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := ByNameWithContext(ctx, "concurrent", "azurerm_shared_parent"); err != nil {
+		t.Fatalf("expected the parent to be available once the children were released: %+v", err)
+	}
+	UnlockByName("concurrent", "azurerm_shared_parent")
+}
+
+func TestMultipleSubnetsInVirtualNetworkSequentially(t *testing.T) {
+	subnet := func(name string) []Lock {
+		return []Lock{
+			{Name: "sequential", ResourceType: "azurerm_virtual_network"},
+			{Name: name, ResourceType: "azurerm_subnet"},
+		}
+	}
+
+	if err := MultipleWithContext(context.Background(), subnet("first")...); err != nil {
+		t.Fatalf("locking the first subnet: %+v", err)
+	}
+
+	// changing a subnet updates the virtual network, so a second subnet can't be changed at the same time
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := MultipleWithContext(ctx, subnet("second")...); err == nil {
+		t.Fatalf("expected an error since the virtual network is locked by the first subnet")
+	}
+
+	UnlockMultiple(subnet("first")...)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := MultipleWithContext(ctx, subnet("second")...); err != nil {
+		t.Fatalf("expected the second subnet to be locked once the first was released: %+v", err)
+	}
+	UnlockMultiple(subnet("second")...)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
 )
 
 func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
@@ -88,6 +89,56 @@ func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
 			},
 		},
 
+		"deletion_protection": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"resource_types": {
+						Type:     pluginsdk.TypeSet,
+						Optional: true,
+						Elem: &pluginsdk.Schema{
+							Type:         pluginsdk.TypeString,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+
+					"resource_id_patterns": {
+						Type:     pluginsdk.TypeList,
+						Optional: true,
+						Elem: &pluginsdk.Schema{
+							Type:         pluginsdk.TypeString,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+
+					"excluded_resource_id_patterns": {
+						Type:     pluginsdk.TypeList,
+						Optional: true,
+						Elem: &pluginsdk.Schema{
+							Type:         pluginsdk.TypeString,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+
+					"tags": {
+						Type:     pluginsdk.TypeMap,
+						Optional: true,
+						Elem: &pluginsdk.Schema{
+							Type: pluginsdk.TypeString,
+						},
+					},
+
+					"prevent_replacement": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						Default:  true,
+					},
+				},
+			},
+		},
+
 		"key_vault": {
 			Type:     pluginsdk.TypeList,
 			Optional: true,
@@ -129,6 +180,13 @@ func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
 						Default:     true,
 					},
 
+					"purge_soft_deleted_hardware_security_module_keys_on_destroy": {
+						Description: "When enabled soft-deleted `azurerm_key_vault_managed_hardware_security_module_key` resources will be permanently deleted (e.g purged), when destroyed",
+						Type:        pluginsdk.TypeBool,
+						Optional:    true,
+						Default:     true,
+					},
+
 					"recover_soft_deleted_certificates": {
 						Description: "When enabled soft-deleted `azurerm_key_vault_certificate` resources will be restored, instead of creating new ones",
 						Type:        pluginsdk.TypeBool,
@@ -270,6 +328,21 @@ func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
 			},
 		},
 
+		"storage": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"prevent_deletion_of_locked_immutability_policies": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						Default:  true,
+					},
+				},
+			},
+		},
+
 		"subscription": {
 			Type:     pluginsdk.TypeList,
 			Optional: true,
@@ -365,6 +438,28 @@ func expandFeatures(input []interface{}) features.UserFeatures {
 		}
 	}
 
+	if raw, ok := val["deletion_protection"]; ok {
+		items := raw.([]interface{})
+		if len(items) > 0 && items[0] != nil {
+			deletionProtectionRaw := items[0].(map[string]interface{})
+			if v, ok := deletionProtectionRaw["resource_types"]; ok {
+				featuresMap.DeletionProtection.ResourceTypes = expandFeaturesStringList(v.(*pluginsdk.Set).List())
+			}
+			if v, ok := deletionProtectionRaw["resource_id_patterns"]; ok {
+				featuresMap.DeletionProtection.ResourceIdPatterns = expandFeaturesStringList(v.([]interface{}))
+			}
+			if v, ok := deletionProtectionRaw["excluded_resource_id_patterns"]; ok {
+				featuresMap.DeletionProtection.ExcludedResourceIdPatterns = expandFeaturesStringList(v.([]interface{}))
+			}
+			if v, ok := deletionProtectionRaw["tags"]; ok {
+				featuresMap.DeletionProtection.Tags = expandFeaturesStringMap(v.(map[string]interface{}))
+			}
+			if v, ok := deletionProtectionRaw["prevent_replacement"]; ok {
+				featuresMap.DeletionProtection.PreventReplacement = v.(bool)
+			}
+		}
+	}
+
 	if raw, ok := val["key_vault"]; ok {
 		items := raw.([]interface{})
 		if len(items) > 0 && items[0] != nil {
@@ -384,6 +479,9 @@ func expandFeatures(input []interface{}) features.UserFeatures {
 			if v, ok := keyVaultRaw["purge_soft_deleted_hardware_security_modules_on_destroy"]; ok {
 				featuresMap.KeyVault.PurgeSoftDeletedHSMsOnDestroy = v.(bool)
 			}
+			if v, ok := keyVaultRaw["purge_soft_deleted_hardware_security_module_keys_on_destroy"]; ok {
+				featuresMap.KeyVault.PurgeSoftDeletedHSMKeysOnDestroy = v.(bool)
+			}
 			if v, ok := keyVaultRaw["recover_soft_deleted_certificates"]; ok {
 				featuresMap.KeyVault.RecoverSoftDeletedCerts = v.(bool)
 			}
@@ -471,6 +569,16 @@ func expandFeatures(input []interface{}) features.UserFeatures {
 		}
 	}
 
+	if raw, ok := val["storage"]; ok {
+		items := raw.([]interface{})
+		if len(items) > 0 {
+			storageRaw := items[0].(map[string]interface{})
+			if v, ok := storageRaw["prevent_deletion_of_locked_immutability_policies"]; ok {
+				featuresMap.Storage.PreventDeletionOfLockedImmutabilityPolicies = v.(bool)
+			}
+		}
+	}
+
 	if raw, ok := val["subscription"]; ok {
 		items := raw.([]interface{})
 		if len(items) > 0 {
@@ -483,3 +591,21 @@ func expandFeatures(input []interface{}) features.UserFeatures {
 
 	return featuresMap
 }
+
+func expandFeaturesStringList(input []interface{}) []string {
+	result := make([]string, 0)
+	for _, item := range input {
+		if v, ok := item.(string); ok && v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func expandFeaturesStringMap(input map[string]interface{}) map[string]string {
+	result := make(map[string]string)
+	for k, v := range input {
+		result[k] = v.(string)
+	}
+	return result
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
 func TestExpandFeatures(t *testing.T) {
@@ -35,12 +36,20 @@ func TestExpandFeatures(t *testing.T) {
 				CognitiveAccount: features.CognitiveAccountFeatures{
 					PurgeSoftDeleteOnDestroy: true,
 				},
+				DeletionProtection: features.DeletionProtectionFeatures{
+					ResourceTypes:              []string{},
+					ResourceIdPatterns:         []string{},
+					ExcludedResourceIdPatterns: []string{},
+					Tags:                       map[string]string{},
+					PreventReplacement:         true,
+				},
 				KeyVault: features.KeyVaultFeatures{
 					PurgeSoftDeletedCertsOnDestroy:   true,
 					PurgeSoftDeletedKeysOnDestroy:    true,
 					PurgeSoftDeletedSecretsOnDestroy: true,
 					PurgeSoftDeleteOnDestroy:         true,
 					PurgeSoftDeletedHSMsOnDestroy:    true,
+					PurgeSoftDeletedHSMKeysOnDestroy: true,
 					RecoverSoftDeletedCerts:          true,
 					RecoverSoftDeletedKeys:           true,
 					RecoverSoftDeletedKeyVaults:      true,
@@ -68,6 +77,9 @@ func TestExpandFeatures(t *testing.T) {
 				ResourceGroup: features.ResourceGroupFeatures{
 					PreventDeletionIfContainsResources: true,
 				},
+				Storage: features.StorageFeatures{
+					PreventDeletionOfLockedImmutabilityPolicies: true,
+				},
 				Subscription: features.SubscriptionFeatures{
 					PreventCancellationOnDestroy: false,
 				},
@@ -99,17 +111,29 @@ func TestExpandFeatures(t *testing.T) {
 							"purge_soft_delete_on_destroy": true,
 						},
 					},
+					"deletion_protection": []interface{}{
+						map[string]interface{}{
+							"resource_types":                pluginsdk.NewSet(pluginsdk.HashString, []interface{}{"azurerm_storage_account"}),
+							"resource_id_patterns":          []interface{}{"/subscriptions/*/resourceGroups/production-*"},
+							"excluded_resource_id_patterns": []interface{}{"/subscriptions/*/resourceGroups/production-temp"},
+							"tags": map[string]interface{}{
+								"protected": "true",
+							},
+							"prevent_replacement": true,
+						},
+					},
 					"key_vault": []interface{}{
 						map[string]interface{}{
-							"purge_soft_deleted_certificates_on_destroy":              true,
-							"purge_soft_deleted_keys_on_destroy":                      true,
-							"purge_soft_deleted_secrets_on_destroy":                   true,
-							"purge_soft_deleted_hardware_security_modules_on_destroy": true,
-							"purge_soft_delete_on_destroy":                            true,
-							"recover_soft_deleted_certificates":                       true,
-							"recover_soft_deleted_keys":                               true,
-							"recover_soft_deleted_key_vaults":                         true,
-							"recover_soft_deleted_secrets":                            true,
+							"purge_soft_deleted_certificates_on_destroy":                  true,
+							"purge_soft_deleted_keys_on_destroy":                          true,
+							"purge_soft_deleted_secrets_on_destroy":                       true,
+							"purge_soft_deleted_hardware_security_modules_on_destroy":     true,
+							"purge_soft_deleted_hardware_security_module_keys_on_destroy": true,
+							"purge_soft_delete_on_destroy":                                true,
+							"recover_soft_deleted_certificates":                           true,
+							"recover_soft_deleted_keys":                                   true,
+							"recover_soft_deleted_key_vaults":                             true,
+							"recover_soft_deleted_secrets":                                true,
 						},
 					},
 					"log_analytics_workspace": []interface{}{
@@ -127,6 +151,11 @@ func TestExpandFeatures(t *testing.T) {
 							"prevent_deletion_if_contains_resources": true,
 						},
 					},
+					"storage": []interface{}{
+						map[string]interface{}{
+							"prevent_deletion_of_locked_immutability_policies": true,
+						},
+					},
 					"subscription": []interface{}{
 						map[string]interface{}{
 							"prevent_cancellation_on_destroy": true,
@@ -168,12 +197,22 @@ func TestExpandFeatures(t *testing.T) {
 				CognitiveAccount: features.CognitiveAccountFeatures{
 					PurgeSoftDeleteOnDestroy: true,
 				},
+				DeletionProtection: features.DeletionProtectionFeatures{
+					ResourceTypes:              []string{"azurerm_storage_account"},
+					ResourceIdPatterns:         []string{"/subscriptions/*/resourceGroups/production-*"},
+					ExcludedResourceIdPatterns: []string{"/subscriptions/*/resourceGroups/production-temp"},
+					Tags: map[string]string{
+						"protected": "true",
+					},
+					PreventReplacement: true,
+				},
 				KeyVault: features.KeyVaultFeatures{
 					PurgeSoftDeletedCertsOnDestroy:   true,
 					PurgeSoftDeletedKeysOnDestroy:    true,
 					PurgeSoftDeletedSecretsOnDestroy: true,
 					PurgeSoftDeleteOnDestroy:         true,
 					PurgeSoftDeletedHSMsOnDestroy:    true,
+					PurgeSoftDeletedHSMKeysOnDestroy: true,
 					RecoverSoftDeletedCerts:          true,
 					RecoverSoftDeletedKeys:           true,
 					RecoverSoftDeletedKeyVaults:      true,
@@ -188,6 +227,9 @@ func TestExpandFeatures(t *testing.T) {
 				ResourceGroup: features.ResourceGroupFeatures{
 					PreventDeletionIfContainsResources: true,
 				},
+				Storage: features.StorageFeatures{
+					PreventDeletionOfLockedImmutabilityPolicies: true,
+				},
 				Subscription: features.SubscriptionFeatures{
 					PreventCancellationOnDestroy: true,
 				},
@@ -232,17 +274,27 @@ func TestExpandFeatures(t *testing.T) {
 							"purge_soft_delete_on_destroy": false,
 						},
 					},
+					"deletion_protection": []interface{}{
+						map[string]interface{}{
+							"resource_types":                pluginsdk.NewSet(pluginsdk.HashString, []interface{}{}),
+							"resource_id_patterns":          []interface{}{},
+							"excluded_resource_id_patterns": []interface{}{},
+							"tags":                          map[string]interface{}{},
+							"prevent_replacement":           false,
+						},
+					},
 					"key_vault": []interface{}{
 						map[string]interface{}{
-							"purge_soft_deleted_certificates_on_destroy":              false,
-							"purge_soft_deleted_keys_on_destroy":                      false,
-							"purge_soft_deleted_secrets_on_destroy":                   false,
-							"purge_soft_deleted_hardware_security_modules_on_destroy": false,
-							"purge_soft_delete_on_destroy":                            false,
-							"recover_soft_deleted_certificates":                       false,
-							"recover_soft_deleted_keys":                               false,
-							"recover_soft_deleted_key_vaults":                         false,
-							"recover_soft_deleted_secrets":                            false,
+							"purge_soft_deleted_certificates_on_destroy":                  false,
+							"purge_soft_deleted_keys_on_destroy":                          false,
+							"purge_soft_deleted_secrets_on_destroy":                       false,
+							"purge_soft_deleted_hardware_security_modules_on_destroy":     false,
+							"purge_soft_deleted_hardware_security_module_keys_on_destroy": false,
+							"purge_soft_delete_on_destroy":                                false,
+							"recover_soft_deleted_certificates":                           false,
+							"recover_soft_deleted_keys":                                   false,
+							"recover_soft_deleted_key_vaults":                             false,
+							"recover_soft_deleted_secrets":                                false,
 						},
 					},
 					"log_analytics_workspace": []interface{}{
@@ -260,6 +312,11 @@ func TestExpandFeatures(t *testing.T) {
 							"prevent_deletion_if_contains_resources": false,
 						},
 					},
+					"storage": []interface{}{
+						map[string]interface{}{
+							"prevent_deletion_of_locked_immutability_policies": false,
+						},
+					},
 					"subscription": []interface{}{
 						map[string]interface{}{
 							"prevent_cancellation_on_destroy": false,
@@ -301,11 +358,19 @@ func TestExpandFeatures(t *testing.T) {
 				CognitiveAccount: features.CognitiveAccountFeatures{
 					PurgeSoftDeleteOnDestroy: false,
 				},
+				DeletionProtection: features.DeletionProtectionFeatures{
+					ResourceTypes:              []string{},
+					ResourceIdPatterns:         []string{},
+					ExcludedResourceIdPatterns: []string{},
+					Tags:                       map[string]string{},
+					PreventReplacement:         false,
+				},
 				KeyVault: features.KeyVaultFeatures{
 					PurgeSoftDeletedCertsOnDestroy:   false,
 					PurgeSoftDeletedKeysOnDestroy:    false,
 					PurgeSoftDeletedSecretsOnDestroy: false,
 					PurgeSoftDeletedHSMsOnDestroy:    false,
+					PurgeSoftDeletedHSMKeysOnDestroy: false,
 					PurgeSoftDeleteOnDestroy:         false,
 					RecoverSoftDeletedCerts:          false,
 					RecoverSoftDeletedKeys:           false,
@@ -321,6 +386,9 @@ func TestExpandFeatures(t *testing.T) {
 				ResourceGroup: features.ResourceGroupFeatures{
 					PreventDeletionIfContainsResources: false,
 				},
+				Storage: features.StorageFeatures{
+					PreventDeletionOfLockedImmutabilityPolicies: false,
+				},
 				Subscription: features.SubscriptionFeatures{
 					PreventCancellationOnDestroy: false,
 				},
@@ -641,6 +709,7 @@ func TestExpandFeaturesKeyVault(t *testing.T) {
 					PurgeSoftDeletedSecretsOnDestroy: true,
 					PurgeSoftDeleteOnDestroy:         true,
 					PurgeSoftDeletedHSMsOnDestroy:    true,
+					PurgeSoftDeletedHSMKeysOnDestroy: true,
 					RecoverSoftDeletedCerts:          true,
 					RecoverSoftDeletedKeys:           true,
 					RecoverSoftDeletedKeyVaults:      true,
@@ -654,15 +723,16 @@ func TestExpandFeaturesKeyVault(t *testing.T) {
 				map[string]interface{}{
 					"key_vault": []interface{}{
 						map[string]interface{}{
-							"purge_soft_deleted_certificates_on_destroy":              true,
-							"purge_soft_deleted_keys_on_destroy":                      true,
-							"purge_soft_deleted_secrets_on_destroy":                   true,
-							"purge_soft_deleted_hardware_security_modules_on_destroy": true,
-							"purge_soft_delete_on_destroy":                            true,
-							"recover_soft_deleted_certificates":                       true,
-							"recover_soft_deleted_keys":                               true,
-							"recover_soft_deleted_key_vaults":                         true,
-							"recover_soft_deleted_secrets":                            true,
+							"purge_soft_deleted_certificates_on_destroy":                  true,
+							"purge_soft_deleted_keys_on_destroy":                          true,
+							"purge_soft_deleted_secrets_on_destroy":                       true,
+							"purge_soft_deleted_hardware_security_modules_on_destroy":     true,
+							"purge_soft_deleted_hardware_security_module_keys_on_destroy": true,
+							"purge_soft_delete_on_destroy":                                true,
+							"recover_soft_deleted_certificates":                           true,
+							"recover_soft_deleted_keys":                                   true,
+							"recover_soft_deleted_key_vaults":                             true,
+							"recover_soft_deleted_secrets":                                true,
 						},
 					},
 				},
@@ -673,6 +743,7 @@ func TestExpandFeaturesKeyVault(t *testing.T) {
 					PurgeSoftDeletedKeysOnDestroy:    true,
 					PurgeSoftDeletedSecretsOnDestroy: true,
 					PurgeSoftDeletedHSMsOnDestroy:    true,
+					PurgeSoftDeletedHSMKeysOnDestroy: true,
 					PurgeSoftDeleteOnDestroy:         true,
 					RecoverSoftDeletedCerts:          true,
 					RecoverSoftDeletedKeys:           true,
@@ -687,15 +758,16 @@ func TestExpandFeaturesKeyVault(t *testing.T) {
 				map[string]interface{}{
 					"key_vault": []interface{}{
 						map[string]interface{}{
-							"purge_soft_deleted_certificates_on_destroy":              false,
-							"purge_soft_deleted_keys_on_destroy":                      false,
-							"purge_soft_deleted_secrets_on_destroy":                   false,
-							"purge_soft_deleted_hardware_security_modules_on_destroy": false,
-							"purge_soft_delete_on_destroy":                            false,
-							"recover_soft_deleted_certificates":                       false,
-							"recover_soft_deleted_keys":                               false,
-							"recover_soft_deleted_key_vaults":                         false,
-							"recover_soft_deleted_secrets":                            false,
+							"purge_soft_deleted_certificates_on_destroy":                  false,
+							"purge_soft_deleted_keys_on_destroy":                          false,
+							"purge_soft_deleted_secrets_on_destroy":                       false,
+							"purge_soft_deleted_hardware_security_modules_on_destroy":     false,
+							"purge_soft_deleted_hardware_security_module_keys_on_destroy": false,
+							"purge_soft_delete_on_destroy":                                false,
+							"recover_soft_deleted_certificates":                           false,
+							"recover_soft_deleted_keys":                                   false,
+							"recover_soft_deleted_key_vaults":                             false,
+							"recover_soft_deleted_secrets":                                false,
 						},
 					},
 				},
@@ -707,6 +779,7 @@ func TestExpandFeaturesKeyVault(t *testing.T) {
 					PurgeSoftDeletedSecretsOnDestroy: false,
 					PurgeSoftDeleteOnDestroy:         false,
 					PurgeSoftDeletedHSMsOnDestroy:    false,
+					PurgeSoftDeletedHSMKeysOnDestroy: false,
 					RecoverSoftDeletedCerts:          false,
 					RecoverSoftDeletedKeyVaults:      false,
 					RecoverSoftDeletedKeys:           false,
@@ -1216,6 +1289,54 @@ func TestExpandFeaturesManagedDisk(t *testing.T) {
 	}
 }
 
+func TestExpandFeaturesStorage(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		EnvVars  map[string]interface{}
+		Expected features.UserFeatures
+	}{
+		{
+			Name: "Empty Block",
+			Input: []interface{}{
+				map[string]interface{}{
+					"storage": []interface{}{},
+				},
+			},
+			Expected: features.UserFeatures{
+				Storage: features.StorageFeatures{
+					PreventDeletionOfLockedImmutabilityPolicies: true,
+				},
+			},
+		},
+		{
+			Name: "Prevent Deletion Of Locked Immutability Policies Disabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"storage": []interface{}{
+						map[string]interface{}{
+							"prevent_deletion_of_locked_immutability_policies": false,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				Storage: features.StorageFeatures{
+					PreventDeletionOfLockedImmutabilityPolicies: false,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Logf("[DEBUG] Test Case: %q", testCase.Name)
+		result := expandFeatures(testCase.Input)
+		if !reflect.DeepEqual(result.Storage, testCase.Expected.Storage) {
+			t.Fatalf("Expected %+v but got %+v", result.Storage, testCase.Expected.Storage)
+		}
+	}
+}
+
 func TestExpandFeaturesSubscription(t *testing.T) {
 	testData := []struct {
 		Name     string
@@ -1263,3 +1384,60 @@ func TestExpandFeaturesSubscription(t *testing.T) {
 		}
 	}
 }
+
+func TestExpandFeaturesDeletionProtection(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		EnvVars  map[string]interface{}
+		Expected features.UserFeatures
+	}{
+		{
+			Name: "Empty Block",
+			Input: []interface{}{
+				map[string]interface{}{
+					"deletion_protection": []interface{}{},
+				},
+			},
+			Expected: features.UserFeatures{
+				DeletionProtection: features.DeletionProtectionFeatures{
+					ResourceTypes:              []string{},
+					ResourceIdPatterns:         []string{},
+					ExcludedResourceIdPatterns: []string{},
+					Tags:                       map[string]string{},
+					PreventReplacement:         true,
+				},
+			},
+		},
+		{
+			Name: "Resource Types Protected",
+			Input: []interface{}{
+				map[string]interface{}{
+					"deletion_protection": []interface{}{
+						map[string]interface{}{
+							"resource_types":      pluginsdk.NewSet(pluginsdk.HashString, []interface{}{"azurerm_mssql_database"}),
+							"prevent_replacement": false,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				DeletionProtection: features.DeletionProtectionFeatures{
+					ResourceTypes:              []string{"azurerm_mssql_database"},
+					ResourceIdPatterns:         []string{},
+					ExcludedResourceIdPatterns: []string{},
+					Tags:                       map[string]string{},
+					PreventReplacement:         false,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Logf("[DEBUG] Test Case: %q", testCase.Name)
+		result := expandFeatures(testCase.Input)
+		if !reflect.DeepEqual(result.DeletionProtection, testCase.Expected.DeletionProtection) {
+			t.Fatalf("Expected %+v but got %+v", result.DeletionProtection, testCase.Expected.DeletionProtection)
+		}
+	}
+}
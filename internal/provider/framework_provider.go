@@ -0,0 +1,175 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	frameworkprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	providerfunction "github.com/hashicorp/terraform-provider-azurerm/internal/provider/function"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+)
+
+var (
+	_ frameworkprovider.Provider                       = &azureFrameworkProvider{}
+	_ frameworkprovider.ProviderWithEphemeralResources = &azureFrameworkProvider{}
+	_ frameworkprovider.ProviderWithFunctions          = &azureFrameworkProvider{}
+)
+
+// azureFrameworkProvider is the Plugin Framework half of the AzureRM Provider, which is muxed
+// together with the Plugin SDKv2 Provider - and serves any Typed Resources which have opted
+// into being served through the Plugin Framework.
+//
+// The Schema for this Provider is cross-compiled from the Plugin SDKv2 Provider - and since the
+// Plugin SDKv2 Provider is configured first, the API Clients are shared between both halves.
+type azureFrameworkProvider struct {
+	sdkProvider *schema.Provider
+}
+
+// AzureProviderSchema returns the Plugin SDKv2 Provider including the Plugin SDKv2 implementation of each of the
+// Typed Resources served through the Plugin Framework, for use in tooling which inspects the Provider Schema
+func AzureProviderSchema() *schema.Provider {
+	p := AzureProvider()
+	for _, service := range SupportedTypedServices() {
+		v, ok := service.(sdk.TypedServiceRegistrationWithFramework)
+		if !ok {
+			continue
+		}
+
+		for _, r := range v.FrameworkResources() {
+			wrapper := sdk.NewResourceWrapper(r)
+			resource, err := wrapper.Resource()
+			if err != nil {
+				panic(fmt.Errorf("creating Wrapper for Resource %q: %+v", r.ResourceType(), err))
+			}
+			p.ResourcesMap[r.ResourceType()] = resource
+		}
+	}
+	return p
+}
+
+func newFrameworkProvider(sdkProvider *schema.Provider) frameworkprovider.Provider {
+	return &azureFrameworkProvider{
+		sdkProvider: sdkProvider,
+	}
+}
+
+func (p *azureFrameworkProvider) Metadata(_ context.Context, _ frameworkprovider.MetadataRequest, resp *frameworkprovider.MetadataResponse) {
+	resp.TypeName = "azurerm"
+}
+
+func (p *azureFrameworkProvider) Schema(ctx context.Context, _ frameworkprovider.SchemaRequest, resp *frameworkprovider.SchemaResponse) {
+	result, err := p.sdkProvider.GRPCProvider().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		resp.Diagnostics.AddError("building Provider Schema", err.Error())
+		return
+	}
+	for _, v := range result.Diagnostics {
+		if v != nil && v.Severity == tfprotov5.DiagnosticSeverityError {
+			resp.Diagnostics.AddError("building Provider Schema", fmt.Sprintf("%s: %s", v.Summary, v.Detail))
+			return
+		}
+	}
+
+	providerSchema, err := frameworkProviderSchemaFromProto(result.Provider)
+	if err != nil {
+		resp.Diagnostics.AddError("building Provider Schema", fmt.Sprintf("cross-compiling the Provider Schema: %+v", err))
+		return
+	}
+
+	resp.Schema = *providerSchema
+}
+
+func (p *azureFrameworkProvider) Configure(_ context.Context, _ frameworkprovider.ConfigureRequest, resp *frameworkprovider.ConfigureResponse) {
+	// the Plugin SDKv2 Provider is configured first, so the API Clients are available from there
+	client, ok := p.sdkProvider.Meta().(*clients.Client)
+	if !ok || client == nil {
+		resp.Diagnostics.AddError("configuring Provider", "the API Clients weren't configured by the Plugin SDKv2 Provider")
+		return
+	}
+
+	resp.DataSourceData = client
+	resp.EphemeralResourceData = client
+	resp.ResourceData = client
+}
+
+func (p *azureFrameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *azureFrameworkProvider) Resources(_ context.Context) []func() resource.Resource {
+	resources := make([]func() resource.Resource, 0)
+	seen := make(map[string]struct{})
+
+	for _, service := range SupportedTypedServices() {
+		v, ok := service.(sdk.TypedServiceRegistrationWithFramework)
+		if !ok {
+			continue
+		}
+
+		withResourceProviders, ok := service.(sdk.TypedServiceRegistrationWithResourceProviders)
+		if !ok || len(withResourceProviders.ResourceProviders()) == 0 {
+			panic(fmt.Sprintf("The Service %q doesn't declare the Resource Providers it uses", service.Name()))
+		}
+		resourceProviders := withResourceProviders.ResourceProviders()
+
+		for _, r := range v.FrameworkResources() {
+			key := r.ResourceType()
+			if _, exists := seen[key]; exists {
+				panic(fmt.Sprintf("An existing Resource exists for %q", key))
+			}
+			if _, exists := p.sdkProvider.ResourcesMap[key]; exists {
+				panic(fmt.Sprintf("An existing Plugin SDKv2 Resource exists for %q", key))
+			}
+			seen[key] = struct{}{}
+
+			resources = append(resources, sdk.NewFrameworkResourceWrapper(r, func(resource *schema.Resource) {
+				sdk.ApplyResourceProviderRegistration(key, resource, resourceProviders)
+				sdk.ApplyProviderTags(key, resource)
+			}))
+		}
+	}
+
+	return resources
+}
+
+func (p *azureFrameworkProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	ephemeralResources := make([]func() ephemeral.EphemeralResource, 0)
+	seen := make(map[string]struct{})
+
+	for _, service := range SupportedTypedServices() {
+		v, ok := service.(sdk.TypedServiceRegistrationWithEphemeralResources)
+		if !ok {
+			continue
+		}
+
+		for _, r := range v.EphemeralResources() {
+			key := r.ResourceType()
+			if _, exists := seen[key]; exists {
+				panic(fmt.Sprintf("An existing Ephemeral Resource exists for %q", key))
+			}
+			seen[key] = struct{}{}
+
+			ephemeralResources = append(ephemeralResources, sdk.NewFrameworkEphemeralResourceWrapper(r))
+		}
+	}
+
+	return ephemeralResources
+}
+
+func (p *azureFrameworkProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		providerfunction.NewBuildResourceIdFunction,
+		providerfunction.NewNormaliseResourceIdFunction,
+		providerfunction.NewParseResourceIdFunction,
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// frameworkProviderSchemaFromProto cross-compiles the Protocol representation of the Plugin SDKv2 Provider Schema
+// into a Plugin Framework Provider Schema - since the Provider Schema must be identical across each muxed server.
+func frameworkProviderSchemaFromProto(input *tfprotov5.Schema) (*schema.Schema, error) {
+	if input == nil || input.Block == nil {
+		return nil, fmt.Errorf("the Protocol Schema was nil")
+	}
+
+	attributes, blocks, err := frameworkProviderSchemaFromProtoBlock(input.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	output := schema.Schema{
+		Attributes: attributes,
+		Blocks:     blocks,
+	}
+	if input.Block.DescriptionKind == tfprotov5.StringKindMarkdown {
+		output.MarkdownDescription = input.Block.Description
+	} else {
+		output.Description = input.Block.Description
+	}
+
+	return &output, nil
+}
+
+func frameworkProviderSchemaFromProtoBlock(input *tfprotov5.SchemaBlock) (map[string]schema.Attribute, map[string]schema.Block, error) {
+	attributes := make(map[string]schema.Attribute, len(input.Attributes))
+	for _, v := range input.Attributes {
+		attribute, err := frameworkProviderAttributeFromProto(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("converting the attribute %q: %+v", v.Name, err)
+		}
+		attributes[v.Name] = attribute
+	}
+
+	blocks := make(map[string]schema.Block, len(input.BlockTypes))
+	for _, v := range input.BlockTypes {
+		block, err := frameworkProviderBlockFromProto(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("converting the block %q: %+v", v.TypeName, err)
+		}
+		blocks[v.TypeName] = block
+	}
+
+	return attributes, blocks, nil
+}
+
+func frameworkProviderAttributeFromProto(input *tfprotov5.SchemaAttribute) (schema.Attribute, error) {
+	if input.Computed {
+		return nil, fmt.Errorf("computed attributes are not supported within the Provider Schema")
+	}
+
+	description := ""
+	markdownDescription := ""
+	if input.DescriptionKind == tfprotov5.StringKindMarkdown {
+		markdownDescription = input.Description
+	} else {
+		description = input.Description
+	}
+
+	// the Plugin SDKv2 only exposes whether an attribute is deprecated, rather than the message
+	deprecationMessage := ""
+	if input.Deprecated {
+		deprecationMessage = fmt.Sprintf("The attribute %q is deprecated", input.Name)
+	}
+
+	switch {
+	case input.Type.Is(tftypes.String):
+		return schema.StringAttribute{
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Sensitive:           input.Sensitive,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+
+	case input.Type.Is(tftypes.Number):
+		return schema.NumberAttribute{
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Sensitive:           input.Sensitive,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+
+	case input.Type.Is(tftypes.Bool):
+		return schema.BoolAttribute{
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Sensitive:           input.Sensitive,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+
+	case input.Type.Is(tftypes.List{}):
+		elementType, err := frameworkProviderAttrTypeFromTerraformType(input.Type.(tftypes.List).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return schema.ListAttribute{
+			ElementType:         elementType,
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Sensitive:           input.Sensitive,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+
+	case input.Type.Is(tftypes.Set{}):
+		elementType, err := frameworkProviderAttrTypeFromTerraformType(input.Type.(tftypes.Set).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return schema.SetAttribute{
+			ElementType:         elementType,
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Sensitive:           input.Sensitive,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+
+	case input.Type.Is(tftypes.Map{}):
+		elementType, err := frameworkProviderAttrTypeFromTerraformType(input.Type.(tftypes.Map).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return schema.MapAttribute{
+			ElementType:         elementType,
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Sensitive:           input.Sensitive,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %q", input.Type.String())
+}
+
+func frameworkProviderBlockFromProto(input *tfprotov5.SchemaNestedBlock) (schema.Block, error) {
+	if input.Block == nil {
+		return nil, fmt.Errorf("the nested block was nil")
+	}
+
+	attributes, blocks, err := frameworkProviderSchemaFromProtoBlock(input.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	description := ""
+	markdownDescription := ""
+	if input.Block.DescriptionKind == tfprotov5.StringKindMarkdown {
+		markdownDescription = input.Block.Description
+	} else {
+		description = input.Block.Description
+	}
+
+	deprecationMessage := ""
+	if input.Block.Deprecated {
+		deprecationMessage = fmt.Sprintf("The block %q is deprecated", input.TypeName)
+	}
+
+	switch input.Nesting {
+	case tfprotov5.SchemaNestedBlockNestingModeList:
+		return schema.ListNestedBlock{
+			NestedObject: schema.NestedBlockObject{
+				Attributes: attributes,
+				Blocks:     blocks,
+			},
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+
+	case tfprotov5.SchemaNestedBlockNestingModeSet:
+		return schema.SetNestedBlock{
+			NestedObject: schema.NestedBlockObject{
+				Attributes: attributes,
+				Blocks:     blocks,
+			},
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+
+	case tfprotov5.SchemaNestedBlockNestingModeSingle:
+		return schema.SingleNestedBlock{
+			Attributes:          attributes,
+			Blocks:              blocks,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported nesting mode %q", input.Nesting.String())
+}
+
+func frameworkProviderAttrTypeFromTerraformType(input tftypes.Type) (attr.Type, error) {
+	switch {
+	case input.Is(tftypes.String):
+		return types.StringType, nil
+
+	case input.Is(tftypes.Number):
+		return types.NumberType, nil
+
+	case input.Is(tftypes.Bool):
+		return types.BoolType, nil
+
+	case input.Is(tftypes.List{}):
+		elementType, err := frameworkProviderAttrTypeFromTerraformType(input.(tftypes.List).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return types.ListType{ElemType: elementType}, nil
+
+	case input.Is(tftypes.Set{}):
+		elementType, err := frameworkProviderAttrTypeFromTerraformType(input.(tftypes.Set).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return types.SetType{ElemType: elementType}, nil
+
+	case input.Is(tftypes.Map{}):
+		elementType, err := frameworkProviderAttrTypeFromTerraformType(input.(tftypes.Map).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return types.MapType{ElemType: elementType}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %q", input.String())
+}
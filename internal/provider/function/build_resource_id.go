@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = BuildResourceIdFunction{}
+
+// BuildResourceIdFunction builds a Resource ID from its components
+type BuildResourceIdFunction struct{}
+
+func NewBuildResourceIdFunction() function.Function {
+	return BuildResourceIdFunction{}
+}
+
+func (BuildResourceIdFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "build_resource_id"
+}
+
+func (BuildResourceIdFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Builds an Azure Resource Manager ID from its components",
+		Description:         "Builds a normalised Azure Resource Manager ID from the Scope, the Resource Type (including the Resource Provider) and the names of the Resource and any Parent Resources.",
+		MarkdownDescription: "Builds a normalised Azure Resource Manager ID from the Scope, the Resource Type (including the Resource Provider) and the names of the Resource and any Parent Resources.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "scope",
+				Description:         "The Scope for the Resource, for example the ID of a Subscription or Resource Group.",
+				MarkdownDescription: "The Scope for the Resource, for example the ID of a Subscription or Resource Group.",
+			},
+			function.StringParameter{
+				Name:                "full_resource_type",
+				Description:         "The Resource Type, including the Resource Provider - for example `Microsoft.Network/virtualNetworks/subnets`.",
+				MarkdownDescription: "The Resource Type, including the Resource Provider - for example `Microsoft.Network/virtualNetworks/subnets`.",
+			},
+			function.ListParameter{
+				Name:                "resource_names",
+				ElementType:         types.StringType,
+				Description:         "The names of any Parent Resources followed by the name of the Resource, in the same order as the Resource Type.",
+				MarkdownDescription: "The names of any Parent Resources followed by the name of the Resource, in the same order as the Resource Type.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (BuildResourceIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var scope, fullResourceType string
+	var names []string
+	if resp.Error = req.Arguments.Get(ctx, &scope, &fullResourceType, &names); resp.Error != nil {
+		return
+	}
+
+	result, err := buildResourceId(scope, fullResourceType, names)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
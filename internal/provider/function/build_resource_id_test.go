@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"testing"
+)
+
+func TestBuildResourceId(t *testing.T) {
+	testData := []struct {
+		Scope            string
+		FullResourceType string
+		Names            []string
+		Expected         string
+	}{
+		{
+			// missing the Resource Provider
+			Scope:            "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1",
+			FullResourceType: "virtualNetworks",
+			Names:            []string{"network1"},
+		},
+		{
+			// mismatched number of names
+			Scope:            "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1",
+			FullResourceType: "Microsoft.Network/virtualNetworks/subnets",
+			Names:            []string{"network1"},
+		},
+		{
+			// names can't contain a separator
+			Scope:            "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1",
+			FullResourceType: "Microsoft.Network/virtualNetworks",
+			Names:            []string{"network1/subnets"},
+		},
+		{
+			Scope:            "not-a-scope",
+			FullResourceType: "Microsoft.Network/virtualNetworks",
+			Names:            []string{"network1"},
+		},
+		{
+			Scope:            "/subscriptions/12345678-1234-9876-4563-123456789012/resourcegroups/resGroup1",
+			FullResourceType: "microsoft.network/virtualnetworks/subnets",
+			Names:            []string{"network1", "subnet1"},
+			Expected:         "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Network/virtualNetworks/network1/subnets/subnet1",
+		},
+		{
+			Scope:            "",
+			FullResourceType: "Microsoft.Management/managementGroups",
+			Names:            []string{"group1"},
+			Expected:         "/providers/Microsoft.Management/managementGroups/group1",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q / %q / %+v", v.Scope, v.FullResourceType, v.Names)
+
+		actual, err := buildResourceId(v.Scope, v.FullResourceType, v.Names)
+		if err != nil {
+			if v.Expected == "" {
+				continue
+			}
+			t.Fatalf("expected a value but got an error: %+v", err)
+		}
+		if v.Expected == "" {
+			t.Fatal("expected an error but didn't get one")
+		}
+
+		if actual != v.Expected {
+			t.Fatalf("expected %q but got %q", v.Expected, actual)
+		}
+	}
+}
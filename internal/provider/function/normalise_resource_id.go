@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = NormaliseResourceIdFunction{}
+
+// NormaliseResourceIdFunction corrects the casing of a Resource ID
+type NormaliseResourceIdFunction struct{}
+
+func NewNormaliseResourceIdFunction() function.Function {
+	return NormaliseResourceIdFunction{}
+}
+
+func (NormaliseResourceIdFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalise_resource_id"
+}
+
+func (NormaliseResourceIdFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Normalises the casing of an Azure Resource Manager ID",
+		Description:         "Corrects the casing of the static segments (and Resource Provider) within an Azure Resource Manager ID, the user-specified values (such as names) are left as-is.",
+		MarkdownDescription: "Corrects the casing of the static segments (and Resource Provider) within an Azure Resource Manager ID, the user-specified values (such as names) are left as-is.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "id",
+				Description:         "The Azure Resource Manager ID to normalise.",
+				MarkdownDescription: "The Azure Resource Manager ID to normalise.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (NormaliseResourceIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+	if resp.Error = req.Arguments.Get(ctx, &id); resp.Error != nil {
+		return
+	}
+
+	result, err := resourceIdNormaliser().Normalise(id)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
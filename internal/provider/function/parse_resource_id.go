@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = ParseResourceIdFunction{}
+
+// ParseResourceIdFunction parses a Resource ID into its components
+type ParseResourceIdFunction struct{}
+
+func NewParseResourceIdFunction() function.Function {
+	return ParseResourceIdFunction{}
+}
+
+func (ParseResourceIdFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_resource_id"
+}
+
+func (ParseResourceIdFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Parses an Azure Resource Manager ID into its components",
+		Description:         "Parses an Azure Resource Manager ID (after correcting the casing of the Resource ID) into an object containing the Subscription ID, Resource Group Name, Resource Provider, Resource Type, Resource Name, Scope and any Parent Resources.",
+		MarkdownDescription: "Parses an Azure Resource Manager ID (after correcting the casing of the Resource ID) into an object containing the Subscription ID, Resource Group Name, Resource Provider, Resource Type, Resource Name, Scope and any Parent Resources.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "id",
+				Description:         "The Azure Resource Manager ID to parse.",
+				MarkdownDescription: "The Azure Resource Manager ID to parse.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: parsedResourceIdAttributeTypes,
+		},
+	}
+}
+
+func (ParseResourceIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+	if resp.Error = req.Arguments.Get(ctx, &id); resp.Error != nil {
+		return
+	}
+
+	result, err := parseResourceId(id)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
+
+var parsedResourceIdAttributeTypes = map[string]attr.Type{
+	"subscription_id":     types.StringType,
+	"resource_group_name": types.StringType,
+	"resource_provider":   types.StringType,
+	"resource_type":       types.StringType,
+	"resource_name":       types.StringType,
+	"full_resource_type":  types.StringType,
+	"resource_scope":      types.StringType,
+	"parent_resources": types.MapType{
+		ElemType: types.StringType,
+	},
+}
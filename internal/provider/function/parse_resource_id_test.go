@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseResourceId(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Expected *parsedResourceId
+	}{
+		{
+			Input: "not-a-resource-id",
+		},
+		{
+			// missing the Resource Type
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Network",
+		},
+		{
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012",
+			Expected: &parsedResourceId{
+				SubscriptionId:   "12345678-1234-9876-4563-123456789012",
+				ResourceProvider: "Microsoft.Resources",
+				ResourceType:     "subscriptions",
+				ResourceName:     "12345678-1234-9876-4563-123456789012",
+				FullResourceType: "Microsoft.Resources/subscriptions",
+				ParentResources:  map[string]string{},
+			},
+		},
+		{
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourcegroups/resGroup1",
+			Expected: &parsedResourceId{
+				SubscriptionId:    "12345678-1234-9876-4563-123456789012",
+				ResourceGroupName: "resGroup1",
+				ResourceProvider:  "Microsoft.Resources",
+				ResourceType:      "resourceGroups",
+				ResourceName:      "resGroup1",
+				FullResourceType:  "Microsoft.Resources/resourceGroups",
+				ResourceScope:     "/subscriptions/12345678-1234-9876-4563-123456789012",
+				ParentResources:   map[string]string{},
+			},
+		},
+		{
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/microsoft.network/virtualnetworks/network1/subnets/subnet1",
+			Expected: &parsedResourceId{
+				SubscriptionId:    "12345678-1234-9876-4563-123456789012",
+				ResourceGroupName: "resGroup1",
+				ResourceProvider:  "Microsoft.Network",
+				ResourceType:      "subnets",
+				ResourceName:      "subnet1",
+				FullResourceType:  "Microsoft.Network/virtualNetworks/subnets",
+				ResourceScope:     "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1",
+				ParentResources: map[string]string{
+					"virtualNetworks": "network1",
+				},
+			},
+		},
+		{
+			// an extension resource, scoped to another resource
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Storage/storageAccounts/account1/providers/Microsoft.Authorization/roleAssignments/assignment1",
+			Expected: &parsedResourceId{
+				SubscriptionId:    "12345678-1234-9876-4563-123456789012",
+				ResourceGroupName: "resGroup1",
+				ResourceProvider:  "Microsoft.Authorization",
+				ResourceType:      "roleAssignments",
+				ResourceName:      "assignment1",
+				FullResourceType:  "Microsoft.Authorization/roleAssignments",
+				ResourceScope:     "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Storage/storageAccounts/account1",
+				ParentResources:   map[string]string{},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual, err := parseResourceId(v.Input)
+		if err != nil {
+			if v.Expected == nil {
+				continue
+			}
+			t.Fatalf("expected a value but got an error: %+v", err)
+		}
+		if v.Expected == nil {
+			t.Fatal("expected an error but didn't get one")
+		}
+
+		if !reflect.DeepEqual(*v.Expected, *actual) {
+			t.Fatalf("expected %+v but got %+v", *v.Expected, *actual)
+		}
+	}
+}
+
+func TestParseResourceIdFunctionRun(t *testing.T) {
+	ctx := context.TODO()
+
+	resultData, funcErr := function.ObjectReturn{AttributeTypes: parsedResourceIdAttributeTypes}.NewResultData(ctx)
+	if funcErr != nil {
+		t.Fatalf("building result: %+v", funcErr)
+	}
+
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{
+			types.StringValue("/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Network/virtualNetworks/network1/subnets/subnet1"),
+		}),
+	}
+	resp := &function.RunResponse{
+		Result: resultData,
+	}
+	ParseResourceIdFunction{}.Run(ctx, req, resp)
+	if resp.Error != nil {
+		t.Fatalf("running: %+v", resp.Error)
+	}
+
+	result, ok := resp.Result.Value().(types.Object)
+	if !ok {
+		t.Fatalf("expected the result to be an Object but got %T", resp.Result.Value())
+	}
+	if v := result.Attributes()["resource_name"]; !v.Equal(types.StringValue("subnet1")) {
+		t.Fatalf("expected `resource_name` to be %q but got %s", "subnet1", v)
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
+)
+
+var (
+	normaliserOnce sync.Once
+	normaliser     azure.ResourceIDNormaliser
+)
+
+// resourceIdNormaliser returns a Normaliser which uses the casing defined in the Resource ID Parsers
+// generated for each Service Package, and the common Resource IDs from `commonids`
+func resourceIdNormaliser() azure.ResourceIDNormaliser {
+	normaliserOnce.Do(func() {
+		knownIds := make([]string, 0, len(generatedResourceIds)+len(commonResourceIds))
+		knownIds = append(knownIds, generatedResourceIds...)
+		for _, id := range commonResourceIds {
+			knownIds = append(knownIds, exampleResourceId(id))
+		}
+		normaliser = azure.NewResourceIDNormaliser(knownIds)
+	})
+
+	return normaliser
+}
+
+// exampleResourceId returns an example of the Resource ID `id`, which retains the casing of each segment
+func exampleResourceId(id resourceids.ResourceId) string {
+	components := make([]string, 0)
+	for _, segment := range id.Segments() {
+		if segment.FixedValue != nil {
+			components = append(components, *segment.FixedValue)
+			continue
+		}
+		components = append(components, segment.ExampleValue)
+	}
+	return "/" + strings.Join(components, "/")
+}
+
+// commonResourceIds is a list of the Resource IDs which are common across Services
+// NOTE: `commonids.ScopeId` is intentionally omitted since it can contain any Resource ID
+var commonResourceIds = []resourceids.ResourceId{
+	&commonids.AppServiceId{},
+	&commonids.AppServiceEnvironmentId{},
+	&commonids.AppServicePlanId{},
+	&commonids.AutomationCompilationJobId{},
+	&commonids.AvailabilitySetId{},
+	&commonids.CloudServicesIPConfigurationId{},
+	&commonids.CloudServicesPublicIPAddressId{},
+	&commonids.DedicatedHostId{},
+	&commonids.DedicatedHostGroupId{},
+	&commonids.DiskEncryptionSetId{},
+	&commonids.ExpressRouteCircuitPeeringId{},
+	&commonids.HyperVSiteJobId{},
+	&commonids.HyperVSiteMachineId{},
+	&commonids.HyperVSiteRunAsAccountId{},
+	&commonids.KeyVaultId{},
+	&commonids.KeyVaultKeyId{},
+	&commonids.KeyVaultKeyVersionId{},
+	&commonids.KeyVaultPrivateEndpointConnectionId{},
+	&commonids.KubernetesClusterId{},
+	&commonids.KustoClusterId{},
+	&commonids.KustoDatabaseId{},
+	&commonids.ManagedDiskId{},
+	&commonids.ManagementGroupId{},
+	&commonids.NetworkInterfaceId{},
+	&commonids.NetworkInterfaceIPConfigurationId{},
+	&commonids.ProvisioningServiceId{},
+	&commonids.PublicIPAddressId{},
+	&commonids.ResourceGroupId{},
+	&commonids.SpringCloudServiceId{},
+	&commonids.StorageAccountId{},
+	&commonids.StorageContainerId{},
+	&commonids.SubnetId{},
+	&commonids.SubscriptionId{},
+	&commonids.UserAssignedIdentityId{},
+	&commonids.VirtualHubBGPConnectionId{},
+	&commonids.VirtualHubIPConfigurationId{},
+	&commonids.VirtualMachineId{},
+	&commonids.VirtualMachineScaleSetId{},
+	&commonids.VirtualMachineScaleSetIPConfigurationId{},
+	&commonids.VirtualMachineScaleSetNetworkInterfaceId{},
+	&commonids.VirtualMachineScaleSetPublicIPAddressId{},
+	&commonids.VirtualNetworkId{},
+	&commonids.VirtualRouterPeeringId{},
+	&commonids.VirtualWANP2SVPNGatewayId{},
+	&commonids.VMwareSiteJobId{},
+	&commonids.VMwareSiteMachineId{},
+	&commonids.VMwareSiteRunAsAccountId{},
+	&commonids.VPNConnectionId{},
+}
+
+// parsedResourceId is the result of the `parse_resource_id` function
+type parsedResourceId struct {
+	SubscriptionId    string            `tfsdk:"subscription_id"`
+	ResourceGroupName string            `tfsdk:"resource_group_name"`
+	ResourceProvider  string            `tfsdk:"resource_provider"`
+	ResourceType      string            `tfsdk:"resource_type"`
+	ResourceName      string            `tfsdk:"resource_name"`
+	FullResourceType  string            `tfsdk:"full_resource_type"`
+	ResourceScope     string            `tfsdk:"resource_scope"`
+	ParentResources   map[string]string `tfsdk:"parent_resources"`
+}
+
+// parseResourceId parses the Resource ID `input` into its components, after correcting the casing
+func parseResourceId(input string) (*parsedResourceId, error) {
+	segments, err := resourceIdNormaliser().NormaliseSegments(input)
+	if err != nil {
+		return nil, err
+	}
+
+	result := parsedResourceId{
+		ParentResources: make(map[string]string),
+	}
+	providerIndex := -1
+	for i, segment := range segments {
+		switch segment.Key {
+		case "subscriptions":
+			if result.SubscriptionId == "" {
+				result.SubscriptionId = segment.Value
+			}
+		case "resourceGroups":
+			if result.ResourceGroupName == "" {
+				result.ResourceGroupName = segment.Value
+			}
+		case "providers":
+			providerIndex = i
+		}
+	}
+
+	// Subscriptions and Resource Groups are exposed by the Resources Resource Provider, but don't contain a `providers` segment
+	typeSegments := segments
+	scopeSegments := make([]azure.ResourceIDSegment, 0)
+	result.ResourceProvider = "Microsoft.Resources"
+	if providerIndex != -1 {
+		result.ResourceProvider = segments[providerIndex].Value
+		typeSegments = segments[providerIndex+1:]
+		scopeSegments = segments[:providerIndex]
+	} else if len(segments) > 1 {
+		typeSegments = segments[len(segments)-1:]
+		scopeSegments = segments[:len(segments)-1]
+	}
+
+	if len(typeSegments) == 0 {
+		return nil, fmt.Errorf("the Resource ID %q doesn't contain a Resource Type after the Resource Provider %q", input, result.ResourceProvider)
+	}
+
+	resourceTypes := make([]string, 0, len(typeSegments))
+	for i, segment := range typeSegments {
+		resourceTypes = append(resourceTypes, segment.Key)
+		if i == len(typeSegments)-1 {
+			result.ResourceType = segment.Key
+			result.ResourceName = segment.Value
+			continue
+		}
+		result.ParentResources[segment.Key] = segment.Value
+	}
+	result.FullResourceType = fmt.Sprintf("%s/%s", result.ResourceProvider, strings.Join(resourceTypes, "/"))
+
+	if len(scopeSegments) > 0 {
+		result.ResourceScope = azure.FormatAzureResourceID(scopeSegments)
+	}
+
+	return &result, nil
+}
+
+// buildResourceId builds a Resource ID for the Resource Type `fullResourceType` (e.g. `Microsoft.Network/virtualNetworks/subnets`)
+// within the Scope `scope`, where `names` contains the name of the Resource and any Parent Resources
+func buildResourceId(scope, fullResourceType string, names []string) (string, error) {
+	segments := make([]azure.ResourceIDSegment, 0)
+	if scope != "" && scope != "/" {
+		scopeSegments, err := azure.SplitAzureResourceID(scope)
+		if err != nil {
+			return "", fmt.Errorf("parsing the Scope %q: %+v", scope, err)
+		}
+		segments = append(segments, scopeSegments...)
+	}
+
+	components := strings.Split(strings.Trim(fullResourceType, "/"), "/")
+	if len(components) < 2 {
+		return "", fmt.Errorf("the Resource Type %q must be in the format `{Resource Provider}/{Resource Type}`, for example `Microsoft.Network/virtualNetworks`", fullResourceType)
+	}
+	resourceProvider := components[0]
+	resourceTypes := components[1:]
+	if len(resourceTypes) != len(names) {
+		return "", fmt.Errorf("expected %d names for the Resource Type %q but got %d", len(resourceTypes), fullResourceType, len(names))
+	}
+
+	segments = append(segments, azure.ResourceIDSegment{
+		Key:   "providers",
+		Value: resourceProvider,
+	})
+	for i, resourceType := range resourceTypes {
+		if resourceType == "" {
+			return "", fmt.Errorf("the Resource Type %q contains an empty segment", fullResourceType)
+		}
+		if names[i] == "" || strings.Contains(names[i], "/") {
+			return "", fmt.Errorf("the name for %q must be a non-empty string without a `/`, got %q", resourceType, names[i])
+		}
+		segments = append(segments, azure.ResourceIDSegment{
+			Key:   resourceType,
+			Value: names[i],
+		})
+	}
+
+	return resourceIdNormaliser().Normalise(azure.FormatAzureResourceID(segments))
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
@@ -32,6 +33,14 @@ func TestAzureProvider() *schema.Provider {
 	return azureProvider(true)
 }
 
+// TestAzureProviderWithRecorder returns the Provider used in the Acceptance Tests, where the requests
+// sent by the API Clients are recorded (or replayed) using the specified Recorder
+func TestAzureProviderWithRecorder(recorder common.Recorder) *schema.Provider {
+	p := azureProvider(true)
+	p.ConfigureContextFunc = providerConfigure(p, recorder)
+	return p
+}
+
 func ValidatePartnerID(i interface{}, k string) ([]string, []error) {
 	// ValidatePartnerID checks if partner_id is any of the following:
 	//  * a valid UUID - will add "pid-" prefix to the ID if it is not already present
@@ -109,9 +118,17 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 
 	dataSources := make(map[string]*schema.Resource)
 	resources := make(map[string]*schema.Resource)
+	listableResources := make([]sdk.ResourceWithList, 0)
+	listableUntypedResources := make([]sdk.UntypedResourceList, 0)
 
 	// first handle the typed services
 	for _, service := range SupportedTypedServices() {
+		v, ok := service.(sdk.TypedServiceRegistrationWithResourceProviders)
+		if !ok || len(v.ResourceProviders()) == 0 {
+			panic(fmt.Sprintf("The Service %q doesn't declare the Resource Providers it uses", service.Name()))
+		}
+		resourceProviders := v.ResourceProviders()
+
 		debugLog("[DEBUG] Registering Data Sources for %q..", service.Name())
 		for _, ds := range service.DataSources() {
 			key := ds.ResourceType()
@@ -124,6 +141,7 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 			if err != nil {
 				panic(fmt.Errorf("creating Wrapper for Data Source %q: %+v", key, err))
 			}
+			sdk.ApplyResourceProviderRegistrationToDataSource(key, dataSource, resourceProviders)
 
 			dataSources[key] = dataSource
 		}
@@ -140,31 +158,68 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 			if err != nil {
 				panic(fmt.Errorf("creating Wrapper for Resource %q: %+v", key, err))
 			}
+			sdk.ApplyResourceProviderRegistration(key, resource, resourceProviders)
+			sdk.ApplyProviderTags(key, resource)
 			resources[key] = resource
+
+			if v, ok := r.(sdk.ResourceWithList); ok {
+				listableResources = append(listableResources, v)
+			}
 		}
 	}
 
 	// then handle the untyped services
 	for _, service := range SupportedUntypedServices() {
+		v, ok := service.(sdk.UntypedServiceRegistrationWithResourceProviders)
+		if !ok || len(v.ResourceProviders()) == 0 {
+			panic(fmt.Sprintf("The Service %q doesn't declare the Resource Providers it uses", service.Name()))
+		}
+		resourceProviders := v.ResourceProviders()
+
 		debugLog("[DEBUG] Registering Data Sources for %q..", service.Name())
 		for k, v := range service.SupportedDataSources() {
 			if existing := dataSources[k]; existing != nil {
 				panic(fmt.Sprintf("An existing Data Source exists for %q", k))
 			}
 
+			sdk.ApplyResourceProviderRegistrationToDataSource(k, v, resourceProviders)
 			dataSources[k] = v
 		}
 
+		if v, ok := service.(sdk.UntypedServiceRegistrationWithListableResources); ok {
+			listableUntypedResources = append(listableUntypedResources, v.ListableResources()...)
+		}
+
 		debugLog("[DEBUG] Registering Resources for %q..", service.Name())
 		for k, v := range service.SupportedResources() {
 			if existing := resources[k]; existing != nil {
 				panic(fmt.Sprintf("An existing Resource exists for %q", k))
 			}
 
+			// Typed Resources enforce the `deletion_protection` feature within the ResourceWrapper
+			sdk.ApplyDeletionProtection(k, v)
+			sdk.ApplyResourceProviderRegistration(k, v, resourceProviders)
+			sdk.ApplyProviderTags(k, v)
 			resources[k] = v
 		}
 	}
 
+	// the Resources which support being listed are exposed via a single Data Source
+	if len(listableResources) > 0 || len(listableUntypedResources) > 0 {
+		ds := sdk.NewResourceListDataSource(listableResources, listableUntypedResources)
+		key := ds.ResourceType()
+		if existing := dataSources[key]; existing != nil {
+			panic(fmt.Sprintf("An existing Data Source exists for %q", key))
+		}
+
+		wrapper := sdk.NewDataSourceWrapper(ds)
+		dataSource, err := wrapper.DataSource()
+		if err != nil {
+			panic(fmt.Errorf("creating Wrapper for Data Source %q: %+v", key, err))
+		}
+		dataSources[key] = dataSource
+	}
+
 	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"subscription_id": {
@@ -336,8 +391,25 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 				Description: "This will disable the Terraform Partner ID which is used if a custom `partner_id` isn't specified.",
 			},
 
+			"http_log_failed_requests_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_HTTP_LOG_FAILED_REQUESTS_ONLY", false),
+				Description: "Should the AzureRM Provider only log HTTP requests which failed? Defaults to `false`.",
+			},
+
 			"features": schemaFeatures(supportLegacyTestSuite),
 
+			"default_tags": schemaDefaultTags(),
+
+			"ignore_tags": schemaIgnoreTags(),
+
+			"tag_policy": schemaTagPolicy(),
+
+			"retry": schemaRetry(),
+
+			"storage_data_plane": schemaStorageDataPlane(),
+
 			// Advanced feature flags
 			"skip_provider_registration": {
 				Type:        schema.TypeBool,
@@ -346,6 +418,14 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 				Description: "Should the AzureRM Provider skip registering all of the Resource Providers that it supports, if they're not already registered?",
 			},
 
+			"resource_provider_registrations": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_RESOURCE_PROVIDER_REGISTRATIONS", resourceproviders.RegistrationModeLegacy),
+				ValidateFunc: validation.StringInSlice(resourceproviders.PossibleValuesForRegistrationMode(), false),
+				Description:  "How should the AzureRM Provider register the Resource Providers it uses? Possible values are `legacy`, `on_demand` and `diagnostics_only`. Defaults to `legacy`.",
+			},
+
 			"storage_use_azuread": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -358,12 +438,12 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 		ResourcesMap:   resources,
 	}
 
-	p.ConfigureContextFunc = providerConfigure(p)
+	p.ConfigureContextFunc = providerConfigure(p, nil)
 
 	return p
 }
 
-func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
+func providerConfigure(p *schema.Provider, recorder common.Recorder) schema.ConfigureContextFunc {
 	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		var auxTenants []string
 		if v, ok := d.Get("auxiliary_tenant_ids").([]interface{}); ok && len(v) > 0 {
@@ -446,21 +526,47 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 			EnableAuthenticationUsingGitHubOIDC:        enableOidc,
 		}
 
-		return buildClient(ctx, p, d, authConfig)
+		return buildClient(ctx, p, d, authConfig, recorder)
 	}
 }
 
-func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData, authConfig *auth.Credentials) (*clients.Client, diag.Diagnostics) {
+func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData, authConfig *auth.Credentials, recorder common.Recorder) (*clients.Client, diag.Diagnostics) {
 	skipProviderRegistration := d.Get("skip_provider_registration").(bool)
+	resourceProviderRegistrations := d.Get("resource_provider_registrations").(string)
+	if resourceProviderRegistrations == resourceproviders.RegistrationModeDiagnosticsOnly {
+		// the API Clients mustn't register Resource Providers either
+		skipProviderRegistration = true
+	}
+
+	httpLogging, err := common.HTTPLoggingOptionsFromEnvironment()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	httpLogging.FailedRequestsOnly = d.Get("http_log_failed_requests_only").(bool)
+
+	retry, err := expandRetry(d.Get("retry").([]interface{}))
+	if err != nil {
+		return nil, diag.Errorf("expanding `retry`: %+v", err)
+	}
+	diags := retryWarnings(*retry)
+
+	storageDataPlane, err := expandStorageDataPlane(d.Get("storage_data_plane").([]interface{}))
+	if err != nil {
+		return nil, diag.Errorf("expanding `storage_data_plane`: %+v", err)
+	}
 
 	clientBuilder := clients.ClientBuilder{
 		AuthConfig:                  authConfig,
 		DisableCorrelationRequestID: d.Get("disable_correlation_request_id").(bool),
 		DisableTerraformPartnerID:   d.Get("disable_terraform_partner_id").(bool),
 		Features:                    expandFeatures(d.Get("features").([]interface{})),
+		HTTPLogging:                 *httpLogging,
 		MetadataHost:                d.Get("metadata_host").(string),
 		PartnerID:                   d.Get("partner_id").(string),
+		Recorder:                    recorder,
+		Retry:                       *retry,
 		SkipProviderRegistration:    skipProviderRegistration,
+		StorageDataPlane:            *storageDataPlane,
 		StorageUseAzureAD:           d.Get("storage_use_azuread").(bool),
 		SubscriptionID:              d.Get("subscription_id").(string),
 		TerraformVersion:            p.TerraformVersion,
@@ -482,8 +588,19 @@ func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData
 	}
 
 	client.StopContext = stopCtx
+	client.ProviderTags = expandProviderTags(d.Get("default_tags").([]interface{}), d.Get("ignore_tags").([]interface{}))
+	tagPolicy, err := expandTagPolicy(d.Get("tag_policy").([]interface{}))
+	if err != nil {
+		return nil, diag.Errorf("expanding `tag_policy`: %+v", err)
+	}
+	client.ProviderTags.Policy = *tagPolicy
+	client.ResourceProviderRegistrations = resourceProviderRegistrations
+	if skipProviderRegistration && resourceProviderRegistrations == resourceproviders.RegistrationModeOnDemand {
+		// `skip_provider_registration` takes precedence
+		client.ResourceProviderRegistrations = resourceproviders.RegistrationModeLegacy
+	}
 
-	if !skipProviderRegistration {
+	if !skipProviderRegistration && resourceProviderRegistrations == resourceproviders.RegistrationModeLegacy {
 		subscriptionId := commonids.NewSubscriptionID(client.Account.SubscriptionId)
 		requiredResourceProviders := resourceproviders.Required()
 		ctx2, cancel := context.WithTimeout(ctx, 30*time.Minute)
@@ -494,7 +611,7 @@ func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData
 		}
 	}
 
-	return client, nil
+	return client, diags
 }
 
 func decodeCertificate(clientCertificate string) ([]byte, error) {
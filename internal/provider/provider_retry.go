@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+func schemaRetry() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:        pluginsdk.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "How requests which failed with a transient error (such as throttling, a conflicting operation or a server error) are retried.",
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"max_attempts": {
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					Default:      common.DefaultRetryMaxAttempts,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+
+				"max_backoff_in_seconds": {
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					Default:      int(common.DefaultRetryMaxBackoff / time.Second),
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+
+				"service_override": {
+					Type:     pluginsdk.TypeList,
+					Optional: true,
+					Elem: &pluginsdk.Resource{
+						Schema: map[string]*pluginsdk.Schema{
+							"resource_provider": {
+								Type:         pluginsdk.TypeString,
+								Required:     true,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+
+							"max_attempts": {
+								Type:         pluginsdk.TypeInt,
+								Required:     true,
+								ValidateFunc: validation.IntAtLeast(1),
+							},
+
+							"max_backoff_in_seconds": {
+								Type:         pluginsdk.TypeInt,
+								Required:     true,
+								ValidateFunc: validation.IntAtLeast(1),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func expandRetry(input []interface{}) (*common.RetryOptions, error) {
+	output := common.DefaultRetryOptions()
+	if len(input) == 0 || input[0] == nil {
+		return &output, nil
+	}
+
+	raw := input[0].(map[string]interface{})
+	if v, ok := raw["max_attempts"].(int); ok && v > 0 {
+		output.MaxAttempts = v
+	}
+	if v, ok := raw["max_backoff_in_seconds"].(int); ok && v > 0 {
+		output.MaxBackoff = time.Duration(v) * time.Second
+	}
+
+	overrides, _ := raw["service_override"].([]interface{})
+	for _, item := range overrides {
+		override, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		resourceProvider := override["resource_provider"].(string)
+		if _, exists := output.ServiceOverrides[resourceProvider]; exists {
+			return nil, fmt.Errorf("the Resource Provider %q is specified in more than one `service_override` block", resourceProvider)
+		}
+		output.ServiceOverrides[resourceProvider] = common.RetryPolicy{
+			MaxAttempts: override["max_attempts"].(int),
+			MaxBackoff:  time.Duration(override["max_backoff_in_seconds"].(int)) * time.Second,
+		}
+	}
+
+	return &output, nil
+}
+
+// retryWarnings returns a warning when the Retry Options contain settings which can't be honoured by the API Clients based
+// on hashicorp/go-azure-sdk, since these retry requests using the SDK's own retry policy (which can't be configured)
+func retryWarnings(options common.RetryOptions) diag.Diagnostics {
+	unsupported := make([]string, 0)
+	if options.MaxAttempts != 1 && options.MaxAttempts != common.DefaultRetryMaxAttempts {
+		unsupported = append(unsupported, `"max_attempts" (other than 1)`)
+	}
+	if options.MaxBackoff != common.DefaultRetryMaxBackoff {
+		unsupported = append(unsupported, `"max_backoff_in_seconds"`)
+	}
+	if len(options.ServiceOverrides) > 0 {
+		unsupported = append(unsupported, `"service_override"`)
+	}
+	if len(unsupported) == 0 {
+		return nil
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  `The "retry" block is only partially supported`,
+			Detail: fmt.Sprintf(`The settings %s within the "retry" block only apply to resources using the legacy "Azure/go-autorest" SDK.
+
+Resources using "hashicorp/go-azure-sdk" retry requests using that SDK's own retry policy, which can't be configured - other than
+disabling the retries performed to work around eventual consistency, by setting "max_attempts" to 1.`, strings.Join(unsupported, ", ")),
+		},
+	}
+}
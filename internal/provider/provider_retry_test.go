@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func TestRetryWarnings(t *testing.T) {
+	testData := []struct {
+		name     string
+		input    []interface{}
+		expected []string
+	}{
+		{
+			name:  "not configured",
+			input: []interface{}{},
+		},
+		{
+			name: "defaults",
+			input: []interface{}{
+				map[string]interface{}{
+					"max_attempts":           4,
+					"max_backoff_in_seconds": 120,
+				},
+			},
+		},
+		{
+			name: "retries disabled",
+			input: []interface{}{
+				map[string]interface{}{
+					"max_attempts":           1,
+					"max_backoff_in_seconds": 120,
+				},
+			},
+		},
+		{
+			name: "more attempts",
+			input: []interface{}{
+				map[string]interface{}{
+					"max_attempts":           10,
+					"max_backoff_in_seconds": 120,
+				},
+			},
+			expected: []string{`"max_attempts" (other than 1)`},
+		},
+		{
+			name: "longer backoff and service overrides",
+			input: []interface{}{
+				map[string]interface{}{
+					"max_attempts":           4,
+					"max_backoff_in_seconds": 300,
+					"service_override": []interface{}{
+						map[string]interface{}{
+							"resource_provider":      "Microsoft.Storage",
+							"max_attempts":           2,
+							"max_backoff_in_seconds": 60,
+						},
+					},
+				},
+			},
+			expected: []string{`"max_backoff_in_seconds"`, `"service_override"`},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		options, err := expandRetry(v.input)
+		if err != nil {
+			t.Fatalf("expanding: %+v", err)
+		}
+
+		actual := retryWarnings(*options)
+		if len(v.expected) == 0 {
+			if len(actual) > 0 {
+				t.Fatalf("expected no warnings but got %+v", actual)
+			}
+			continue
+		}
+
+		if len(actual) != 1 || actual[0].Severity != diag.Warning {
+			t.Fatalf("expected a single warning but got %+v", actual)
+		}
+		for _, setting := range v.expected {
+			if !strings.Contains(actual[0].Detail, setting) {
+				t.Fatalf("expected the warning to mention %s but got %q", setting, actual[0].Detail)
+			}
+		}
+	}
+}
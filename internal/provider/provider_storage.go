@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+func schemaStorageDataPlane() *pluginsdk.Schema {
+	endpoint := func(service string) *pluginsdk.Schema {
+		return &pluginsdk.Schema{
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.IsURLWithHTTPS,
+			Description:  fmt.Sprintf("The endpoint used to connect to the %s service within this Storage Account.", service),
+		}
+	}
+
+	return &pluginsdk.Schema{
+		Type:        pluginsdk.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Where requests to the Storage Data Plane API's are sent, for example when the Storage Accounts are only reachable using a Private Endpoint.",
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"domain_suffix": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+					Description:  "The DNS Suffix used to connect to the Storage Data Plane API's, in place of the Storage Endpoint Suffix for the Environment.",
+				},
+
+				"account_endpoint": {
+					Type:     pluginsdk.TypeList,
+					Optional: true,
+					Elem: &pluginsdk.Resource{
+						Schema: map[string]*pluginsdk.Schema{
+							"storage_account_name": {
+								Type:         pluginsdk.TypeString,
+								Required:     true,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+
+							"blob_endpoint": endpoint("Blob"),
+
+							"dfs_endpoint": endpoint("Data Lake Gen2"),
+
+							"file_endpoint": endpoint("File"),
+
+							"queue_endpoint": endpoint("Queue"),
+
+							"table_endpoint": endpoint("Table"),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func expandStorageDataPlane(input []interface{}) (*common.StorageDataPlaneOptions, error) {
+	output := common.StorageDataPlaneOptions{
+		AccountEndpoints: map[string]common.StorageAccountEndpoints{},
+	}
+	if len(input) == 0 || input[0] == nil {
+		return &output, nil
+	}
+
+	raw := input[0].(map[string]interface{})
+	output.DomainSuffix = strings.TrimPrefix(raw["domain_suffix"].(string), ".")
+
+	accounts, _ := raw["account_endpoint"].([]interface{})
+	for _, item := range accounts {
+		account, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		accountName := account["storage_account_name"].(string)
+		if _, exists := output.AccountEndpoints[accountName]; exists {
+			return nil, fmt.Errorf("the Storage Account %q is specified in more than one `account_endpoint` block", accountName)
+		}
+		output.AccountEndpoints[accountName] = common.StorageAccountEndpoints{
+			Blob:  account["blob_endpoint"].(string),
+			Dfs:   account["dfs_endpoint"].(string),
+			File:  account["file_endpoint"].(string),
+			Queue: account["queue_endpoint"].(string),
+			Table: account["table_endpoint"].(string),
+		}
+	}
+
+	return &output, nil
+}
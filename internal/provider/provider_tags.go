@@ -0,0 +1,187 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+func schemaDefaultTags() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:        pluginsdk.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "The Tags which should be applied to every Resource supporting Tags, unless the Resource specifies a Tag with the same key.",
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"tags": {
+					Type:         pluginsdk.TypeMap,
+					Required:     true,
+					ValidateFunc: tags.Validate,
+					Elem: &pluginsdk.Schema{
+						Type: pluginsdk.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+func schemaIgnoreTags() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:        pluginsdk.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "The Tags which should be ignored when reading a Resource, for example those applied by Azure Policy.",
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"keys": {
+					Type:     pluginsdk.TypeSet,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+
+				"key_prefixes": {
+					Type:     pluginsdk.TypeSet,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+			},
+		},
+	}
+}
+
+func schemaTagPolicy() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:        pluginsdk.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "The Tag Policy which the Tags of every Resource supporting Tags must satisfy, which is checked during the plan.",
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"required_keys": {
+					Type:     pluginsdk.TypeSet,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+
+				"allowed_values": {
+					Type:         pluginsdk.TypeMap,
+					Optional:     true,
+					ValidateFunc: validateTagPolicyAllowedValues,
+					Elem: &pluginsdk.Schema{
+						Type: pluginsdk.TypeString,
+					},
+				},
+
+				"reserved_key_prefixes": {
+					Type:     pluginsdk.TypeSet,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+			},
+		},
+	}
+}
+
+func validateTagPolicyAllowedValues(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(map[string]interface{})
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be map", k))
+		return warnings, errors
+	}
+
+	for key, value := range v {
+		pattern, ok := value.(string)
+		if !ok {
+			errors = append(errors, fmt.Errorf("expected the value for %q in %s to be a string", key, k))
+			continue
+		}
+		if _, err := tags.CompileAllowedValue(pattern); err != nil {
+			errors = append(errors, fmt.Errorf("the value for %q in %s must be a valid regular expression: %+v", key, k, err))
+		}
+	}
+
+	return warnings, errors
+}
+
+func expandProviderTags(defaultTags []interface{}, ignoreTags []interface{}) tags.ProviderTags {
+	output := tags.ProviderTags{
+		DefaultTags:       map[string]string{},
+		IgnoreKeys:        []string{},
+		IgnoreKeyPrefixes: []string{},
+	}
+
+	if len(defaultTags) > 0 && defaultTags[0] != nil {
+		raw := defaultTags[0].(map[string]interface{})
+		if v, ok := raw["tags"].(map[string]interface{}); ok {
+			for key, value := range tags.Expand(v) {
+				output.DefaultTags[key] = *value
+			}
+		}
+	}
+
+	if len(ignoreTags) > 0 && ignoreTags[0] != nil {
+		raw := ignoreTags[0].(map[string]interface{})
+		if v, ok := raw["keys"].(*pluginsdk.Set); ok {
+			output.IgnoreKeys = expandFeaturesStringList(v.List())
+		}
+		if v, ok := raw["key_prefixes"].(*pluginsdk.Set); ok {
+			output.IgnoreKeyPrefixes = expandFeaturesStringList(v.List())
+		}
+	}
+
+	return output
+}
+
+func expandTagPolicy(input []interface{}) (*tags.Policy, error) {
+	output := tags.Policy{
+		RequiredKeys:        []string{},
+		AllowedValues:       map[string]*regexp.Regexp{},
+		ReservedKeyPrefixes: []string{},
+	}
+
+	if len(input) == 0 || input[0] == nil {
+		return &output, nil
+	}
+
+	raw := input[0].(map[string]interface{})
+	if v, ok := raw["required_keys"].(*pluginsdk.Set); ok {
+		output.RequiredKeys = expandFeaturesStringList(v.List())
+		sort.Strings(output.RequiredKeys)
+	}
+	if v, ok := raw["reserved_key_prefixes"].(*pluginsdk.Set); ok {
+		output.ReservedKeyPrefixes = expandFeaturesStringList(v.List())
+		sort.Strings(output.ReservedKeyPrefixes)
+	}
+	if v, ok := raw["allowed_values"].(map[string]interface{}); ok {
+		for key, pattern := range expandFeaturesStringMap(v) {
+			regex, err := tags.CompileAllowedValue(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("compiling the allowed values for the tag %q: %+v", key, err)
+			}
+			output.AllowedValues[key] = regex
+		}
+	}
+
+	return &output, nil
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 )
 
 func TestProvider(t *testing.T) {
@@ -108,6 +109,23 @@ func TestResourcesSupportCustomTimeouts(t *testing.T) {
 	}
 }
 
+func TestServicesDeclareResourceProviders(t *testing.T) {
+	// each Service must declare the Resource Providers it uses, so that these can be registered on-demand
+	for _, service := range SupportedTypedServices() {
+		v, ok := service.(sdk.TypedServiceRegistrationWithResourceProviders)
+		if !ok || len(v.ResourceProviders()) == 0 {
+			t.Errorf("the Typed Service %q doesn't declare the Resource Providers it uses", service.Name())
+		}
+	}
+
+	for _, service := range SupportedUntypedServices() {
+		v, ok := service.(sdk.UntypedServiceRegistrationWithResourceProviders)
+		if !ok || len(v.ResourceProviders()) == 0 {
+			t.Errorf("the Untyped Service %q doesn't declare the Resource Providers it uses", service.Name())
+		}
+	}
+}
+
 func TestProvider_impl(t *testing.T) {
 	_ = AzureProvider()
 }
@@ -145,7 +163,7 @@ func TestAccProvider_cliAuth(t *testing.T) {
 			EnableAuthenticatingUsingAzureCLI: true,
 		}
 
-		return buildClient(ctx, provider, d, authConfig)
+		return buildClient(ctx, provider, d, authConfig, nil)
 	}
 
 	d := provider.Configure(ctx, terraform.NewResourceConfigRaw(nil))
@@ -207,7 +225,7 @@ func TestAccProvider_clientCertificateAuth(t *testing.T) {
 			ClientCertificatePassword:                  d.Get("client_certificate_password").(string),
 		}
 
-		return buildClient(ctx, provider, d, authConfig)
+		return buildClient(ctx, provider, d, authConfig, nil)
 	}
 
 	d := provider.Configure(ctx, terraform.NewResourceConfigRaw(nil))
@@ -275,7 +293,7 @@ func testAccProvider_clientSecretAuthFromEnvironment(t *testing.T) {
 			ClientSecret:                          *clientSecret,
 		}
 
-		return buildClient(ctx, provider, d, authConfig)
+		return buildClient(ctx, provider, d, authConfig, nil)
 	}
 
 	d := provider.Configure(ctx, terraform.NewResourceConfigRaw(nil))
@@ -338,7 +356,7 @@ func testAccProvider_clientSecretAuthFromFiles(t *testing.T) {
 			ClientSecret:                          *clientSecret,
 		}
 
-		return buildClient(ctx, provider, d, authConfig)
+		return buildClient(ctx, provider, d, authConfig, nil)
 	}
 
 	d := provider.Configure(ctx, terraform.NewResourceConfigRaw(nil))
@@ -388,7 +406,7 @@ func TestAccProvider_genericOidcAuth(t *testing.T) {
 			OIDCAssertionToken:            *oidcToken,
 		}
 
-		return buildClient(ctx, provider, d, authConfig)
+		return buildClient(ctx, provider, d, authConfig, nil)
 	}
 
 	d := provider.Configure(ctx, terraform.NewResourceConfigRaw(nil))
@@ -437,7 +455,7 @@ func TestAccProvider_githubOidcAuth(t *testing.T) {
 			GitHubOIDCTokenRequestURL:           d.Get("oidc_request_url").(string),
 		}
 
-		return buildClient(ctx, provider, d, authConfig)
+		return buildClient(ctx, provider, d, authConfig, nil)
 	}
 
 	d := provider.Configure(ctx, terraform.NewResourceConfigRaw(nil))
@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+)
+
+// ProtoV5ProviderServerFactory returns a Provider Server which muxes together the
+// Plugin SDKv2 and Plugin Framework halves of the AzureRM Provider
+func ProtoV5ProviderServerFactory(ctx context.Context) (func() tfprotov5.ProviderServer, error) {
+	return protoV5ProviderServerFactory(ctx, AzureProvider())
+}
+
+// TestProtoV5ProviderServerFactory returns a muxed Provider Server for use in the Acceptance Tests
+func TestProtoV5ProviderServerFactory(ctx context.Context) (func() tfprotov5.ProviderServer, error) {
+	return protoV5ProviderServerFactory(ctx, TestAzureProvider())
+}
+
+// TestProtoV5ProviderServerFactoryWithRecorder returns a muxed Provider Server for use in the Acceptance Tests,
+// where the requests sent by the API Clients are recorded (or replayed) using the specified Recorder
+func TestProtoV5ProviderServerFactoryWithRecorder(ctx context.Context, recorder common.Recorder) (func() tfprotov5.ProviderServer, error) {
+	return protoV5ProviderServerFactory(ctx, TestAzureProviderWithRecorder(recorder))
+}
+
+func protoV5ProviderServerFactory(ctx context.Context, sdkProvider *schema.Provider) (func() tfprotov5.ProviderServer, error) {
+	// NOTE: the Plugin SDKv2 Provider must be first, since the servers are configured in order and the
+	// Plugin Framework Provider obtains the API Clients from the Plugin SDKv2 Provider.
+	servers := []func() tfprotov5.ProviderServer{
+		func() tfprotov5.ProviderServer {
+			return sdk.NewDeletionProtectionProviderServer(sdkProvider)
+		},
+		providerserver.NewProtocol5(newFrameworkProvider(sdkProvider)),
+	}
+
+	muxServer, err := tf5muxserver.NewMuxServer(ctx, servers...)
+	if err != nil {
+		return nil, err
+	}
+
+	return muxServer.ProviderServer, nil
+}
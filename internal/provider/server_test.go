@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+)
+
+func TestProtoV5ProviderServerSchema(t *testing.T) {
+	ctx := context.TODO()
+	serverFactory, err := TestProtoV5ProviderServerFactory(ctx)
+	if err != nil {
+		t.Fatalf("building the Provider Server: %+v", err)
+	}
+
+	// the Provider Schema must be identical across both the Plugin SDKv2 and Plugin Framework servers
+	resp, err := serverFactory().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("retrieving the Provider Schema: %+v", err)
+	}
+	for _, v := range resp.Diagnostics {
+		if v.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Fatalf("retrieving the Provider Schema: %s: %s", v.Summary, v.Detail)
+		}
+	}
+
+	if _, ok := resp.ResourceSchemas["azurerm_resource_group"]; !ok {
+		t.Fatalf("expected the Resource `azurerm_resource_group` to be exposed via the muxed Provider Server")
+	}
+
+	// Resources served through the Plugin Framework must expose the same type as their Plugin SDKv2 implementation
+	frameworkResourceType := "azurerm_key_vault_managed_hardware_security_module_role_assignment"
+	frameworkSchema, ok := resp.ResourceSchemas[frameworkResourceType]
+	if !ok {
+		t.Fatalf("expected the Resource %q to be exposed via the muxed Provider Server", frameworkResourceType)
+	}
+	sdkResp, err := AzureProviderSchema().GRPCProvider().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("retrieving the Plugin SDKv2 Provider Schema: %+v", err)
+	}
+	if sdkSchema := sdkResp.ResourceSchemas[frameworkResourceType]; sdkSchema == nil || !frameworkSchema.ValueType().Equal(sdkSchema.ValueType()) {
+		t.Fatalf("expected the Resource %q to expose the same type through the Plugin Framework and the Plugin SDKv2", frameworkResourceType)
+	}
+
+	if _, ok := resp.EphemeralResourceSchemas["azurerm_key_vault_secret"]; !ok {
+		t.Fatalf("expected the Ephemeral Resource `azurerm_key_vault_secret` to be exposed via the muxed Provider Server")
+	}
+
+	for _, name := range []string{"build_resource_id", "normalise_resource_id", "parse_resource_id"} {
+		if _, ok := resp.Functions[name]; !ok {
+			t.Fatalf("expected the Function %q to be exposed via the muxed Provider Server", name)
+		}
+	}
+}
@@ -27,7 +27,7 @@ func TestTypedDataSourcesContainValidModelObjects(t *testing.T) {
 func TestTypedResourcesContainValidModelObjects(t *testing.T) {
 	for _, service := range SupportedTypedServices() {
 		t.Logf("Service %q..", service.Name())
-		for _, resource := range service.Resources() {
+		for _, resource := range sdk.TypedResourcesForService(service) {
 			t.Logf("- Resource %q..", resource.ResourceType())
 			obj := resource.ModelObject()
 			if err := sdk.ValidateModelObject(obj); err != nil {
@@ -46,7 +46,7 @@ func TestTypedResourcesContainValidIDParsers(t *testing.T) {
 	// Untyped Resources are checked via TestUntypedResourcesContainImporters
 	for _, service := range SupportedTypedServices() {
 		t.Logf("Service %q..", service.Name())
-		for _, resource := range service.Resources() {
+		for _, resource := range sdk.TypedResourcesForService(service) {
 			t.Logf("- Resource %q..", resource.ResourceType())
 			obj := resource.IDValidationFunc()
 			if obj == nil {
@@ -94,7 +94,7 @@ func TestResourcesAreNamedConsistently(t *testing.T) {
 				t.Fatalf("the Data Source %q isn't named consistently: %+v", dataSource.ResourceType(), err)
 			}
 		}
-		for _, resource := range service.Resources() {
+		for _, resource := range sdk.TypedResourcesForService(service) {
 			if err := validateResourceTypeName(resource.ResourceType()); err != nil {
 				t.Fatalf("the Resource %q isn't named consistently: %+v", resource.ResourceType(), err)
 			}
@@ -41,6 +41,10 @@ func ClearCache() {
 	registeredResourceProviders = nil
 	unregisteredResourceProviders = nil
 	cacheLock.Unlock()
+
+	onDemandLock.Lock()
+	onDemandRegistrations = make(map[string]*onDemandRegistration)
+	onDemandLock.Unlock()
 }
 
 func populateCache(ctx context.Context, client *providers.ProvidersClient, subscriptionId commonids.SubscriptionId) error {
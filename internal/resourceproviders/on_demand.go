@@ -0,0 +1,190 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceproviders
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2022-09-01/providers"
+)
+
+const (
+	// RegistrationModeLegacy registers all of the Resource Providers returned from Required when the Provider is configured
+	RegistrationModeLegacy = "legacy"
+
+	// RegistrationModeOnDemand registers the Resource Providers used by a Service the first time a resource
+	// within that Service is planned
+	RegistrationModeOnDemand = "on_demand"
+
+	// RegistrationModeDiagnosticsOnly doesn't register any Resource Providers, instead the Resource Providers used
+	// by a Service which require registration are surfaced as an error when a resource within that Service is planned
+	RegistrationModeDiagnosticsOnly = "diagnostics_only"
+)
+
+// PossibleValuesForRegistrationMode returns the supported Resource Provider Registration modes
+func PossibleValuesForRegistrationMode() []string {
+	return []string{
+		RegistrationModeLegacy,
+		RegistrationModeOnDemand,
+		RegistrationModeDiagnosticsOnly,
+	}
+}
+
+// onDemandRegistration tracks the registration of a single Resource Provider, so that concurrent callers
+// requiring the same Resource Provider wait on a single registration
+type onDemandRegistration struct {
+	done chan struct{}
+	err  error
+}
+
+var onDemandRegistrations = make(map[string]*onDemandRegistration)
+var onDemandLock = &sync.Mutex{}
+
+// onDemandRegistrationTimeout is the maximum duration of a single Resource Provider registration, which matches
+// the duration allowed for registering the Resource Providers when the Provider is configured
+const onDemandRegistrationTimeout = 30 * time.Minute
+
+// onDemandRegister registers the Resource Provider within the Subscription, this is overridden in tests
+var onDemandRegister = registerWithSubscription
+
+// RegisterOnDemand ensures that each of the specified Resource Providers is registered, registering any which
+// aren't. Each Resource Provider is registered at most once, regardless of how many callers require it - should
+// registration fail, a subsequent call will attempt to register the Resource Provider again.
+//
+// Since the registration is shared between callers, it's performed using a context which is detached from `ctx`
+// (with its own timeout) - `ctx` only bounds how long this caller waits for the registration to complete.
+func RegisterOnDemand(ctx context.Context, client *providers.ProvidersClient, subscriptionId commonids.SubscriptionId, resourceProviders []string) error {
+	if err := ensureCachePopulated(ctx, client, subscriptionId); err != nil {
+		return err
+	}
+
+	pending := make(map[string]*onDemandRegistration)
+	onDemandLock.Lock()
+	for _, resourceProvider := range resourceProviders {
+		if existing, ok := onDemandRegistrations[resourceProvider]; ok {
+			pending[resourceProvider] = existing
+			continue
+		}
+
+		if !requiresRegistration(resourceProvider) {
+			continue
+		}
+
+		registration := &onDemandRegistration{
+			done: make(chan struct{}),
+		}
+		onDemandRegistrations[resourceProvider] = registration
+		pending[resourceProvider] = registration
+
+		registrationCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), onDemandRegistrationTimeout)
+		go func(resourceProvider string, registration *onDemandRegistration) {
+			defer cancel()
+
+			log.Printf("[DEBUG] Registering the Resource Provider %q on-demand..", resourceProvider)
+			registration.err = onDemandRegister(registrationCtx, client, subscriptionId, resourceProvider)
+			if registration.err == nil {
+				markAsRegistered(resourceProvider)
+			} else {
+				// allow the registration to be retried
+				onDemandLock.Lock()
+				delete(onDemandRegistrations, resourceProvider)
+				onDemandLock.Unlock()
+			}
+			close(registration.done)
+		}(resourceProvider, registration)
+	}
+	onDemandLock.Unlock()
+
+	errs := make([]string, 0)
+	for resourceProvider, registration := range pending {
+		select {
+		case <-registration.done:
+			if registration.err != nil {
+				errs = append(errs, registration.err.Error())
+			}
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for the Resource Provider %q to be registered: %+v", resourceProvider, ctx.Err())
+		}
+	}
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return fmt.Errorf("registering Resource Providers: %s", strings.Join(errs, "\n"))
+	}
+
+	return nil
+}
+
+// RequiringRegistration returns the specified Resource Providers which require registration, without
+// registering them
+func RequiringRegistration(ctx context.Context, client *providers.ProvidersClient, subscriptionId commonids.SubscriptionId, resourceProviders []string) ([]string, error) {
+	if err := ensureCachePopulated(ctx, client, subscriptionId); err != nil {
+		return nil, err
+	}
+
+	out := make([]string, 0)
+	for _, resourceProvider := range resourceProviders {
+		if requiresRegistration(resourceProvider) {
+			out = append(out, resourceProvider)
+		}
+	}
+	sort.Strings(out)
+
+	return out, nil
+}
+
+func ensureCachePopulated(ctx context.Context, client *providers.ProvidersClient, subscriptionId commonids.SubscriptionId) error {
+	cacheLock.Lock()
+	populated := registeredResourceProviders != nil && unregisteredResourceProviders != nil
+	cacheLock.Unlock()
+	if populated {
+		return nil
+	}
+
+	if err := populateCache(ctx, client, subscriptionId); err != nil {
+		return fmt.Errorf("populating Resource Provider cache: %+v", err)
+	}
+
+	return nil
+}
+
+// requiresRegistration returns whether the Resource Provider is known to Resource Manager but isn't registered
+func requiresRegistration(resourceProvider string) bool {
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+
+	if registeredResourceProviders == nil || unregisteredResourceProviders == nil {
+		return false
+	}
+
+	if _, isRegistered := (*registeredResourceProviders)[resourceProvider]; isRegistered {
+		return false
+	}
+
+	if _, isUnregistered := (*unregisteredResourceProviders)[resourceProvider]; !isUnregistered {
+		// not every Resource Provider is available in every cloud, so this isn't necessarily a typo
+		log.Printf("[WARN] The Resource Provider %q wasn't returned from the Azure API - skipping registration", resourceProvider)
+		return false
+	}
+
+	return true
+}
+
+func markAsRegistered(resourceProvider string) {
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+
+	if registeredResourceProviders == nil || unregisteredResourceProviders == nil {
+		return
+	}
+
+	(*registeredResourceProviders)[resourceProvider] = struct{}{}
+	delete(*unregisteredResourceProviders, resourceProvider)
+}
@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceproviders
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2022-09-01/providers"
+)
+
+func seedCache(registered []string, unregistered []string) {
+	ClearCache()
+
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+
+	names := make([]string, 0)
+	registeredProviders := make(map[string]struct{})
+	for _, v := range registered {
+		names = append(names, v)
+		registeredProviders[v] = struct{}{}
+	}
+	unregisteredProviders := make(map[string]struct{})
+	for _, v := range unregistered {
+		names = append(names, v)
+		unregisteredProviders[v] = struct{}{}
+	}
+
+	cachedResourceProviders = &names
+	registeredResourceProviders = &registeredProviders
+	unregisteredResourceProviders = &unregisteredProviders
+}
+
+func TestRequiringRegistration(t *testing.T) {
+	seedCache([]string{"Microsoft.Compute", "Microsoft.Network"}, []string{"Microsoft.Storage", "Microsoft.Web"})
+	defer ClearCache()
+
+	subscriptionId := commonids.NewSubscriptionID("00000000-0000-0000-0000-000000000000")
+	actual, err := RequiringRegistration(context.Background(), nil, subscriptionId, []string{"Microsoft.Web", "Microsoft.Compute", "Microsoft.Storage", "Microsoft.Unknown"})
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	expected := []string{"Microsoft.Storage", "Microsoft.Web"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, actual)
+	}
+}
+
+func TestRegisterOnDemandAlreadyRegistered(t *testing.T) {
+	seedCache([]string{"Microsoft.Compute", "Microsoft.Network"}, []string{"Microsoft.Storage"})
+	defer ClearCache()
+
+	// since these are either registered or unknown, no requests are made - so no client is needed
+	subscriptionId := commonids.NewSubscriptionID("00000000-0000-0000-0000-000000000000")
+	var wait sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			if err := RegisterOnDemand(context.Background(), nil, subscriptionId, []string{"Microsoft.Compute", "Microsoft.Network", "Microsoft.Unknown"}); err != nil {
+				t.Errorf("unexpected error: %+v", err)
+			}
+		}()
+	}
+	wait.Wait()
+}
+
+func TestRegisterOnDemandWaitsForPendingRegistration(t *testing.T) {
+	seedCache([]string{}, []string{"Microsoft.Storage"})
+	defer ClearCache()
+
+	// simulate a registration which is already in progress
+	registration := &onDemandRegistration{
+		done: make(chan struct{}),
+	}
+	onDemandLock.Lock()
+	onDemandRegistrations["Microsoft.Storage"] = registration
+	onDemandLock.Unlock()
+
+	subscriptionId := commonids.NewSubscriptionID("00000000-0000-0000-0000-000000000000")
+	result := make(chan error)
+	go func() {
+		result <- RegisterOnDemand(context.Background(), nil, subscriptionId, []string{"Microsoft.Storage"})
+	}()
+
+	select {
+	case <-result:
+		t.Fatalf("expected RegisterOnDemand to wait for the pending registration")
+	default:
+	}
+
+	close(registration.done)
+	if err := <-result; err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+}
+
+func TestRegisterOnDemandIsDetachedFromTheCallersContext(t *testing.T) {
+	seedCache([]string{}, []string{"Microsoft.Storage"})
+	defer ClearCache()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	onDemandRegister = func(ctx context.Context, _ *providers.ProvidersClient, _ commonids.SubscriptionId, _ string) error {
+		close(started)
+		<-release
+		// the registration mustn't be cancelled when the caller which started it gives up
+		return ctx.Err()
+	}
+	defer func() {
+		onDemandRegister = registerWithSubscription
+	}()
+
+	subscriptionId := commonids.NewSubscriptionID("00000000-0000-0000-0000-000000000000")
+
+	// the first caller starts the registration, but gives up waiting on it..
+	firstCtx, cancel := context.WithCancel(context.Background())
+	firstResult := make(chan error)
+	go func() {
+		firstResult <- RegisterOnDemand(firstCtx, nil, subscriptionId, []string{"Microsoft.Storage"})
+	}()
+	<-started
+	cancel()
+	if err := <-firstResult; err == nil {
+		t.Fatalf("expected an error when the first caller's context is cancelled")
+	}
+
+	// ..whereas the second caller waits on the same registration, which completes successfully
+	secondResult := make(chan error)
+	go func() {
+		secondResult <- RegisterOnDemand(context.Background(), nil, subscriptionId, []string{"Microsoft.Storage"})
+	}()
+	close(release)
+	if err := <-secondResult; err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if requiresRegistration("Microsoft.Storage") {
+		t.Fatalf("expected Microsoft.Storage to be marked as registered")
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+// ApplyDeletionProtection wraps the Delete functions for an (untyped) Plugin SDK Resource so that the
+// `deletion_protection` block within the Provider's `features` block is enforced when deleting the resource - Typed
+// Resources are handled by the ResourceWrapper. Replacements are enforced by NewDeletionProtectionProviderServer.
+func ApplyDeletionProtection(resourceType string, resource *schema.Resource) {
+	resourceSchema := resource.Schema
+
+	if resource.Delete != nil { //nolint:staticcheck
+		deleteFunc := resource.Delete                                            //nolint:staticcheck
+		resource.Delete = func(d *schema.ResourceData, meta interface{}) error { //nolint:staticcheck
+			if err := preventProtectedDeletion(resourceType, resourceSchema, d, meta); err != nil {
+				return err
+			}
+			return deleteFunc(d, meta)
+		}
+	}
+
+	wrapContextFunc := func(in func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if err := preventProtectedDeletion(resourceType, resourceSchema, d, meta); err != nil {
+				return diag.FromErr(err)
+			}
+			return in(ctx, d, meta)
+		}
+	}
+	if resource.DeleteContext != nil {
+		resource.DeleteContext = wrapContextFunc(resource.DeleteContext)
+	}
+	if resource.DeleteWithoutTimeout != nil {
+		resource.DeleteWithoutTimeout = wrapContextFunc(resource.DeleteWithoutTimeout)
+	}
+}
+
+var _ tfprotov5.ProviderServer = deletionProtectionProviderServer{}
+
+// deletionProtectionProviderServer serves a Plugin SDKv2 Provider, returning an error when the plan for a resource
+// protected by the `deletion_protection` block within the Provider's `features` block requires it to be replaced.
+//
+// This is checked against the planned diff (rather than the Schema) since a replacement can also be required by a
+// CustomizeDiff function (e.g. using `ForceNew` or `ForceNewIfChange`), which is only known once the diff is complete.
+type deletionProtectionProviderServer struct {
+	tfprotov5.ProviderServer
+
+	provider *schema.Provider
+}
+
+// NewDeletionProtectionProviderServer returns a Provider Server for the Plugin SDKv2 Provider `provider` which enforces
+// the `prevent_replacement` field within the `deletion_protection` block
+func NewDeletionProtectionProviderServer(provider *schema.Provider) tfprotov5.ProviderServer {
+	return deletionProtectionProviderServer{
+		ProviderServer: provider.GRPCProvider(),
+		provider:       provider,
+	}
+}
+
+func (s deletionProtectionProviderServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	resp, err := s.ProviderServer.PlanResourceChange(ctx, req)
+	if err != nil || resp == nil || len(resp.RequiresReplace) == 0 {
+		return resp, err
+	}
+	for _, v := range resp.Diagnostics {
+		if v != nil && v.Severity == tfprotov5.DiagnosticSeverityError {
+			return resp, nil
+		}
+	}
+
+	if err := preventProtectedReplacement(req.TypeName, s.provider, req.PriorState, resp.RequiresReplace); err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  err.Error(),
+		})
+	}
+
+	return resp, nil
+}
+
+// preventProtectedDeletion returns an error if the resource is protected from deletion
+func preventProtectedDeletion(resourceType string, resourceSchema map[string]*schema.Schema, d *schema.ResourceData, meta interface{}) error {
+	client, ok := meta.(*clients.Client)
+	if !ok || client == nil {
+		return nil
+	}
+
+	protected, reason := isProtected(client, resourceType, resourceSchema, d)
+	if !protected {
+		return nil
+	}
+
+	return fmt.Errorf(`deleting the %s %q is prevented by the "deletion_protection" block within the Provider's "features" block, since %s.
+
+To delete this resource, either remove the protection from the "deletion_protection" block, or add a pattern matching
+this Resource ID to "excluded_resource_id_patterns"`, resourceType, d.Id(), reason)
+}
+
+// preventProtectedReplacement returns an error if the resource is protected and the planned diff requires it to be
+// replaced, as indicated by the paths to the attributes requiring replacement
+func preventProtectedReplacement(resourceType string, provider *schema.Provider, priorState *tfprotov5.DynamicValue, requiresReplace []*tftypes.AttributePath) error {
+	client, ok := provider.Meta().(*clients.Client)
+	if !ok || client == nil || !client.Features.DeletionProtection.PreventReplacement {
+		return nil
+	}
+
+	resource, ok := provider.ResourcesMap[resourceType]
+	if !ok || priorState == nil {
+		return nil
+	}
+
+	priorStateValue, err := msgpack.Unmarshal(priorState.MsgPack, resource.CoreConfigSchema().ImpliedType())
+	if err != nil {
+		return fmt.Errorf("decoding the prior state for %s: %+v", resourceType, err)
+	}
+	// new resources can't be replaced
+	if priorStateValue.IsNull() {
+		return nil
+	}
+
+	state, err := resource.ShimInstanceStateFromValue(priorStateValue)
+	if err != nil {
+		return fmt.Errorf("building the prior state for %s: %+v", resourceType, err)
+	}
+	// the tags which are currently applied (including the Default Tags) are what protect the resource
+	d := resource.Data(state)
+
+	protected, reason := isProtected(client, resourceType, resource.Schema, d)
+	if !protected {
+		return nil
+	}
+
+	return fmt.Errorf(`the %s %q would be replaced (since changing %s requires a new resource) - however this is prevented by the
+"deletion_protection" block within the Provider's "features" block, since %s.
+
+To replace this resource, either set "prevent_replacement" to false, remove the protection from the "deletion_protection"
+block, or add a pattern matching this Resource ID to "excluded_resource_id_patterns"`, resourceType, d.Id(), strings.Join(fieldsRequiringReplacement(requiresReplace), ", "), reason)
+}
+
+// isProtected returns whether the resource is protected from deletion, and if so the reason why
+func isProtected(client *clients.Client, resourceType string, resourceSchema map[string]*schema.Schema, d *schema.ResourceData) (bool, string) {
+	var tags interface{}
+	if _, hasTagsAll := resourceSchema["tags_all"]; hasTagsAll {
+		// includes the Default Tags configured within the Provider block
+		tags = d.Get("tags_all")
+	} else if _, hasTags := resourceSchema["tags"]; hasTags {
+		tags = d.Get("tags")
+	}
+
+	return client.Features.DeletionProtection.IsProtected(resourceType, d.Id(), flattenDeletionProtectionTags(tags))
+}
+
+// fieldsRequiringReplacement returns the (top-level) fields containing the attributes which require a new resource
+func fieldsRequiringReplacement(requiresReplace []*tftypes.AttributePath) []string {
+	unique := make(map[string]struct{})
+	for _, v := range requiresReplace {
+		if v == nil || len(v.Steps()) == 0 {
+			continue
+		}
+		// the Plugin SDKv2 also marks the `id` as requiring replacement, since this becomes unknown
+		if name, ok := v.Steps()[0].(tftypes.AttributeName); ok && name != "id" {
+			unique[fmt.Sprintf("%q", string(name))] = struct{}{}
+		}
+	}
+
+	out := make([]string, 0)
+	for k := range unique {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func flattenDeletionProtectionTags(input interface{}) map[string]string {
+	out := make(map[string]string)
+
+	raw, ok := input.(map[string]interface{})
+	if !ok {
+		return out
+	}
+	for k, v := range raw {
+		if value, ok := v.(string); ok {
+			out[k] = value
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,363 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+)
+
+func TestFieldsRequiringReplacement(t *testing.T) {
+	testData := []struct {
+		Input    []*tftypes.AttributePath
+		Expected []string
+	}{
+		{
+			Input:    nil,
+			Expected: []string{},
+		},
+		{
+			Input: []*tftypes.AttributePath{
+				tftypes.NewAttributePath().WithAttributeName("id"),
+				tftypes.NewAttributePath().WithAttributeName("name"),
+			},
+			Expected: []string{`"name"`},
+		},
+		{
+			Input: []*tftypes.AttributePath{
+				tftypes.NewAttributePath().WithAttributeName("network").WithElementKeyInt(0).WithAttributeName("subnet_id"),
+				tftypes.NewAttributePath().WithAttributeName("network").WithElementKeyInt(1).WithAttributeName("subnet_id"),
+				tftypes.NewAttributePath().WithAttributeName("name"),
+			},
+			Expected: []string{`"name"`, `"network"`},
+		},
+		{
+			Input: []*tftypes.AttributePath{
+				tftypes.NewAttributePath(),
+			},
+			Expected: []string{},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %+v", v.Input)
+
+		actual := fieldsRequiringReplacement(v.Input)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+type deletionProtectionTestResource struct {
+	frameworkTestResource
+}
+
+var _ ResourceWithCustomizeDiff = deletionProtectionTestResource{}
+
+func (r deletionProtectionTestResource) CustomizeDiff() ResourceFunc {
+	return ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			if metadata.ResourceDiff.HasChange("count") {
+				return metadata.ResourceDiff.ForceNew("count")
+			}
+			return nil
+		},
+	}
+}
+
+func TestDeletionProtectionProviderServerUntypedResource(t *testing.T) {
+	resource := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"account_kind": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"sku_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"size": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		CustomizeDiff: customdiff.All(
+			func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				if d.HasChange("account_kind") {
+					return d.ForceNew("account_kind")
+				}
+				return nil
+			},
+			customdiff.ForceNewIfChange("sku_name", func(ctx context.Context, old, new, meta interface{}) bool {
+				return old.(string) == "Premium"
+			}),
+		),
+		ReadContext:   schema.NoopContext,
+		DeleteContext: schema.NoopContext,
+	}
+	ApplyDeletionProtection("azurerm_example", resource)
+
+	existing := map[string]cty.Value{
+		"id":           cty.StringVal("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example"),
+		"name":         cty.StringVal("example"),
+		"account_kind": cty.StringVal("StorageV2"),
+		"sku_name":     cty.StringVal("Premium"),
+		"size":         cty.NumberIntVal(1),
+		"tags": cty.MapVal(map[string]cty.Value{
+			"environment": cty.StringVal("production"),
+		}),
+	}
+	protectedByTags := features.DeletionProtectionFeatures{
+		Tags: map[string]string{
+			"environment": "production",
+		},
+		PreventReplacement: true,
+	}
+
+	testData := []struct {
+		Name     string
+		Features features.DeletionProtectionFeatures
+		Prior    map[string]cty.Value
+		Changes  map[string]cty.Value
+		Expected string
+	}{
+		{
+			Name:     "updated in-place",
+			Features: protectedByTags,
+			Prior:    existing,
+			Changes: map[string]cty.Value{
+				"size": cty.NumberIntVal(2),
+			},
+		},
+		{
+			Name:     "replaced by the Schema",
+			Features: protectedByTags,
+			Prior:    existing,
+			Changes: map[string]cty.Value{
+				"name": cty.StringVal("renamed"),
+			},
+			Expected: `changing "name" requires a new resource`,
+		},
+		{
+			Name:     "replaced by ForceNew within the CustomizeDiff",
+			Features: protectedByTags,
+			Prior:    existing,
+			Changes: map[string]cty.Value{
+				"account_kind": cty.StringVal("BlobStorage"),
+			},
+			Expected: `changing "account_kind" requires a new resource`,
+		},
+		{
+			Name:     "replaced by ForceNewIfChange within the CustomizeDiff",
+			Features: protectedByTags,
+			Prior:    existing,
+			Changes: map[string]cty.Value{
+				"sku_name": cty.StringVal("Standard"),
+			},
+			Expected: `changing "sku_name" requires a new resource`,
+		},
+		{
+			Name: "replaced when replacements aren't prevented",
+			Features: features.DeletionProtectionFeatures{
+				Tags:               protectedByTags.Tags,
+				PreventReplacement: false,
+			},
+			Prior: existing,
+			Changes: map[string]cty.Value{
+				"account_kind": cty.StringVal("BlobStorage"),
+			},
+		},
+		{
+			Name: "replaced when excluded",
+			Features: features.DeletionProtectionFeatures{
+				Tags:                       protectedByTags.Tags,
+				ExcludedResourceIdPatterns: []string{"/subscriptions/*/resourceGroups/example"},
+				PreventReplacement:         true,
+			},
+			Prior: existing,
+			Changes: map[string]cty.Value{
+				"sku_name": cty.StringVal("Standard"),
+			},
+		},
+		{
+			Name:     "created",
+			Features: protectedByTags,
+			Prior:    nil,
+			Changes:  existing,
+		},
+	}
+
+	for _, v := range testData {
+		t.Run(v.Name, func(t *testing.T) {
+			diagnostics := planDeletionProtectionTestResource(t, "azurerm_example", resource, v.Features, v.Prior, v.Changes)
+			assertDeletionProtectionDiagnostics(t, diagnostics, v.Expected)
+		})
+	}
+}
+
+func TestDeletionProtectionProviderServerTypedResource(t *testing.T) {
+	wrapper := NewResourceWrapper(deletionProtectionTestResource{})
+	resource, err := wrapper.Resource()
+	if err != nil {
+		t.Fatalf("building Resource: %+v", err)
+	}
+
+	protectedByType := features.DeletionProtectionFeatures{
+		ResourceTypes:      []string{"azurerm_framework_test"},
+		PreventReplacement: true,
+	}
+	existing := map[string]cty.Value{
+		"id":      cty.StringVal("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example"),
+		"name":    cty.StringVal("example"),
+		"count":   cty.NumberIntVal(1),
+		"enabled": cty.True,
+	}
+
+	testData := []struct {
+		Name     string
+		Changes  map[string]cty.Value
+		Expected string
+	}{
+		{
+			Name: "updated in-place",
+			Changes: map[string]cty.Value{
+				"enabled": cty.False,
+			},
+		},
+		{
+			Name: "replaced by ForceNew within the CustomizeDiff",
+			Changes: map[string]cty.Value{
+				"count": cty.NumberIntVal(2),
+			},
+			Expected: `changing "count" requires a new resource`,
+		},
+	}
+
+	for _, v := range testData {
+		t.Run(v.Name, func(t *testing.T) {
+			diagnostics := planDeletionProtectionTestResource(t, "azurerm_framework_test", resource, protectedByType, existing, v.Changes)
+			assertDeletionProtectionDiagnostics(t, diagnostics, v.Expected)
+		})
+	}
+}
+
+// planDeletionProtectionTestResource plans the changes to the resource (which is created when `prior` is nil)
+// through the Provider Server, returning the diagnostics
+func planDeletionProtectionTestResource(t *testing.T, resourceType string, resource *schema.Resource, deletionProtection features.DeletionProtectionFeatures, prior map[string]cty.Value, changes map[string]cty.Value) []*tfprotov5.Diagnostic {
+	provider := &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			resourceType: resource,
+		},
+	}
+	provider.SetMeta(&clients.Client{
+		Features: features.UserFeatures{
+			DeletionProtection: deletionProtection,
+		},
+	})
+
+	block := resource.CoreConfigSchema()
+	encode := func(values map[string]cty.Value) *tfprotov5.DynamicValue {
+		var value cty.Value
+		if values == nil {
+			value = cty.NullVal(block.ImpliedType())
+		} else {
+			attributes := make(map[string]cty.Value)
+			for name, attributeType := range block.ImpliedType().AttributeTypes() {
+				attributes[name] = cty.NullVal(attributeType)
+			}
+			for name, nested := range block.BlockTypes {
+				if attributeType := block.ImpliedType().AttributeType(name); attributeType.IsListType() {
+					attributes[name] = cty.ListValEmpty(nested.Block.ImpliedType())
+				}
+			}
+			for name, v := range values {
+				attributes[name] = v
+			}
+			value = cty.ObjectVal(attributes)
+		}
+
+		b, err := msgpack.Marshal(value, block.ImpliedType())
+		if err != nil {
+			t.Fatalf("encoding %+v: %+v", values, err)
+		}
+		return &tfprotov5.DynamicValue{
+			MsgPack: b,
+		}
+	}
+
+	proposed := make(map[string]cty.Value)
+	for k, v := range prior {
+		proposed[k] = v
+	}
+	for k, v := range changes {
+		proposed[k] = v
+	}
+	config := make(map[string]cty.Value)
+	for k, v := range proposed {
+		if k != "id" {
+			config[k] = v
+		}
+	}
+
+	resp, err := NewDeletionProtectionProviderServer(provider).PlanResourceChange(context.TODO(), &tfprotov5.PlanResourceChangeRequest{
+		TypeName:         resourceType,
+		PriorState:       encode(prior),
+		ProposedNewState: encode(proposed),
+		Config:           encode(config),
+	})
+	if err != nil {
+		t.Fatalf("planning: %+v", err)
+	}
+
+	return resp.Diagnostics
+}
+
+func assertDeletionProtectionDiagnostics(t *testing.T, diagnostics []*tfprotov5.Diagnostic, expected string) {
+	errs := make([]string, 0)
+	for _, v := range diagnostics {
+		if v.Severity == tfprotov5.DiagnosticSeverityError {
+			errs = append(errs, v.Summary+v.Detail)
+		}
+	}
+
+	if expected == "" {
+		if len(errs) > 0 {
+			t.Fatalf("expected no errors but got %+v", errs)
+		}
+		return
+	}
+
+	if len(errs) != 1 {
+		t.Fatalf("expected a single error but got %+v", errs)
+	}
+	if !strings.Contains(errs[0], expected) || !strings.Contains(errs[0], `"deletion_protection" block`) {
+		t.Fatalf("expected the error to contain %q but got %q", expected, errs[0])
+	}
+}
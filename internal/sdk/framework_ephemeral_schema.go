@@ -0,0 +1,195 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// frameworkEphemeralResourceSchemaFromProto cross-compiles the Protocol representation of an Ephemeral Resource's
+// Schema (as generated by the Plugin SDKv2) into a Plugin Framework Schema - using the same conversion as Resources.
+func frameworkEphemeralResourceSchemaFromProto(input *tfprotov5.SchemaBlock, sdkSchema map[string]*sdkschema.Schema) (*schema.Schema, error) {
+	spec, err := frameworkBlockSpecFromProto(input, sdkSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes, blocks, err := frameworkEphemeralResourceSchemaFromSpec(*spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.Schema{
+		Attributes:          attributes,
+		Blocks:              blocks,
+		Description:         spec.Description,
+		MarkdownDescription: spec.MarkdownDescription,
+		DeprecationMessage:  spec.DeprecationMessage,
+	}, nil
+}
+
+func frameworkEphemeralResourceSchemaFromSpec(input frameworkBlockSpec) (map[string]schema.Attribute, map[string]schema.Block, error) {
+	attributes := make(map[string]schema.Attribute, len(input.Attributes))
+	for k, v := range input.Attributes {
+		attribute, err := frameworkEphemeralResourceAttributeFromSpec(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("converting the attribute %q: %+v", k, err)
+		}
+		attributes[k] = attribute
+	}
+
+	blocks := make(map[string]schema.Block, len(input.Blocks))
+	for k, v := range input.Blocks {
+		block, err := frameworkEphemeralResourceBlockFromSpec(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("converting the block %q: %+v", k, err)
+		}
+		blocks[k] = block
+	}
+
+	return attributes, blocks, nil
+}
+
+func frameworkEphemeralResourceAttributeFromSpec(input frameworkAttributeSpec) (schema.Attribute, error) {
+	// Ephemeral Resources aren't persisted, so there's nothing for a write-only attribute to be omitted from
+	if input.WriteOnly {
+		return nil, fmt.Errorf("write-only attributes are not supported within Ephemeral Resources")
+	}
+
+	switch t := input.Type.(type) {
+	case basetypes.StringType:
+		return schema.StringAttribute{
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Computed:            input.Computed,
+			Sensitive:           input.Sensitive,
+			Description:         input.Description,
+			MarkdownDescription: input.MarkdownDescription,
+			DeprecationMessage:  input.DeprecationMessage,
+		}, nil
+
+	case basetypes.NumberType:
+		return schema.NumberAttribute{
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Computed:            input.Computed,
+			Sensitive:           input.Sensitive,
+			Description:         input.Description,
+			MarkdownDescription: input.MarkdownDescription,
+			DeprecationMessage:  input.DeprecationMessage,
+		}, nil
+
+	case basetypes.BoolType:
+		return schema.BoolAttribute{
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Computed:            input.Computed,
+			Sensitive:           input.Sensitive,
+			Description:         input.Description,
+			MarkdownDescription: input.MarkdownDescription,
+			DeprecationMessage:  input.DeprecationMessage,
+		}, nil
+
+	case basetypes.ListType:
+		return schema.ListAttribute{
+			ElementType:         t.ElemType,
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Computed:            input.Computed,
+			Sensitive:           input.Sensitive,
+			Description:         input.Description,
+			MarkdownDescription: input.MarkdownDescription,
+			DeprecationMessage:  input.DeprecationMessage,
+			Validators:          frameworkListValidators(input.MinItems, input.MaxItems),
+		}, nil
+
+	case basetypes.SetType:
+		return schema.SetAttribute{
+			ElementType:         t.ElemType,
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Computed:            input.Computed,
+			Sensitive:           input.Sensitive,
+			Description:         input.Description,
+			MarkdownDescription: input.MarkdownDescription,
+			DeprecationMessage:  input.DeprecationMessage,
+			Validators:          frameworkSetValidators(input.MinItems, input.MaxItems),
+		}, nil
+
+	case basetypes.MapType:
+		return schema.MapAttribute{
+			ElementType:         t.ElemType,
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Computed:            input.Computed,
+			Sensitive:           input.Sensitive,
+			Description:         input.Description,
+			MarkdownDescription: input.MarkdownDescription,
+			DeprecationMessage:  input.DeprecationMessage,
+		}, nil
+
+	case basetypes.ObjectType:
+		return schema.ObjectAttribute{
+			AttributeTypes:      t.AttrTypes,
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Computed:            input.Computed,
+			Sensitive:           input.Sensitive,
+			Description:         input.Description,
+			MarkdownDescription: input.MarkdownDescription,
+			DeprecationMessage:  input.DeprecationMessage,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %q", input.Type.String())
+}
+
+func frameworkEphemeralResourceBlockFromSpec(input frameworkNestedBlockSpec) (schema.Block, error) {
+	attributes, blocks, err := frameworkEphemeralResourceSchemaFromSpec(input.frameworkBlockSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	switch input.NestingMode {
+	case tfprotov5.SchemaNestedBlockNestingModeList:
+		return schema.ListNestedBlock{
+			NestedObject: schema.NestedBlockObject{
+				Attributes: attributes,
+				Blocks:     blocks,
+			},
+			Description:         input.Description,
+			MarkdownDescription: input.MarkdownDescription,
+			DeprecationMessage:  input.DeprecationMessage,
+			Validators:          frameworkListValidators(input.MinItems, input.MaxItems),
+		}, nil
+
+	case tfprotov5.SchemaNestedBlockNestingModeSet:
+		return schema.SetNestedBlock{
+			NestedObject: schema.NestedBlockObject{
+				Attributes: attributes,
+				Blocks:     blocks,
+			},
+			Description:         input.Description,
+			MarkdownDescription: input.MarkdownDescription,
+			DeprecationMessage:  input.DeprecationMessage,
+			Validators:          frameworkSetValidators(input.MinItems, input.MaxItems),
+		}, nil
+
+	case tfprotov5.SchemaNestedBlockNestingModeSingle:
+		return schema.SingleNestedBlock{
+			Attributes:          attributes,
+			Blocks:              blocks,
+			Description:         input.Description,
+			MarkdownDescription: input.MarkdownDescription,
+			DeprecationMessage:  input.DeprecationMessage,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported nesting mode %q", input.NestingMode.String())
+}
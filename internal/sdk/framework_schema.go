@@ -0,0 +1,410 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// frameworkBlockSpec describes a Block (or the root of a Schema) independently of the Plugin Framework
+// package it's served through, so that the same conversion can be used for each type of Schema.
+type frameworkBlockSpec struct {
+	Attributes          map[string]frameworkAttributeSpec
+	Blocks              map[string]frameworkNestedBlockSpec
+	Description         string
+	MarkdownDescription string
+	DeprecationMessage  string
+}
+
+// frameworkNestedBlockSpec describes a Block nested within another Block (or the root of a Schema)
+type frameworkNestedBlockSpec struct {
+	frameworkBlockSpec
+
+	NestingMode tfprotov5.SchemaNestedBlockNestingMode
+	MinItems    int64
+	MaxItems    int64
+}
+
+// frameworkAttributeSpec describes an Attribute independently of the Plugin Framework package it's served through
+type frameworkAttributeSpec struct {
+	Type                attr.Type
+	Required            bool
+	Optional            bool
+	Computed            bool
+	Sensitive           bool
+	WriteOnly           bool
+	Description         string
+	MarkdownDescription string
+	DeprecationMessage  string
+
+	// MinItems and MaxItems are only applicable to List and Set attributes
+	MinItems int64
+	MaxItems int64
+}
+
+// frameworkBlockSpecFromProto builds a frameworkBlockSpec from the Protocol representation of a Block (as generated
+// by the Plugin SDKv2). The Plugin SDKv2 Schema for this Block is used to obtain the information which isn't exposed
+// over the Protocol, such as Deprecation messages and the number of items allowed within a List/Set attribute.
+//
+// Since both Schemas are generated from the same Protocol representation, the Types exposed to Terraform
+// are identical regardless of which SDK the Resource is served through.
+func frameworkBlockSpecFromProto(input *tfprotov5.SchemaBlock, sdkSchema map[string]*sdkschema.Schema) (*frameworkBlockSpec, error) {
+	if input == nil {
+		return nil, fmt.Errorf("the Protocol Schema was nil")
+	}
+
+	description, markdownDescription := frameworkDescriptionsFromProto(input.Description, input.DescriptionKind)
+	output := frameworkBlockSpec{
+		Attributes:          make(map[string]frameworkAttributeSpec, len(input.Attributes)),
+		Blocks:              make(map[string]frameworkNestedBlockSpec, len(input.BlockTypes)),
+		Description:         description,
+		MarkdownDescription: markdownDescription,
+	}
+
+	for _, v := range input.Attributes {
+		attributeType, err := frameworkAttrTypeFromTerraformType(v.Type)
+		if err != nil {
+			return nil, fmt.Errorf("converting the attribute %q: %+v", v.Name, err)
+		}
+
+		description, markdownDescription := frameworkDescriptionsFromProto(v.Description, v.DescriptionKind)
+		attribute := frameworkAttributeSpec{
+			Type:                attributeType,
+			Required:            v.Required,
+			Optional:            v.Optional,
+			Computed:            v.Computed,
+			Sensitive:           v.Sensitive,
+			WriteOnly:           v.WriteOnly,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+		}
+		if s, ok := sdkSchema[v.Name]; ok {
+			attribute.MinItems = int64(s.MinItems)
+			attribute.MaxItems = int64(s.MaxItems)
+		}
+		attribute.DeprecationMessage = frameworkDeprecationMessage(v.Deprecated, sdkSchema[v.Name])
+		output.Attributes[v.Name] = attribute
+	}
+
+	for _, v := range input.BlockTypes {
+		var nestedSchema map[string]*sdkschema.Schema
+		if s, ok := sdkSchema[v.TypeName]; ok {
+			if r, ok := s.Elem.(*sdkschema.Resource); ok {
+				nestedSchema = r.SchemaMap()
+			}
+		}
+
+		nested, err := frameworkBlockSpecFromProto(v.Block, nestedSchema)
+		if err != nil {
+			return nil, fmt.Errorf("converting the block %q: %+v", v.TypeName, err)
+		}
+		if v.Block != nil {
+			nested.DeprecationMessage = frameworkDeprecationMessage(v.Block.Deprecated, sdkSchema[v.TypeName])
+		}
+
+		output.Blocks[v.TypeName] = frameworkNestedBlockSpec{
+			frameworkBlockSpec: *nested,
+			NestingMode:        v.Nesting,
+			MinItems:           v.MinItems,
+			MaxItems:           v.MaxItems,
+		}
+	}
+
+	return &output, nil
+}
+
+// frameworkDeprecationMessage returns the Deprecation message for an Attribute or Block, which is only exposed
+// over the Protocol as a boolean - so the message is taken from the Plugin SDKv2 Schema where available.
+func frameworkDeprecationMessage(deprecated bool, input *sdkschema.Schema) string {
+	if input != nil && input.Deprecated != "" {
+		return input.Deprecated
+	}
+	if deprecated {
+		return "This field is deprecated and will be removed in a future version of the provider."
+	}
+	return ""
+}
+
+// frameworkResourceSchemaFromProto cross-compiles the Protocol representation of a Resource's Schema
+// (as generated by the Plugin SDKv2) into a Plugin Framework Schema.
+func frameworkResourceSchemaFromProto(input *tfprotov5.Schema, sdkSchema map[string]*sdkschema.Schema) (*schema.Schema, error) {
+	if input == nil || input.Block == nil {
+		return nil, fmt.Errorf("the Protocol Schema was nil")
+	}
+
+	spec, err := frameworkBlockSpecFromProto(input.Block, sdkSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes, blocks, err := frameworkResourceSchemaFromSpec(*spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.Schema{
+		Attributes:          attributes,
+		Blocks:              blocks,
+		Description:         spec.Description,
+		MarkdownDescription: spec.MarkdownDescription,
+		DeprecationMessage:  spec.DeprecationMessage,
+		Version:             input.Version,
+	}, nil
+}
+
+func frameworkResourceSchemaFromSpec(input frameworkBlockSpec) (map[string]schema.Attribute, map[string]schema.Block, error) {
+	attributes := make(map[string]schema.Attribute, len(input.Attributes))
+	for k, v := range input.Attributes {
+		attribute, err := frameworkResourceAttributeFromSpec(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("converting the attribute %q: %+v", k, err)
+		}
+		attributes[k] = attribute
+	}
+
+	blocks := make(map[string]schema.Block, len(input.Blocks))
+	for k, v := range input.Blocks {
+		block, err := frameworkResourceBlockFromSpec(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("converting the block %q: %+v", k, err)
+		}
+		blocks[k] = block
+	}
+
+	return attributes, blocks, nil
+}
+
+func frameworkResourceAttributeFromSpec(input frameworkAttributeSpec) (schema.Attribute, error) {
+	switch t := input.Type.(type) {
+	case basetypes.StringType:
+		return schema.StringAttribute{
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Computed:            input.Computed,
+			Sensitive:           input.Sensitive,
+			WriteOnly:           input.WriteOnly,
+			Description:         input.Description,
+			MarkdownDescription: input.MarkdownDescription,
+			DeprecationMessage:  input.DeprecationMessage,
+		}, nil
+
+	case basetypes.NumberType:
+		return schema.NumberAttribute{
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Computed:            input.Computed,
+			Sensitive:           input.Sensitive,
+			WriteOnly:           input.WriteOnly,
+			Description:         input.Description,
+			MarkdownDescription: input.MarkdownDescription,
+			DeprecationMessage:  input.DeprecationMessage,
+		}, nil
+
+	case basetypes.BoolType:
+		return schema.BoolAttribute{
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Computed:            input.Computed,
+			Sensitive:           input.Sensitive,
+			WriteOnly:           input.WriteOnly,
+			Description:         input.Description,
+			MarkdownDescription: input.MarkdownDescription,
+			DeprecationMessage:  input.DeprecationMessage,
+		}, nil
+
+	case basetypes.ListType:
+		return schema.ListAttribute{
+			ElementType:         t.ElemType,
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Computed:            input.Computed,
+			Sensitive:           input.Sensitive,
+			WriteOnly:           input.WriteOnly,
+			Description:         input.Description,
+			MarkdownDescription: input.MarkdownDescription,
+			DeprecationMessage:  input.DeprecationMessage,
+			Validators:          frameworkListValidators(input.MinItems, input.MaxItems),
+		}, nil
+
+	case basetypes.SetType:
+		if input.WriteOnly {
+			return nil, fmt.Errorf("write-only sets are not supported")
+		}
+		return schema.SetAttribute{
+			ElementType:         t.ElemType,
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Computed:            input.Computed,
+			Sensitive:           input.Sensitive,
+			Description:         input.Description,
+			MarkdownDescription: input.MarkdownDescription,
+			DeprecationMessage:  input.DeprecationMessage,
+			Validators:          frameworkSetValidators(input.MinItems, input.MaxItems),
+		}, nil
+
+	case basetypes.MapType:
+		return schema.MapAttribute{
+			ElementType:         t.ElemType,
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Computed:            input.Computed,
+			Sensitive:           input.Sensitive,
+			WriteOnly:           input.WriteOnly,
+			Description:         input.Description,
+			MarkdownDescription: input.MarkdownDescription,
+			DeprecationMessage:  input.DeprecationMessage,
+		}, nil
+
+	case basetypes.ObjectType:
+		return schema.ObjectAttribute{
+			AttributeTypes:      t.AttrTypes,
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Computed:            input.Computed,
+			Sensitive:           input.Sensitive,
+			WriteOnly:           input.WriteOnly,
+			Description:         input.Description,
+			MarkdownDescription: input.MarkdownDescription,
+			DeprecationMessage:  input.DeprecationMessage,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %q", input.Type.String())
+}
+
+func frameworkResourceBlockFromSpec(input frameworkNestedBlockSpec) (schema.Block, error) {
+	attributes, blocks, err := frameworkResourceSchemaFromSpec(input.frameworkBlockSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	switch input.NestingMode {
+	case tfprotov5.SchemaNestedBlockNestingModeList:
+		return schema.ListNestedBlock{
+			NestedObject: schema.NestedBlockObject{
+				Attributes: attributes,
+				Blocks:     blocks,
+			},
+			Description:         input.Description,
+			MarkdownDescription: input.MarkdownDescription,
+			DeprecationMessage:  input.DeprecationMessage,
+			Validators:          frameworkListValidators(input.MinItems, input.MaxItems),
+		}, nil
+
+	case tfprotov5.SchemaNestedBlockNestingModeSet:
+		return schema.SetNestedBlock{
+			NestedObject: schema.NestedBlockObject{
+				Attributes: attributes,
+				Blocks:     blocks,
+			},
+			Description:         input.Description,
+			MarkdownDescription: input.MarkdownDescription,
+			DeprecationMessage:  input.DeprecationMessage,
+			Validators:          frameworkSetValidators(input.MinItems, input.MaxItems),
+		}, nil
+
+	case tfprotov5.SchemaNestedBlockNestingModeSingle:
+		return schema.SingleNestedBlock{
+			Attributes:          attributes,
+			Blocks:              blocks,
+			Description:         input.Description,
+			MarkdownDescription: input.MarkdownDescription,
+			DeprecationMessage:  input.DeprecationMessage,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported nesting mode %q", input.NestingMode.String())
+}
+
+func frameworkListValidators(minItems, maxItems int64) []validator.List {
+	if minItems == 0 && maxItems == 0 {
+		return nil
+	}
+	return []validator.List{
+		frameworkItemCountValidator{
+			minItems: minItems,
+			maxItems: maxItems,
+		},
+	}
+}
+
+func frameworkSetValidators(minItems, maxItems int64) []validator.Set {
+	if minItems == 0 && maxItems == 0 {
+		return nil
+	}
+	return []validator.Set{
+		frameworkItemCountValidator{
+			minItems: minItems,
+			maxItems: maxItems,
+		},
+	}
+}
+
+// frameworkDescriptionsFromProto returns the plain-text and markdown descriptions for the Protocol description `input`
+func frameworkDescriptionsFromProto(input string, kind tfprotov5.StringKind) (description string, markdownDescription string) {
+	if kind == tfprotov5.StringKindMarkdown {
+		return "", input
+	}
+	return input, ""
+}
+
+// frameworkAttrTypeFromTerraformType returns the Plugin Framework type which represents the Terraform type `input`
+func frameworkAttrTypeFromTerraformType(input tftypes.Type) (attr.Type, error) {
+	switch {
+	case input.Is(tftypes.String):
+		return types.StringType, nil
+
+	case input.Is(tftypes.Number):
+		return types.NumberType, nil
+
+	case input.Is(tftypes.Bool):
+		return types.BoolType, nil
+
+	case input.Is(tftypes.DynamicPseudoType):
+		return types.DynamicType, nil
+
+	case input.Is(tftypes.List{}):
+		elementType, err := frameworkAttrTypeFromTerraformType(input.(tftypes.List).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return types.ListType{ElemType: elementType}, nil
+
+	case input.Is(tftypes.Set{}):
+		elementType, err := frameworkAttrTypeFromTerraformType(input.(tftypes.Set).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return types.SetType{ElemType: elementType}, nil
+
+	case input.Is(tftypes.Map{}):
+		elementType, err := frameworkAttrTypeFromTerraformType(input.(tftypes.Map).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return types.MapType{ElemType: elementType}, nil
+
+	case input.Is(tftypes.Object{}):
+		attributeTypes := make(map[string]attr.Type)
+		for k, v := range input.(tftypes.Object).AttributeTypes {
+			attributeType, err := frameworkAttrTypeFromTerraformType(v)
+			if err != nil {
+				return nil, fmt.Errorf("converting the nested attribute %q: %+v", k, err)
+			}
+			attributeTypes[k] = attributeType
+		}
+		return types.ObjectType{AttrTypes: attributeTypes}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %q", input.String())
+}
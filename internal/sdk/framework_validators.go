@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var (
+	_ validator.List = frameworkItemCountValidator{}
+	_ validator.Set  = frameworkItemCountValidator{}
+)
+
+// frameworkItemCountValidator validates the number of items within a List or Set, mirroring the
+// MinItems and MaxItems validation performed by the Plugin SDKv2
+type frameworkItemCountValidator struct {
+	minItems int64
+	maxItems int64
+}
+
+func (v frameworkItemCountValidator) Description(_ context.Context) string {
+	switch {
+	case v.minItems > 0 && v.maxItems > 0:
+		return fmt.Sprintf("must contain between %d and %d items", v.minItems, v.maxItems)
+	case v.minItems > 0:
+		return fmt.Sprintf("must contain at least %d items", v.minItems)
+	default:
+		return fmt.Sprintf("must contain at most %d items", v.maxItems)
+	}
+}
+
+func (v frameworkItemCountValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v frameworkItemCountValidator) ValidateList(_ context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	// omitted Attributes are validated by Terraform based on whether these are Required
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(v.validate(req.Path, len(req.ConfigValue.Elements()))...)
+}
+
+func (v frameworkItemCountValidator) ValidateSet(_ context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	// omitted Attributes are validated by Terraform based on whether these are Required
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(v.validate(req.Path, len(req.ConfigValue.Elements()))...)
+}
+
+func (v frameworkItemCountValidator) validate(attributePath path.Path, count int) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if v.maxItems > 0 && int64(count) > v.maxItems {
+		diags.AddAttributeError(attributePath, "Too many list items", fmt.Sprintf("Attribute %s supports %d item maximum, but config has %d declared.", attributePath, v.maxItems, count))
+	}
+	if v.minItems > 0 && int64(count) < v.minItems {
+		diags.AddAttributeError(attributePath, "Not enough list items", fmt.Sprintf("Attribute %s requires %d item minimum, but config has only %d declared.", attributePath, v.minItems, count))
+	}
+
+	return diags
+}
@@ -0,0 +1,326 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2020-06-01/resources" // nolint: staticcheck
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+)
+
+// ApplyProviderTags wraps the Create, Read, Update and CustomizeDiff functions for a Plugin SDK Resource (either
+// Typed or Untyped) supporting Tags, so that the `default_tags` and `ignore_tags` blocks within the Provider block
+// are applied - and adds the Computed `tags_all` field, containing all of the Tags applied to the Resource.
+//
+// The Default Tags are merged into the `tags` field prior to calling the Create and Update functions (so that these
+// are included by `tags.Expand`), and removed again once the Resource has been read (by `tags.FlattenAndSet`), so
+// that the `tags` field only ever contains the Tags defined in the configuration. Since most Update functions only
+// update the Tags when `tags` has changed, any planned Tags which weren't applied (e.g. when only the Default Tags
+// have changed) are then applied using the Tags API for the Resource.
+//
+// The Tag Policy (the `tag_policy` block within the Provider block) is checked when planning every Resource with
+// a `tags` field, including those which don't support the Default Tags.
+func ApplyProviderTags(resourceType string, resource *schema.Resource) {
+	if !hasTags(resource) {
+		return
+	}
+
+	supportsDefaultTags := supportsProviderTags(resource)
+	customizeDiff := resource.CustomizeDiff
+	resource.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if customizeDiff != nil {
+			if err := customizeDiff(ctx, d, meta); err != nil {
+				return err
+			}
+		}
+
+		providerTags := providerTagsFromMeta(meta)
+		if err := validateTagPolicy(resourceType, d, providerTags, supportsDefaultTags); err != nil {
+			return err
+		}
+
+		if supportsDefaultTags {
+			return planTagsAll(d, providerTags)
+		}
+
+		return nil
+	}
+
+	if !supportsDefaultTags {
+		return
+	}
+
+	resource.Schema["tags_all"] = tags.SchemaTagsAll()
+
+	wrapContextFunc := func(in func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics, mergeDefaults bool) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			var diags diag.Diagnostics
+			err := withProviderTags(ctx, resourceType, d, meta, mergeDefaults, func() bool {
+				diags = in(ctx, d, meta)
+				return !diags.HasError()
+			})
+			if err != nil {
+				diags = append(diags, diag.FromErr(err)...)
+			}
+			return diags
+		}
+	}
+	wrapFunc := func(in func(*schema.ResourceData, interface{}) error, mergeDefaults bool) func(*schema.ResourceData, interface{}) error {
+		return func(d *schema.ResourceData, meta interface{}) error {
+			var innerErr error
+			err := withProviderTags(stopContextFromMeta(meta), resourceType, d, meta, mergeDefaults, func() bool {
+				innerErr = in(d, meta)
+				return innerErr == nil
+			})
+			if innerErr != nil {
+				return innerErr
+			}
+			return err
+		}
+	}
+
+	//nolint:staticcheck
+	if resource.Create != nil {
+		resource.Create = wrapFunc(resource.Create, true)
+	}
+	if resource.CreateContext != nil {
+		resource.CreateContext = wrapContextFunc(resource.CreateContext, true)
+	}
+	if resource.CreateWithoutTimeout != nil {
+		resource.CreateWithoutTimeout = wrapContextFunc(resource.CreateWithoutTimeout, true)
+	}
+
+	//nolint:staticcheck
+	if resource.Read != nil {
+		resource.Read = wrapFunc(resource.Read, false)
+	}
+	if resource.ReadContext != nil {
+		resource.ReadContext = wrapContextFunc(resource.ReadContext, false)
+	}
+	if resource.ReadWithoutTimeout != nil {
+		resource.ReadWithoutTimeout = wrapContextFunc(resource.ReadWithoutTimeout, false)
+	}
+
+	//nolint:staticcheck
+	if resource.Update != nil {
+		resource.Update = wrapFunc(resource.Update, true)
+	}
+	if resource.UpdateContext != nil {
+		resource.UpdateContext = wrapContextFunc(resource.UpdateContext, true)
+	}
+	if resource.UpdateWithoutTimeout != nil {
+		resource.UpdateWithoutTimeout = wrapContextFunc(resource.UpdateWithoutTimeout, true)
+	}
+}
+
+// hasTags returns whether the Resource exposes a configurable `tags` field
+func hasTags(resource *schema.Resource) bool {
+	v, ok := resource.Schema["tags"]
+	return ok && v.Type == schema.TypeMap && v.Optional
+}
+
+// supportsProviderTags returns whether the Resource exposes an updatable `tags` field which the
+// Default Tags can be applied to
+func supportsProviderTags(resource *schema.Resource) bool {
+	//nolint:staticcheck
+	if resource.Update == nil && resource.UpdateContext == nil && resource.UpdateWithoutTimeout == nil {
+		return false
+	}
+
+	if _, exists := resource.Schema["tags_all"]; exists {
+		return false
+	}
+
+	return !resource.Schema["tags"].ForceNew
+}
+
+// withProviderTags calls `fn` (the Create, Read or Update function for the Resource), optionally merging the Default Tags
+// into `tags` beforehand (and applying any planned Tags which `fn` didn't apply afterwards) - and then sets `tags` and
+// `tags_all` from the Tags read from the Resource, if `fn` succeeded.
+func withProviderTags(ctx context.Context, resourceType string, d *schema.ResourceData, meta interface{}, mergeDefaults bool, fn func() bool) error {
+	providerTags := providerTagsFromMeta(meta)
+
+	// during a Create/Update these are the Tags from the configuration, otherwise these are from the State
+	configured := configuredTags(d)
+	if mergeDefaults && len(providerTags.DefaultTags) > 0 {
+		if err := d.Set("tags", providerTags.Merge(configured)); err != nil {
+			return fmt.Errorf("merging the Default Tags for %s: %+v", resourceType, err)
+		}
+	}
+
+	if !fn() {
+		return nil
+	}
+
+	if mergeDefaults && d.Id() != "" && d.HasChange("tags_all") {
+		if err := applyPlannedTags(ctx, d, meta, providerTags, configured); err != nil {
+			return fmt.Errorf("applying the Tags for %s: %+v", resourceType, err)
+		}
+	}
+
+	if err := setProviderTags(d, providerTags, configured); err != nil {
+		return fmt.Errorf("setting the Tags for %s: %+v", resourceType, err)
+	}
+
+	return nil
+}
+
+// applyPlannedTags applies any of the planned Tags (the Default Tags merged with those in the configuration) which
+// weren't applied by the Create/Update function, using the Tags API for the Resource - since most Update functions only
+// update the Tags when `tags` has changed, which isn't the case when only the Default Tags have changed.
+func applyPlannedTags(ctx context.Context, d *schema.ResourceData, meta interface{}, providerTags tags.ProviderTags, configured map[string]interface{}) error {
+	previous, _ := d.GetChange("tags_all")
+	previousTags, _ := previous.(map[string]interface{})
+
+	// `tags` contains the Tags read from the Resource by the Create/Update function
+	merge, remove := tags.Changes(configuredTags(d), providerTags.Merge(configured), previousTags)
+	if len(merge) == 0 && len(remove) == 0 {
+		return nil
+	}
+
+	// the Tags API is only available for Resource Manager resources
+	if !strings.HasPrefix(strings.ToLower(d.Id()), "/subscriptions/") || strings.Contains(d.Id(), "|") {
+		log.Printf("[WARN] the planned Tags couldn't be applied to %q since this isn't a Resource Manager resource", d.Id())
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
+	applied, err := updateTagsAtScope(ctx, meta, d.Id(), merge, remove)
+	if err != nil {
+		return err
+	}
+
+	return d.Set("tags", applied)
+}
+
+// updateTagsAtScope merges (and then removes) the specified Tags for the Resource Manager resource with the specified ID
+// using the Tags API, returning the Tags for the Resource - this is a variable to allow this to be replaced in tests
+var updateTagsAtScope = func(ctx context.Context, meta interface{}, id string, merge, remove map[string]interface{}) (map[string]interface{}, error) {
+	client := meta.(*clients.Client).Resource.TagsClient
+	scope := strings.TrimPrefix(id, "/")
+
+	var result resources.TagsResource
+	patches := []struct {
+		operation resources.TagsPatchOperation
+		tags      map[string]interface{}
+	}{
+		{operation: resources.TagsPatchOperationMerge, tags: merge},
+		{operation: resources.TagsPatchOperationDelete, tags: remove},
+	}
+	for _, patch := range patches {
+		if len(patch.tags) == 0 {
+			continue
+		}
+
+		var err error
+		result, err = client.UpdateAtScope(ctx, scope, resources.TagsPatchResource{
+			Operation: patch.operation,
+			Properties: &resources.Tags{
+				Tags: tags.Expand(patch.tags),
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("updating the Tags (%s): %+v", patch.operation, err)
+		}
+	}
+
+	if result.Properties == nil {
+		return map[string]interface{}{}, nil
+	}
+	return tags.Flatten(result.Properties.Tags), nil
+}
+
+func stopContextFromMeta(meta interface{}) context.Context {
+	client, ok := meta.(*clients.Client)
+	if !ok || client == nil || client.StopContext == nil {
+		return context.Background()
+	}
+	return client.StopContext
+}
+
+func providerTagsFromMeta(meta interface{}) tags.ProviderTags {
+	client, ok := meta.(*clients.Client)
+	if !ok || client == nil {
+		return tags.ProviderTags{}
+	}
+	return client.ProviderTags
+}
+
+func configuredTags(d *schema.ResourceData) map[string]interface{} {
+	if v, ok := d.Get("tags").(map[string]interface{}); ok {
+		return v
+	}
+	return map[string]interface{}{}
+}
+
+// setProviderTags sets `tags_all` to the Tags read from the Resource (excluding those which are ignored) and
+// `tags` to the Tags read from the Resource excluding the Default Tags, unless these are configured
+func setProviderTags(d *schema.ResourceData, providerTags tags.ProviderTags, configured map[string]interface{}) error {
+	// the Resource has been removed
+	if d.Id() == "" {
+		return nil
+	}
+
+	all := providerTags.RemoveIgnored(configuredTags(d), configured)
+	if err := d.Set("tags_all", all); err != nil {
+		return fmt.Errorf("setting `tags_all`: %+v", err)
+	}
+	if err := d.Set("tags", providerTags.RemoveDefaults(all, configured)); err != nil {
+		return fmt.Errorf("setting `tags`: %+v", err)
+	}
+
+	return nil
+}
+
+// planTagsAll plans `tags_all` from the `tags` within the configuration and the Provider Tags
+func planTagsAll(d *schema.ResourceDiff, providerTags tags.ProviderTags) error {
+	if !d.NewValueKnown("tags") {
+		return d.SetNewComputed("tags_all")
+	}
+
+	configured, _ := d.Get("tags").(map[string]interface{})
+	planned := providerTags.Merge(configured)
+
+	// Azure treats the keys of Tags case-insensitively, see tags.SuppressCaseOnlyKeyDiff
+	if existing, ok := d.Get("tags_all").(map[string]interface{}); ok && d.Id() != "" && tags.EqualFold(existing, planned) {
+		return nil
+	}
+
+	return d.SetNew("tags_all", planned)
+}
+
+// validateTagPolicy returns an error if the Tags planned for the Resource (including the Default Tags, when supported)
+// don't satisfy the Tag Policy configured within the Provider block
+func validateTagPolicy(resourceType string, d *schema.ResourceDiff, providerTags tags.ProviderTags, includeDefaultTags bool) error {
+	if !d.NewValueKnown("tags") {
+		return nil
+	}
+
+	planned, _ := d.Get("tags").(map[string]interface{})
+	if includeDefaultTags {
+		planned = providerTags.Merge(planned)
+	}
+
+	errs := providerTags.Policy.Validate(planned)
+	if len(errs) == 0 {
+		return nil
+	}
+
+	violations := make([]string, 0, len(errs))
+	for _, err := range errs {
+		violations = append(violations, err.Error())
+	}
+	return fmt.Errorf(`the tags for this %s don't satisfy the "tag_policy" block within the Provider block:
+
+* %s`, resourceType, strings.Join(violations, "\n* "))
+}
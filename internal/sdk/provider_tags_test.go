@@ -0,0 +1,294 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+)
+
+func TestApplyProviderTags(t *testing.T) {
+	// the Tags which exist on the (fake) API
+	var applied map[string]*string
+
+	read := func(d *schema.ResourceData, meta interface{}) error {
+		withPolicy := map[string]*string{}
+		for k, v := range applied {
+			withPolicy[k] = v
+		}
+		policyValue := "audit"
+		withPolicy["policy-mode"] = &policyValue
+		return tags.FlattenAndSet(d, withPolicy)
+	}
+	resource := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"tags": tags.Schema(),
+		},
+		Create: func(d *schema.ResourceData, meta interface{}) error {
+			applied = tags.Expand(d.Get("tags").(map[string]interface{}))
+			d.SetId("example")
+			return read(d, meta)
+		},
+		Read: read,
+		Update: func(d *schema.ResourceData, meta interface{}) error {
+			return nil
+		},
+		Delete: func(d *schema.ResourceData, meta interface{}) error {
+			return nil
+		},
+	}
+	ApplyProviderTags("azurerm_example", resource)
+
+	if _, ok := resource.Schema["tags_all"]; !ok {
+		t.Fatalf("expected the `tags_all` field to be added to the schema")
+	}
+
+	meta := &clients.Client{
+		ProviderTags: tags.ProviderTags{
+			DefaultTags: map[string]string{
+				"environment": "production",
+				"owner":       "platform",
+			},
+			IgnoreKeyPrefixes: []string{"policy-"},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"tags": map[string]interface{}{
+			"owner": "storage",
+			"cost":  "123",
+		},
+	})
+	if err := resource.Create(d, meta); err != nil { //nolint:staticcheck
+		t.Fatalf("creating: %+v", err)
+	}
+
+	expectedApplied := map[string]string{
+		"environment": "production",
+		"owner":       "storage",
+		"cost":        "123",
+	}
+	if actual := tags.ToTypedObject(applied); !reflect.DeepEqual(actual, expectedApplied) {
+		t.Fatalf("expected the Tags %+v to be applied but got %+v", expectedApplied, actual)
+	}
+
+	expectedTags := map[string]interface{}{
+		"owner": "storage",
+		"cost":  "123",
+	}
+	if actual := d.Get("tags"); !reflect.DeepEqual(actual, expectedTags) {
+		t.Fatalf("expected `tags` to be %+v but got %+v", expectedTags, actual)
+	}
+
+	expectedTagsAll := map[string]interface{}{
+		"environment": "production",
+		"owner":       "storage",
+		"cost":        "123",
+	}
+	if actual := d.Get("tags_all"); !reflect.DeepEqual(actual, expectedTagsAll) {
+		t.Fatalf("expected `tags_all` to be %+v but got %+v", expectedTagsAll, actual)
+	}
+}
+
+func TestApplyProviderTagsUpdatingOnlyTheDefaultTags(t *testing.T) {
+	// the Tags which exist on the (fake) API
+	var applied map[string]*string
+
+	read := func(d *schema.ResourceData, meta interface{}) error {
+		return tags.FlattenAndSet(d, applied)
+	}
+	resource := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"tags": tags.Schema(),
+		},
+		Create: func(d *schema.ResourceData, meta interface{}) error {
+			applied = tags.Expand(d.Get("tags").(map[string]interface{}))
+			d.SetId("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example")
+			return read(d, meta)
+		},
+		Read: read,
+		Update: func(d *schema.ResourceData, meta interface{}) error {
+			// as with most Resources, the Tags are only updated when `tags` has changed
+			if d.HasChange("tags") {
+				applied = tags.Expand(d.Get("tags").(map[string]interface{}))
+			}
+			return read(d, meta)
+		},
+		Delete: func(d *schema.ResourceData, meta interface{}) error {
+			return nil
+		},
+	}
+	ApplyProviderTags("azurerm_example", resource)
+
+	updated := false
+	originalUpdateTagsAtScope := updateTagsAtScope
+	defer func() {
+		updateTagsAtScope = originalUpdateTagsAtScope
+	}()
+	updateTagsAtScope = func(_ context.Context, _ interface{}, id string, merge, remove map[string]interface{}) (map[string]interface{}, error) {
+		updated = true
+		for k, v := range tags.Expand(merge) {
+			applied[k] = v
+		}
+		for k := range remove {
+			delete(applied, k)
+		}
+		return tags.Flatten(applied), nil
+	}
+
+	newMeta := func(defaultTags map[string]string) *clients.Client {
+		return &clients.Client{
+			ProviderTags: tags.ProviderTags{
+				DefaultTags: defaultTags,
+			},
+		}
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"tags": map[string]interface{}{
+			"cost": "123",
+		},
+	})
+	apply := func(state *terraform.InstanceState, meta interface{}) *terraform.InstanceState {
+		diff, err := resource.Diff(context.TODO(), state, config, meta)
+		if err != nil {
+			t.Fatalf("planning: %+v", err)
+		}
+		newState, diags := resource.Apply(context.TODO(), state, diff, meta)
+		if diags.HasError() {
+			t.Fatalf("applying: %+v", diags)
+		}
+		return newState
+	}
+
+	state := apply(nil, newMeta(map[string]string{
+		"environment": "production",
+		"owner":       "platform",
+	}))
+	if updated {
+		t.Fatalf("expected the Tags to be applied by the Create function")
+	}
+
+	// only the Default Tags change, so `tags` hasn't changed
+	state = apply(state, newMeta(map[string]string{
+		"environment": "staging",
+	}))
+	if !updated {
+		t.Fatalf("expected the Tags to be updated using the Tags API")
+	}
+
+	expectedApplied := map[string]string{
+		"environment": "staging",
+		"cost":        "123",
+	}
+	if actual := tags.ToTypedObject(applied); !reflect.DeepEqual(actual, expectedApplied) {
+		t.Fatalf("expected the Tags %+v to be applied but got %+v", expectedApplied, actual)
+	}
+
+	expectedAttributes := map[string]string{
+		"tags.%":               "1",
+		"tags.cost":            "123",
+		"tags_all.%":           "2",
+		"tags_all.cost":        "123",
+		"tags_all.environment": "staging",
+	}
+	for k, v := range expectedAttributes {
+		if actual := state.Attributes[k]; actual != v {
+			t.Fatalf("expected %q to be %q but got %q", k, v, actual)
+		}
+	}
+
+	// and the Tags converge, so there's no further diff
+	diff, err := resource.Diff(context.TODO(), state, config, newMeta(map[string]string{
+		"environment": "staging",
+	}))
+	if err != nil {
+		t.Fatalf("planning: %+v", err)
+	}
+	if !diff.Empty() {
+		t.Fatalf("expected no diff once the Default Tags were applied but got %+v", diff)
+	}
+}
+
+func TestApplyProviderTagsPolicy(t *testing.T) {
+	noop := func(d *schema.ResourceData, meta interface{}) error {
+		return nil
+	}
+	newResource := func(tagsSchema *schema.Schema) *schema.Resource {
+		resource := &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"tags": tagsSchema,
+			},
+			Create: noop,
+			Read:   noop,
+			Update: noop,
+			Delete: noop,
+		}
+		ApplyProviderTags("azurerm_example", resource)
+		return resource
+	}
+
+	meta := &clients.Client{
+		ProviderTags: tags.ProviderTags{
+			DefaultTags: map[string]string{
+				"owner": "platform",
+			},
+			Policy: tags.Policy{
+				RequiredKeys:        []string{"environment", "owner"},
+				ReservedKeyPrefixes: []string{"hidden-"},
+			},
+		},
+	}
+
+	cases := []struct {
+		resource      *schema.Resource
+		tags          map[string]interface{}
+		expectedError string
+	}{
+		{
+			// `owner` is applied by the Default Tags
+			resource: newResource(tags.Schema()),
+			tags: map[string]interface{}{
+				"Environment": "production",
+			},
+		},
+		{
+			resource: newResource(tags.Schema()),
+			tags: map[string]interface{}{
+				"environment":  "production",
+				"hidden-title": "example",
+			},
+			expectedError: `the tag "hidden-title" uses the prefix "hidden-"`,
+		},
+		{
+			// the Default Tags aren't applied to Resources where the Tags can't be updated
+			resource: newResource(tags.ForceNewSchema()),
+			tags: map[string]interface{}{
+				"environment": "production",
+			},
+			expectedError: `the tag "owner" is required`,
+		},
+	}
+
+	for _, tc := range cases {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"tags": tc.tags,
+		})
+		_, err := tc.resource.Diff(context.TODO(), nil, config, meta)
+		if tc.expectedError == "" {
+			if err != nil {
+				t.Fatalf("expected no error for %+v but got: %+v", tc.tags, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+			t.Fatalf("expected an error containing %q for %+v but got: %+v", tc.expectedError, tc.tags, err)
+		}
+	}
+}
@@ -27,9 +27,9 @@ type resourceWithPluginSdkSchema interface {
 
 type resourceBase interface {
 	// resourceWithPluginSdkSchema ensure that the Arguments and Attributes are sourced
-	// from Plugin SDKv2 for now - longer term we'll likely introduce a `Typed Schema`
-	// which will cross-compile down to both the Plugin SDKv2 and Plugin Framework, but
-	// that's a story for another day.
+	// from Plugin SDKv2 - this Schema is cross-compiled into a Plugin Framework Schema
+	// for Resources which are served through the Plugin Framework (see the
+	// TypedServiceRegistrationWithFramework interface).
 	resourceWithPluginSdkSchema
 
 	// ModelObject is an instance of the object the Schema is decoded/encoded into
@@ -64,6 +64,20 @@ type DataSourceWithDeprecationReplacedBy interface {
 	DeprecatedInFavourOfDataSource() string
 }
 
+// An EphemeralResource is an object which retrieves (or generates) a short-lived value, such as
+// a credential, which is available to other resources/providers during a Terraform operation
+// but which is never persisted into the Plan or State.
+//
+// Ephemeral Resources are served through the Plugin Framework, since the Plugin SDKv2 doesn't
+// support these.
+type EphemeralResource interface {
+	resourceBase
+
+	// Open retrieves (or generates) the values for this Ephemeral Resource, which are then Encoded
+	// into the result - since this isn't persisted, the ID for this object isn't required to be set
+	Open() ResourceFunc
+}
+
 // A Resource is an object which can be provisioned and managed by Terraform
 // that is, Created, Retrieved, Deleted, Imported (and optionally, Updated, by implementing
 // the 'ResourceWithUpdate' interface)
@@ -96,10 +110,11 @@ type ResourceWithStateMigration interface {
 
 type StateUpgradeData struct {
 	SchemaVersion int
-	Upgraders     map[int]pluginsdk.StateUpgrade
-}
 
-// TODO: a generic state migration for updating ID's
+	// Upgraders is a map of the Schema Version to the State Upgrade to apply to that version
+	// NOTE: State Upgrades which only update Resource ID's can use a ResourceIDStateUpgrade
+	Upgraders map[int]pluginsdk.StateUpgrade
+}
 
 type ResourceWithCustomImporter interface {
 	Resource
@@ -122,6 +137,18 @@ type ResourceWithUpdate interface {
 	Update() ResourceFunc
 }
 
+// ResourceWithList is an optional interface
+//
+// Resources implementing this interface are able to enumerate the existing instances
+// of this Resource within a Subscription (or Resource Group), which allows these to be
+// discovered and subsequently imported in bulk.
+type ResourceWithList interface {
+	Resource
+
+	// List returns a ResourceListFunc which enumerates the existing instances of this Resource
+	List() ResourceListFunc
+}
+
 // ResourceWithDeprecationReplacedBy is an optional interface
 //
 // Resources implementing this interface will be marked as Deprecated
@@ -173,6 +200,45 @@ type ResourceFunc struct {
 	Timeout time.Duration
 }
 
+// ResourceListRunFunc is the function which enumerates the existing instances of a Resource
+// ctx provides a Context instance with the user-provided timeout
+// metadata is a reference to an object containing the Client, a Logger and the Scope to list within
+type ResourceListRunFunc func(ctx context.Context, metadata ResourceListMetaData) ([]resourceids.Id, error)
+
+type ResourceListFunc struct {
+	// Func is the function which should be called to enumerate the existing instances of this Resource
+	// NOTE: this should page through all of the results (e.g. using the `ListComplete` methods)
+	Func ResourceListRunFunc
+
+	// Timeout is the default timeout, which is used when a timeout hasn't otherwise been specified
+	Timeout time.Duration
+}
+
+type ResourceListMetaData struct {
+	// Client is a reference to the Azure Providers Client - providing a typed reference to this object
+	Client *clients.Client
+
+	// Logger provides a logger for debug purposes
+	Logger Logger
+
+	// ResourceGroupName optionally limits the results to the instances within this Resource Group,
+	// when empty the instances within the entire Subscription should be returned
+	ResourceGroupName string
+}
+
+// UntypedResourceList allows an Untyped Resource to be enumerated in the same manner as
+// a Typed Resource implementing the ResourceWithList interface
+type UntypedResourceList struct {
+	// ResourceType is the Terraform Resource Type which is being listed, for example `azurerm_storage_account`
+	ResourceType string
+
+	// IDValidationFunc validates that each Resource ID returned can be imported into this Resource
+	IDValidationFunc pluginsdk.SchemaValidateFunc
+
+	// List enumerates the existing instances of this Resource
+	List ResourceListFunc
+}
+
 type ResourceMetaData struct {
 	// Client is a reference to the Azure Providers Client - providing a typed reference to this object
 	Client *clients.Client
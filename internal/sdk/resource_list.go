@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+// ListResourceIdsWithinScope enumerates the items returned from listByResourceGroup when the metadata is
+// scoped to a Resource Group (or listBySubscription otherwise), parsing the ID of each using parseFunc.
+//
+// This is intended to be used from a ResourceListFunc, for example:
+//
+//	return sdk.ListResourceIdsWithinScope(ctx, metadata,
+//		func(ctx context.Context, id commonids.ResourceGroupId) ([]storagemovers.StorageMover, error) {
+//			resp, err := client.ListComplete(ctx, id)
+//			return resp.Items, err
+//		},
+//		func(ctx context.Context, id commonids.SubscriptionId) ([]storagemovers.StorageMover, error) {
+//			resp, err := client.ListBySubscriptionComplete(ctx, id)
+//			return resp.Items, err
+//		},
+//		func(item storagemovers.StorageMover) *string { return item.Id },
+//		storagemovers.ParseStorageMoverIDInsensitively,
+//	)
+func ListResourceIdsWithinScope[T any, ID resourceids.Id](
+	ctx context.Context,
+	metadata ResourceListMetaData,
+	listByResourceGroup func(ctx context.Context, id commonids.ResourceGroupId) ([]T, error),
+	listBySubscription func(ctx context.Context, id commonids.SubscriptionId) ([]T, error),
+	idFunc func(item T) *string,
+	parseFunc func(input string) (ID, error),
+) ([]resourceids.Id, error) {
+	subscriptionId := metadata.Client.Account.SubscriptionId
+
+	var items []T
+	if metadata.ResourceGroupName != "" {
+		resourceGroupId := commonids.NewResourceGroupID(subscriptionId, metadata.ResourceGroupName)
+		result, err := listByResourceGroup(ctx, resourceGroupId)
+		if err != nil {
+			return nil, fmt.Errorf("listing within %s: %+v", resourceGroupId, err)
+		}
+		items = result
+	} else {
+		subId := commonids.NewSubscriptionID(subscriptionId)
+		result, err := listBySubscription(ctx, subId)
+		if err != nil {
+			return nil, fmt.Errorf("listing within %s: %+v", subId, err)
+		}
+		items = result
+	}
+
+	ids := make([]resourceids.Id, 0)
+	for _, item := range items {
+		raw := idFunc(item)
+		if raw == nil {
+			continue
+		}
+
+		id, err := parseFunc(*raw)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// listResourceIds runs the ResourceListFunc for the specified Resource, returning the de-duplicated and
+// sorted Resource IDs, each of which is validated as being importable into this Resource
+func listResourceIds(ctx context.Context, list UntypedResourceList, meta interface{}, logger Logger, resourceGroupName string) ([]string, error) {
+	// the user-specified timeout (e.g. from the Data Source) takes precedence
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline && list.List.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, list.List.Timeout)
+		defer cancel()
+	}
+
+	metaData := ResourceListMetaData{
+		Client:            meta.(*clients.Client),
+		Logger:            logger,
+		ResourceGroupName: resourceGroupName,
+	}
+	ids, err := list.List.Func(ctx, metaData)
+	if err != nil {
+		return nil, fmt.Errorf("listing %q: %+v", list.ResourceType, err)
+	}
+
+	seen := make(map[string]struct{})
+	out := make([]string, 0)
+	for _, id := range ids {
+		if id == nil {
+			continue
+		}
+
+		resourceId := id.ID()
+		if _, errs := list.IDValidationFunc(resourceId, "id"); len(errs) > 0 {
+			return nil, fmt.Errorf("the Resource ID %q returned when listing %q isn't importable: %+v", resourceId, list.ResourceType, errs[0])
+		}
+
+		if _, exists := seen[resourceId]; exists {
+			continue
+		}
+		seen[resourceId] = struct{}{}
+		out = append(out, resourceId)
+	}
+	sort.Strings(out)
+
+	return out, nil
+}
@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+var _ DataSource = ResourceListDataSource{}
+
+// ResourceListDataSource is a Data Source which returns the Resource IDs for the existing
+// instances of any Resource implementing the ResourceWithList interface (or any Untyped Resource
+// exposed via an UntypedResourceList) - which can then be used to bulk-import these, for example
+// using an `import` block with `for_each`
+type ResourceListDataSource struct {
+	resources map[string]UntypedResourceList
+}
+
+type ResourceListDataSourceModel struct {
+	ResourceType      string   `tfschema:"resource_type"`
+	ResourceGroupName string   `tfschema:"resource_group_name"`
+	Ids               []string `tfschema:"ids"`
+}
+
+// NewResourceListDataSource returns a ResourceListDataSource for the specified Typed and Untyped Resources
+func NewResourceListDataSource(resources []ResourceWithList, untypedResources []UntypedResourceList) ResourceListDataSource {
+	lists := make(map[string]UntypedResourceList)
+	for _, r := range resources {
+		lists[r.ResourceType()] = UntypedResourceList{
+			ResourceType:     r.ResourceType(),
+			IDValidationFunc: r.IDValidationFunc(),
+			List:             r.List(),
+		}
+	}
+	for _, r := range untypedResources {
+		if _, exists := lists[r.ResourceType]; exists {
+			panic(fmt.Sprintf("an existing Resource List exists for %q", r.ResourceType))
+		}
+		lists[r.ResourceType] = r
+	}
+
+	return ResourceListDataSource{
+		resources: lists,
+	}
+}
+
+func (r ResourceListDataSource) ResourceType() string {
+	return "azurerm_resource_ids"
+}
+
+func (r ResourceListDataSource) ModelObject() interface{} {
+	return &ResourceListDataSourceModel{}
+}
+
+func (r ResourceListDataSource) Arguments() map[string]*pluginsdk.Schema {
+	resourceTypes := make([]string, 0)
+	for k := range r.resources {
+		resourceTypes = append(resourceTypes, k)
+	}
+	sort.Strings(resourceTypes)
+
+	return map[string]*pluginsdk.Schema{
+		"resource_type": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice(resourceTypes, false),
+		},
+
+		"resource_group_name": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+	}
+}
+
+func (r ResourceListDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"ids": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+	}
+}
+
+func (r ResourceListDataSource) Read() ResourceFunc {
+	return ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			var state ResourceListDataSourceModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			list, ok := r.resources[state.ResourceType]
+			if !ok {
+				return fmt.Errorf("the Resource %q doesn't support being listed", state.ResourceType)
+			}
+
+			ids, err := listResourceIds(ctx, list, metadata.Client, metadata.Logger, state.ResourceGroupName)
+			if err != nil {
+				return err
+			}
+			state.Ids = ids
+
+			metadata.ResourceData.SetId(fmt.Sprintf("resourceIds-%s", uuid.New().String()))
+
+			return metadata.Encode(&state)
+		},
+	}
+}
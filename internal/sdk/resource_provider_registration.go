@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
+)
+
+// ApplyResourceProviderRegistration wraps the CustomizeDiff function for a Plugin SDK Resource so that the
+// Resource Providers used by its Service are registered (or, when registration is diagnostics only, reported)
+// the first time the Resource is planned - this is a no-op unless `resource_provider_registrations` is set to
+// either `on_demand` or `diagnostics_only`.
+func ApplyResourceProviderRegistration(resourceType string, resource *schema.Resource, resourceProviders []string) {
+	customizeDiff := resource.CustomizeDiff
+	resource.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if err := ensureResourceProvidersRegistered(ctx, resourceType, resourceProviders, meta); err != nil {
+			return err
+		}
+
+		if customizeDiff != nil {
+			return customizeDiff(ctx, d, meta)
+		}
+
+		return nil
+	}
+}
+
+// ApplyResourceProviderRegistrationToDataSource wraps the Read function for a Plugin SDK Data Source so that the
+// Resource Providers used by its Service are registered (or reported) the first time the Data Source is read,
+// in the same manner as ApplyResourceProviderRegistration does for Resources.
+func ApplyResourceProviderRegistrationToDataSource(dataSourceType string, dataSource *schema.Resource, resourceProviders []string) {
+	switch {
+	case dataSource.ReadContext != nil:
+		readContext := dataSource.ReadContext
+		dataSource.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if err := ensureResourceProvidersRegistered(ctx, dataSourceType, resourceProviders, meta); err != nil {
+				return diag.FromErr(err)
+			}
+			return readContext(ctx, d, meta)
+		}
+
+	case dataSource.ReadWithoutTimeout != nil:
+		readWithoutTimeout := dataSource.ReadWithoutTimeout
+		dataSource.ReadWithoutTimeout = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if err := ensureResourceProvidersRegistered(ctx, dataSourceType, resourceProviders, meta); err != nil {
+				return diag.FromErr(err)
+			}
+			return readWithoutTimeout(ctx, d, meta)
+		}
+
+	case dataSource.Read != nil:
+		// the (deprecated) Read function doesn't expose a context, so this is replaced by ReadContext
+		read := dataSource.Read
+		dataSource.Read = nil
+		dataSource.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if err := ensureResourceProvidersRegistered(ctx, dataSourceType, resourceProviders, meta); err != nil {
+				return diag.FromErr(err)
+			}
+			return diag.FromErr(read(d, meta))
+		}
+	}
+}
+
+func ensureResourceProvidersRegistered(ctx context.Context, resourceType string, resourceProviders []string, meta interface{}) error {
+	client, ok := meta.(*clients.Client)
+	if !ok || client == nil || client.Account == nil {
+		return nil
+	}
+
+	subscriptionId := commonids.NewSubscriptionID(client.Account.SubscriptionId)
+	switch client.ResourceProviderRegistrations {
+	case resourceproviders.RegistrationModeOnDemand:
+		if err := resourceproviders.RegisterOnDemand(ctx, client.Resource.ResourceProvidersClient, subscriptionId, resourceProviders); err != nil {
+			return fmt.Errorf("registering the Resource Providers required by %s: %+v", resourceType, err)
+		}
+
+	case resourceproviders.RegistrationModeDiagnosticsOnly:
+		requiringRegistration, err := resourceproviders.RequiringRegistration(ctx, client.Resource.ResourceProvidersClient, subscriptionId, resourceProviders)
+		if err != nil {
+			return fmt.Errorf("determining which Resource Providers required by %s require registration: %+v", resourceType, err)
+		}
+		if len(requiringRegistration) > 0 {
+			return fmt.Errorf(`the following Resource Providers are required by %s but aren't registered in the Subscription %q:
+
+* %s
+
+Since "resource_provider_registrations" is set to %q these haven't been registered automatically - these can be
+registered using the Azure CLI (e.g. "az provider register --namespace %s"), or by setting "resource_provider_registrations"
+to %q`, resourceType, client.Account.SubscriptionId, strings.Join(requiringRegistration, "\n* "), resourceproviders.RegistrationModeDiagnosticsOnly, requiringRegistration[0], resourceproviders.RegistrationModeOnDemand)
+		}
+	}
+
+	return nil
+}
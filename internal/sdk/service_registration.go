@@ -64,3 +64,65 @@ type UntypedServiceRegistrationWithAGitHubLabel interface {
 
 	AssociatedGitHubLabel() string
 }
+
+// TypedServiceRegistrationWithFramework is a superset of TypedServiceRegistration allowing
+// Typed Resources to be served through the Plugin Framework, rather than the Plugin SDKv2.
+//
+// NOTE: Resources returned from FrameworkResources must not also be returned from Resources,
+// since each Resource can only be served through a single SDK.
+type TypedServiceRegistrationWithFramework interface {
+	TypedServiceRegistration
+
+	// FrameworkResources returns a list of Resources supported by this Service which should be
+	// served through the Plugin Framework
+	FrameworkResources() []Resource
+}
+
+// TypedResourcesForService returns each of the Typed Resources within the specified Service, including
+// those which are served through the Plugin Framework
+func TypedResourcesForService(service TypedServiceRegistration) []Resource {
+	resources := service.Resources()
+	if v, ok := service.(TypedServiceRegistrationWithFramework); ok {
+		resources = append(resources, v.FrameworkResources()...)
+	}
+	return resources
+}
+
+// TypedServiceRegistrationWithEphemeralResources is a superset of TypedServiceRegistration allowing
+// Ephemeral Resources to be registered, which are served through the Plugin Framework.
+type TypedServiceRegistrationWithEphemeralResources interface {
+	TypedServiceRegistration
+
+	// EphemeralResources returns a list of Ephemeral Resources supported by this Service
+	EphemeralResources() []EphemeralResource
+}
+
+// TypedServiceRegistrationWithResourceProviders is a superset of TypedServiceRegistration allowing the
+// Resource Provider namespaces used by this Service to be declared, which can then be registered
+// on-demand the first time a Resource within this Service is planned.
+type TypedServiceRegistrationWithResourceProviders interface {
+	TypedServiceRegistration
+
+	// ResourceProviders returns the (case-sensitive) Resource Provider namespaces used by this Service,
+	// for example `Microsoft.Network`
+	ResourceProviders() []string
+}
+
+// UntypedServiceRegistrationWithResourceProviders is a superset of UntypedServiceRegistration allowing the
+// Resource Provider namespaces used by this Service to be declared - see TypedServiceRegistrationWithResourceProviders
+type UntypedServiceRegistrationWithResourceProviders interface {
+	UntypedServiceRegistration
+
+	// ResourceProviders returns the (case-sensitive) Resource Provider namespaces used by this Service,
+	// for example `Microsoft.Network`
+	ResourceProviders() []string
+}
+
+// UntypedServiceRegistrationWithListableResources is a superset of UntypedServiceRegistration allowing
+// the existing instances of Untyped Resources within this Service to be enumerated - see ResourceWithList
+type UntypedServiceRegistrationWithListableResources interface {
+	UntypedServiceRegistration
+
+	// ListableResources returns the Untyped Resources within this Service which support being listed
+	ListableResources() []UntypedResourceList
+}
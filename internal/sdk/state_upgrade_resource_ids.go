@@ -0,0 +1,237 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+var _ pluginsdk.StateUpgrade = ResourceIDStateUpgrade{}
+
+// ResourceIDStateUpgrade is a generic State Upgrade which rewrites the Resource ID (and optionally any
+// nested fields containing Resource IDs) from one Resource ID format into another - for example when the
+// casing of a segment has changed, or when a Resource ID has moved to a different Resource ID Type.
+//
+// This can be used within the Upgraders for a ResourceWithStateMigration, for example:
+//
+//	0: sdk.ResourceIDStateUpgrade{
+//		StateSchema: exampleSchemaForV0(),
+//		ID: sdk.ResourceIDUpgrade{
+//			Old: &parse.OldExampleId{},
+//			New: &examples.ExampleId{},
+//		},
+//	}
+type ResourceIDStateUpgrade struct {
+	// StateSchema is a point-in-time reference to the Schema at the time of this version
+	// NOTE: as with all State Upgrades, this shouldn't reference the existing Schema
+	StateSchema map[string]*pluginsdk.Schema
+
+	// ID defines how the `id` field should be rewritten
+	ID ResourceIDUpgrade
+
+	// NestedIDs is an optional map of the path to a field within the State to how the Resource IDs
+	// within that field should be rewritten. Each path is a `.` separated list of field names, where
+	// `*` matches every item within a List/Set/Map - for example `subnet_ids.*` or `ip_configuration.*.subnet_id`.
+	NestedIDs map[string]ResourceIDUpgrade
+}
+
+// ResourceIDUpgrade rewrites a Resource ID from the format defined in Old into the format defined in New
+type ResourceIDUpgrade struct {
+	// Old is a reference to the previous Resource ID Type, which is parsed insensitively
+	Old resourceids.ResourceId
+
+	// New is a reference to the new Resource ID Type
+	New resourceids.ResourceId
+
+	// SegmentNames is an optional map of the name of a segment within the New Resource ID to the name
+	// of the segment within the Old Resource ID, for segments which have been renamed - segments which
+	// aren't defined here are expected to have the same name in both the Old and New Resource IDs
+	SegmentNames map[string]string
+}
+
+func (u ResourceIDStateUpgrade) Schema() map[string]*pluginsdk.Schema {
+	return u.StateSchema
+}
+
+func (u ResourceIDStateUpgrade) UpgradeFunc() pluginsdk.StateUpgraderFunc {
+	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+		oldId, ok := rawState["id"].(string)
+		if !ok || oldId == "" {
+			return rawState, fmt.Errorf("the `id` field was missing from the State")
+		}
+
+		newId, err := u.ID.Upgrade(oldId)
+		if err != nil {
+			return rawState, err
+		}
+		log.Printf("[DEBUG] Updating ID from %q to %q", oldId, newId)
+		rawState["id"] = newId
+
+		// sort the paths so that any errors are consistent
+		paths := make([]string, 0, len(u.NestedIDs))
+		for path := range u.NestedIDs {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+
+		for _, path := range paths {
+			upgrade := u.NestedIDs[path]
+			updated, err := upgradeNestedResourceIDs(rawState, strings.Split(path, "."), "", upgrade)
+			if err != nil {
+				return rawState, fmt.Errorf("updating the Resource IDs within %q: %+v", path, err)
+			}
+			rawState = updated.(map[string]interface{})
+		}
+
+		return rawState, nil
+	}
+}
+
+// Upgrade parses the Resource ID `input` using the Old Resource ID Type and returns it in the format of the New Resource ID Type
+func (u ResourceIDUpgrade) Upgrade(input string) (string, error) {
+	if u.Old == nil || u.New == nil {
+		return "", fmt.Errorf("both the Old and New Resource ID Types must be specified")
+	}
+
+	parsed, err := resourceids.NewParserFromResourceIdType(u.Old).Parse(input, true)
+	if err != nil {
+		return "", fmt.Errorf("parsing %q: %+v", input, err)
+	}
+
+	components := make([]string, 0)
+	for _, segment := range u.New.Segments() {
+		switch segment.Type {
+		case resourceids.StaticSegmentType, resourceids.ResourceProviderSegmentType:
+			if segment.FixedValue == nil {
+				return "", fmt.Errorf("the segment %q within the New Resource ID has no fixed value", segment.Name)
+			}
+			components = append(components, *segment.FixedValue)
+
+		case resourceids.ScopeSegmentType:
+			value, err := u.valueForSegment(*parsed, segment.Name)
+			if err != nil {
+				return "", err
+			}
+			// Scopes are themselves Resource IDs, so have a leading `/` which we add below
+			components = append(components, strings.TrimPrefix(value, "/"))
+
+		default:
+			value, err := u.valueForSegment(*parsed, segment.Name)
+			if err != nil {
+				return "", err
+			}
+			if segment.Type == resourceids.ConstantSegmentType && segment.PossibleValues != nil {
+				// Constants are parsed insensitively, so we need to ensure the correct casing is used
+				for _, v := range *segment.PossibleValues {
+					if strings.EqualFold(v, value) {
+						value = v
+						break
+					}
+				}
+			}
+			components = append(components, value)
+		}
+	}
+
+	return "/" + strings.Join(components, "/"), nil
+}
+
+func (u ResourceIDUpgrade) valueForSegment(parsed resourceids.ParseResult, name string) (string, error) {
+	oldName := name
+	if v, ok := u.SegmentNames[name]; ok {
+		oldName = v
+	}
+
+	value, ok := parsed.SegmentNamed(oldName, false)
+	if !ok || value == nil || *value == "" {
+		return "", fmt.Errorf("the segment %q (required for %q) was not found in %q", oldName, name, parsed.RawInput)
+	}
+	return *value, nil
+}
+
+// upgradeNestedResourceIDs walks `input` using the remaining components of the path `path`, rewriting the Resource IDs found at the end of the path
+func upgradeNestedResourceIDs(input interface{}, path []string, location string, upgrade ResourceIDUpgrade) (interface{}, error) {
+	if input == nil {
+		return nil, nil
+	}
+
+	if len(path) == 0 {
+		value, ok := input.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a string at %q but got %T", location, input)
+		}
+		if value == "" {
+			return value, nil
+		}
+		return upgrade.Upgrade(value)
+	}
+
+	key := path[0]
+	switch v := input.(type) {
+	case map[string]interface{}:
+		keys := []string{key}
+		if key == "*" {
+			keys = make([]string, 0, len(v))
+			for k := range v {
+				keys = append(keys, k)
+			}
+		}
+
+		for _, k := range keys {
+			item, ok := v[k]
+			if !ok {
+				continue
+			}
+			updated, err := upgradeNestedResourceIDs(item, path[1:], joinStatePath(location, k), upgrade)
+			if err != nil {
+				return nil, err
+			}
+			v[k] = updated
+		}
+		return v, nil
+
+	case []interface{}:
+		if key != "*" {
+			index, err := strconv.Atoi(key)
+			if err != nil {
+				return nil, fmt.Errorf("expected `*` or an index for the List at %q but got %q", location, key)
+			}
+			if index < 0 || index >= len(v) {
+				return v, nil
+			}
+			updated, err := upgradeNestedResourceIDs(v[index], path[1:], joinStatePath(location, key), upgrade)
+			if err != nil {
+				return nil, err
+			}
+			v[index] = updated
+			return v, nil
+		}
+
+		for i, item := range v {
+			updated, err := upgradeNestedResourceIDs(item, path[1:], joinStatePath(location, strconv.Itoa(i)), upgrade)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = updated
+		}
+		return v, nil
+	}
+
+	return nil, fmt.Errorf("expected a List, Set or Map at %q but got %T", location, input)
+}
+
+func joinStatePath(location, key string) string {
+	if location == "" {
+		return key
+	}
+	return location + "." + key
+}
@@ -0,0 +1,181 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/stateupgrade"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// legacyThingId is the previous format of a Resource ID, where the segment `Things` was cased incorrectly
+// and the name of the Parent Resource was exposed as `parentName`
+type legacyThingId struct{}
+
+func (legacyThingId) ID() string     { return "" }
+func (legacyThingId) String() string { return "" }
+func (legacyThingId) Segments() []resourceids.Segment {
+	return []resourceids.Segment{
+		resourceids.StaticSegment("staticSubscriptions", "subscriptions", "subscriptions"),
+		resourceids.SubscriptionIdSegment("subscriptionId", "12345678-1234-9876-4563-123456789012"),
+		resourceids.StaticSegment("staticResourceGroups", "resourceGroups", "resourceGroups"),
+		resourceids.ResourceGroupSegment("resourceGroupName", "example-resource-group"),
+		resourceids.StaticSegment("staticProviders", "providers", "providers"),
+		resourceids.ResourceProviderSegment("staticMicrosoftExample", "Microsoft.Example", "Microsoft.Example"),
+		resourceids.StaticSegment("staticParents", "parents", "parents"),
+		resourceids.UserSpecifiedSegment("parentName", "parentValue"),
+		resourceids.StaticSegment("staticThings", "Things", "Things"),
+		resourceids.UserSpecifiedSegment("thingName", "thingValue"),
+		resourceids.StaticSegment("staticModes", "modes", "modes"),
+		resourceids.ConstantSegment("mode", []string{"Default", "Custom"}, "Default"),
+	}
+}
+
+type thingId struct{}
+
+func (thingId) ID() string     { return "" }
+func (thingId) String() string { return "" }
+func (thingId) Segments() []resourceids.Segment {
+	return []resourceids.Segment{
+		resourceids.StaticSegment("staticSubscriptions", "subscriptions", "subscriptions"),
+		resourceids.SubscriptionIdSegment("subscriptionId", "12345678-1234-9876-4563-123456789012"),
+		resourceids.StaticSegment("staticResourceGroups", "resourceGroups", "resourceGroups"),
+		resourceids.ResourceGroupSegment("resourceGroupName", "example-resource-group"),
+		resourceids.StaticSegment("staticProviders", "providers", "providers"),
+		resourceids.ResourceProviderSegment("staticMicrosoftExample", "Microsoft.Example", "Microsoft.Example"),
+		resourceids.StaticSegment("staticParents", "parents", "parents"),
+		resourceids.UserSpecifiedSegment("parentResourceName", "parentValue"),
+		resourceids.StaticSegment("staticThings", "things", "things"),
+		resourceids.UserSpecifiedSegment("thingName", "thingValue"),
+		resourceids.StaticSegment("staticModes", "modes", "modes"),
+		resourceids.ConstantSegment("mode", []string{"Default", "Custom"}, "Default"),
+	}
+}
+
+func TestResourceIDStateUpgrade(t *testing.T) {
+	upgrade := ResourceIDStateUpgrade{
+		StateSchema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:     pluginsdk.TypeString,
+				Required: true,
+			},
+			"subnet_ids": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+			"ip_configuration": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"subnet_id": {
+							Type:     pluginsdk.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+		ID: ResourceIDUpgrade{
+			Old: legacyThingId{},
+			New: thingId{},
+			SegmentNames: map[string]string{
+				"parentResourceName": "parentName",
+			},
+		},
+		NestedIDs: map[string]ResourceIDUpgrade{
+			"subnet_ids.*": {
+				Old: &commonids.SubnetId{},
+				New: &commonids.SubnetId{},
+			},
+			"ip_configuration.*.subnet_id": {
+				Old: &commonids.SubnetId{},
+				New: &commonids.SubnetId{},
+			},
+		},
+	}
+
+	stateupgrade.RunTestCases(t, upgrade, []stateupgrade.TestCase{
+		{
+			Name: "missing id",
+			Input: map[string]interface{}{
+				"name": "thing1",
+			},
+		},
+		{
+			Name: "invalid id",
+			Input: map[string]interface{}{
+				"id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1",
+			},
+		},
+		{
+			Name: "old id",
+			Input: map[string]interface{}{
+				"id":   "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Example/parents/parent1/Things/thing1/modes/default",
+				"name": "thing1",
+			},
+			Expected: map[string]interface{}{
+				"id":   "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Example/parents/parent1/things/thing1/modes/Default",
+				"name": "thing1",
+			},
+		},
+		{
+			Name: "new id",
+			Input: map[string]interface{}{
+				"id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Example/parents/parent1/things/thing1/modes/Custom",
+			},
+			Expected: map[string]interface{}{
+				"id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Example/parents/parent1/things/thing1/modes/Custom",
+			},
+		},
+		{
+			Name: "nested ids",
+			Input: map[string]interface{}{
+				"id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Example/parents/parent1/Things/thing1/modes/Default",
+				"subnet_ids": []interface{}{
+					"/subscriptions/12345678-1234-9876-4563-123456789012/resourcegroups/group1/providers/Microsoft.Network/virtualnetworks/network1/subnets/subnet1",
+					"/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1/subnets/subnet2",
+				},
+				"ip_configuration": []interface{}{
+					map[string]interface{}{
+						"subnet_id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1/Subnets/subnet1",
+					},
+					map[string]interface{}{
+						"subnet_id": "",
+					},
+				},
+			},
+			Expected: map[string]interface{}{
+				"id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Example/parents/parent1/things/thing1/modes/Default",
+				"subnet_ids": []interface{}{
+					"/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1/subnets/subnet1",
+					"/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1/subnets/subnet2",
+				},
+				"ip_configuration": []interface{}{
+					map[string]interface{}{
+						"subnet_id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1/subnets/subnet1",
+					},
+					map[string]interface{}{
+						"subnet_id": "",
+					},
+				},
+			},
+		},
+		{
+			Name: "invalid nested id",
+			Input: map[string]interface{}{
+				"id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Example/parents/parent1/Things/thing1/modes/Default",
+				"subnet_ids": []interface{}{
+					"/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1",
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,285 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	ephemeralschema "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+var (
+	_ ephemeral.EphemeralResource                   = &frameworkEphemeralResourceWrapper{}
+	_ ephemeral.EphemeralResourceWithConfigure      = &frameworkEphemeralResourceWrapper{}
+	_ ephemeral.EphemeralResourceWithValidateConfig = &frameworkEphemeralResourceWrapper{}
+)
+
+// frameworkEphemeralResourceWrapper serves a Typed Ephemeral Resource through the Plugin Framework.
+//
+// In the same manner as Resources served through the Plugin Framework, the Ephemeral Resource is exposed
+// to the Plugin SDKv2 (as a Data Source) - which allows the existing Schema and Encode/Decode functionality
+// to be reused, without the result being persisted into the State.
+type frameworkEphemeralResourceWrapper struct {
+	resource EphemeralResource
+
+	once     sync.Once
+	err      error
+	provider *schema.Provider
+	server   tfprotov5.ProviderServer
+	schema   *ephemeralschema.Schema
+
+	// sdkType is the type of the Data Source within the Plugin SDKv2, which includes an `id` field
+	sdkType tftypes.Type
+
+	// terraformType is the type exposed to Terraform, which only includes an `id` field when it's
+	// defined within the Schema for this Ephemeral Resource
+	terraformType tftypes.Type
+	placeholderId bool
+}
+
+// NewFrameworkEphemeralResourceWrapper returns a Plugin Framework Ephemeral Resource for the Typed Ephemeral Resource `r`
+func NewFrameworkEphemeralResourceWrapper(r EphemeralResource) func() ephemeral.EphemeralResource {
+	wrapper := &frameworkEphemeralResourceWrapper{
+		resource: r,
+	}
+	return func() ephemeral.EphemeralResource {
+		return wrapper
+	}
+}
+
+// ephemeralResourceDataSource exposes an Ephemeral Resource as a Data Source, so that it can be handled by the Plugin SDKv2
+type ephemeralResourceDataSource struct {
+	EphemeralResource
+}
+
+func (e ephemeralResourceDataSource) Read() ResourceFunc {
+	open := e.Open()
+	return ResourceFunc{
+		Timeout: open.Timeout,
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			if open.Timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, open.Timeout)
+				defer cancel()
+			}
+
+			if err := open.Func(ctx, metadata); err != nil {
+				return err
+			}
+
+			// the Plugin SDKv2 requires that an ID is set, however this isn't exposed for Ephemeral Resources
+			if metadata.ResourceData.Id() == "" {
+				metadata.ResourceData.SetId(e.ResourceType())
+			}
+
+			return nil
+		},
+	}
+}
+
+func (w *frameworkEphemeralResourceWrapper) init(ctx context.Context) error {
+	w.once.Do(func() {
+		resourceType := w.resource.ResourceType()
+		wrapper := NewDataSourceWrapper(ephemeralResourceDataSource{w.resource})
+		sdkDataSource, err := wrapper.DataSource()
+		if err != nil {
+			w.err = fmt.Errorf("building the Plugin SDKv2 Data Source for %q: %+v", resourceType, err)
+			return
+		}
+
+		// the timeout for Ephemeral Resources isn't user-configurable
+		sdkDataSource.Timeouts = nil
+
+		w.provider = &schema.Provider{
+			DataSourcesMap: map[string]*schema.Resource{
+				resourceType: sdkDataSource,
+			},
+		}
+		w.server = w.provider.GRPCProvider()
+
+		resp, err := w.server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+		if err != nil {
+			w.err = fmt.Errorf("retrieving the Schema for %q: %+v", resourceType, err)
+			return
+		}
+		for _, v := range resp.Diagnostics {
+			if v != nil && v.Severity == tfprotov5.DiagnosticSeverityError {
+				w.err = fmt.Errorf("retrieving the Schema for %q: %s: %s", resourceType, v.Summary, v.Detail)
+				return
+			}
+		}
+
+		protoSchema, ok := resp.DataSourceSchemas[resourceType]
+		if !ok || protoSchema.Block == nil {
+			w.err = fmt.Errorf("the Schema for %q was not found", resourceType)
+			return
+		}
+		w.sdkType = protoSchema.ValueType()
+
+		// the `id` field is added by the Plugin SDKv2 - and so is only exposed when explicitly defined
+		block := *protoSchema.Block
+		_, hasIdArgument := w.resource.Arguments()["id"]
+		_, hasIdAttribute := w.resource.Attributes()["id"]
+		if !hasIdArgument && !hasIdAttribute {
+			attributes := make([]*tfprotov5.SchemaAttribute, 0)
+			for _, v := range block.Attributes {
+				if v.Name != "id" {
+					attributes = append(attributes, v)
+				}
+			}
+			block.Attributes = attributes
+			w.placeholderId = true
+		}
+		w.terraformType = block.ValueType()
+
+		frameworkSchema, err := frameworkEphemeralResourceSchemaFromProto(&block, sdkDataSource.SchemaMap())
+		if err != nil {
+			w.err = fmt.Errorf("cross-compiling the Schema for %q: %+v", resourceType, err)
+			return
+		}
+		w.schema = frameworkSchema
+	})
+
+	return w.err
+}
+
+func (w *frameworkEphemeralResourceWrapper) Metadata(_ context.Context, _ ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = w.resource.ResourceType()
+}
+
+func (w *frameworkEphemeralResourceWrapper) Schema(ctx context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	if err := w.init(ctx); err != nil {
+		resp.Diagnostics.AddError("building Schema", err.Error())
+		return
+	}
+
+	resp.Schema = *w.schema
+}
+
+func (w *frameworkEphemeralResourceWrapper) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// the Provider Data isn't available until the Provider has been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError("configuring Ephemeral Resource", fmt.Sprintf("expected a `*clients.Client` but got %T", req.ProviderData))
+		return
+	}
+
+	if err := w.init(ctx); err != nil {
+		resp.Diagnostics.AddError("configuring Ephemeral Resource", err.Error())
+		return
+	}
+
+	w.provider.SetMeta(client)
+}
+
+func (w *frameworkEphemeralResourceWrapper) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	if err := w.init(ctx); err != nil {
+		resp.Diagnostics.AddError("validating Configuration", err.Error())
+		return
+	}
+
+	config, err := w.encode(req.Config.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError("validating Configuration", err.Error())
+		return
+	}
+
+	result, err := w.server.ValidateDataSourceConfig(ctx, &tfprotov5.ValidateDataSourceConfigRequest{
+		TypeName: w.resource.ResourceType(),
+		Config:   config,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("validating Configuration", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(frameworkDiagnosticsFromProto(frameworkValidationDiagnostics(result.Diagnostics))...)
+}
+
+func (w *frameworkEphemeralResourceWrapper) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	if err := w.init(ctx); err != nil {
+		resp.Diagnostics.AddError("opening Ephemeral Resource", err.Error())
+		return
+	}
+
+	config, err := w.encode(req.Config.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError("opening Ephemeral Resource", err.Error())
+		return
+	}
+
+	result, err := w.server.ReadDataSource(ctx, &tfprotov5.ReadDataSourceRequest{
+		TypeName: w.resource.ResourceType(),
+		Config:   config,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("opening Ephemeral Resource", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(frameworkDiagnosticsFromProto(result.Diagnostics)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	value, err := w.decode(result.State)
+	if err != nil {
+		resp.Diagnostics.AddError("opening Ephemeral Resource", err.Error())
+		return
+	}
+
+	resp.Result.Raw = value
+}
+
+// encode converts the value `input` exposed to Terraform into the value used by the Plugin SDKv2
+func (w *frameworkEphemeralResourceWrapper) encode(input tftypes.Value) (*tfprotov5.DynamicValue, error) {
+	value := input
+	if w.placeholderId && !input.IsNull() {
+		values := make(map[string]tftypes.Value)
+		if err := input.As(&values); err != nil {
+			return nil, fmt.Errorf("converting value: %+v", err)
+		}
+		values["id"] = tftypes.NewValue(tftypes.String, nil)
+		value = tftypes.NewValue(w.sdkType, values)
+	}
+
+	output, err := tfprotov5.NewDynamicValue(w.sdkType, value)
+	if err != nil {
+		return nil, fmt.Errorf("encoding value: %+v", err)
+	}
+	return &output, nil
+}
+
+// decode converts the value `input` used by the Plugin SDKv2 into the value exposed to Terraform
+func (w *frameworkEphemeralResourceWrapper) decode(input *tfprotov5.DynamicValue) (tftypes.Value, error) {
+	if input == nil {
+		return tftypes.NewValue(w.terraformType, nil), nil
+	}
+
+	value, err := input.Unmarshal(w.sdkType)
+	if err != nil {
+		return tftypes.Value{}, fmt.Errorf("decoding value: %+v", err)
+	}
+
+	if !w.placeholderId || value.IsNull() {
+		return value, nil
+	}
+
+	values := make(map[string]tftypes.Value)
+	if err := value.As(&values); err != nil {
+		return tftypes.Value{}, fmt.Errorf("converting value: %+v", err)
+	}
+	delete(values, "id")
+	return tftypes.NewValue(w.terraformType, values), nil
+}
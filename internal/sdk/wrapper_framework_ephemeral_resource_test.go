@@ -0,0 +1,152 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type frameworkTestEphemeralResource struct{}
+
+var _ EphemeralResource = frameworkTestEphemeralResource{}
+
+type frameworkTestEphemeralResourceModel struct {
+	Name  string `tfschema:"name"`
+	Value string `tfschema:"value"`
+}
+
+func (f frameworkTestEphemeralResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+		},
+	}
+}
+
+func (f frameworkTestEphemeralResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"value": {
+			Type:      pluginsdk.TypeString,
+			Computed:  true,
+			Sensitive: true,
+		},
+	}
+}
+
+func (f frameworkTestEphemeralResource) ModelObject() interface{} {
+	return &frameworkTestEphemeralResourceModel{}
+}
+
+func (f frameworkTestEphemeralResource) ResourceType() string {
+	return "azurerm_framework_ephemeral_test"
+}
+
+func (f frameworkTestEphemeralResource) Open() ResourceFunc {
+	return ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			var model frameworkTestEphemeralResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return err
+			}
+
+			model.Value = fmt.Sprintf("secret-%s", model.Name)
+			return metadata.Encode(&model)
+		},
+	}
+}
+
+func TestFrameworkEphemeralResourceWrapperSchema(t *testing.T) {
+	ctx := context.TODO()
+	wrapper := NewFrameworkEphemeralResourceWrapper(frameworkTestEphemeralResource{})()
+
+	resp := &ephemeral.SchemaResponse{}
+	wrapper.Schema(ctx, ephemeral.SchemaRequest{}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("building Schema: %+v", resp.Diagnostics)
+	}
+
+	if _, ok := resp.Schema.Attributes["id"]; ok {
+		t.Fatalf("expected the placeholder `id` field to be removed from the Schema")
+	}
+	if v, ok := resp.Schema.Attributes["name"]; !ok || !v.IsRequired() {
+		t.Fatalf("expected `name` to be a Required attribute")
+	}
+	if v, ok := resp.Schema.Attributes["value"]; !ok || !v.IsComputed() || !v.IsSensitive() {
+		t.Fatalf("expected `value` to be a Computed and Sensitive attribute")
+	}
+	if _, ok := resp.Schema.Blocks["timeouts"]; ok {
+		t.Fatalf("expected the `timeouts` block to be removed from the Schema")
+	}
+}
+
+func TestFrameworkEphemeralResourceWrapperOpen(t *testing.T) {
+	ctx := context.TODO()
+	wrapper := NewFrameworkEphemeralResourceWrapper(frameworkTestEphemeralResource{})()
+
+	schemaResp := &ephemeral.SchemaResponse{}
+	wrapper.Schema(ctx, ephemeral.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("building Schema: %+v", schemaResp.Diagnostics)
+	}
+
+	configureResp := &ephemeral.ConfigureResponse{}
+	wrapper.(ephemeral.EphemeralResourceWithConfigure).Configure(ctx, ephemeral.ConfigureRequest{
+		ProviderData: &clients.Client{},
+	}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("configuring: %+v", configureResp.Diagnostics)
+	}
+
+	objectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"name":  tftypes.String,
+			"value": tftypes.String,
+		},
+	}
+	config := tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"name":  tftypes.NewValue(tftypes.String, "example"),
+		"value": tftypes.NewValue(tftypes.String, nil),
+	})
+
+	resp := &ephemeral.OpenResponse{
+		Result: tfsdk.EphemeralResultData{
+			Schema: schemaResp.Schema,
+		},
+	}
+	wrapper.Open(ctx, ephemeral.OpenRequest{
+		Config: tfsdk.Config{
+			Raw:    config,
+			Schema: schemaResp.Schema,
+		},
+	}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("opening: %+v", resp.Diagnostics)
+	}
+
+	values := make(map[string]tftypes.Value)
+	if err := resp.Result.Raw.As(&values); err != nil {
+		t.Fatalf("converting result: %+v", err)
+	}
+	if _, ok := values["id"]; ok {
+		t.Fatalf("expected the placeholder `id` field to be removed from the result")
+	}
+	var value string
+	if err := values["value"].As(&value); err != nil {
+		t.Fatalf("converting `value`: %+v", err)
+	}
+	if value != "secret-example" {
+		t.Fatalf("expected `value` to be %q but got %q", "secret-example", value)
+	}
+}
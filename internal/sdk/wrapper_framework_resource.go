@@ -0,0 +1,617 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	frameworkschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+// frameworkPrivateStateKey is the key within the Plugin Framework's Private State used to store the
+// Private State managed by the Plugin SDKv2 (which contains the Timeouts and Schema Version)
+const frameworkPrivateStateKey = "plugin_sdk_v2"
+
+var (
+	_ resource.Resource                   = &frameworkResourceWrapper{}
+	_ resource.ResourceWithConfigure      = &frameworkResourceWrapper{}
+	_ resource.ResourceWithImportState    = &frameworkResourceWrapper{}
+	_ resource.ResourceWithModifyPlan     = &frameworkResourceWrapper{}
+	_ resource.ResourceWithUpgradeState   = &frameworkResourceWrapper{}
+	_ resource.ResourceWithValidateConfig = &frameworkResourceWrapper{}
+)
+
+// frameworkResourceWrapper serves a Typed Resource through the Plugin Framework.
+//
+// The Schema for the Resource is cross-compiled from the Plugin SDKv2 Schema, and each RPC is handled by the
+// Plugin SDKv2 implementation of the Resource (as built by the ResourceWrapper) - meaning that Typed Resources
+// can be served through either SDK without being rewritten.
+//
+// NOTE: unlike the Plugin SDKv2, the Plugin Framework doesn't opt into the Legacy Type System - as such
+// Terraform validates that the planned and applied values are consistent with the configuration. The Plugin SDKv2
+// already normalises null and zero values against the configuration (and plan) when planning and applying, which
+// leaves attributes whose value intentionally differs from the configuration (those using a DiffSuppressFunc or
+// StateFunc) - Resources containing these can't be served through the Plugin Framework, which is checked in `init`.
+type frameworkResourceWrapper struct {
+	resource Resource
+	decorate func(resource *schema.Resource)
+
+	once          sync.Once
+	err           error
+	provider      *schema.Provider
+	server        tfprotov5.ProviderServer
+	schema        *frameworkschema.Schema
+	terraformType tftypes.Type
+}
+
+// NewFrameworkResourceWrapper returns a Plugin Framework Resource for the Typed Resource `r` - where `decorate`
+// (when specified) is called with the Plugin SDKv2 implementation of the Resource, allowing the same Provider-level
+// behaviours to be applied as when the Resource is served through the Plugin SDKv2
+func NewFrameworkResourceWrapper(r Resource, decorate func(resource *schema.Resource)) func() resource.Resource {
+	wrapper := &frameworkResourceWrapper{
+		resource: r,
+		decorate: decorate,
+	}
+	return func() resource.Resource {
+		return wrapper
+	}
+}
+
+// init builds the Plugin SDKv2 implementation of this Resource, which is used to handle each RPC
+func (w *frameworkResourceWrapper) init(ctx context.Context) error {
+	w.once.Do(func() {
+		resourceType := w.resource.ResourceType()
+		wrapper := NewResourceWrapper(w.resource)
+		sdkResource, err := wrapper.Resource()
+		if err != nil {
+			w.err = fmt.Errorf("building the Plugin SDKv2 Resource for %q: %+v", resourceType, err)
+			return
+		}
+		if w.decorate != nil {
+			w.decorate(sdkResource)
+		}
+
+		if attributes := frameworkLegacyTypeSystemAttributes(sdkResource.SchemaMap(), ""); len(attributes) > 0 {
+			w.err = fmt.Errorf("%q can't be served through the Plugin Framework since the attributes %s rely on the Legacy Type System", resourceType, strings.Join(attributes, ", "))
+			return
+		}
+
+		w.provider = &schema.Provider{
+			ResourcesMap: map[string]*schema.Resource{
+				resourceType: sdkResource,
+			},
+		}
+		// protected resources are prevented from being replaced in the same way as when served through the Plugin SDKv2
+		w.server = NewDeletionProtectionProviderServer(w.provider)
+
+		resp, err := w.server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+		if err != nil {
+			w.err = fmt.Errorf("retrieving the Schema for %q: %+v", resourceType, err)
+			return
+		}
+		for _, v := range resp.Diagnostics {
+			if v != nil && v.Severity == tfprotov5.DiagnosticSeverityError {
+				w.err = fmt.Errorf("retrieving the Schema for %q: %s: %s", resourceType, v.Summary, v.Detail)
+				return
+			}
+		}
+
+		protoSchema, ok := resp.ResourceSchemas[resourceType]
+		if !ok {
+			w.err = fmt.Errorf("the Schema for %q was not found", resourceType)
+			return
+		}
+
+		frameworkSchema, err := frameworkResourceSchemaFromProto(protoSchema, sdkResource.SchemaMap())
+		if err != nil {
+			w.err = fmt.Errorf("cross-compiling the Schema for %q: %+v", resourceType, err)
+			return
+		}
+
+		w.schema = frameworkSchema
+		w.terraformType = protoSchema.ValueType()
+	})
+
+	return w.err
+}
+
+func (w *frameworkResourceWrapper) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = w.resource.ResourceType()
+}
+
+func (w *frameworkResourceWrapper) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	if err := w.init(ctx); err != nil {
+		resp.Diagnostics.AddError("building Schema", err.Error())
+		return
+	}
+
+	resp.Schema = *w.schema
+}
+
+func (w *frameworkResourceWrapper) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// the Provider Data isn't available until the Provider has been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError("configuring Resource", fmt.Sprintf("expected a `*clients.Client` but got %T", req.ProviderData))
+		return
+	}
+
+	if err := w.init(ctx); err != nil {
+		resp.Diagnostics.AddError("configuring Resource", err.Error())
+		return
+	}
+
+	w.provider.SetMeta(client)
+}
+
+func (w *frameworkResourceWrapper) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	if err := w.init(ctx); err != nil {
+		resp.Diagnostics.AddError("validating Configuration", err.Error())
+		return
+	}
+
+	config, err := tfprotov5.NewDynamicValue(w.terraformType, req.Config.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError("validating Configuration", fmt.Sprintf("encoding the Configuration: %+v", err))
+		return
+	}
+
+	result, err := w.server.ValidateResourceTypeConfig(ctx, &tfprotov5.ValidateResourceTypeConfigRequest{
+		TypeName: w.resource.ResourceType(),
+		Config:   &config,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("validating Configuration", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(frameworkDiagnosticsFromProto(frameworkValidationDiagnostics(result.Diagnostics))...)
+}
+
+// frameworkValidationDiagnostics removes the Diagnostics returned when validating the configuration using the
+// Plugin SDKv2 which are also raised by the Plugin Framework, since these are mapped into the Plugin Framework Schema
+// (as Deprecation Messages and List/Set Validators) - and would otherwise be output twice.
+func frameworkValidationDiagnostics(input []*tfprotov5.Diagnostic) []*tfprotov5.Diagnostic {
+	output := make([]*tfprotov5.Diagnostic, 0, len(input))
+	for _, v := range input {
+		if v == nil {
+			continue
+		}
+
+		switch v.Summary {
+		case "Argument is deprecated", "Too many list items", "Not enough list items":
+			continue
+		}
+		output = append(output, v)
+	}
+	return output
+}
+
+func (w *frameworkResourceWrapper) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to plan when the Resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if err := w.init(ctx); err != nil {
+		resp.Diagnostics.AddError("planning changes", err.Error())
+		return
+	}
+
+	proposedNewStateValue, err := frameworkProposedNewState(req.State.Raw, req.Plan.Raw, req.Config.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError("planning changes", fmt.Sprintf("building the proposed new state: %+v", err))
+		return
+	}
+
+	values, err := w.encode(req.State.Raw, proposedNewStateValue, req.Config.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError("planning changes", err.Error())
+		return
+	}
+
+	priorPrivate, diags := req.Private.GetKey(ctx, frameworkPrivateStateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := w.server.PlanResourceChange(ctx, &tfprotov5.PlanResourceChangeRequest{
+		TypeName:         w.resource.ResourceType(),
+		PriorState:       &values[0],
+		ProposedNewState: &values[1],
+		Config:           &values[2],
+		PriorPrivate:     priorPrivate,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("planning changes", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(frameworkDiagnosticsFromProto(result.Diagnostics)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned, err := w.decode(result.PlannedState)
+	if err != nil {
+		resp.Diagnostics.AddError("planning changes", err.Error())
+		return
+	}
+	resp.Plan.Raw = planned
+
+	// the Plugin SDKv2 returns the path to each attribute requiring replacement, including those nested within
+	// blocks and those marked as ForceNew within a CustomizeDiff
+	seen := make(map[string]struct{})
+	for _, v := range result.RequiresReplace {
+		attributePath, ok := frameworkPathFromAttributePath(v)
+		if !ok {
+			continue
+		}
+		if _, exists := seen[attributePath.String()]; exists {
+			continue
+		}
+		seen[attributePath.String()] = struct{}{}
+		resp.RequiresReplace = append(resp.RequiresReplace, attributePath)
+	}
+
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, frameworkPrivateStateKey, result.PlannedPrivate)...)
+}
+
+func (w *frameworkResourceWrapper) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	prior := tftypes.NewValue(w.terraformType, nil)
+	// the Private State from the plan is exposed via the response during creation
+	newState, diags := w.applyResourceChange(ctx, prior, req.Plan.Raw, req.Config.Raw, resp.Private, resp.Private)
+	resp.Diagnostics.Append(diags...)
+	if newState != nil {
+		resp.State.Raw = *newState
+	}
+}
+
+func (w *frameworkResourceWrapper) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if err := w.init(ctx); err != nil {
+		resp.Diagnostics.AddError("reading Resource", err.Error())
+		return
+	}
+
+	currentState, err := tfprotov5.NewDynamicValue(w.terraformType, req.State.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError("reading Resource", fmt.Sprintf("encoding the current state: %+v", err))
+		return
+	}
+
+	private, diags := req.Private.GetKey(ctx, frameworkPrivateStateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := w.server.ReadResource(ctx, &tfprotov5.ReadResourceRequest{
+		TypeName:     w.resource.ResourceType(),
+		CurrentState: &currentState,
+		Private:      private,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("reading Resource", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(frameworkDiagnosticsFromProto(result.Diagnostics)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	newState, err := w.decode(result.NewState)
+	if err != nil {
+		resp.Diagnostics.AddError("reading Resource", err.Error())
+		return
+	}
+
+	if newState.IsNull() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.State.Raw = newState
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, frameworkPrivateStateKey, result.Private)...)
+}
+
+func (w *frameworkResourceWrapper) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	newState, diags := w.applyResourceChange(ctx, req.State.Raw, req.Plan.Raw, req.Config.Raw, req.Private, resp.Private)
+	resp.Diagnostics.Append(diags...)
+	if newState != nil {
+		resp.State.Raw = *newState
+	}
+}
+
+func (w *frameworkResourceWrapper) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	planned := tftypes.NewValue(w.terraformType, nil)
+	config := tftypes.NewValue(w.terraformType, nil)
+	_, diags := w.applyResourceChange(ctx, req.State.Raw, planned, config, req.Private, resp.Private)
+	resp.Diagnostics.Append(diags...)
+	if !resp.Diagnostics.HasError() {
+		resp.State.RemoveResource(ctx)
+	}
+}
+
+func (w *frameworkResourceWrapper) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if err := w.init(ctx); err != nil {
+		resp.Diagnostics.AddError("importing Resource", err.Error())
+		return
+	}
+
+	result, err := w.server.ImportResourceState(ctx, &tfprotov5.ImportResourceStateRequest{
+		TypeName: w.resource.ResourceType(),
+		ID:       req.ID,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("importing Resource", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(frameworkDiagnosticsFromProto(result.Diagnostics)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(result.ImportedResources) != 1 {
+		resp.Diagnostics.AddError("importing Resource", fmt.Sprintf("expected a single Resource to be imported but got %d", len(result.ImportedResources)))
+		return
+	}
+
+	imported := result.ImportedResources[0]
+	state, err := w.decode(imported.State)
+	if err != nil {
+		resp.Diagnostics.AddError("importing Resource", err.Error())
+		return
+	}
+
+	resp.State.Raw = state
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, frameworkPrivateStateKey, imported.Private)...)
+}
+
+func (w *frameworkResourceWrapper) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	if err := w.init(ctx); err != nil {
+		return nil
+	}
+
+	upgraders := make(map[int64]resource.StateUpgrader)
+	for i := int64(0); i < w.schema.Version; i++ {
+		version := i
+		upgraders[version] = resource.StateUpgrader{
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				rawState := &tfprotov5.RawState{}
+				if req.RawState != nil {
+					rawState.JSON = req.RawState.JSON
+					rawState.Flatmap = req.RawState.Flatmap
+				}
+
+				result, err := w.server.UpgradeResourceState(ctx, &tfprotov5.UpgradeResourceStateRequest{
+					TypeName: w.resource.ResourceType(),
+					Version:  version,
+					RawState: rawState,
+				})
+				if err != nil {
+					resp.Diagnostics.AddError("upgrading state", err.Error())
+					return
+				}
+				resp.Diagnostics.Append(frameworkDiagnosticsFromProto(result.Diagnostics)...)
+				if resp.Diagnostics.HasError() || result.UpgradedState == nil {
+					return
+				}
+
+				// MessagePack is the same across both versions of the Plugin Protocol
+				resp.DynamicValue = &tfprotov6.DynamicValue{
+					MsgPack: result.UpgradedState.MsgPack,
+					JSON:    result.UpgradedState.JSON,
+				}
+			},
+		}
+	}
+	return upgraders
+}
+
+func (w *frameworkResourceWrapper) applyResourceChange(ctx context.Context, prior, planned, config tftypes.Value, plannedPrivate, private privateStateData) (*tftypes.Value, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if err := w.init(ctx); err != nil {
+		diags.AddError("applying changes", err.Error())
+		return nil, diags
+	}
+
+	values, err := w.encode(prior, planned, config)
+	if err != nil {
+		diags.AddError("applying changes", err.Error())
+		return nil, diags
+	}
+
+	plannedPrivateData, privateDiags := plannedPrivate.GetKey(ctx, frameworkPrivateStateKey)
+	diags.Append(privateDiags...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	result, err := w.server.ApplyResourceChange(ctx, &tfprotov5.ApplyResourceChangeRequest{
+		TypeName:       w.resource.ResourceType(),
+		PriorState:     &values[0],
+		PlannedState:   &values[1],
+		Config:         &values[2],
+		PlannedPrivate: plannedPrivateData,
+	})
+	if err != nil {
+		diags.AddError("applying changes", err.Error())
+		return nil, diags
+	}
+	diags.Append(frameworkDiagnosticsFromProto(result.Diagnostics)...)
+
+	// the new state is returned even when the apply fails, so that partial state can be persisted
+	if result.NewState == nil {
+		return nil, diags
+	}
+	newState, err := w.decode(result.NewState)
+	if err != nil {
+		diags.AddError("applying changes", err.Error())
+		return nil, diags
+	}
+
+	if !newState.IsNull() {
+		diags.Append(private.SetKey(ctx, frameworkPrivateStateKey, result.Private)...)
+	}
+
+	return &newState, diags
+}
+
+// frameworkLegacyTypeSystemAttributes returns the paths to the attributes within `input` whose value can differ from
+// the configuration (by suppressing the diff or transforming the value stored in the state) - which Terraform only
+// tolerates for providers opting into the Legacy Type System
+func frameworkLegacyTypeSystemAttributes(input map[string]*schema.Schema, prefix string) []string {
+	output := make([]string, 0)
+	for name, v := range input {
+		attributePath := name
+		if prefix != "" {
+			attributePath = fmt.Sprintf("%s.%s", prefix, name)
+		}
+
+		if v.DiffSuppressFunc != nil || v.DiffSuppressOnRefresh || v.StateFunc != nil {
+			output = append(output, attributePath)
+		}
+
+		switch elem := v.Elem.(type) {
+		case *schema.Resource:
+			output = append(output, frameworkLegacyTypeSystemAttributes(elem.SchemaMap(), attributePath)...)
+		case *schema.Schema:
+			if elem.DiffSuppressFunc != nil || elem.StateFunc != nil {
+				output = append(output, attributePath)
+			}
+		}
+	}
+	sort.Strings(output)
+	return output
+}
+
+// privateStateData is the subset of the Plugin Framework's Private State used by the wrapper
+type privateStateData interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+func (w *frameworkResourceWrapper) encode(input ...tftypes.Value) ([]tfprotov5.DynamicValue, error) {
+	output := make([]tfprotov5.DynamicValue, 0, len(input))
+	for _, v := range input {
+		value, err := tfprotov5.NewDynamicValue(w.terraformType, v)
+		if err != nil {
+			return nil, fmt.Errorf("encoding value: %+v", err)
+		}
+		output = append(output, value)
+	}
+	return output, nil
+}
+
+func (w *frameworkResourceWrapper) decode(input *tfprotov5.DynamicValue) (tftypes.Value, error) {
+	if input == nil {
+		return tftypes.NewValue(w.terraformType, nil), nil
+	}
+
+	value, err := input.Unmarshal(w.terraformType)
+	if err != nil {
+		return tftypes.Value{}, fmt.Errorf("decoding value: %+v", err)
+	}
+	return value, nil
+}
+
+// frameworkProposedNewState approximates the proposed new state sent by Terraform, from the plan generated by
+// the Plugin Framework - which marks any Computed attributes which aren't specified in the configuration as
+// unknown when the Resource has changes. The Plugin SDKv2 instead expects these to contain the prior value.
+func frameworkProposedNewState(prior, planned, config tftypes.Value) (tftypes.Value, error) {
+	if prior.IsNull() || !prior.IsKnown() {
+		return planned, nil
+	}
+
+	return tftypes.Transform(planned, func(attributePath *tftypes.AttributePath, value tftypes.Value) (tftypes.Value, error) {
+		if value.IsKnown() || len(attributePath.Steps()) == 0 {
+			return value, nil
+		}
+
+		// values which are unknown in the configuration must remain unknown
+		configValue, _, err := tftypes.WalkAttributePath(config, attributePath)
+		if err != nil {
+			return value, nil
+		}
+		if v, ok := configValue.(tftypes.Value); !ok || !v.IsNull() {
+			return value, nil
+		}
+
+		priorValue, _, err := tftypes.WalkAttributePath(prior, attributePath)
+		if err != nil {
+			return value, nil
+		}
+		if v, ok := priorValue.(tftypes.Value); ok && v.Type().Equal(value.Type()) {
+			return v, nil
+		}
+		return value, nil
+	})
+}
+
+// frameworkDiagnosticsFromProto converts the Diagnostics returned from the Plugin SDKv2 into Plugin Framework Diagnostics
+func frameworkDiagnosticsFromProto(input []*tfprotov5.Diagnostic) diag.Diagnostics {
+	output := make(diag.Diagnostics, 0, len(input))
+	for _, v := range input {
+		if v == nil {
+			continue
+		}
+
+		attributePath, hasPath := frameworkPathFromAttributePath(v.Attribute)
+		switch {
+		case v.Severity == tfprotov5.DiagnosticSeverityWarning && hasPath:
+			output.AddAttributeWarning(attributePath, v.Summary, v.Detail)
+		case v.Severity == tfprotov5.DiagnosticSeverityWarning:
+			output.AddWarning(v.Summary, v.Detail)
+		case hasPath:
+			output.AddAttributeError(attributePath, v.Summary, v.Detail)
+		default:
+			output.AddError(v.Summary, v.Detail)
+		}
+	}
+	return output
+}
+
+func frameworkPathFromAttributePath(input *tftypes.AttributePath) (path.Path, bool) {
+	if input == nil || len(input.Steps()) == 0 {
+		return path.Empty(), false
+	}
+
+	steps := input.Steps()
+	name, ok := steps[0].(tftypes.AttributeName)
+	if !ok {
+		return path.Empty(), false
+	}
+
+	output := path.Root(string(name))
+	for _, step := range steps[1:] {
+		switch v := step.(type) {
+		case tftypes.AttributeName:
+			output = output.AtName(string(v))
+		case tftypes.ElementKeyInt:
+			output = output.AtListIndex(int(v))
+		case tftypes.ElementKeyString:
+			output = output.AtMapKey(string(v))
+		default:
+			// elements within a Set can't be referenced by index, so return the closest path
+			return output, true
+		}
+	}
+	return output, true
+}
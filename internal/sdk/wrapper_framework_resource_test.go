@@ -0,0 +1,330 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	frameworkschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type frameworkTestResource struct{}
+
+var _ ResourceWithUpdate = frameworkTestResource{}
+
+func (f frameworkTestResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"count": {
+			Type:     pluginsdk.TypeInt,
+			Optional: true,
+		},
+		"legacy_count": {
+			Type:       pluginsdk.TypeInt,
+			Optional:   true,
+			Deprecated: "`legacy_count` has been superseded by `count`",
+		},
+		"enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"tags": {
+			Type:     pluginsdk.TypeMap,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+		"rule": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"values": {
+						Type:     pluginsdk.TypeSet,
+						Required: true,
+						Elem: &pluginsdk.Schema{
+							Type: pluginsdk.TypeString,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (f frameworkTestResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"endpoints": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"address": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (f frameworkTestResource) ModelObject() interface{} {
+	return nil
+}
+
+func (f frameworkTestResource) ResourceType() string {
+	return "azurerm_framework_test"
+}
+
+func (f frameworkTestResource) Create() ResourceFunc {
+	return ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			return nil
+		},
+	}
+}
+
+func (f frameworkTestResource) Read() ResourceFunc {
+	return ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			return nil
+		},
+	}
+}
+
+func (f frameworkTestResource) Update() ResourceFunc {
+	return ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			return nil
+		},
+	}
+}
+
+func (f frameworkTestResource) Delete() ResourceFunc {
+	return ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			return nil
+		},
+	}
+}
+
+func (f frameworkTestResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return commonids.ValidateResourceGroupID
+}
+
+func TestFrameworkResourceWrapperSchema(t *testing.T) {
+	ctx := context.TODO()
+	wrapper := NewFrameworkResourceWrapper(frameworkTestResource{}, nil)().(*frameworkResourceWrapper)
+	if err := wrapper.init(ctx); err != nil {
+		t.Fatalf("building the wrapper: %+v", err)
+	}
+
+	// the Plugin Framework Schema must expose the same type to Terraform as the Plugin SDKv2 Schema
+	actual := wrapper.schema.Type().TerraformType(ctx)
+	if !actual.Equal(wrapper.terraformType) {
+		t.Fatalf("expected the type %s but got %s", wrapper.terraformType.String(), actual.String())
+	}
+
+	for _, name := range []string{"id", "name", "count", "enabled", "tags", "endpoints"} {
+		if _, ok := wrapper.schema.Attributes[name]; !ok {
+			t.Fatalf("expected the attribute %q to exist", name)
+		}
+	}
+	for _, name := range []string{"rule", "timeouts"} {
+		if _, ok := wrapper.schema.Blocks[name]; !ok {
+			t.Fatalf("expected the block %q to exist", name)
+		}
+	}
+
+	if actual := wrapper.schema.Attributes["legacy_count"].GetDeprecationMessage(); actual != "`legacy_count` has been superseded by `count`" {
+		t.Fatalf("expected the Deprecation Message for `legacy_count` to be mapped but got %q", actual)
+	}
+
+	rule, ok := wrapper.schema.Blocks["rule"].(frameworkschema.ListNestedBlock)
+	if !ok {
+		t.Fatalf("expected `rule` to be a List Nested Block but got %T", wrapper.schema.Blocks["rule"])
+	}
+	if len(rule.Validators) != 1 {
+		t.Fatalf("expected `rule` to have a single validator but got %d", len(rule.Validators))
+	}
+}
+
+type frameworkLegacyTypeSystemTestResource struct {
+	frameworkTestResource
+}
+
+func (f frameworkLegacyTypeSystemTestResource) Arguments() map[string]*pluginsdk.Schema {
+	arguments := f.frameworkTestResource.Arguments()
+	arguments["location"] = &pluginsdk.Schema{
+		Type:     pluginsdk.TypeString,
+		Required: true,
+		DiffSuppressFunc: func(_, old, new string, _ *pluginsdk.ResourceData) bool {
+			return strings.EqualFold(old, new)
+		},
+	}
+	return arguments
+}
+
+func TestFrameworkResourceWrapperLegacyTypeSystem(t *testing.T) {
+	wrapper := NewFrameworkResourceWrapper(frameworkLegacyTypeSystemTestResource{}, nil)().(*frameworkResourceWrapper)
+
+	// the suppressed diff would cause Terraform to raise an inconsistent plan, so the Resource can't be served
+	err := wrapper.init(context.TODO())
+	if err == nil {
+		t.Fatalf("expected an error since `location` relies on the Legacy Type System but didn't get one")
+	}
+	if !strings.Contains(err.Error(), "location") {
+		t.Fatalf("expected the error to reference `location` but got %+v", err)
+	}
+}
+
+func TestFrameworkItemCountValidator(t *testing.T) {
+	ctx := context.TODO()
+	testData := []struct {
+		items    int
+		minItems int64
+		maxItems int64
+		hasError bool
+	}{
+		{
+			items:    1,
+			maxItems: 1,
+			hasError: false,
+		},
+		{
+			items:    2,
+			maxItems: 1,
+			hasError: true,
+		},
+		{
+			items:    0,
+			minItems: 1,
+			hasError: true,
+		},
+		{
+			items:    2,
+			minItems: 1,
+			maxItems: 3,
+			hasError: false,
+		},
+	}
+
+	for _, v := range testData {
+		elements := make([]attr.Value, 0)
+		for i := 0; i < v.items; i++ {
+			elements = append(elements, types.StringValue(fmt.Sprintf("item-%d", i)))
+		}
+		req := validator.ListRequest{
+			Path:        path.Root("rule"),
+			ConfigValue: types.ListValueMust(types.StringType, elements),
+		}
+		resp := &validator.ListResponse{}
+
+		frameworkItemCountValidator{minItems: v.minItems, maxItems: v.maxItems}.ValidateList(ctx, req, resp)
+		if resp.Diagnostics.HasError() != v.hasError {
+			t.Fatalf("expected hasError to be %t for %d items (min %d / max %d) but got %+v", v.hasError, v.items, v.minItems, v.maxItems, resp.Diagnostics)
+		}
+	}
+}
+
+func TestFrameworkProposedNewState(t *testing.T) {
+	objectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"name":     tftypes.String,
+			"computed": tftypes.String,
+			"unknown":  tftypes.String,
+		},
+	}
+
+	prior := tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"name":     tftypes.NewValue(tftypes.String, "before"),
+		"computed": tftypes.NewValue(tftypes.String, "existing"),
+		"unknown":  tftypes.NewValue(tftypes.String, "existing"),
+	})
+	config := tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"name":     tftypes.NewValue(tftypes.String, "after"),
+		"computed": tftypes.NewValue(tftypes.String, nil),
+		"unknown":  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+	planned := tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"name":     tftypes.NewValue(tftypes.String, "after"),
+		"computed": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"unknown":  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+
+	actual, err := frameworkProposedNewState(prior, planned, config)
+	if err != nil {
+		t.Fatalf("building the proposed new state: %+v", err)
+	}
+
+	expected := tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"name":     tftypes.NewValue(tftypes.String, "after"),
+		"computed": tftypes.NewValue(tftypes.String, "existing"),
+		"unknown":  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+	if !actual.Equal(expected) {
+		t.Fatalf("expected %s but got %s", expected.String(), actual.String())
+	}
+}
+
+func TestFrameworkPathFromAttributePath(t *testing.T) {
+	testData := []struct {
+		input    *tftypes.AttributePath
+		expected path.Path
+		hasPath  bool
+	}{
+		{
+			input:   nil,
+			hasPath: false,
+		},
+		{
+			input:    tftypes.NewAttributePath().WithAttributeName("name"),
+			expected: path.Root("name"),
+			hasPath:  true,
+		},
+		{
+			input:    tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(0).WithAttributeName("values"),
+			expected: path.Root("rule").AtListIndex(0).AtName("values"),
+			hasPath:  true,
+		},
+		{
+			input:    tftypes.NewAttributePath().WithAttributeName("tags").WithElementKeyString("env"),
+			expected: path.Root("tags").AtMapKey("env"),
+			hasPath:  true,
+		},
+	}
+
+	for _, v := range testData {
+		actual, hasPath := frameworkPathFromAttributePath(v.input)
+		if hasPath != v.hasPath {
+			t.Fatalf("expected hasPath to be %t but got %t for %s", v.hasPath, hasPath, v.input)
+		}
+		if hasPath && !actual.Equal(v.expected) {
+			t.Fatalf("expected %s but got %s", v.expected, actual)
+		}
+	}
+}
@@ -68,6 +68,10 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 			return rw.resource.Read().Func(ctx, metaData)
 		}),
 		DeleteContext: rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+			if err := preventProtectedDeletion(rw.resource.ResourceType(), *resourceSchema, d, meta); err != nil {
+				return err
+			}
+
 			metaData := runArgs(d, meta, rw.logger)
 			return rw.resource.Delete().Func(ctx, metaData)
 		}),
@@ -180,6 +184,22 @@ and we recommend using the %[2]q resource instead.
 	return &resource, nil
 }
 
+// List enumerates the existing instances of this Resource, returning the Resource ID for each
+// in the format accepted by the IDValidationFunc, so that these can be imported as-is
+func (rw *ResourceWrapper) List(ctx context.Context, meta interface{}, resourceGroupName string) ([]string, error) {
+	v, ok := rw.resource.(ResourceWithList)
+	if !ok {
+		return nil, fmt.Errorf("Resource %q doesn't support being listed", rw.resource.ResourceType())
+	}
+
+	list := UntypedResourceList{
+		ResourceType:     rw.resource.ResourceType(),
+		IDValidationFunc: rw.resource.IDValidationFunc(),
+		List:             v.List(),
+	}
+	return listResourceIds(ctx, list, meta, rw.logger, resourceGroupName)
+}
+
 func (rw *ResourceWrapper) diagnosticsWrapper(in func(ctx context.Context, d *schema.ResourceData, meta interface{}) error) func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	return diagnosticsWrapper(in, rw.logger)
 }
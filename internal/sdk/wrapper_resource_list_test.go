@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type listableResource struct {
+	ids []resourceids.Id
+
+	// deadline is the deadline of the context which the List function was called with
+	deadline *time.Time
+}
+
+var _ ResourceWithList = listableResource{}
+
+func (l listableResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (l listableResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (l listableResource) ModelObject() interface{} {
+	return nil
+}
+
+func (l listableResource) ResourceType() string {
+	return "azurerm_listable"
+}
+
+func (l listableResource) Create() ResourceFunc {
+	return ResourceFunc{}
+}
+
+func (l listableResource) Read() ResourceFunc {
+	return ResourceFunc{}
+}
+
+func (l listableResource) Delete() ResourceFunc {
+	return ResourceFunc{}
+}
+
+func (l listableResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return commonids.ValidateResourceGroupID
+}
+
+func (l listableResource) List() ResourceListFunc {
+	return ResourceListFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata ResourceListMetaData) ([]resourceids.Id, error) {
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				return nil, fmt.Errorf("expected the context to have a deadline")
+			}
+			if l.deadline != nil {
+				*l.deadline = deadline
+			}
+
+			return l.ids, nil
+		},
+	}
+}
+
+func TestResourceWrapperList(t *testing.T) {
+	r := listableResource{
+		ids: []resourceids.Id{
+			commonids.NewResourceGroupID("12345678-1234-9876-4563-123456789012", "second"),
+			commonids.NewResourceGroupID("12345678-1234-9876-4563-123456789012", "first"),
+			nil,
+			commonids.NewResourceGroupID("12345678-1234-9876-4563-123456789012", "second"),
+		},
+	}
+	wrapper := NewResourceWrapper(r)
+
+	actual, err := wrapper.List(context.TODO(), &clients.Client{}, "")
+	if err != nil {
+		t.Fatalf("listing: %+v", err)
+	}
+
+	expected := []string{
+		"/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/first",
+		"/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/second",
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected %+v but got %+v", expected, actual)
+	}
+}
+
+func TestResourceWrapperListTimeout(t *testing.T) {
+	var deadline time.Time
+	r := listableResource{
+		ids: []resourceids.Id{
+			commonids.NewResourceGroupID("12345678-1234-9876-4563-123456789012", "first"),
+		},
+		deadline: &deadline,
+	}
+	wrapper := NewResourceWrapper(r)
+
+	if _, err := wrapper.List(context.TODO(), &clients.Client{}, ""); err != nil {
+		t.Fatalf("listing: %+v", err)
+	}
+
+	// the List timeout is used when there's no existing deadline
+	if remaining := time.Until(deadline); remaining > 5*time.Minute || remaining <= 0 {
+		t.Fatalf("expected the deadline to be the List timeout (5m) but got %s", remaining)
+	}
+}
+
+func TestResourceWrapperListExistingDeadline(t *testing.T) {
+	var deadline time.Time
+	r := listableResource{
+		ids: []resourceids.Id{
+			commonids.NewResourceGroupID("12345678-1234-9876-4563-123456789012", "first"),
+		},
+		deadline: &deadline,
+	}
+	wrapper := NewResourceWrapper(r)
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Hour)
+	defer cancel()
+
+	if _, err := wrapper.List(ctx, &clients.Client{}, ""); err != nil {
+		t.Fatalf("listing: %+v", err)
+	}
+
+	// the user-specified timeout takes precedence over the List timeout
+	expected, _ := ctx.Deadline()
+	if !deadline.Equal(expected) {
+		t.Fatalf("expected the existing deadline %s to be used but got %s", expected, deadline)
+	}
+}
+
+func TestResourceWrapperListInvalidID(t *testing.T) {
+	r := listableResource{
+		ids: []resourceids.Id{
+			commonids.NewSubscriptionID("12345678-1234-9876-4563-123456789012"),
+		},
+	}
+	wrapper := NewResourceWrapper(r)
+
+	if _, err := wrapper.List(context.TODO(), &clients.Client{}, ""); err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+}
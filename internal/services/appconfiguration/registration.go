@@ -11,8 +11,10 @@ import (
 type Registration struct{}
 
 var (
-	_ sdk.TypedServiceRegistrationWithAGitHubLabel = Registration{}
-	_ sdk.UntypedServiceRegistration               = Registration{}
+	_ sdk.TypedServiceRegistrationWithAGitHubLabel        = Registration{}
+	_ sdk.UntypedServiceRegistration                      = Registration{}
+	_ sdk.TypedServiceRegistrationWithResourceProviders   = Registration{}
+	_ sdk.UntypedServiceRegistrationWithResourceProviders = Registration{}
 )
 
 func (r Registration) AssociatedGitHubLabel() string {
@@ -33,6 +35,13 @@ func (r Registration) Resources() []sdk.Resource {
 	}
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.AppConfiguration",
+	}
+}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "App Configuration"
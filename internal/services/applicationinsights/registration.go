@@ -11,14 +11,23 @@ import (
 type Registration struct{}
 
 var (
-	_ sdk.TypedServiceRegistrationWithAGitHubLabel   = Registration{}
-	_ sdk.UntypedServiceRegistrationWithAGitHubLabel = Registration{}
+	_ sdk.TypedServiceRegistrationWithAGitHubLabel        = Registration{}
+	_ sdk.UntypedServiceRegistrationWithAGitHubLabel      = Registration{}
+	_ sdk.TypedServiceRegistrationWithResourceProviders   = Registration{}
+	_ sdk.UntypedServiceRegistrationWithResourceProviders = Registration{}
 )
 
 func (r Registration) AssociatedGitHubLabel() string {
 	return "service/application-insights"
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"microsoft.insights",
+	}
+}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "Application Insights"
@@ -8,6 +8,7 @@ import (
 )
 
 var _ sdk.TypedServiceRegistrationWithAGitHubLabel = Registration{}
+var _ sdk.TypedServiceRegistrationWithResourceProviders = Registration{}
 
 type Registration struct{}
 
@@ -19,6 +20,13 @@ func (r Registration) WebsiteCategories() []string {
 	return nil
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.Web",
+	}
+}
+
 func (r Registration) Name() string {
 	return "AppService"
 }
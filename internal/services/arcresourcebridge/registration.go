@@ -5,6 +5,7 @@ import (
 )
 
 var _ sdk.TypedServiceRegistrationWithAGitHubLabel = Registration{}
+var _ sdk.TypedServiceRegistrationWithResourceProviders = Registration{}
 
 type Registration struct{}
 
@@ -12,6 +13,13 @@ func (r Registration) AssociatedGitHubLabel() string {
 	return "service/arc-resource-bridge"
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.ResourceConnector",
+	}
+}
+
 func (r Registration) Name() string {
 	return "Arc Resource Bridge"
 }
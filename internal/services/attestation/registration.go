@@ -11,11 +11,19 @@ import (
 type Registration struct{}
 
 var _ sdk.UntypedServiceRegistrationWithAGitHubLabel = Registration{}
+var _ sdk.UntypedServiceRegistrationWithResourceProviders = Registration{}
 
 func (r Registration) AssociatedGitHubLabel() string {
 	return "service/attestation"
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.Attestation",
+	}
+}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "Attestation"
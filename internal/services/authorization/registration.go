@@ -13,11 +13,20 @@ type Registration struct {
 
 var _ sdk.TypedServiceRegistrationWithAGitHubLabel = Registration{}
 var _ sdk.UntypedServiceRegistrationWithAGitHubLabel = Registration{}
+var _ sdk.TypedServiceRegistrationWithResourceProviders = Registration{}
+var _ sdk.UntypedServiceRegistrationWithResourceProviders = Registration{}
 
 func (r Registration) AssociatedGitHubLabel() string {
 	return "service/authorization"
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.Authorization",
+	}
+}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "Authorization"
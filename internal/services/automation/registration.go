@@ -12,6 +12,8 @@ type Registration struct{}
 
 var _ sdk.UntypedServiceRegistrationWithAGitHubLabel = Registration{}
 var _ sdk.TypedServiceRegistrationWithAGitHubLabel = Registration{}
+var _ sdk.TypedServiceRegistrationWithResourceProviders = Registration{}
+var _ sdk.UntypedServiceRegistrationWithResourceProviders = Registration{}
 
 func (r Registration) DataSources() []sdk.DataSource {
 	return []sdk.DataSource{
@@ -35,6 +37,13 @@ func (r Registration) AssociatedGitHubLabel() string {
 	return "service/automation"
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.Automation",
+	}
+}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "Automation"
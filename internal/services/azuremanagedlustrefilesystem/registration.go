@@ -7,11 +7,19 @@ import (
 type Registration struct{}
 
 var _ sdk.TypedServiceRegistrationWithAGitHubLabel = Registration{}
+var _ sdk.TypedServiceRegistrationWithResourceProviders = Registration{}
 
 func (r Registration) AssociatedGitHubLabel() string {
 	return "service/azuremanagedlustrefilesystem"
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.StorageCache",
+	}
+}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "Azure Managed Lustre File System"
@@ -4,12 +4,22 @@
 package confidentialledger
 
 import (
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
 // Registration type for Azure Confidential Ledger.
 type Registration struct{}
 
+var _ sdk.UntypedServiceRegistrationWithResourceProviders = Registration{}
+
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.ConfidentialLedger",
+	}
+}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "Confidential Ledger"
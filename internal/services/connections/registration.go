@@ -9,6 +9,7 @@ import (
 )
 
 var _ sdk.UntypedServiceRegistration = Registration{}
+var _ sdk.UntypedServiceRegistrationWithResourceProviders = Registration{}
 
 type Registration struct{}
 
@@ -16,6 +17,13 @@ func (r Registration) AssociatedGitHubLabel() string {
 	return "service/connections"
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.Web",
+	}
+}
+
 func (r Registration) Name() string {
 	return "Connections"
 }
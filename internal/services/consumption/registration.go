@@ -11,8 +11,10 @@ import (
 type Registration struct{}
 
 var (
-	_ sdk.TypedServiceRegistrationWithAGitHubLabel = Registration{}
-	_ sdk.UntypedServiceRegistration               = Registration{}
+	_ sdk.TypedServiceRegistrationWithAGitHubLabel        = Registration{}
+	_ sdk.UntypedServiceRegistration                      = Registration{}
+	_ sdk.TypedServiceRegistrationWithResourceProviders   = Registration{}
+	_ sdk.UntypedServiceRegistrationWithResourceProviders = Registration{}
 )
 
 func (r Registration) AssociatedGitHubLabel() string {
@@ -31,6 +33,13 @@ func (r Registration) Resources() []sdk.Resource {
 	}
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.Consumption",
+	}
+}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "Consumption"
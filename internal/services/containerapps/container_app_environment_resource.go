@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/tags"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/containerapps/2023-05-01/managedenvironments"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/operationalinsights/2020-08-01/workspaces"
@@ -43,6 +44,7 @@ type ContainerAppEnvironmentModel struct {
 }
 
 var _ sdk.ResourceWithUpdate = ContainerAppEnvironmentResource{}
+var _ sdk.ResourceWithList = ContainerAppEnvironmentResource{}
 
 func (r ContainerAppEnvironmentResource) ModelObject() interface{} {
 	return &ContainerAppEnvironmentModel{}
@@ -320,6 +322,28 @@ func (r ContainerAppEnvironmentResource) Delete() sdk.ResourceFunc {
 	}
 }
 
+func (r ContainerAppEnvironmentResource) List() sdk.ResourceListFunc {
+	return sdk.ResourceListFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceListMetaData) ([]resourceids.Id, error) {
+			client := metadata.Client.ContainerApps.ManagedEnvironmentClient
+
+			return sdk.ListResourceIdsWithinScope(ctx, metadata,
+				func(ctx context.Context, id commonids.ResourceGroupId) ([]managedenvironments.ManagedEnvironment, error) {
+					resp, err := client.ListByResourceGroupComplete(ctx, id)
+					return resp.Items, err
+				},
+				func(ctx context.Context, id commonids.SubscriptionId) ([]managedenvironments.ManagedEnvironment, error) {
+					resp, err := client.ListBySubscriptionComplete(ctx, id)
+					return resp.Items, err
+				},
+				func(item managedenvironments.ManagedEnvironment) *string { return item.Id },
+				managedenvironments.ParseManagedEnvironmentIDInsensitively,
+			)
+		},
+	}
+}
+
 func (r ContainerAppEnvironmentResource) Update() sdk.ResourceFunc {
 	return sdk.ResourceFunc{
 		Timeout: 30 * time.Minute,
@@ -8,6 +8,7 @@ import (
 )
 
 var _ sdk.TypedServiceRegistration = Registration{}
+var _ sdk.TypedServiceRegistrationWithResourceProviders = Registration{}
 
 type Registration struct{}
 
@@ -21,6 +22,13 @@ func (r Registration) WebsiteCategories() []string {
 	}
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.App",
+	}
+}
+
 func (r Registration) Name() string {
 	return "Container Apps"
 }
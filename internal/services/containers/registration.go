@@ -13,10 +13,22 @@ type Registration struct {
 }
 
 var (
-	_ sdk.TypedServiceRegistration   = Registration{}
-	_ sdk.UntypedServiceRegistration = Registration{}
+	_ sdk.TypedServiceRegistration                        = Registration{}
+	_ sdk.UntypedServiceRegistration                      = Registration{}
+	_ sdk.TypedServiceRegistrationWithResourceProviders   = Registration{}
+	_ sdk.UntypedServiceRegistrationWithResourceProviders = Registration{}
 )
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.ContainerInstance",
+		"Microsoft.ContainerRegistry",
+		"Microsoft.ContainerService",
+		"Microsoft.KubernetesConfiguration",
+	}
+}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "Container Services"
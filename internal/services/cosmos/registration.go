@@ -11,8 +11,10 @@ import (
 type Registration struct{}
 
 var (
-	_ sdk.TypedServiceRegistration                   = Registration{}
-	_ sdk.UntypedServiceRegistrationWithAGitHubLabel = Registration{}
+	_ sdk.TypedServiceRegistration                        = Registration{}
+	_ sdk.UntypedServiceRegistrationWithAGitHubLabel      = Registration{}
+	_ sdk.TypedServiceRegistrationWithResourceProviders   = Registration{}
+	_ sdk.UntypedServiceRegistrationWithResourceProviders = Registration{}
 )
 
 func (r Registration) AssociatedGitHubLabel() string {
@@ -36,6 +38,14 @@ func (r Registration) Resources() []sdk.Resource {
 	}
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.DBforPostgreSQL",
+		"Microsoft.DocumentDB",
+	}
+}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "CosmosDB"
@@ -10,6 +10,7 @@ import (
 type Registration struct{}
 
 var _ sdk.TypedServiceRegistrationWithAGitHubLabel = Registration{}
+var _ sdk.TypedServiceRegistrationWithResourceProviders = Registration{}
 
 func (r Registration) AssociatedGitHubLabel() string {
 	return "service/cost-management"
@@ -31,6 +32,13 @@ func (r Registration) Resources() []sdk.Resource {
 	}
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.CostManagement",
+	}
+}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "Cost Management"
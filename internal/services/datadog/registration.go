@@ -3,14 +3,26 @@
 
 package datadog
 
-import "github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+import (
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
 
 type Registration struct{}
 
+var _ sdk.UntypedServiceRegistrationWithResourceProviders = Registration{}
+
 func (r Registration) AssociatedGitHubLabel() string {
 	return "service/datadog"
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.Datadog",
+	}
+}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "Datadog"
@@ -5,11 +5,19 @@ import (
 )
 
 var _ sdk.TypedServiceRegistrationWithAGitHubLabel = Registration{}
+var _ sdk.TypedServiceRegistrationWithResourceProviders = Registration{}
 
 type Registration struct {
 	autoRegistration
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.DevCenter",
+	}
+}
+
 func (r Registration) Name() string {
 	return "Dev Center"
 }
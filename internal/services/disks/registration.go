@@ -8,6 +8,7 @@ import (
 )
 
 var _ sdk.TypedServiceRegistrationWithAGitHubLabel = Registration{}
+var _ sdk.TypedServiceRegistrationWithResourceProviders = Registration{}
 
 type Registration struct{}
 
@@ -15,6 +16,13 @@ func (r Registration) AssociatedGitHubLabel() string {
 	return "service/disks"
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.StoragePool",
+	}
+}
+
 func (r Registration) Name() string {
 	return "Disks"
 }
@@ -9,6 +9,7 @@ import (
 )
 
 var _ sdk.UntypedServiceRegistrationWithAGitHubLabel = Registration{}
+var _ sdk.UntypedServiceRegistrationWithResourceProviders = Registration{}
 
 type Registration struct{}
 
@@ -16,6 +17,13 @@ func (r Registration) AssociatedGitHubLabel() string {
 	return "service/elastic"
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.Elastic",
+	}
+}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "Elastic"
@@ -4,56 +4,70 @@
 package migration
 
 import (
-	"context"
 	"testing"
 
-	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/stateupgrade"
 )
 
 func TestConsumerGroupsV0ToV1(t *testing.T) {
-	testData := []struct {
-		name     string
-		input    map[string]interface{}
-		expected *string
-	}{
+	stateupgrade.RunTestCases(t, ConsumerGroupsV0ToV1{}, []stateupgrade.TestCase{
 		{
-			name: "old id (shared)",
-			input: map[string]interface{}{
+			Name: "old id (shared)",
+			Input: map[string]interface{}{
 				"id": "/subscriptions/12345678-1234-5678-1234-123456789012/resourceGroups/group1/providers/Microsoft.EventHub/namespaces/namespace1/eventhubs/eventhub1/consumergroups/consumergroup1",
 			},
-			expected: utils.String("/subscriptions/12345678-1234-5678-1234-123456789012/resourceGroups/group1/providers/Microsoft.EventHub/namespaces/namespace1/eventhubs/eventhub1/consumerGroups/consumergroup1"),
+			Expected: map[string]interface{}{
+				"id": "/subscriptions/12345678-1234-5678-1234-123456789012/resourceGroups/group1/providers/Microsoft.EventHub/namespaces/namespace1/eventhubs/eventhub1/consumerGroups/consumergroup1",
+			},
 		},
 		{
-			name: "old id - mixed case (shared)",
-			input: map[string]interface{}{
+			Name: "old id - mixed case (shared)",
+			Input: map[string]interface{}{
 				"id": "/subscriptions/12345678-1234-5678-1234-123456789012/resourceGroups/group1/providers/Microsoft.EventHub/namespaces/namespace1/eventhubs/eventhub1/ConsumerGroups/consumergroup1",
 			},
-			expected: utils.String("/subscriptions/12345678-1234-5678-1234-123456789012/resourceGroups/group1/providers/Microsoft.EventHub/namespaces/namespace1/eventhubs/eventhub1/consumerGroups/consumergroup1"),
+			Expected: map[string]interface{}{
+				"id": "/subscriptions/12345678-1234-5678-1234-123456789012/resourceGroups/group1/providers/Microsoft.EventHub/namespaces/namespace1/eventhubs/eventhub1/consumerGroups/consumergroup1",
+			},
 		},
 		{
-			name: "new id (shared)",
-			input: map[string]interface{}{
+			Name: "new id (shared)",
+			Input: map[string]interface{}{
+				"id": "/subscriptions/12345678-1234-5678-1234-123456789012/resourceGroups/group1/providers/Microsoft.EventHub/namespaces/namespace1/eventhubs/eventhub1/consumerGroups/consumergroup1",
+			},
+			Expected: map[string]interface{}{
 				"id": "/subscriptions/12345678-1234-5678-1234-123456789012/resourceGroups/group1/providers/Microsoft.EventHub/namespaces/namespace1/eventhubs/eventhub1/consumerGroups/consumergroup1",
 			},
-			expected: utils.String("/subscriptions/12345678-1234-5678-1234-123456789012/resourceGroups/group1/providers/Microsoft.EventHub/namespaces/namespace1/eventhubs/eventhub1/consumerGroups/consumergroup1"),
 		},
-	}
-	for _, test := range testData {
-		t.Logf("Testing %q...", test.name)
-		result, err := ConsumerGroupsV0ToV1{}.UpgradeFunc()(context.TODO(), test.input, nil)
-		if err != nil && test.expected == nil {
-			continue
-		} else {
-			if err == nil && test.expected == nil {
-				t.Fatalf("Expected an error but didn't get one")
-			} else if err != nil && test.expected != nil {
-				t.Fatalf("Expected no error but got: %+v", err)
-			}
-		}
-
-		actualId := result["id"].(string)
-		if *test.expected != actualId {
-			t.Fatalf("expected %q but got %q!", *test.expected, actualId)
-		}
-	}
+		{
+			Name: "old id with properties",
+			Input: map[string]interface{}{
+				"id":                  "/subscriptions/12345678-1234-5678-1234-123456789012/resourceGroups/group1/providers/Microsoft.EventHub/namespaces/namespace1/eventhubs/eventhub1/consumergroups/consumergroup1",
+				"name":                "consumergroup1",
+				"namespace_name":      "namespace1",
+				"eventhub_name":       "eventhub1",
+				"resource_group_name": "group1",
+				"user_metadata":       "some-metadata",
+			},
+			Expected: map[string]interface{}{
+				"id":                  "/subscriptions/12345678-1234-5678-1234-123456789012/resourceGroups/group1/providers/Microsoft.EventHub/namespaces/namespace1/eventhubs/eventhub1/consumerGroups/consumergroup1",
+				"name":                "consumergroup1",
+				"namespace_name":      "namespace1",
+				"eventhub_name":       "eventhub1",
+				"resource_group_name": "group1",
+				"user_metadata":       "some-metadata",
+			},
+		},
+		{
+			Name: "missing id",
+			Input: map[string]interface{}{
+				"name": "consumergroup1",
+			},
+		},
+		{
+			Name: "invalid id",
+			Input: map[string]interface{}{
+				"id": "/subscriptions/12345678-1234-5678-1234-123456789012/resourceGroups/group1/providers/Microsoft.EventHub/namespaces/namespace1",
+			},
+		},
+	})
 }
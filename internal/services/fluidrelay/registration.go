@@ -11,6 +11,13 @@ func (r Registration) AssociatedGitHubLabel() string {
 	return "service/fluid-relay"
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.FluidRelay",
+	}
+}
+
 func (r Registration) Name() string {
 	return "Fluid Relay"
 }
@@ -32,5 +39,6 @@ func (r Registration) WebsiteCategories() []string {
 }
 
 var (
-	_ sdk.TypedServiceRegistration = (*Registration)(nil)
+	_ sdk.TypedServiceRegistration                      = (*Registration)(nil)
+	_ sdk.TypedServiceRegistrationWithResourceProviders = (*Registration)(nil)
 )
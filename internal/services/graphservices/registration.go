@@ -7,11 +7,19 @@ import (
 type Registration struct{}
 
 var _ sdk.TypedServiceRegistrationWithAGitHubLabel = Registration{}
+var _ sdk.TypedServiceRegistrationWithResourceProviders = Registration{}
 
 func (r Registration) AssociatedGitHubLabel() string {
 	return "service/graph"
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.GraphServices",
+	}
+}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "Graph Services"
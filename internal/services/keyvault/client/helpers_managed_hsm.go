@@ -0,0 +1,167 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/keyvault/2023-02-01/managedhsms"
+	resourcesClient "github.com/hashicorp/terraform-provider-azurerm/internal/services/resource/client"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+var managedHSMsCache = map[string]managedHSMDetails{}
+
+type managedHSMDetails struct {
+	managedHSMId     string
+	dataPlaneBaseUri string
+}
+
+func (c *Client) AddManagedHSMToCache(managedHSMId managedhsms.ManagedHSMId, dataPlaneUri string) {
+	cacheKey := c.cacheKeyForManagedHSM(managedHSMId.ManagedHSMName)
+	keysmith.Lock()
+	managedHSMsCache[cacheKey] = managedHSMDetails{
+		managedHSMId:     managedHSMId.ID(),
+		dataPlaneBaseUri: dataPlaneUri,
+	}
+	keysmith.Unlock()
+}
+
+func (c *Client) BaseUriForManagedHSM(ctx context.Context, managedHSMId managedhsms.ManagedHSMId) (*string, error) {
+	cacheKey := c.cacheKeyForManagedHSM(managedHSMId.ManagedHSMName)
+	keysmith.Lock()
+	if lock[cacheKey] == nil {
+		lock[cacheKey] = &sync.RWMutex{}
+	}
+	keysmith.Unlock()
+	lock[cacheKey].Lock()
+	defer lock[cacheKey].Unlock()
+
+	if v, ok := managedHSMsCache[cacheKey]; ok {
+		return &v.dataPlaneBaseUri, nil
+	}
+
+	hsmUri, err := c.hsmUriForManagedHSM(ctx, managedHSMId)
+	if err != nil {
+		return nil, err
+	}
+
+	c.AddManagedHSMToCache(managedHSMId, *hsmUri)
+	return hsmUri, nil
+}
+
+func (c *Client) ManagedHSMIDFromBaseUrl(ctx context.Context, resourcesClient *resourcesClient.Client, managedHSMBaseUrl string) (*string, error) {
+	managedHSMName, err := c.parseManagedHSMNameFromBaseUrl(managedHSMBaseUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheKey := c.cacheKeyForManagedHSM(*managedHSMName)
+	keysmith.Lock()
+	if lock[cacheKey] == nil {
+		lock[cacheKey] = &sync.RWMutex{}
+	}
+	keysmith.Unlock()
+	lock[cacheKey].Lock()
+	defer lock[cacheKey].Unlock()
+
+	if v, ok := managedHSMsCache[cacheKey]; ok {
+		return &v.managedHSMId, nil
+	}
+
+	filter := fmt.Sprintf("resourceType eq 'Microsoft.KeyVault/managedHSMs' and name eq '%s'", *managedHSMName)
+	result, err := resourcesClient.ResourcesClient.List(ctx, filter, "", utils.Int32(5))
+	if err != nil {
+		return nil, fmt.Errorf("listing resources matching %q: %+v", filter, err)
+	}
+
+	for result.NotDone() {
+		for _, v := range result.Values() {
+			if v.ID == nil {
+				continue
+			}
+
+			id, err := managedhsms.ParseManagedHSMIDInsensitively(*v.ID)
+			if err != nil {
+				return nil, fmt.Errorf("parsing %q: %+v", *v.ID, err)
+			}
+			if !strings.EqualFold(id.ManagedHSMName, *managedHSMName) {
+				continue
+			}
+
+			hsmUri, err := c.hsmUriForManagedHSM(ctx, *id)
+			if err != nil {
+				return nil, err
+			}
+			c.AddManagedHSMToCache(*id, *hsmUri)
+			return utils.String(id.ID()), nil
+		}
+
+		if err := result.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("iterating over results: %+v", err)
+		}
+	}
+
+	// we haven't found it, but Data Sources and Resources need to handle this error separately
+	return nil, nil
+}
+
+func (c *Client) PurgeManagedHSM(managedHSMId managedhsms.ManagedHSMId) {
+	cacheKey := c.cacheKeyForManagedHSM(managedHSMId.ManagedHSMName)
+	keysmith.Lock()
+	if lock[cacheKey] == nil {
+		lock[cacheKey] = &sync.RWMutex{}
+	}
+	keysmith.Unlock()
+	lock[cacheKey].Lock()
+	delete(managedHSMsCache, cacheKey)
+	lock[cacheKey].Unlock()
+}
+
+func (c *Client) hsmUriForManagedHSM(ctx context.Context, managedHSMId managedhsms.ManagedHSMId) (*string, error) {
+	resp, err := c.ManagedHsmClient.Get(ctx, managedHSMId)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil, fmt.Errorf("%s was not found", managedHSMId)
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", managedHSMId, err)
+	}
+
+	hsmUri := ""
+	if model := resp.Model; model != nil && model.Properties != nil && model.Properties.HsmUri != nil {
+		hsmUri = *model.Properties.HsmUri
+	}
+	if hsmUri == "" {
+		return nil, fmt.Errorf("retrieving %s: `properties.HsmUri` was nil", managedHSMId)
+	}
+
+	return &hsmUri, nil
+}
+
+func (c *Client) cacheKeyForManagedHSM(name string) string {
+	// Key Vaults and Managed HSMs share a lock map, so these are namespaced to avoid clashing with a Key Vault of the same name
+	return fmt.Sprintf("managedhsm-%s", strings.ToLower(name))
+}
+
+func (c *Client) parseManagedHSMNameFromBaseUrl(input string) (*string, error) {
+	uri, err := url.Parse(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// https://the-hsm.managedhsm.azure.net
+	// https://the-hsm.managedhsm.usgovcloudapi.net
+	// https://the-hsm.managedhsm.azure.cn
+
+	segments := strings.Split(uri.Host, ".")
+	if len(segments) < 3 || segments[1] != "managedhsm" {
+		return nil, fmt.Errorf("expected a URI in the format `the-managed-hsm-name.managedhsm.**` but got %q", uri.Host)
+	}
+	return &segments[0], nil
+}
@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+var _ sdk.EphemeralResource = KeyVaultCertificateEphemeralResource{}
+
+type KeyVaultCertificateEphemeralResource struct{}
+
+type KeyVaultCertificateEphemeralResourceModel struct {
+	Name              string `tfschema:"name"`
+	KeyVaultId        string `tfschema:"key_vault_id"`
+	Version           string `tfschema:"version"`
+	Hex               string `tfschema:"hex"`
+	Pem               string `tfschema:"pem"`
+	Key               string `tfschema:"key"`
+	Expires           string `tfschema:"expires"`
+	NotBefore         string `tfschema:"not_before"`
+	CertificatesCount int64  `tfschema:"certificates_count"`
+}
+
+func (KeyVaultCertificateEphemeralResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validate.NestedItemName,
+		},
+
+		"key_vault_id": commonschema.ResourceIDReferenceRequired(commonids.KeyVaultId{}),
+
+		"version": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+		},
+	}
+}
+
+func (KeyVaultCertificateEphemeralResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"hex": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"pem": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"key": {
+			Type:      pluginsdk.TypeString,
+			Computed:  true,
+			Sensitive: true,
+		},
+
+		"expires": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"not_before": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"certificates_count": {
+			Type:     pluginsdk.TypeInt,
+			Computed: true,
+		},
+	}
+}
+
+func (KeyVaultCertificateEphemeralResource) ModelObject() interface{} {
+	return &KeyVaultCertificateEphemeralResourceModel{}
+}
+
+func (KeyVaultCertificateEphemeralResource) ResourceType() string {
+	return "azurerm_key_vault_certificate"
+}
+
+func (KeyVaultCertificateEphemeralResource) Open() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			keyVaultsClient := metadata.Client.KeyVault
+			client := metadata.Client.KeyVault.ManagementClient
+
+			var model KeyVaultCertificateEphemeralResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			keyVaultId, err := commonids.ParseKeyVaultID(model.KeyVaultId)
+			if err != nil {
+				return err
+			}
+
+			keyVaultBaseUri, err := keyVaultsClient.BaseUriForKeyVault(ctx, *keyVaultId)
+			if err != nil {
+				return fmt.Errorf("looking up Certificate %q vault url from id %q: %+v", model.Name, *keyVaultId, err)
+			}
+
+			cert, err := client.GetCertificate(ctx, *keyVaultBaseUri, model.Name, model.Version)
+			if err != nil {
+				if utils.ResponseWasNotFound(cert.Response) {
+					return fmt.Errorf("the Certificate %q was not found in Key Vault at URI %q", model.Name, *keyVaultBaseUri)
+				}
+				return fmt.Errorf("retrieving Key Vault Certificate %q: %+v", model.Name, err)
+			}
+
+			if cert.ID == nil || *cert.ID == "" {
+				return fmt.Errorf("failure reading Key Vault Certificate ID for %q", model.Name)
+			}
+
+			id, err := parse.ParseNestedItemID(*cert.ID)
+			if err != nil {
+				return err
+			}
+			model.Version = id.Version
+
+			if contents := cert.Cer; contents != nil {
+				model.Hex = strings.ToUpper(hex.EncodeToString(*contents))
+			}
+			if attributes := cert.Attributes; attributes != nil {
+				if expires := attributes.Expires; expires != nil {
+					model.Expires = time.Time(*expires).Format(time.RFC3339)
+				}
+				if notBefore := attributes.NotBefore; notBefore != nil {
+					model.NotBefore = time.Time(*notBefore).Format(time.RFC3339)
+				}
+			}
+
+			// the certificate chain and private key are exposed through the Secret backing this Certificate
+			secret, err := client.GetSecret(ctx, id.KeyVaultBaseUrl, id.Name, id.Version)
+			if err != nil {
+				return fmt.Errorf("retrieving the Secret for Certificate %q from Key Vault at URI %q: %+v", id.Name, pointer.From(keyVaultBaseUri), err)
+			}
+
+			certificates, privateKey, certificatesCount, err := keyVaultCertificateDataFromSecret(id.Name, secret)
+			if err != nil {
+				return err
+			}
+			model.Pem = certificates
+			model.Key = privateKey
+			model.CertificatesCount = int64(certificatesCount)
+
+			return metadata.Encode(&model)
+		},
+	}
+}
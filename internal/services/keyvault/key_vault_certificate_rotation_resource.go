@@ -0,0 +1,273 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	keyVaultValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+type KeyVaultCertificateRotationResource struct{}
+
+var _ sdk.ResourceWithCustomizeDiff = KeyVaultCertificateRotationResource{}
+
+type KeyVaultCertificateRotationResourceModel struct {
+	KeyVaultCertificateId string            `tfschema:"key_vault_certificate_id"`
+	RotationPeriod        string            `tfschema:"rotation_period"`
+	Triggers              map[string]string `tfschema:"triggers"`
+	Version               string            `tfschema:"version"`
+	VersionlessId         string            `tfschema:"versionless_id"`
+	SecretId              string            `tfschema:"secret_id"`
+	VersionlessSecretId   string            `tfschema:"versionless_secret_id"`
+	Thumbprint            string            `tfschema:"thumbprint"`
+	NextRotationDate      string            `tfschema:"next_rotation_date"`
+}
+
+func (r KeyVaultCertificateRotationResource) Arguments() map[string]*pluginsdk.Schema {
+	arguments := map[string]*pluginsdk.Schema{
+		"key_vault_certificate_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: keyVaultValidate.VersionlessNestedItemId,
+		},
+	}
+
+	for k, v := range rotationArguments() {
+		arguments[k] = v
+	}
+
+	return arguments
+}
+
+func (r KeyVaultCertificateRotationResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"version": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"versionless_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"secret_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"versionless_secret_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"thumbprint": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"next_rotation_date": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r KeyVaultCertificateRotationResource) ResourceType() string {
+	return "azurerm_key_vault_certificate_rotation"
+}
+
+func (r KeyVaultCertificateRotationResource) ModelObject() interface{} {
+	return &KeyVaultCertificateRotationResourceModel{}
+}
+
+func (r KeyVaultCertificateRotationResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return keyVaultValidate.NestedItemId
+}
+
+func (r KeyVaultCertificateRotationResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.KeyVault.ManagementClient
+
+			var config KeyVaultCertificateRotationResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			certificateId, err := parse.ParseOptionallyVersionedNestedItemID(config.KeyVaultCertificateId)
+			if err != nil {
+				return err
+			}
+
+			locks.ByName(certificateId.VersionlessID(), r.ResourceType())
+			defer locks.UnlockByName(certificateId.VersionlessID(), r.ResourceType())
+
+			// a new version is issued using the existing Certificate Policy
+			policy, err := client.GetCertificatePolicy(ctx, certificateId.KeyVaultBaseUrl, certificateId.Name)
+			if err != nil {
+				if utils.ResponseWasNotFound(policy.Response) {
+					return fmt.Errorf("the Certificate %q was not found in the Key Vault at URI %q", certificateId.Name, certificateId.KeyVaultBaseUrl)
+				}
+				return fmt.Errorf("retrieving the Policy for Certificate %q (Key Vault %q): %+v", certificateId.Name, certificateId.KeyVaultBaseUrl, err)
+			}
+
+			issuerName := ""
+			if policy.IssuerParameters != nil {
+				issuerName = pointer.From(policy.IssuerParameters.Name)
+			}
+			if issuerName == "" || strings.EqualFold(issuerName, "Unknown") {
+				return fmt.Errorf("the Certificate %q (Key Vault %q) can't be rotated since it's issued by an `Unknown` issuer - a new version must be imported instead", certificateId.Name, certificateId.KeyVaultBaseUrl)
+			}
+
+			parameters := keyvault.CertificateCreateParameters{
+				CertificatePolicy: &policy,
+			}
+			if _, err := client.CreateCertificate(ctx, certificateId.KeyVaultBaseUrl, certificateId.Name, parameters); err != nil {
+				return fmt.Errorf("creating a new version of Certificate %q (Key Vault %q): %+v", certificateId.Name, certificateId.KeyVaultBaseUrl, err)
+			}
+
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				return fmt.Errorf("internal-error: context had no deadline")
+			}
+			stateConf := &pluginsdk.StateChangeConf{
+				Pending:    []string{"Provisioning"},
+				Target:     []string{"Ready"},
+				Refresh:    keyVaultCertificateCreationRefreshFunc(ctx, client, certificateId.KeyVaultBaseUrl, certificateId.Name),
+				MinTimeout: 15 * time.Second,
+				Timeout:    time.Until(deadline),
+			}
+			// as with `azurerm_key_vault_certificate`, certificates from other issuers may take substantially longer to be issued
+			if !strings.EqualFold(issuerName, "Self") {
+				stateConf.PollInterval = 30 * time.Second
+				stateConf.NotFoundChecks = int(math.Floor(float64(stateConf.Timeout) / float64(stateConf.PollInterval)))
+			}
+			if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+				return fmt.Errorf("waiting for the new version of Certificate %q (Key Vault %q) to be issued: %+v", certificateId.Name, certificateId.KeyVaultBaseUrl, err)
+			}
+
+			// "" indicates the latest version
+			cert, err := client.GetCertificate(ctx, certificateId.KeyVaultBaseUrl, certificateId.Name, "")
+			if err != nil {
+				return fmt.Errorf("retrieving the latest version of Certificate %q (Key Vault %q): %+v", certificateId.Name, certificateId.KeyVaultBaseUrl, err)
+			}
+			if cert.ID == nil {
+				return fmt.Errorf("retrieving the latest version of Certificate %q (Key Vault %q): `id` was nil", certificateId.Name, certificateId.KeyVaultBaseUrl)
+			}
+
+			id, err := parse.ParseNestedItemID(*cert.ID)
+			if err != nil {
+				return err
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+		Timeout: 60 * time.Minute,
+	}
+}
+
+func (r KeyVaultCertificateRotationResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.KeyVault.ManagementClient
+
+			id, err := parse.ParseNestedItemID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var state KeyVaultCertificateRotationResourceModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			cert, err := client.GetCertificate(ctx, id.KeyVaultBaseUrl, id.Name, id.Version)
+			if err != nil {
+				if utils.ResponseWasNotFound(cert.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			state.KeyVaultCertificateId = id.VersionlessID()
+			state.Version = id.Version
+			state.VersionlessId = id.VersionlessID()
+			state.SecretId = ""
+			state.VersionlessSecretId = ""
+			state.Thumbprint = ""
+			state.NextRotationDate = ""
+
+			if cert.Sid != nil {
+				secretId, err := parse.ParseNestedItemID(*cert.Sid)
+				if err != nil {
+					return err
+				}
+				state.SecretId = secretId.ID()
+				state.VersionlessSecretId = secretId.VersionlessID()
+			}
+
+			if v := cert.X509Thumbprint; v != nil {
+				x509Thumbprint, err := base64.RawURLEncoding.DecodeString(*v)
+				if err != nil {
+					return err
+				}
+				state.Thumbprint = strings.ToUpper(hex.EncodeToString(x509Thumbprint))
+			}
+
+			if attributes := cert.Attributes; attributes != nil && attributes.Created != nil {
+				next, err := nextRotationDate(time.Time(*attributes.Created), state.RotationPeriod)
+				if err != nil {
+					return err
+				}
+				state.NextRotationDate = next
+			}
+
+			return metadata.Encode(&state)
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
+
+func (r KeyVaultCertificateRotationResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parse.ParseNestedItemID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			// versions of a Certificate can't be deleted individually, so the version issued by this resource
+			// is retained - and will be removed alongside the Certificate itself
+			metadata.Logger.Infof("removing %s from the state - the version will be retained", id)
+			return nil
+		},
+		Timeout: 30 * time.Minute,
+	}
+}
+
+func (r KeyVaultCertificateRotationResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			return forceNewWhenRotationIsDue(metadata.ResourceDiff, time.Now(), "version", "secret_id", "thumbprint")
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
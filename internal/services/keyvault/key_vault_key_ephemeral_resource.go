@@ -0,0 +1,180 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+var _ sdk.EphemeralResource = KeyVaultKeyEphemeralResource{}
+
+type KeyVaultKeyEphemeralResource struct{}
+
+type KeyVaultKeyEphemeralResourceModel struct {
+	Name             string   `tfschema:"name"`
+	KeyVaultId       string   `tfschema:"key_vault_id"`
+	Version          string   `tfschema:"version"`
+	KeyType          string   `tfschema:"key_type"`
+	KeyOpts          []string `tfschema:"key_opts"`
+	Curve            string   `tfschema:"curve"`
+	N                string   `tfschema:"n"`
+	E                string   `tfschema:"e"`
+	X                string   `tfschema:"x"`
+	Y                string   `tfschema:"y"`
+	PublicKeyPem     string   `tfschema:"public_key_pem"`
+	PublicKeyOpenSSH string   `tfschema:"public_key_openssh"`
+}
+
+func (KeyVaultKeyEphemeralResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validate.NestedItemName,
+		},
+
+		"key_vault_id": commonschema.ResourceIDReferenceRequired(commonids.KeyVaultId{}),
+
+		"version": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+		},
+	}
+}
+
+func (KeyVaultKeyEphemeralResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"key_type": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"key_opts": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+
+		"curve": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"n": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"e": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"x": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"y": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"public_key_pem": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"public_key_openssh": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (KeyVaultKeyEphemeralResource) ModelObject() interface{} {
+	return &KeyVaultKeyEphemeralResourceModel{}
+}
+
+func (KeyVaultKeyEphemeralResource) ResourceType() string {
+	return "azurerm_key_vault_key"
+}
+
+func (KeyVaultKeyEphemeralResource) Open() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			keyVaultsClient := metadata.Client.KeyVault
+			client := metadata.Client.KeyVault.ManagementClient
+
+			var model KeyVaultKeyEphemeralResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			keyVaultId, err := commonids.ParseKeyVaultID(model.KeyVaultId)
+			if err != nil {
+				return err
+			}
+
+			keyVaultBaseUri, err := keyVaultsClient.BaseUriForKeyVault(ctx, *keyVaultId)
+			if err != nil {
+				return fmt.Errorf("looking up Key %q vault url from id %q: %+v", model.Name, *keyVaultId, err)
+			}
+
+			resp, err := client.GetKey(ctx, *keyVaultBaseUri, model.Name, model.Version)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return fmt.Errorf("Key %q was not found in Key Vault at URI %q", model.Name, *keyVaultBaseUri)
+				}
+				return fmt.Errorf("retrieving Key %q from Key Vault at URI %q: %+v", model.Name, *keyVaultBaseUri, err)
+			}
+
+			key := resp.Key
+			if key == nil || key.Kid == nil {
+				return fmt.Errorf("retrieving Key %q from Key Vault at URI %q: `key` was nil", model.Name, *keyVaultBaseUri)
+			}
+
+			id, err := parse.ParseNestedItemID(*key.Kid)
+			if err != nil {
+				return err
+			}
+			model.Version = id.Version
+
+			model.KeyType = string(key.Kty)
+			model.KeyOpts = pointer.From(key.KeyOps)
+			model.Curve = string(key.Crv)
+			model.N = pointer.From(key.N)
+			model.E = pointer.From(key.E)
+			model.X = pointer.From(key.X)
+			model.Y = pointer.From(key.Y)
+
+			publicKey, err := keyVaultKeyPublicKey(*key)
+			if err != nil {
+				return err
+			}
+			if publicKey != nil {
+				model.PublicKeyPem, model.PublicKeyOpenSSH, err = encodePublicKey(publicKey)
+				if err != nil {
+					return fmt.Errorf("failed to read public key: %+v", err)
+				}
+			}
+
+			return metadata.Encode(&model)
+		},
+	}
+}
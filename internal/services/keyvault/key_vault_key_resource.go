@@ -812,25 +812,86 @@ func flattenKeyVaultKeyRotationPolicy(input keyvault.KeyRotationPolicy) []interf
 
 // Credit to Hashicorp modified from https://github.com/hashicorp/terraform-provider-tls/blob/v3.1.0/internal/provider/util.go#L79-L105
 func readPublicKey(d *pluginsdk.ResourceData, pubKey interface{}) error {
+	publicKeyPem, publicKeyOpenSSH, err := encodePublicKey(pubKey)
+	if err != nil {
+		return err
+	}
+
+	d.Set("public_key_pem", publicKeyPem)
+	d.Set("public_key_openssh", publicKeyOpenSSH)
+	return nil
+}
+
+// encodePublicKey returns the PEM and OpenSSH representations of the Public Key `pubKey`
+func encodePublicKey(pubKey interface{}) (publicKeyPem string, publicKeyOpenSSH string, err error) {
 	pubKeyBytes, err := x509.MarshalPKIXPublicKey(pubKey)
 	if err != nil {
-		return fmt.Errorf("failed to marshal public key error: %s", err)
+		return "", "", fmt.Errorf("failed to marshal public key error: %s", err)
 	}
 	pubKeyPemBlock := &pem.Block{
 		Type:  "PUBLIC KEY",
 		Bytes: pubKeyBytes,
 	}
-
-	d.Set("public_key_pem", string(pem.EncodeToMemory(pubKeyPemBlock)))
+	publicKeyPem = string(pem.EncodeToMemory(pubKeyPemBlock))
 
 	sshPubKey, err := ssh.NewPublicKey(pubKey)
 	if err == nil {
 		// Not all EC types can be SSH keys, so we'll produce this only
 		// if an appropriate type was selected.
-		sshPubKeyBytes := ssh.MarshalAuthorizedKey(sshPubKey)
-		d.Set("public_key_openssh", string(sshPubKeyBytes))
-	} else {
-		d.Set("public_key_openssh", "")
+		publicKeyOpenSSH = string(ssh.MarshalAuthorizedKey(sshPubKey))
 	}
-	return nil
+	return publicKeyPem, publicKeyOpenSSH, nil
+}
+
+// keyVaultKeyPublicKey returns the Public Key for the JSON Web Key `key`, which is nil when
+// the Key Type (or Curve) isn't supported
+func keyVaultKeyPublicKey(key keyvault.JSONWebKey) (interface{}, error) {
+	switch key.Kty {
+	case keyvault.JSONWebKeyTypeRSA, keyvault.JSONWebKeyTypeRSAHSM:
+		if key.N == nil || key.E == nil {
+			return nil, nil
+		}
+		nBytes, err := base64.RawURLEncoding.DecodeString(*key.N)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode N: %+v", err)
+		}
+		eBytes, err := base64.RawURLEncoding.DecodeString(*key.E)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode E: %+v", err)
+		}
+		return &rsa.PublicKey{
+			N: big.NewInt(0).SetBytes(nBytes),
+			E: int(big.NewInt(0).SetBytes(eBytes).Uint64()),
+		}, nil
+
+	case keyvault.JSONWebKeyTypeEC, keyvault.JSONWebKeyTypeECHSM:
+		if key.X == nil || key.Y == nil {
+			return nil, nil
+		}
+		xBytes, err := base64.RawURLEncoding.DecodeString(*key.X)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode X: %+v", err)
+		}
+		yBytes, err := base64.RawURLEncoding.DecodeString(*key.Y)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode Y: %+v", err)
+		}
+		publicKey := &ecdsa.PublicKey{
+			X: big.NewInt(0).SetBytes(xBytes),
+			Y: big.NewInt(0).SetBytes(yBytes),
+		}
+		switch key.Crv {
+		case keyvault.JSONWebKeyCurveNameP256:
+			publicKey.Curve = elliptic.P256()
+		case keyvault.JSONWebKeyCurveNameP384:
+			publicKey.Curve = elliptic.P384()
+		case keyvault.JSONWebKeyCurveNameP521:
+			publicKey.Curve = elliptic.P521()
+		default:
+			return nil, nil
+		}
+		return publicKey, nil
+	}
+
+	return nil, nil
 }
@@ -0,0 +1,409 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/keyvault/2023-02-01/managedhsms"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	keyVaultValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+type KeyVaultManagedHardwareSecurityModuleKeyResource struct{}
+
+var _ sdk.ResourceWithUpdate = KeyVaultManagedHardwareSecurityModuleKeyResource{}
+
+type KeyVaultManagedHardwareSecurityModuleKeyResourceModel struct {
+	Name           string            `tfschema:"name"`
+	ManagedHSMId   string            `tfschema:"managed_hsm_id"`
+	KeyType        string            `tfschema:"key_type"`
+	KeySize        int64             `tfschema:"key_size"`
+	Curve          string            `tfschema:"curve"`
+	KeyOpts        []string          `tfschema:"key_opts"`
+	NotBeforeDate  string            `tfschema:"not_before_date"`
+	ExpirationDate string            `tfschema:"expiration_date"`
+	Tags           map[string]string `tfschema:"tags"`
+	VersionedId    string            `tfschema:"versioned_id"`
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleKeyResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: keyVaultValidate.NestedItemName,
+		},
+
+		"managed_hsm_id": commonschema.ResourceIDReferenceRequiredForceNew(&managedhsms.ManagedHSMId{}),
+
+		"key_type": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				string(keyvault.JSONWebKeyTypeECHSM),
+				string(keyvault.JSONWebKeyTypeOctHSM),
+				string(keyvault.JSONWebKeyTypeRSAHSM),
+			}, false),
+		},
+
+		"key_size": {
+			Type:          pluginsdk.TypeInt,
+			Optional:      true,
+			Computed:      true,
+			ForceNew:      true,
+			ValidateFunc:  validation.IntInSlice([]int{128, 192, 256, 2048, 3072, 4096}),
+			ConflictsWith: []string{"curve"},
+		},
+
+		"curve": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+			Computed: true,
+			ForceNew: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				string(keyvault.JSONWebKeyCurveNameP256),
+				string(keyvault.JSONWebKeyCurveNameP256K),
+				string(keyvault.JSONWebKeyCurveNameP384),
+				string(keyvault.JSONWebKeyCurveNameP521),
+			}, false),
+			ConflictsWith: []string{"key_size"},
+		},
+
+		"key_opts": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(keyvault.JSONWebKeyOperationDecrypt),
+					string(keyvault.JSONWebKeyOperationEncrypt),
+					string(keyvault.JSONWebKeyOperationImport),
+					string(keyvault.JSONWebKeyOperationSign),
+					string(keyvault.JSONWebKeyOperationUnwrapKey),
+					string(keyvault.JSONWebKeyOperationVerify),
+					string(keyvault.JSONWebKeyOperationWrapKey),
+				}, false),
+			},
+		},
+
+		"not_before_date": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.IsRFC3339Time,
+		},
+
+		"expiration_date": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.IsRFC3339Time,
+		},
+
+		"tags": commonschema.Tags(),
+	}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleKeyResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"versioned_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleKeyResource) ResourceType() string {
+	return "azurerm_key_vault_managed_hardware_security_module_key"
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleKeyResource) ModelObject() interface{} {
+	return &KeyVaultManagedHardwareSecurityModuleKeyResourceModel{}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleKeyResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return keyVaultValidate.ManagedHSMKeyID
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleKeyResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			hsmClient := metadata.Client.KeyVault
+			client := metadata.Client.KeyVault.MHSMKeyClient
+
+			var config KeyVaultManagedHardwareSecurityModuleKeyResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			managedHSMId, err := managedhsms.ParseManagedHSMID(config.ManagedHSMId)
+			if err != nil {
+				return err
+			}
+
+			baseUri, err := hsmClient.BaseUriForManagedHSM(ctx, *managedHSMId)
+			if err != nil {
+				return fmt.Errorf("looking up the Base URI for Key %q from %s: %+v", config.Name, *managedHSMId, err)
+			}
+
+			id, err := parse.NewManagedHSMKeyID(*baseUri, config.Name)
+			if err != nil {
+				return err
+			}
+
+			locks.ByID(id.ID())
+			defer locks.UnlockByID(id.ID())
+
+			existing, err := client.GetKey(ctx, id.ManagedHSMBaseUrl, id.Name, "")
+			if err != nil {
+				if !utils.ResponseWasNotFound(existing.Response) {
+					return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+				}
+			}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return tf.ImportAsExistsError(r.ResourceType(), id.ID())
+			}
+
+			parameters := keyvault.KeyCreateParameters{
+				Kty:    keyvault.JSONWebKeyType(config.KeyType),
+				KeyOps: expandManagedHSMKeyOptions(config.KeyOpts),
+				KeyAttributes: &keyvault.KeyAttributes{
+					Enabled: pointer.To(true),
+				},
+				Tags: tags.FromTypedObject(config.Tags),
+			}
+
+			switch parameters.Kty {
+			case keyvault.JSONWebKeyTypeECHSM:
+				if config.Curve == "" {
+					return fmt.Errorf("`curve` must be specified when `key_type` is %q", config.KeyType)
+				}
+				parameters.Curve = keyvault.JSONWebKeyCurveName(config.Curve)
+			default:
+				if config.KeySize == 0 {
+					return fmt.Errorf("`key_size` must be specified when `key_type` is %q", config.KeyType)
+				}
+				parameters.KeySize = pointer.To(int32(config.KeySize))
+			}
+
+			if config.NotBeforeDate != "" {
+				notBeforeDate, _ := time.Parse(time.RFC3339, config.NotBeforeDate) // validated by schema
+				parameters.KeyAttributes.NotBefore = pointer.To(date.UnixTime(notBeforeDate))
+			}
+			if config.ExpirationDate != "" {
+				expirationDate, _ := time.Parse(time.RFC3339, config.ExpirationDate) // validated by schema
+				parameters.KeyAttributes.Expires = pointer.To(date.UnixTime(expirationDate))
+			}
+
+			if _, err := client.CreateKey(ctx, id.ManagedHSMBaseUrl, id.Name, parameters); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+		Timeout: 30 * time.Minute,
+	}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleKeyResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			hsmClient := metadata.Client.KeyVault
+			client := metadata.Client.KeyVault.MHSMKeyClient
+			resourcesClient := metadata.Client.Resource
+
+			id, err := parse.ManagedHSMKeyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			managedHSMIdRaw, err := hsmClient.ManagedHSMIDFromBaseUrl(ctx, resourcesClient, id.ManagedHSMBaseUrl)
+			if err != nil {
+				return fmt.Errorf("retrieving the Resource ID of the Managed HSM at URL %q: %+v", id.ManagedHSMBaseUrl, err)
+			}
+			if managedHSMIdRaw == nil {
+				metadata.Logger.Infof("Unable to determine the Resource ID for the Managed HSM at URL %q - removing from state!", id.ManagedHSMBaseUrl)
+				return metadata.MarkAsGone(id)
+			}
+			managedHSMId, err := managedhsms.ParseManagedHSMID(*managedHSMIdRaw)
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.GetKey(ctx, id.ManagedHSMBaseUrl, id.Name, "")
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			state := KeyVaultManagedHardwareSecurityModuleKeyResourceModel{
+				Name:         id.Name,
+				ManagedHSMId: managedHSMId.ID(),
+				Tags:         tags.ToTypedObject(resp.Tags),
+			}
+
+			if key := resp.Key; key != nil {
+				state.KeyType = string(key.Kty)
+				state.Curve = string(key.Crv)
+				state.VersionedId = pointer.From(key.Kid)
+
+				if key.KeyOps != nil {
+					state.KeyOpts = *key.KeyOps
+				}
+
+				if key.N != nil {
+					nBytes, err := base64.RawURLEncoding.DecodeString(*key.N)
+					if err != nil {
+						return fmt.Errorf("decoding the modulus of %s: %+v", id, err)
+					}
+					state.KeySize = int64(len(nBytes) * 8)
+				} else {
+					// the size of a symmetric key isn't returned by the API, so this is taken from the config
+					var config KeyVaultManagedHardwareSecurityModuleKeyResourceModel
+					if err := metadata.Decode(&config); err != nil {
+						return fmt.Errorf("decoding: %+v", err)
+					}
+					state.KeySize = config.KeySize
+				}
+			}
+
+			if attributes := resp.Attributes; attributes != nil {
+				if v := attributes.NotBefore; v != nil {
+					state.NotBeforeDate = time.Time(*v).Format(time.RFC3339)
+				}
+				if v := attributes.Expires; v != nil {
+					state.ExpirationDate = time.Time(*v).Format(time.RFC3339)
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleKeyResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.KeyVault.MHSMKeyClient
+
+			id, err := parse.ManagedHSMKeyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var config KeyVaultManagedHardwareSecurityModuleKeyResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			locks.ByID(id.ID())
+			defer locks.UnlockByID(id.ID())
+
+			parameters := keyvault.KeyUpdateParameters{
+				KeyOps:        expandManagedHSMKeyOptions(config.KeyOpts),
+				KeyAttributes: &keyvault.KeyAttributes{},
+				Tags:          tags.FromTypedObject(config.Tags),
+			}
+
+			if config.NotBeforeDate != "" {
+				notBeforeDate, _ := time.Parse(time.RFC3339, config.NotBeforeDate) // validated by schema
+				parameters.KeyAttributes.NotBefore = pointer.To(date.UnixTime(notBeforeDate))
+			}
+			if config.ExpirationDate != "" {
+				expirationDate, _ := time.Parse(time.RFC3339, config.ExpirationDate) // validated by schema
+				parameters.KeyAttributes.Expires = pointer.To(date.UnixTime(expirationDate))
+			}
+
+			// "" indicates the latest version
+			if _, err := client.UpdateKey(ctx, id.ManagedHSMBaseUrl, id.Name, "", parameters); err != nil {
+				return fmt.Errorf("updating %s: %+v", id, err)
+			}
+
+			return nil
+		},
+		Timeout: 30 * time.Minute,
+	}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleKeyResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			hsmClient := metadata.Client.KeyVault
+			client := metadata.Client.KeyVault.MHSMKeyClient
+
+			id, err := parse.ManagedHSMKeyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var state KeyVaultManagedHardwareSecurityModuleKeyResourceModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			managedHSMId, err := managedhsms.ParseManagedHSMID(state.ManagedHSMId)
+			if err != nil {
+				return err
+			}
+
+			locks.ByID(id.ID())
+			defer locks.UnlockByID(id.ID())
+
+			hsm, err := hsmClient.ManagedHsmClient.Get(ctx, *managedHSMId)
+			if err != nil {
+				if response.WasNotFound(hsm.HttpResponse) {
+					metadata.Logger.Infof("%s was not found - removing %s from state", *managedHSMId, id)
+					return nil
+				}
+				return fmt.Errorf("retrieving %s: %+v", *managedHSMId, err)
+			}
+
+			shouldPurge := metadata.Client.Features.KeyVault.PurgeSoftDeletedHSMKeysOnDestroy
+			if shouldPurge && hsm.Model != nil && hsm.Model.Properties != nil && pointer.From(hsm.Model.Properties.EnablePurgeProtection) {
+				metadata.Logger.Infof("cannot purge %s because %s has purge protection enabled", id, *managedHSMId)
+				shouldPurge = false
+			}
+
+			deleter := deleteAndPurgeKey{
+				client:      client,
+				keyVaultUri: id.ManagedHSMBaseUrl,
+				name:        id.Name,
+			}
+			if err := deleteAndOptionallyPurge(ctx, id.String(), shouldPurge, deleter); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Timeout: 30 * time.Minute,
+	}
+}
+
+func expandManagedHSMKeyOptions(input []string) *[]keyvault.JSONWebKeyOperation {
+	results := make([]keyvault.JSONWebKeyOperation, 0, len(input))
+	for _, option := range input {
+		results = append(results, keyvault.JSONWebKeyOperation(option))
+	}
+	return &results
+}
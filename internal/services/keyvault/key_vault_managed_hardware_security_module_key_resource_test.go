@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type KeyVaultManagedHardwareSecurityModuleKeyResource struct{}
+
+// NOTE: these tests are run as a part of TestAccKeyVaultManagedHardwareSecurityModule, since only a single
+// Managed HSM can be provisioned at a time
+
+func testAccKeyVaultManagedHardwareSecurityModuleKey_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_managed_hardware_security_module_key", "test")
+	r := KeyVaultManagedHardwareSecurityModuleKeyResource{}
+
+	data.ResourceSequentialTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("versioned_id").IsSet(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func testAccKeyVaultManagedHardwareSecurityModuleKey_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_managed_hardware_security_module_key", "test")
+	r := KeyVaultManagedHardwareSecurityModuleKeyResource{}
+
+	data.ResourceSequentialTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (KeyVaultManagedHardwareSecurityModuleKeyResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.ManagedHSMKeyID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.KeyVault.MHSMKeyClient.GetKey(ctx, id.ManagedHSMBaseUrl, id.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	return utils.Bool(resp.Key != nil), nil
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleKeyResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_key_vault_managed_hardware_security_module_key" "test" {
+  name           = "acctest-key-%d"
+  managed_hsm_id = azurerm_key_vault_managed_hardware_security_module.test.id
+  key_type       = "EC-HSM"
+  curve          = "P-521"
+  key_opts       = ["sign"]
+
+  depends_on = [azurerm_key_vault_managed_hardware_security_module_role_assignment.crypto_user]
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleKeyResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_key_vault_managed_hardware_security_module_key" "test" {
+  name            = "acctest-key-%d"
+  managed_hsm_id  = azurerm_key_vault_managed_hardware_security_module.test.id
+  key_type        = "EC-HSM"
+  curve           = "P-521"
+  key_opts        = ["sign", "verify"]
+  not_before_date = "2021-01-01T01:02:03Z"
+  expiration_date = "2031-01-01T01:02:03Z"
+
+  tags = {
+    environment = "Production"
+  }
+
+  depends_on = [azurerm_key_vault_managed_hardware_security_module_role_assignment.crypto_user]
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (KeyVaultManagedHardwareSecurityModuleKeyResource) template(data acceptance.TestData) string {
+	// the built-in `Managed HSM Crypto User` role is required to manage keys within the Managed HSM
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_key_vault_managed_hardware_security_module_role_assignment" "crypto_user" {
+  name               = "1e243909-064c-6ac3-84e9-1c8bf8d6ad52"
+  managed_hsm_id     = azurerm_key_vault_managed_hardware_security_module.test.id
+  scope              = "/keys"
+  role_definition_id = "Microsoft.KeyVault/providers/Microsoft.Authorization/roleDefinitions/21dbd100-6940-42c2-9190-5d6cb909625b"
+  principal_id       = data.azurerm_client_config.current.object_id
+}
+`, KeyVaultManagedHardwareSecurityModuleResource{}.download(data, 3))
+}
@@ -29,6 +29,17 @@ func TestAccKeyVaultManagedHardwareSecurityModule(t *testing.T) {
 			"complete":    testAccKeyVaultManagedHardwareSecurityModule_complete,
 			"download":    testAccKeyVaultManagedHardwareSecurityModule_download,
 		},
+		"key": {
+			"basic":  testAccKeyVaultManagedHardwareSecurityModuleKey_basic,
+			"update": testAccKeyVaultManagedHardwareSecurityModuleKey_update,
+		},
+		"role_definition": {
+			"basic":  testAccKeyVaultManagedHardwareSecurityModuleRoleDefinition_basic,
+			"update": testAccKeyVaultManagedHardwareSecurityModuleRoleDefinition_update,
+		},
+		"role_assignment": {
+			"basic": testAccKeyVaultManagedHardwareSecurityModuleRoleAssignment_basic,
+		},
 	})
 }
 
@@ -0,0 +1,230 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/keyvault/2023-02-01/managedhsms"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	keyVaultValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+type KeyVaultManagedHardwareSecurityModuleRoleAssignmentResource struct{}
+
+var _ sdk.Resource = KeyVaultManagedHardwareSecurityModuleRoleAssignmentResource{}
+
+type KeyVaultManagedHardwareSecurityModuleRoleAssignmentResourceModel struct {
+	Name             string `tfschema:"name"`
+	ManagedHSMId     string `tfschema:"managed_hsm_id"`
+	Scope            string `tfschema:"scope"`
+	RoleDefinitionId string `tfschema:"role_definition_id"`
+	PrincipalId      string `tfschema:"principal_id"`
+	ResourceId       string `tfschema:"resource_id"`
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleAssignmentResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IsUUID,
+		},
+
+		"managed_hsm_id": commonschema.ResourceIDReferenceRequiredForceNew(&managedhsms.ManagedHSMId{}),
+
+		"scope": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+			ValidateFunc: validation.Any(
+				validation.StringInSlice([]string{
+					string(keyvault.RoleScopeGlobal),
+					string(keyvault.RoleScopeKeys),
+				}, false),
+				validation.StringMatch(regexp.MustCompile(`^/keys/[^/]+$`), "`scope` must be `/`, `/keys` or `/keys/{key-name}`"),
+			),
+		},
+
+		"role_definition_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"principal_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IsUUID,
+		},
+	}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleAssignmentResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"resource_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleAssignmentResource) ResourceType() string {
+	return "azurerm_key_vault_managed_hardware_security_module_role_assignment"
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleAssignmentResource) ModelObject() interface{} {
+	return &KeyVaultManagedHardwareSecurityModuleRoleAssignmentResourceModel{}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleAssignmentResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return keyVaultValidate.ManagedHSMRoleAssignmentID
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleAssignmentResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			hsmClient := metadata.Client.KeyVault
+			client := metadata.Client.KeyVault.MHSMRoleAssignmentsClient
+
+			var config KeyVaultManagedHardwareSecurityModuleRoleAssignmentResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			managedHSMId, err := managedhsms.ParseManagedHSMID(config.ManagedHSMId)
+			if err != nil {
+				return err
+			}
+
+			baseUri, err := hsmClient.BaseUriForManagedHSM(ctx, *managedHSMId)
+			if err != nil {
+				return fmt.Errorf("looking up the Base URI for Role Assignment %q from %s: %+v", config.Name, *managedHSMId, err)
+			}
+
+			id, err := parse.NewManagedHSMRoleAssignmentID(*baseUri, config.Scope, config.Name)
+			if err != nil {
+				return err
+			}
+
+			locks.ByID(id.ID())
+			defer locks.UnlockByID(id.ID())
+
+			existing, err := client.Get(ctx, id.ManagedHSMBaseUrl, id.Scope, id.Name)
+			if err != nil {
+				if !utils.ResponseWasNotFound(existing.Response) {
+					return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+				}
+			}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return tf.ImportAsExistsError(r.ResourceType(), id.ID())
+			}
+
+			parameters := keyvault.RoleAssignmentCreateParameters{
+				Properties: &keyvault.RoleAssignmentProperties{
+					RoleDefinitionID: pointer.To(config.RoleDefinitionId),
+					PrincipalID:      pointer.To(config.PrincipalId),
+				},
+			}
+			if _, err := client.Create(ctx, id.ManagedHSMBaseUrl, id.Scope, id.Name, parameters); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+		Timeout: 30 * time.Minute,
+	}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleAssignmentResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			hsmClient := metadata.Client.KeyVault
+			client := metadata.Client.KeyVault.MHSMRoleAssignmentsClient
+			resourcesClient := metadata.Client.Resource
+
+			id, err := parse.ManagedHSMRoleAssignmentID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			managedHSMIdRaw, err := hsmClient.ManagedHSMIDFromBaseUrl(ctx, resourcesClient, id.ManagedHSMBaseUrl)
+			if err != nil {
+				return fmt.Errorf("retrieving the Resource ID of the Managed HSM at URL %q: %+v", id.ManagedHSMBaseUrl, err)
+			}
+			if managedHSMIdRaw == nil {
+				metadata.Logger.Infof("Unable to determine the Resource ID for the Managed HSM at URL %q - removing from state!", id.ManagedHSMBaseUrl)
+				return metadata.MarkAsGone(id)
+			}
+			managedHSMId, err := managedhsms.ParseManagedHSMID(*managedHSMIdRaw)
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, id.ManagedHSMBaseUrl, id.Scope, id.Name)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			state := KeyVaultManagedHardwareSecurityModuleRoleAssignmentResourceModel{
+				Name:         id.Name,
+				ManagedHSMId: managedHSMId.ID(),
+				Scope:        id.Scope,
+				ResourceId:   pointer.From(resp.ID),
+			}
+
+			if props := resp.Properties; props != nil {
+				state.RoleDefinitionId = pointer.From(props.RoleDefinitionID)
+				state.PrincipalId = pointer.From(props.PrincipalID)
+			}
+
+			return metadata.Encode(&state)
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleAssignmentResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.KeyVault.MHSMRoleAssignmentsClient
+
+			id, err := parse.ManagedHSMRoleAssignmentID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			locks.ByID(id.ID())
+			defer locks.UnlockByID(id.ID())
+
+			if resp, err := client.Delete(ctx, id.ManagedHSMBaseUrl, id.Scope, id.Name); err != nil {
+				if !utils.ResponseWasNotFound(resp.Response) {
+					return fmt.Errorf("deleting %s: %+v", id, err)
+				}
+			}
+
+			return nil
+		},
+		Timeout: 30 * time.Minute,
+	}
+}
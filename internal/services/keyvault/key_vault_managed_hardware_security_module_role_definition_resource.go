@@ -0,0 +1,379 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/keyvault/2023-02-01/managedhsms"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	keyVaultValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+type KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource struct{}
+
+var _ sdk.ResourceWithUpdate = KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource{}
+
+type KeyVaultManagedHardwareSecurityModuleRoleDefinitionResourceModel struct {
+	Name              string                                    `tfschema:"name"`
+	ManagedHSMId      string                                    `tfschema:"managed_hsm_id"`
+	RoleName          string                                    `tfschema:"role_name"`
+	Description       string                                    `tfschema:"description"`
+	Permission        []ManagedHSMRoleDefinitionPermissionModel `tfschema:"permission"`
+	ResourceManagerId string                                    `tfschema:"resource_manager_id"`
+	RoleType          string                                    `tfschema:"role_type"`
+}
+
+type ManagedHSMRoleDefinitionPermissionModel struct {
+	Actions        []string `tfschema:"actions"`
+	NotActions     []string `tfschema:"not_actions"`
+	DataActions    []string `tfschema:"data_actions"`
+	NotDataActions []string `tfschema:"not_data_actions"`
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource) Arguments() map[string]*pluginsdk.Schema {
+	dataActionsSchema := func() *pluginsdk.Schema {
+		dataActions := make([]string, 0)
+		for _, v := range keyvault.PossibleDataActionValues() {
+			dataActions = append(dataActions, string(v))
+		}
+
+		return &pluginsdk.Schema{
+			Type:     pluginsdk.TypeSet,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.StringInSlice(dataActions, false),
+			},
+		}
+	}
+
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IsUUID,
+		},
+
+		"managed_hsm_id": commonschema.ResourceIDReferenceRequiredForceNew(&managedhsms.ManagedHSMId{}),
+
+		"role_name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"description": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"permission": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"actions": {
+						Type:     pluginsdk.TypeSet,
+						Optional: true,
+						Elem: &pluginsdk.Schema{
+							Type:         pluginsdk.TypeString,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+
+					"not_actions": {
+						Type:     pluginsdk.TypeSet,
+						Optional: true,
+						Elem: &pluginsdk.Schema{
+							Type:         pluginsdk.TypeString,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+
+					"data_actions": dataActionsSchema(),
+
+					"not_data_actions": dataActionsSchema(),
+				},
+			},
+		},
+	}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"resource_manager_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"role_type": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource) ResourceType() string {
+	return "azurerm_key_vault_managed_hardware_security_module_role_definition"
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource) ModelObject() interface{} {
+	return &KeyVaultManagedHardwareSecurityModuleRoleDefinitionResourceModel{}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return keyVaultValidate.ManagedHSMRoleDefinitionID
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			hsmClient := metadata.Client.KeyVault
+			client := metadata.Client.KeyVault.MHSMRoleClient
+
+			var config KeyVaultManagedHardwareSecurityModuleRoleDefinitionResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			managedHSMId, err := managedhsms.ParseManagedHSMID(config.ManagedHSMId)
+			if err != nil {
+				return err
+			}
+
+			baseUri, err := hsmClient.BaseUriForManagedHSM(ctx, *managedHSMId)
+			if err != nil {
+				return fmt.Errorf("looking up the Base URI for Role Definition %q from %s: %+v", config.Name, *managedHSMId, err)
+			}
+
+			// custom Role Definitions can only be created at the global scope
+			id, err := parse.NewManagedHSMRoleDefinitionID(*baseUri, string(keyvault.RoleScopeGlobal), config.Name)
+			if err != nil {
+				return err
+			}
+
+			locks.ByID(id.ID())
+			defer locks.UnlockByID(id.ID())
+
+			existing, err := client.Get(ctx, id.ManagedHSMBaseUrl, id.Scope, id.Name)
+			if err != nil {
+				if !utils.ResponseWasNotFound(existing.Response) {
+					return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+				}
+			}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return tf.ImportAsExistsError(r.ResourceType(), id.ID())
+			}
+
+			parameters := keyvault.RoleDefinitionCreateParameters{
+				Properties: &keyvault.RoleDefinitionProperties{
+					RoleName:         pointer.To(config.RoleName),
+					Description:      pointer.To(config.Description),
+					RoleType:         keyvault.RoleTypeCustomRole,
+					Permissions:      expandManagedHSMRoleDefinitionPermissions(config.Permission),
+					AssignableScopes: &[]keyvault.RoleScope{keyvault.RoleScopeGlobal},
+				},
+			}
+
+			if _, err := client.CreateOrUpdate(ctx, id.ManagedHSMBaseUrl, id.Scope, id.Name, parameters); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+		Timeout: 30 * time.Minute,
+	}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			hsmClient := metadata.Client.KeyVault
+			client := metadata.Client.KeyVault.MHSMRoleClient
+			resourcesClient := metadata.Client.Resource
+
+			id, err := parse.ManagedHSMRoleDefinitionID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			managedHSMIdRaw, err := hsmClient.ManagedHSMIDFromBaseUrl(ctx, resourcesClient, id.ManagedHSMBaseUrl)
+			if err != nil {
+				return fmt.Errorf("retrieving the Resource ID of the Managed HSM at URL %q: %+v", id.ManagedHSMBaseUrl, err)
+			}
+			if managedHSMIdRaw == nil {
+				metadata.Logger.Infof("Unable to determine the Resource ID for the Managed HSM at URL %q - removing from state!", id.ManagedHSMBaseUrl)
+				return metadata.MarkAsGone(id)
+			}
+			managedHSMId, err := managedhsms.ParseManagedHSMID(*managedHSMIdRaw)
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, id.ManagedHSMBaseUrl, id.Scope, id.Name)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			state := KeyVaultManagedHardwareSecurityModuleRoleDefinitionResourceModel{
+				Name:              id.Name,
+				ManagedHSMId:      managedHSMId.ID(),
+				ResourceManagerId: pointer.From(resp.ID),
+			}
+
+			if props := resp.RoleDefinitionProperties; props != nil {
+				state.RoleName = pointer.From(props.RoleName)
+				state.Description = pointer.From(props.Description)
+				state.RoleType = string(props.RoleType)
+				state.Permission = flattenManagedHSMRoleDefinitionPermissions(props.Permissions)
+			}
+
+			return metadata.Encode(&state)
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.KeyVault.MHSMRoleClient
+
+			id, err := parse.ManagedHSMRoleDefinitionID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var config KeyVaultManagedHardwareSecurityModuleRoleDefinitionResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			locks.ByID(id.ID())
+			defer locks.UnlockByID(id.ID())
+
+			existing, err := client.Get(ctx, id.ManagedHSMBaseUrl, id.Scope, id.Name)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+			if existing.RoleDefinitionProperties == nil {
+				return fmt.Errorf("retrieving %s: `properties` was nil", id)
+			}
+
+			props := existing.RoleDefinitionProperties
+			if metadata.ResourceData.HasChange("role_name") {
+				props.RoleName = pointer.To(config.RoleName)
+			}
+			if metadata.ResourceData.HasChange("description") {
+				props.Description = pointer.To(config.Description)
+			}
+			if metadata.ResourceData.HasChange("permission") {
+				props.Permissions = expandManagedHSMRoleDefinitionPermissions(config.Permission)
+			}
+
+			parameters := keyvault.RoleDefinitionCreateParameters{
+				Properties: props,
+			}
+			if _, err := client.CreateOrUpdate(ctx, id.ManagedHSMBaseUrl, id.Scope, id.Name, parameters); err != nil {
+				return fmt.Errorf("updating %s: %+v", id, err)
+			}
+
+			return nil
+		},
+		Timeout: 30 * time.Minute,
+	}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.KeyVault.MHSMRoleClient
+
+			id, err := parse.ManagedHSMRoleDefinitionID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			locks.ByID(id.ID())
+			defer locks.UnlockByID(id.ID())
+
+			if resp, err := client.Delete(ctx, id.ManagedHSMBaseUrl, id.Scope, id.Name); err != nil {
+				if !utils.ResponseWasNotFound(resp.Response) {
+					return fmt.Errorf("deleting %s: %+v", id, err)
+				}
+			}
+
+			return nil
+		},
+		Timeout: 30 * time.Minute,
+	}
+}
+
+func expandManagedHSMRoleDefinitionPermissions(input []ManagedHSMRoleDefinitionPermissionModel) *[]keyvault.Permission {
+	results := make([]keyvault.Permission, 0)
+	for _, v := range input {
+		results = append(results, keyvault.Permission{
+			Actions:        pointer.To(v.Actions),
+			NotActions:     pointer.To(v.NotActions),
+			DataActions:    expandManagedHSMRoleDefinitionDataActions(v.DataActions),
+			NotDataActions: expandManagedHSMRoleDefinitionDataActions(v.NotDataActions),
+		})
+	}
+	return &results
+}
+
+func expandManagedHSMRoleDefinitionDataActions(input []string) *[]keyvault.DataAction {
+	results := make([]keyvault.DataAction, 0)
+	for _, v := range input {
+		results = append(results, keyvault.DataAction(v))
+	}
+	return &results
+}
+
+func flattenManagedHSMRoleDefinitionPermissions(input *[]keyvault.Permission) []ManagedHSMRoleDefinitionPermissionModel {
+	results := make([]ManagedHSMRoleDefinitionPermissionModel, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, v := range *input {
+		results = append(results, ManagedHSMRoleDefinitionPermissionModel{
+			Actions:        pointer.From(v.Actions),
+			NotActions:     pointer.From(v.NotActions),
+			DataActions:    flattenManagedHSMRoleDefinitionDataActions(v.DataActions),
+			NotDataActions: flattenManagedHSMRoleDefinitionDataActions(v.NotDataActions),
+		})
+	}
+	return results
+}
+
+func flattenManagedHSMRoleDefinitionDataActions(input *[]keyvault.DataAction) []string {
+	results := make([]string, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, v := range *input {
+		results = append(results, string(v))
+	}
+	return results
+}
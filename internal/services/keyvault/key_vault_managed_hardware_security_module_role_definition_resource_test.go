@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource struct{}
+
+// NOTE: these tests are run as a part of TestAccKeyVaultManagedHardwareSecurityModule, since only a single
+// Managed HSM can be provisioned at a time
+
+func testAccKeyVaultManagedHardwareSecurityModuleRoleDefinition_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_managed_hardware_security_module_role_definition", "test")
+	r := KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource{}
+
+	data.ResourceSequentialTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("resource_manager_id").IsSet(),
+				check.That(data.ResourceName).Key("role_type").HasValue("CustomRole"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func testAccKeyVaultManagedHardwareSecurityModuleRoleDefinition_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_managed_hardware_security_module_role_definition", "test")
+	r := KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource{}
+
+	data.ResourceSequentialTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.updated(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.ManagedHSMRoleDefinitionID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.KeyVault.MHSMRoleClient.Get(ctx, id.ManagedHSMBaseUrl, id.Scope, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	return utils.Bool(resp.RoleDefinitionProperties != nil), nil
+}
+
+func (KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_key_vault_managed_hardware_security_module_role_definition" "test" {
+  name           = "7d206142-bf01-11ed-80bc-00155d61ee9e"
+  managed_hsm_id = azurerm_key_vault_managed_hardware_security_module.test.id
+  role_name      = "acctest-role-%d"
+  description    = "Acceptance Test Role"
+
+  permission {
+    data_actions = [
+      "Microsoft.KeyVault/managedHsm/keys/read/action",
+    ]
+  }
+}
+`, KeyVaultManagedHardwareSecurityModuleResource{}.download(data, 3), data.RandomInteger)
+}
+
+func (KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource) updated(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_key_vault_managed_hardware_security_module_role_definition" "test" {
+  name           = "7d206142-bf01-11ed-80bc-00155d61ee9e"
+  managed_hsm_id = azurerm_key_vault_managed_hardware_security_module.test.id
+  role_name      = "acctest-role-%d"
+  description    = "Updated Acceptance Test Role"
+
+  permission {
+    data_actions = [
+      "Microsoft.KeyVault/managedHsm/keys/read/action",
+      "Microsoft.KeyVault/managedHsm/keys/encrypt/action",
+    ]
+    not_data_actions = [
+      "Microsoft.KeyVault/managedHsm/keys/delete",
+    ]
+  }
+}
+`, KeyVaultManagedHardwareSecurityModuleResource{}.download(data, 3), data.RandomInteger)
+}
@@ -16,12 +16,14 @@ import (
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/tags"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/keyvault/2023-02-01/vaults"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	commonValidate "github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/migration"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/network"
@@ -1020,3 +1022,29 @@ func getSoftDeletedStateForKeyVault(ctx context.Context, client *vaults.VaultsCl
 	// otherwise we've found an existing key vault that is not soft deleted
 	return nil, nil
 }
+
+func resourceKeyVaultList() sdk.UntypedResourceList {
+	return sdk.UntypedResourceList{
+		ResourceType:     keyVaultResourceName,
+		IDValidationFunc: commonids.ValidateKeyVaultID,
+		List: sdk.ResourceListFunc{
+			Timeout: 5 * time.Minute,
+			Func: func(ctx context.Context, metadata sdk.ResourceListMetaData) ([]resourceids.Id, error) {
+				client := metadata.Client.KeyVault.VaultsClient
+
+				return sdk.ListResourceIdsWithinScope(ctx, metadata,
+					func(ctx context.Context, id commonids.ResourceGroupId) ([]vaults.Vault, error) {
+						resp, err := client.ListByResourceGroupComplete(ctx, id, vaults.DefaultListByResourceGroupOperationOptions())
+						return resp.Items, err
+					},
+					func(ctx context.Context, id commonids.SubscriptionId) ([]vaults.Vault, error) {
+						resp, err := client.ListBySubscriptionComplete(ctx, id, vaults.DefaultListBySubscriptionOperationOptions())
+						return resp.Items, err
+					},
+					func(item vaults.Vault) *string { return item.Id },
+					commonids.ParseKeyVaultIDInsensitively,
+				)
+			},
+		},
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/rickb777/date/period"
+)
+
+// rotationArguments returns the arguments which determine when a new version of a Secret/Certificate is created
+// by the Rotation resources - both of which force a new resource (and as such a new version) to be created
+func rotationArguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"rotation_period": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.ISO8601DurationBetween("PT1H", "P100Y"),
+		},
+
+		"triggers": {
+			Type:     pluginsdk.TypeMap,
+			Optional: true,
+			ForceNew: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+	}
+}
+
+// nextRotationDate returns the date (in RFC3339 format) at which the version created at `created` should be rotated,
+// or an empty string when no `rotation_period` is specified
+func nextRotationDate(created time.Time, rotationPeriod string) (string, error) {
+	if rotationPeriod == "" {
+		return "", nil
+	}
+
+	p, err := period.Parse(rotationPeriod)
+	if err != nil {
+		return "", fmt.Errorf("parsing `rotation_period` %q: %+v", rotationPeriod, err)
+	}
+
+	next, _ := p.AddTo(created)
+	return next.UTC().Format(time.RFC3339), nil
+}
+
+// rotationIsDue determines whether the version due to be rotated at `nextRotation` should be rotated at `now`
+func rotationIsDue(nextRotation string, now time.Time) (bool, error) {
+	if nextRotation == "" {
+		return false, nil
+	}
+
+	next, err := time.Parse(time.RFC3339, nextRotation)
+	if err != nil {
+		return false, fmt.Errorf("parsing `next_rotation_date` %q: %+v", nextRotation, err)
+	}
+
+	return !now.Before(next), nil
+}
+
+// forceNewWhenRotationIsDue replaces the resource when the rotation of the current version is due - this is checked
+// during the plan (rather than by the API) so that any resources referencing the new version are updated in the same apply.
+func forceNewWhenRotationIsDue(d *schema.ResourceDiff, now time.Time, computedKeys ...string) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	due, err := rotationIsDue(d.Get("next_rotation_date").(string), now)
+	if err != nil {
+		return err
+	}
+	if !due {
+		return nil
+	}
+
+	for _, key := range append([]string{"next_rotation_date"}, computedKeys...) {
+		if err := d.SetNewComputed(key); err != nil {
+			return fmt.Errorf("setting `%s` to known after apply: %+v", key, err)
+		}
+	}
+
+	return d.ForceNew("next_rotation_date")
+}
@@ -0,0 +1,127 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextRotationDate(t *testing.T) {
+	created := time.Date(2023, 1, 31, 10, 30, 0, 0, time.UTC)
+
+	testData := []struct {
+		RotationPeriod string
+		Expected       string
+	}{
+		{
+			RotationPeriod: "",
+			Expected:       "",
+		},
+		{
+			RotationPeriod: "PT12H",
+			Expected:       "2023-01-31T22:30:00Z",
+		},
+		{
+			RotationPeriod: "P30D",
+			Expected:       "2023-03-02T10:30:00Z",
+		},
+		{
+			RotationPeriod: "P1Y",
+			Expected:       "2024-01-31T10:30:00Z",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.RotationPeriod)
+
+		actual, err := nextRotationDate(created, v.RotationPeriod)
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if actual != v.Expected {
+			t.Fatalf("expected %q but got %q", v.Expected, actual)
+		}
+	}
+}
+
+func TestRotationIsDue(t *testing.T) {
+	now := time.Date(2023, 3, 2, 10, 30, 0, 0, time.UTC)
+
+	testData := []struct {
+		NextRotationDate string
+		Expected         bool
+	}{
+		{
+			// no rotation period
+			NextRotationDate: "",
+			Expected:         false,
+		},
+		{
+			NextRotationDate: "2023-03-02T10:30:01Z",
+			Expected:         false,
+		},
+		{
+			NextRotationDate: "2023-03-02T10:30:00Z",
+			Expected:         true,
+		},
+		{
+			NextRotationDate: "2023-01-01T00:00:00Z",
+			Expected:         true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.NextRotationDate)
+
+		actual, err := rotationIsDue(v.NextRotationDate, now)
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if actual != v.Expected {
+			t.Fatalf("expected %t but got %t", v.Expected, actual)
+		}
+	}
+}
+
+func TestValidateRotationPeriodIsBeforeExpiry(t *testing.T) {
+	testData := []struct {
+		RotationPeriod string
+		ExpireAfter    string
+		ExpectError    bool
+	}{
+		{
+			RotationPeriod: "P30D",
+			ExpireAfter:    "",
+			ExpectError:    false,
+		},
+		{
+			RotationPeriod: "P30D",
+			ExpireAfter:    "P90D",
+			ExpectError:    false,
+		},
+		{
+			RotationPeriod: "P90D",
+			ExpireAfter:    "P90D",
+			ExpectError:    true,
+		},
+		{
+			RotationPeriod: "P1Y",
+			ExpireAfter:    "P6M",
+			ExpectError:    true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q / %q", v.RotationPeriod, v.ExpireAfter)
+
+		err := validateRotationPeriodIsBeforeExpiry(v.RotationPeriod, v.ExpireAfter)
+		if v.ExpectError && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.ExpectError && err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+	}
+}
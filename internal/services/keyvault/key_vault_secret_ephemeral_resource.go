@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+var _ sdk.EphemeralResource = KeyVaultSecretEphemeralResource{}
+
+type KeyVaultSecretEphemeralResource struct{}
+
+type KeyVaultSecretEphemeralResourceModel struct {
+	Name           string `tfschema:"name"`
+	KeyVaultId     string `tfschema:"key_vault_id"`
+	Version        string `tfschema:"version"`
+	Value          string `tfschema:"value"`
+	ContentType    string `tfschema:"content_type"`
+	NotBeforeDate  string `tfschema:"not_before_date"`
+	ExpirationDate string `tfschema:"expiration_date"`
+}
+
+func (KeyVaultSecretEphemeralResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validate.NestedItemName,
+		},
+
+		"key_vault_id": commonschema.ResourceIDReferenceRequired(commonids.KeyVaultId{}),
+
+		"version": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+		},
+	}
+}
+
+func (KeyVaultSecretEphemeralResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"value": {
+			Type:      pluginsdk.TypeString,
+			Computed:  true,
+			Sensitive: true,
+		},
+
+		"content_type": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"not_before_date": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"expiration_date": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (KeyVaultSecretEphemeralResource) ModelObject() interface{} {
+	return &KeyVaultSecretEphemeralResourceModel{}
+}
+
+func (KeyVaultSecretEphemeralResource) ResourceType() string {
+	return "azurerm_key_vault_secret"
+}
+
+func (KeyVaultSecretEphemeralResource) Open() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			keyVaultsClient := metadata.Client.KeyVault
+			client := metadata.Client.KeyVault.ManagementClient
+
+			var model KeyVaultSecretEphemeralResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			keyVaultId, err := commonids.ParseKeyVaultID(model.KeyVaultId)
+			if err != nil {
+				return err
+			}
+
+			keyVaultBaseUri, err := keyVaultsClient.BaseUriForKeyVault(ctx, *keyVaultId)
+			if err != nil {
+				return fmt.Errorf("looking up Secret %q vault url from id %q: %+v", model.Name, *keyVaultId, err)
+			}
+
+			resp, err := client.GetSecret(ctx, *keyVaultBaseUri, model.Name, model.Version)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return fmt.Errorf("KeyVault Secret %q (KeyVault URI %q) does not exist", model.Name, *keyVaultBaseUri)
+				}
+				return fmt.Errorf("retrieving Azure KeyVault Secret %s: %+v", model.Name, err)
+			}
+
+			// the version may have changed, so parse the updated id
+			respID, err := parse.ParseNestedItemID(pointer.From(resp.ID))
+			if err != nil {
+				return err
+			}
+
+			model.Version = respID.Version
+			model.Value = pointer.From(resp.Value)
+			model.ContentType = pointer.From(resp.ContentType)
+			if attributes := resp.Attributes; attributes != nil {
+				if notBefore := attributes.NotBefore; notBefore != nil {
+					model.NotBeforeDate = time.Time(*notBefore).Format(time.RFC3339)
+				}
+				if expires := attributes.Expires; expires != nil {
+					model.ExpirationDate = time.Time(*expires).Format(time.RFC3339)
+				}
+			}
+
+			return metadata.Encode(&model)
+		},
+	}
+}
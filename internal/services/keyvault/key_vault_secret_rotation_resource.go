@@ -0,0 +1,282 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	keyVaultValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/rickb777/date/period"
+	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+type KeyVaultSecretRotationResource struct{}
+
+var _ sdk.ResourceWithCustomizeDiff = KeyVaultSecretRotationResource{}
+
+type KeyVaultSecretRotationResourceModel struct {
+	KeyVaultSecretId string            `tfschema:"key_vault_secret_id"`
+	Value            string            `tfschema:"value"`
+	ContentType      string            `tfschema:"content_type"`
+	ExpireAfter      string            `tfschema:"expire_after"`
+	RotationPeriod   string            `tfschema:"rotation_period"`
+	Triggers         map[string]string `tfschema:"triggers"`
+	Version          string            `tfschema:"version"`
+	VersionlessId    string            `tfschema:"versionless_id"`
+	ExpirationDate   string            `tfschema:"expiration_date"`
+	NextRotationDate string            `tfschema:"next_rotation_date"`
+}
+
+func (r KeyVaultSecretRotationResource) Arguments() map[string]*pluginsdk.Schema {
+	arguments := map[string]*pluginsdk.Schema{
+		"key_vault_secret_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: keyVaultValidate.VersionlessNestedItemId,
+		},
+
+		"value": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			Sensitive:    true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"content_type": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+			ForceNew: true,
+		},
+
+		"expire_after": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.ISO8601DurationBetween("PT1H", "P100Y"),
+		},
+	}
+
+	for k, v := range rotationArguments() {
+		arguments[k] = v
+	}
+
+	return arguments
+}
+
+func (r KeyVaultSecretRotationResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"version": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"versionless_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"expiration_date": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"next_rotation_date": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r KeyVaultSecretRotationResource) ResourceType() string {
+	return "azurerm_key_vault_secret_rotation"
+}
+
+func (r KeyVaultSecretRotationResource) ModelObject() interface{} {
+	return &KeyVaultSecretRotationResourceModel{}
+}
+
+func (r KeyVaultSecretRotationResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return keyVaultValidate.NestedItemId
+}
+
+func (r KeyVaultSecretRotationResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.KeyVault.ManagementClient
+
+			var config KeyVaultSecretRotationResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			secretId, err := parse.ParseOptionallyVersionedNestedItemID(config.KeyVaultSecretId)
+			if err != nil {
+				return err
+			}
+
+			locks.ByName(secretId.VersionlessID(), r.ResourceType())
+			defer locks.UnlockByName(secretId.VersionlessID(), r.ResourceType())
+
+			existing, err := client.GetSecret(ctx, secretId.KeyVaultBaseUrl, secretId.Name, "")
+			if err != nil {
+				if utils.ResponseWasNotFound(existing.Response) {
+					return fmt.Errorf("the Secret %q was not found in the Key Vault at URI %q", secretId.Name, secretId.KeyVaultBaseUrl)
+				}
+				return fmt.Errorf("retrieving the latest version of Secret %q (Key Vault %q): %+v", secretId.Name, secretId.KeyVaultBaseUrl, err)
+			}
+
+			now := time.Now()
+			parameters := keyvault.SecretSetParameters{
+				Value:            pointer.To(config.Value),
+				ContentType:      existing.ContentType,
+				Tags:             existing.Tags,
+				SecretAttributes: &keyvault.SecretAttributes{},
+			}
+			if config.ContentType != "" {
+				parameters.ContentType = pointer.To(config.ContentType)
+			}
+			if config.ExpireAfter != "" {
+				p, err := period.Parse(config.ExpireAfter)
+				if err != nil {
+					return fmt.Errorf("parsing `expire_after` %q: %+v", config.ExpireAfter, err)
+				}
+				expires, _ := p.AddTo(now)
+				parameters.SecretAttributes.Expires = pointer.To(date.UnixTime(expires))
+			}
+
+			resp, err := client.SetSecret(ctx, secretId.KeyVaultBaseUrl, secretId.Name, parameters)
+			if err != nil {
+				return fmt.Errorf("creating a new version of Secret %q (Key Vault %q): %+v", secretId.Name, secretId.KeyVaultBaseUrl, err)
+			}
+			if resp.ID == nil {
+				return fmt.Errorf("creating a new version of Secret %q (Key Vault %q): `id` was nil", secretId.Name, secretId.KeyVaultBaseUrl)
+			}
+
+			id, err := parse.ParseNestedItemID(*resp.ID)
+			if err != nil {
+				return err
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+		Timeout: 30 * time.Minute,
+	}
+}
+
+func (r KeyVaultSecretRotationResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.KeyVault.ManagementClient
+
+			id, err := parse.ParseNestedItemID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var state KeyVaultSecretRotationResourceModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			resp, err := client.GetSecret(ctx, id.KeyVaultBaseUrl, id.Name, id.Version)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			state.KeyVaultSecretId = id.VersionlessID()
+			state.Version = id.Version
+			state.VersionlessId = id.VersionlessID()
+			state.Value = pointer.From(resp.Value)
+			state.ExpirationDate = ""
+			state.NextRotationDate = ""
+
+			if attributes := resp.Attributes; attributes != nil {
+				if v := attributes.Expires; v != nil {
+					state.ExpirationDate = time.Time(*v).Format(time.RFC3339)
+				}
+				if v := attributes.Created; v != nil {
+					next, err := nextRotationDate(time.Time(*v), state.RotationPeriod)
+					if err != nil {
+						return err
+					}
+					state.NextRotationDate = next
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
+
+func (r KeyVaultSecretRotationResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parse.ParseNestedItemID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			// versions of a Secret can't be deleted individually, so the version created by this resource is
+			// retained - and will be removed alongside the Secret itself
+			metadata.Logger.Infof("removing %s from the state - the version will be retained", id)
+			return nil
+		},
+		Timeout: 30 * time.Minute,
+	}
+}
+
+func (r KeyVaultSecretRotationResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			rd := metadata.ResourceDiff
+
+			if err := validateRotationPeriodIsBeforeExpiry(rd.Get("rotation_period").(string), rd.Get("expire_after").(string)); err != nil {
+				return err
+			}
+
+			return forceNewWhenRotationIsDue(rd, time.Now(), "version", "expiration_date")
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
+
+// validateRotationPeriodIsBeforeExpiry ensures that a new version is created before the current version expires
+func validateRotationPeriodIsBeforeExpiry(rotationPeriod, expireAfter string) error {
+	if rotationPeriod == "" || expireAfter == "" {
+		return nil
+	}
+
+	rotation, err := period.Parse(rotationPeriod)
+	if err != nil {
+		return fmt.Errorf("parsing `rotation_period` %q: %+v", rotationPeriod, err)
+	}
+	expiry, err := period.Parse(expireAfter)
+	if err != nil {
+		return fmt.Errorf("parsing `expire_after` %q: %+v", expireAfter, err)
+	}
+
+	if rotation.DurationApprox() >= expiry.DurationApprox() {
+		return fmt.Errorf("`rotation_period` (%s) must be shorter than `expire_after` (%s), so that a new version is created before the current version expires", rotationPeriod, expireAfter)
+	}
+
+	return nil
+}
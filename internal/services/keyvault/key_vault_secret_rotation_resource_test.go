@@ -0,0 +1,132 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type KeyVaultSecretRotationResource struct{}
+
+func TestAccKeyVaultSecretRotation_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_secret_rotation", "test")
+	r := KeyVaultSecretRotationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, "rick"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("version").Exists(),
+				check.That(data.ResourceName).Key("next_rotation_date").IsEmpty(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccKeyVaultSecretRotation_triggers(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_secret_rotation", "test")
+	r := KeyVaultSecretRotationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.triggers(data, "first"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("triggers"),
+		{
+			Config: r.triggers(data, "second"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("triggers"),
+	})
+}
+
+func TestAccKeyVaultSecretRotation_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_secret_rotation", "test")
+	r := KeyVaultSecretRotationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("expiration_date").Exists(),
+				check.That(data.ResourceName).Key("next_rotation_date").Exists(),
+			),
+		},
+		data.ImportStep("content_type", "expire_after", "rotation_period", "next_rotation_date"),
+	})
+}
+
+func (r KeyVaultSecretRotationResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.ParseNestedItemID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.KeyVault.ManagementClient.GetSecret(ctx, id.KeyVaultBaseUrl, id.Name, id.Version)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	return utils.Bool(resp.ID != nil), nil
+}
+
+func (r KeyVaultSecretRotationResource) basic(data acceptance.TestData, value string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_key_vault_secret_rotation" "test" {
+  key_vault_secret_id = azurerm_key_vault_secret.test.versionless_id
+  value               = "%s"
+}
+`, KeyVaultSecretResource{}.basic(data), value)
+}
+
+func (r KeyVaultSecretRotationResource) triggers(data acceptance.TestData, trigger string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_key_vault_secret_rotation" "test" {
+  key_vault_secret_id = azurerm_key_vault_secret.test.versionless_id
+  value               = "morty"
+
+  triggers = {
+    upstream = "%s"
+  }
+}
+`, KeyVaultSecretResource{}.basic(data), trigger)
+}
+
+func (r KeyVaultSecretRotationResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_key_vault_secret_rotation" "test" {
+  key_vault_secret_id = azurerm_key_vault_secret.test.versionless_id
+  value               = "<rick><morty /></rick>"
+  content_type        = "application/xml"
+  expire_after        = "P90D"
+  rotation_period     = "P30D"
+}
+`, KeyVaultSecretResource{}.basic(data))
+}
@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+type ManagedHSMKeyId struct {
+	ManagedHSMBaseUrl string
+	Name              string
+}
+
+func NewManagedHSMKeyID(managedHSMBaseUrl, name string) (*ManagedHSMKeyId, error) {
+	// example: https://example-hsm.managedhsm.azure.net/keys/example-key
+	managedHSMUrl, err := url.Parse(managedHSMBaseUrl)
+	if err != nil || managedHSMBaseUrl == "" {
+		return nil, fmt.Errorf("parsing %q: %+v", managedHSMBaseUrl, err)
+	}
+
+	if hostParts := strings.Split(managedHSMUrl.Host, ":"); len(hostParts) > 1 {
+		managedHSMUrl.Host = hostParts[0]
+	}
+
+	return &ManagedHSMKeyId{
+		ManagedHSMBaseUrl: managedHSMUrl.String(),
+		Name:              name,
+	}, nil
+}
+
+func (id ManagedHSMKeyId) String() string {
+	components := []string{
+		fmt.Sprintf("Base Url %q", id.ManagedHSMBaseUrl),
+		fmt.Sprintf("Name %q", id.Name),
+	}
+	return fmt.Sprintf("Managed HSM Key: (%s)", strings.Join(components, " / "))
+}
+
+func (id ManagedHSMKeyId) ID() string {
+	// example: https://example-hsm.managedhsm.azure.net/keys/example-key
+	segments := []string{
+		strings.TrimSuffix(id.ManagedHSMBaseUrl, "/"),
+		"keys",
+		id.Name,
+	}
+	return strings.Join(segments, "/")
+}
+
+// ManagedHSMKeyID parses a versionless Managed HSM Key ID into a ManagedHSMKeyId object
+func ManagedHSMKeyID(input string) (*ManagedHSMKeyId, error) {
+	idURL, err := url.ParseRequestURI(input)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse Managed HSM Key Id: %s", err)
+	}
+
+	if !strings.Contains(strings.ToLower(idURL.Host), ".managedhsm.") {
+		return nil, fmt.Errorf("expected a Managed HSM URI in the format `the-managed-hsm-name.managedhsm.**` but got %q", idURL.Host)
+	}
+
+	path := strings.TrimSuffix(strings.TrimPrefix(idURL.Path, "/"), "/")
+	components := strings.Split(path, "/")
+	if len(components) != 2 || components[0] != "keys" || components[1] == "" {
+		return nil, fmt.Errorf("Managed HSM Key ID path must be in the format '/keys/{name}', got %q", idURL.Path)
+	}
+
+	id := ManagedHSMKeyId{
+		ManagedHSMBaseUrl: fmt.Sprintf("%s://%s/", idURL.Scheme, idURL.Host),
+		Name:              components[1],
+	}
+
+	return &id, nil
+}
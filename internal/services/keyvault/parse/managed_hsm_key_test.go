@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+)
+
+var _ resourceids.Id = ManagedHSMKeyId{}
+
+func TestManagedHSMKeyIDFormatter(t *testing.T) {
+	actual, err := NewManagedHSMKeyID("https://example-hsm.managedhsm.azure.net:443", "example-key")
+	if err != nil {
+		t.Fatalf("Error occurred when creating ID: %+v", err)
+	}
+	expected := "https://example-hsm.managedhsm.azure.net/keys/example-key"
+	if actual.ID() != expected {
+		t.Fatalf("Expected %q but got %q", expected, actual.ID())
+	}
+}
+
+func TestManagedHSMKeyID(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Error    bool
+		Expected *ManagedHSMKeyId
+	}{
+		{
+			// empty
+			Input: "",
+			Error: true,
+		},
+		{
+			// key vault
+			Input: "https://my-keyvault.vault.azure.net/keys/example-key",
+			Error: true,
+		},
+		{
+			// missing name
+			Input: "https://example-hsm.managedhsm.azure.net/keys",
+			Error: true,
+		},
+		{
+			// versioned
+			Input: "https://example-hsm.managedhsm.azure.net/keys/example-key/fdf067c93bbb4b22bff4d8b7a9a56217",
+			Error: true,
+		},
+		{
+			// valid
+			Input: "https://example-hsm.managedhsm.azure.net/keys/example-key",
+			Expected: &ManagedHSMKeyId{
+				ManagedHSMBaseUrl: "https://example-hsm.managedhsm.azure.net/",
+				Name:              "example-key",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual, err := ManagedHSMKeyID(v.Input)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expect a value but got an error: %s", err)
+		}
+		if v.Error {
+			t.Fatal("Expect an error but didn't get one")
+		}
+
+		if actual.ManagedHSMBaseUrl != v.Expected.ManagedHSMBaseUrl {
+			t.Fatalf("Expected %q but got %q for ManagedHSMBaseUrl", v.Expected.ManagedHSMBaseUrl, actual.ManagedHSMBaseUrl)
+		}
+		if actual.Name != v.Expected.Name {
+			t.Fatalf("Expected %q but got %q for Name", v.Expected.Name, actual.Name)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+type ManagedHSMRoleAssignmentId struct {
+	ManagedHSMBaseUrl string
+	Scope             string
+	Name              string
+}
+
+func NewManagedHSMRoleAssignmentID(managedHSMBaseUrl, scope, name string) (*ManagedHSMRoleAssignmentId, error) {
+	// example: https://example-hsm.managedhsm.azure.net/keys/providers/Microsoft.Authorization/roleAssignments/00000000-0000-0000-0000-000000000000
+	managedHSMUrl, err := url.Parse(managedHSMBaseUrl)
+	if err != nil || managedHSMBaseUrl == "" {
+		return nil, fmt.Errorf("parsing %q: %+v", managedHSMBaseUrl, err)
+	}
+
+	if hostParts := strings.Split(managedHSMUrl.Host, ":"); len(hostParts) > 1 {
+		managedHSMUrl.Host = hostParts[0]
+	}
+
+	return &ManagedHSMRoleAssignmentId{
+		ManagedHSMBaseUrl: managedHSMUrl.String(),
+		Scope:             scope,
+		Name:              name,
+	}, nil
+}
+
+func (id ManagedHSMRoleAssignmentId) String() string {
+	components := []string{
+		fmt.Sprintf("Base Url %q", id.ManagedHSMBaseUrl),
+		fmt.Sprintf("Scope %q", id.Scope),
+		fmt.Sprintf("Name %q", id.Name),
+	}
+	return fmt.Sprintf("Managed HSM Role Assignment: (%s)", strings.Join(components, " / "))
+}
+
+func (id ManagedHSMRoleAssignmentId) ID() string {
+	// example: https://example-hsm.managedhsm.azure.net/keys/providers/Microsoft.Authorization/roleAssignments/00000000-0000-0000-0000-000000000000
+	return fmt.Sprintf("%s%s/providers/Microsoft.Authorization/roleAssignments/%s", strings.TrimSuffix(id.ManagedHSMBaseUrl, "/"), strings.TrimSuffix(id.Scope, "/"), id.Name)
+}
+
+func ManagedHSMRoleAssignmentID(input string) (*ManagedHSMRoleAssignmentId, error) {
+	idURL, err := url.ParseRequestURI(input)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse Managed HSM Role Assignment Id: %s", err)
+	}
+
+	scope, name, err := parseManagedHSMRoleItemPath(idURL, "roleAssignments")
+	if err != nil {
+		return nil, fmt.Errorf("parsing Managed HSM Role Assignment Id %q: %+v", input, err)
+	}
+
+	id := ManagedHSMRoleAssignmentId{
+		ManagedHSMBaseUrl: fmt.Sprintf("%s://%s/", idURL.Scheme, idURL.Host),
+		Scope:             scope,
+		Name:              name,
+	}
+
+	return &id, nil
+}
@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+type ManagedHSMRoleDefinitionId struct {
+	ManagedHSMBaseUrl string
+	Scope             string
+	Name              string
+}
+
+func NewManagedHSMRoleDefinitionID(managedHSMBaseUrl, scope, name string) (*ManagedHSMRoleDefinitionId, error) {
+	// example: https://example-hsm.managedhsm.azure.net/providers/Microsoft.Authorization/roleDefinitions/00000000-0000-0000-0000-000000000000
+	managedHSMUrl, err := url.Parse(managedHSMBaseUrl)
+	if err != nil || managedHSMBaseUrl == "" {
+		return nil, fmt.Errorf("parsing %q: %+v", managedHSMBaseUrl, err)
+	}
+
+	if hostParts := strings.Split(managedHSMUrl.Host, ":"); len(hostParts) > 1 {
+		managedHSMUrl.Host = hostParts[0]
+	}
+
+	return &ManagedHSMRoleDefinitionId{
+		ManagedHSMBaseUrl: managedHSMUrl.String(),
+		Scope:             scope,
+		Name:              name,
+	}, nil
+}
+
+func (id ManagedHSMRoleDefinitionId) String() string {
+	components := []string{
+		fmt.Sprintf("Base Url %q", id.ManagedHSMBaseUrl),
+		fmt.Sprintf("Scope %q", id.Scope),
+		fmt.Sprintf("Name %q", id.Name),
+	}
+	return fmt.Sprintf("Managed HSM Role Definition: (%s)", strings.Join(components, " / "))
+}
+
+func (id ManagedHSMRoleDefinitionId) ID() string {
+	// example: https://example-hsm.managedhsm.azure.net/providers/Microsoft.Authorization/roleDefinitions/00000000-0000-0000-0000-000000000000
+	return fmt.Sprintf("%s%s/providers/Microsoft.Authorization/roleDefinitions/%s", strings.TrimSuffix(id.ManagedHSMBaseUrl, "/"), strings.TrimSuffix(id.Scope, "/"), id.Name)
+}
+
+func ManagedHSMRoleDefinitionID(input string) (*ManagedHSMRoleDefinitionId, error) {
+	idURL, err := url.ParseRequestURI(input)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse Managed HSM Role Definition Id: %s", err)
+	}
+
+	scope, name, err := parseManagedHSMRoleItemPath(idURL, "roleDefinitions")
+	if err != nil {
+		return nil, fmt.Errorf("parsing Managed HSM Role Definition Id %q: %+v", input, err)
+	}
+
+	id := ManagedHSMRoleDefinitionId{
+		ManagedHSMBaseUrl: fmt.Sprintf("%s://%s/", idURL.Scheme, idURL.Host),
+		Scope:             scope,
+		Name:              name,
+	}
+
+	return &id, nil
+}
+
+// parseManagedHSMRoleItemPath splits the path of a Managed HSM Role Definition/Assignment ID into its scope and name,
+// e.g. `/keys/example/providers/Microsoft.Authorization/roleAssignments/{name}` has the scope `/keys/example`
+func parseManagedHSMRoleItemPath(idURL *url.URL, itemType string) (scope string, name string, err error) {
+	if !strings.Contains(strings.ToLower(idURL.Host), ".managedhsm.") {
+		return "", "", fmt.Errorf("expected a Managed HSM URI in the format `the-managed-hsm-name.managedhsm.**` but got %q", idURL.Host)
+	}
+
+	separator := fmt.Sprintf("/providers/Microsoft.Authorization/%s/", itemType)
+	path := strings.TrimSuffix(idURL.Path, "/")
+	index := strings.LastIndex(path, separator)
+	if index == -1 {
+		return "", "", fmt.Errorf("expected the path to contain %q but got %q", separator, idURL.Path)
+	}
+
+	scope = path[:index]
+	if scope == "" {
+		scope = "/"
+	}
+	name = path[index+len(separator):]
+	if name == "" || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("expected a name to follow %q but got %q", separator, idURL.Path)
+	}
+
+	return scope, name, nil
+}
@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+)
+
+var _ resourceids.Id = ManagedHSMRoleDefinitionId{}
+
+func TestManagedHSMRoleDefinitionIDFormatter(t *testing.T) {
+	actual, err := NewManagedHSMRoleDefinitionID("https://example-hsm.managedhsm.azure.net:443", "/", "00000000-0000-0000-0000-000000000000")
+	if err != nil {
+		t.Fatalf("Error occurred when creating ID: %+v", err)
+	}
+	expected := "https://example-hsm.managedhsm.azure.net/providers/Microsoft.Authorization/roleDefinitions/00000000-0000-0000-0000-000000000000"
+	if actual.ID() != expected {
+		t.Fatalf("Expected %q but got %q", expected, actual.ID())
+	}
+}
+
+func TestManagedHSMRoleDefinitionID(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Error    bool
+		Expected *ManagedHSMRoleDefinitionId
+	}{
+		{
+			// empty
+			Input: "",
+			Error: true,
+		},
+		{
+			// key vault
+			Input: "https://my-keyvault.vault.azure.net/providers/Microsoft.Authorization/roleDefinitions/00000000-0000-0000-0000-000000000000",
+			Error: true,
+		},
+		{
+			// missing name
+			Input: "https://example-hsm.managedhsm.azure.net/providers/Microsoft.Authorization/roleDefinitions/",
+			Error: true,
+		},
+		{
+			// wrong type
+			Input: "https://example-hsm.managedhsm.azure.net/providers/Microsoft.Authorization/roleThings/00000000-0000-0000-0000-000000000000",
+			Error: true,
+		},
+		{
+			// valid
+			Input: "https://example-hsm.managedhsm.azure.net/providers/Microsoft.Authorization/roleDefinitions/00000000-0000-0000-0000-000000000000",
+			Expected: &ManagedHSMRoleDefinitionId{
+				ManagedHSMBaseUrl: "https://example-hsm.managedhsm.azure.net/",
+				Scope:             "/",
+				Name:              "00000000-0000-0000-0000-000000000000",
+			},
+		},
+		{
+			// valid, scoped to keys
+			Input: "https://example-hsm.managedhsm.azure.net/keys/providers/Microsoft.Authorization/roleDefinitions/00000000-0000-0000-0000-000000000000",
+			Expected: &ManagedHSMRoleDefinitionId{
+				ManagedHSMBaseUrl: "https://example-hsm.managedhsm.azure.net/",
+				Scope:             "/keys",
+				Name:              "00000000-0000-0000-0000-000000000000",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual, err := ManagedHSMRoleDefinitionID(v.Input)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expect a value but got an error: %s", err)
+		}
+		if v.Error {
+			t.Fatal("Expect an error but didn't get one")
+		}
+
+		if actual.ManagedHSMBaseUrl != v.Expected.ManagedHSMBaseUrl {
+			t.Fatalf("Expected %q but got %q for ManagedHSMBaseUrl", v.Expected.ManagedHSMBaseUrl, actual.ManagedHSMBaseUrl)
+		}
+		if actual.Scope != v.Expected.Scope {
+			t.Fatalf("Expected %q but got %q for Scope", v.Expected.Scope, actual.Scope)
+		}
+		if actual.Name != v.Expected.Name {
+			t.Fatalf("Expected %q but got %q for Name", v.Expected.Name, actual.Name)
+		}
+	}
+}
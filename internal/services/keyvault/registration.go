@@ -12,11 +12,30 @@ type Registration struct{}
 
 var _ sdk.TypedServiceRegistrationWithAGitHubLabel = Registration{}
 var _ sdk.UntypedServiceRegistrationWithAGitHubLabel = Registration{}
+var _ sdk.TypedServiceRegistrationWithEphemeralResources = Registration{}
+var _ sdk.TypedServiceRegistrationWithFramework = Registration{}
+var _ sdk.TypedServiceRegistrationWithResourceProviders = Registration{}
+var _ sdk.UntypedServiceRegistrationWithResourceProviders = Registration{}
+var _ sdk.UntypedServiceRegistrationWithListableResources = Registration{}
 
 func (r Registration) AssociatedGitHubLabel() string {
 	return "service/key-vault"
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.KeyVault",
+	}
+}
+
+// ListableResources returns the Untyped Resources within this Service which support being listed
+func (r Registration) ListableResources() []sdk.UntypedResourceList {
+	return []sdk.UntypedResourceList{
+		resourceKeyVaultList(),
+	}
+}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "KeyVault"
@@ -69,5 +88,24 @@ func (r Registration) DataSources() []sdk.DataSource {
 func (r Registration) Resources() []sdk.Resource {
 	return []sdk.Resource{
 		KeyVaultCertificateContactsResource{},
+		KeyVaultCertificateRotationResource{},
+		KeyVaultManagedHardwareSecurityModuleKeyResource{},
+		KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource{},
+		KeyVaultSecretRotationResource{},
+	}
+}
+
+// FrameworkResources returns the Resources supported by this Service which are served through the Plugin Framework
+func (r Registration) FrameworkResources() []sdk.Resource {
+	return []sdk.Resource{
+		KeyVaultManagedHardwareSecurityModuleRoleAssignmentResource{},
+	}
+}
+
+func (r Registration) EphemeralResources() []sdk.EphemeralResource {
+	return []sdk.EphemeralResource{
+		KeyVaultCertificateEphemeralResource{},
+		KeyVaultKeyEphemeralResource{},
+		KeyVaultSecretEphemeralResource{},
 	}
 }
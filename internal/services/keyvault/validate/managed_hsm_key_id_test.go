@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import "testing"
+
+func TestManagedHSMKeyID(t *testing.T) {
+	cases := []struct {
+		Input       string
+		ExpectError bool
+	}{
+		{
+			Input:       "https://example-hsm.managedhsm.azure.net/keys/example-key",
+			ExpectError: false,
+		},
+		{
+			// empty
+			Input:       "",
+			ExpectError: true,
+		},
+		{
+			// key vault
+			Input:       "https://my-keyvault.vault.azure.net/keys/example-key",
+			ExpectError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing Value %s", tc.Input)
+		_, errors := ManagedHSMKeyID(tc.Input, "test")
+		valid := len(errors) == 0
+
+		if tc.ExpectError && valid {
+			t.Fatalf("Expected an error but got none for %q", tc.Input)
+		}
+		if !tc.ExpectError && !valid {
+			t.Fatalf("Expected no error but got %+v for %q", errors, tc.Input)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import "testing"
+
+func TestManagedHSMRoleAssignmentID(t *testing.T) {
+	cases := []struct {
+		Input       string
+		ExpectError bool
+	}{
+		{
+			Input:       "https://example-hsm.managedhsm.azure.net/keys/providers/Microsoft.Authorization/roleAssignments/00000000-0000-0000-0000-000000000000",
+			ExpectError: false,
+		},
+		{
+			// empty
+			Input:       "",
+			ExpectError: true,
+		},
+		{
+			// key vault
+			Input:       "https://my-keyvault.vault.azure.net/keys/providers/Microsoft.Authorization/roleAssignments/00000000-0000-0000-0000-000000000000",
+			ExpectError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing Value %s", tc.Input)
+		_, errors := ManagedHSMRoleAssignmentID(tc.Input, "test")
+		valid := len(errors) == 0
+
+		if tc.ExpectError && valid {
+			t.Fatalf("Expected an error but got none for %q", tc.Input)
+		}
+		if !tc.ExpectError && !valid {
+			t.Fatalf("Expected no error but got %+v for %q", errors, tc.Input)
+		}
+	}
+}
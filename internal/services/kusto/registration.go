@@ -13,6 +13,8 @@ type Registration struct{}
 var _ sdk.UntypedServiceRegistrationWithAGitHubLabel = Registration{}
 
 var _ sdk.TypedServiceRegistrationWithAGitHubLabel = Registration{}
+var _ sdk.TypedServiceRegistrationWithResourceProviders = Registration{}
+var _ sdk.UntypedServiceRegistrationWithResourceProviders = Registration{}
 
 func (r Registration) DataSources() []sdk.DataSource {
 	return []sdk.DataSource{}
@@ -28,6 +30,13 @@ func (r Registration) AssociatedGitHubLabel() string {
 	return "service/kusto"
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.Kusto",
+	}
+}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "Kusto"
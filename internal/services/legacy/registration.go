@@ -4,11 +4,21 @@
 package legacy
 
 import (
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
 type Registration struct{}
 
+var _ sdk.UntypedServiceRegistrationWithResourceProviders = Registration{}
+
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.Compute",
+	}
+}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "Legacy"
@@ -9,8 +9,10 @@ import (
 )
 
 var (
-	_ sdk.TypedServiceRegistrationWithAGitHubLabel = Registration{}
-	_ sdk.UntypedServiceRegistration               = Registration{}
+	_ sdk.TypedServiceRegistrationWithAGitHubLabel        = Registration{}
+	_ sdk.UntypedServiceRegistration                      = Registration{}
+	_ sdk.TypedServiceRegistrationWithResourceProviders   = Registration{}
+	_ sdk.UntypedServiceRegistrationWithResourceProviders = Registration{}
 )
 
 type Registration struct{}
@@ -19,6 +21,13 @@ func (r Registration) AssociatedGitHubLabel() string {
 	return "service/load-balancers"
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.Network",
+	}
+}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "Load Balancer"
@@ -8,6 +8,7 @@ import (
 )
 
 var _ sdk.TypedServiceRegistrationWithAGitHubLabel = Registration{}
+var _ sdk.TypedServiceRegistrationWithResourceProviders = Registration{}
 
 type Registration struct {
 	autoRegistration
@@ -21,6 +22,13 @@ func (r Registration) WebsiteCategories() []string {
 	return r.autoRegistration.WebsiteCategories()
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.LoadTestService",
+	}
+}
+
 func (r Registration) Name() string {
 	return r.autoRegistration.Name()
 }
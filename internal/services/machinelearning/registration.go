@@ -12,11 +12,20 @@ type Registration struct{}
 
 var _ sdk.TypedServiceRegistration = Registration{}
 var _ sdk.UntypedServiceRegistrationWithAGitHubLabel = Registration{}
+var _ sdk.TypedServiceRegistrationWithResourceProviders = Registration{}
+var _ sdk.UntypedServiceRegistrationWithResourceProviders = Registration{}
 
 func (r Registration) AssociatedGitHubLabel() string {
 	return "service/machine-learning"
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.MachineLearningServices",
+	}
+}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "Machine Learning"
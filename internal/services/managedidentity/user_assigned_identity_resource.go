@@ -4,6 +4,12 @@
 package managedidentity
 
 import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/managedidentity/2023-01-31/managedidentities"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/managedidentity/migration"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
@@ -11,6 +17,7 @@ import (
 
 var _ sdk.Resource = UserAssignedIdentityResource{}
 var _ sdk.ResourceWithStateMigration = UserAssignedIdentityResource{}
+var _ sdk.ResourceWithList = UserAssignedIdentityResource{}
 
 func (r UserAssignedIdentityResource) StateUpgraders() sdk.StateUpgradeData {
 	return sdk.StateUpgradeData{
@@ -20,3 +27,25 @@ func (r UserAssignedIdentityResource) StateUpgraders() sdk.StateUpgradeData {
 		},
 	}
 }
+
+func (r UserAssignedIdentityResource) List() sdk.ResourceListFunc {
+	return sdk.ResourceListFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceListMetaData) ([]resourceids.Id, error) {
+			client := metadata.Client.ManagedIdentity.V20230131.ManagedIdentities
+
+			return sdk.ListResourceIdsWithinScope(ctx, metadata,
+				func(ctx context.Context, id commonids.ResourceGroupId) ([]managedidentities.Identity, error) {
+					resp, err := client.UserAssignedIdentitiesListByResourceGroupComplete(ctx, id)
+					return resp.Items, err
+				},
+				func(ctx context.Context, id commonids.SubscriptionId) ([]managedidentities.Identity, error) {
+					resp, err := client.UserAssignedIdentitiesListBySubscriptionComplete(ctx, id)
+					return resp.Items, err
+				},
+				func(item managedidentities.Identity) *string { return item.Id },
+				commonids.ParseUserAssignedIdentityIDInsensitively,
+			)
+		},
+	}
+}
@@ -10,11 +10,19 @@ import (
 type Registration struct{}
 
 var _ sdk.TypedServiceRegistrationWithAGitHubLabel = Registration{}
+var _ sdk.TypedServiceRegistrationWithResourceProviders = Registration{}
 
 func (r Registration) AssociatedGitHubLabel() string {
 	return "service/mobile-network"
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.MobileNetwork",
+	}
+}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "Mobile Network"
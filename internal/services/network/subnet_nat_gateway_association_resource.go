@@ -74,12 +74,15 @@ func resourceSubnetNatGatewayAssociationCreate(d *pluginsdk.ResourceData, meta i
 		return fmt.Errorf("parsing NAT gateway id '%s': %+v", natGatewayId, err)
 	}
 
-	locks.ByName(parsedGatewayId.Name, natGatewayResourceName)
-	defer locks.UnlockByName(parsedGatewayId.Name, natGatewayResourceName)
-	locks.ByName(parsedSubnetId.VirtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(parsedSubnetId.VirtualNetworkName, VirtualNetworkResourceName)
-	locks.ByName(parsedSubnetId.SubnetName, SubnetResourceName)
-	defer locks.UnlockByName(parsedSubnetId.SubnetName, SubnetResourceName)
+	locksToAcquire := []locks.Lock{
+		{Name: parsedGatewayId.Name, ResourceType: natGatewayResourceName},
+		{Name: parsedSubnetId.VirtualNetworkName, ResourceType: VirtualNetworkResourceName},
+		{Name: parsedSubnetId.SubnetName, ResourceType: SubnetResourceName},
+	}
+	if err := locks.MultipleWithContext(ctx, locksToAcquire...); err != nil {
+		return err
+	}
+	defer locks.UnlockMultiple(locksToAcquire...)
 
 	subnet, err := client.Get(ctx, parsedSubnetId.ResourceGroupName, parsedSubnetId.VirtualNetworkName, parsedSubnetId.SubnetName, "")
 	if err != nil {
@@ -209,10 +212,15 @@ func resourceSubnetNatGatewayAssociationDelete(d *pluginsdk.ResourceData, meta i
 		return err
 	}
 
-	locks.ByName(parsedGatewayId.Name, natGatewayResourceName)
-	defer locks.UnlockByName(parsedGatewayId.Name, natGatewayResourceName)
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
+	locksToAcquire := []locks.Lock{
+		{Name: parsedGatewayId.Name, ResourceType: natGatewayResourceName},
+		{Name: id.VirtualNetworkName, ResourceType: VirtualNetworkResourceName},
+		{Name: id.SubnetName, ResourceType: SubnetResourceName},
+	}
+	if err := locks.MultipleWithContext(ctx, locksToAcquire...); err != nil {
+		return err
+	}
+	defer locks.UnlockMultiple(locksToAcquire...)
 
 	// ensure we get the latest state
 	subnet, err = client.Get(ctx, id.ResourceGroupName, id.VirtualNetworkName, id.SubnetName, "")
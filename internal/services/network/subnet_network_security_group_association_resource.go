@@ -77,14 +77,15 @@ func resourceSubnetNetworkSecurityGroupAssociationCreate(d *pluginsdk.ResourceDa
 		return err
 	}
 
-	locks.ByName(parsedNetworkSecurityGroupId.Name, networkSecurityGroupResourceName)
-	defer locks.UnlockByName(parsedNetworkSecurityGroupId.Name, networkSecurityGroupResourceName)
-
-	locks.ByName(parsedSubnetId.VirtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(parsedSubnetId.VirtualNetworkName, VirtualNetworkResourceName)
-
-	locks.ByName(parsedSubnetId.SubnetName, SubnetResourceName)
-	defer locks.UnlockByName(parsedSubnetId.SubnetName, SubnetResourceName)
+	locksToAcquire := []locks.Lock{
+		{Name: parsedNetworkSecurityGroupId.Name, ResourceType: networkSecurityGroupResourceName},
+		{Name: parsedSubnetId.VirtualNetworkName, ResourceType: VirtualNetworkResourceName},
+		{Name: parsedSubnetId.SubnetName, ResourceType: SubnetResourceName},
+	}
+	if err := locks.MultipleWithContext(ctx, locksToAcquire...); err != nil {
+		return err
+	}
+	defer locks.UnlockMultiple(locksToAcquire...)
 
 	subnet, err := client.Get(ctx, parsedSubnetId.ResourceGroupName, parsedSubnetId.VirtualNetworkName, parsedSubnetId.SubnetName, "")
 	if err != nil {
@@ -222,14 +223,15 @@ func resourceSubnetNetworkSecurityGroupAssociationDelete(d *pluginsdk.ResourceDa
 		return err
 	}
 
-	locks.ByName(parsedNetworkSecurityGroupId.Name, networkSecurityGroupResourceName)
-	defer locks.UnlockByName(parsedNetworkSecurityGroupId.Name, networkSecurityGroupResourceName)
-
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
-
-	locks.ByName(id.SubnetName, SubnetResourceName)
-	defer locks.UnlockByName(id.SubnetName, SubnetResourceName)
+	locksToAcquire := []locks.Lock{
+		{Name: parsedNetworkSecurityGroupId.Name, ResourceType: networkSecurityGroupResourceName},
+		{Name: id.VirtualNetworkName, ResourceType: VirtualNetworkResourceName},
+		{Name: id.SubnetName, ResourceType: SubnetResourceName},
+	}
+	if err := locks.MultipleWithContext(ctx, locksToAcquire...); err != nil {
+		return err
+	}
+	defer locks.UnlockMultiple(locksToAcquire...)
 
 	// then re-retrieve it to ensure we've got the latest state
 	read, err = client.Get(ctx, id.ResourceGroupName, id.VirtualNetworkName, id.SubnetName, "")
@@ -284,8 +284,14 @@ func resourceSubnetCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 		return tf.ImportAsExistsError("azurerm_subnet", id.ID())
 	}
 
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
+	locksToAcquire := []locks.Lock{
+		{Name: id.VirtualNetworkName, ResourceType: VirtualNetworkResourceName},
+		{Name: id.SubnetName, ResourceType: SubnetResourceName},
+	}
+	if err := locks.MultipleWithContext(ctx, locksToAcquire...); err != nil {
+		return err
+	}
+	defer locks.UnlockMultiple(locksToAcquire...)
 
 	properties := network.SubnetPropertiesFormat{}
 	if value, ok := d.GetOk("address_prefixes"); ok {
@@ -432,11 +438,14 @@ func resourceSubnetUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
-
-	locks.ByName(id.SubnetName, SubnetResourceName)
-	defer locks.UnlockByName(id.SubnetName, SubnetResourceName)
+	locksToAcquire := []locks.Lock{
+		{Name: id.VirtualNetworkName, ResourceType: VirtualNetworkResourceName},
+		{Name: id.SubnetName, ResourceType: SubnetResourceName},
+	}
+	if err := locks.MultipleWithContext(ctx, locksToAcquire...); err != nil {
+		return err
+	}
+	defer locks.UnlockMultiple(locksToAcquire...)
 
 	existing, err := client.Get(ctx, id.ResourceGroupName, id.VirtualNetworkName, id.SubnetName, "")
 	if err != nil {
@@ -643,11 +652,14 @@ func resourceSubnetDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
-
-	locks.ByName(id.SubnetName, SubnetResourceName)
-	defer locks.UnlockByName(id.SubnetName, SubnetResourceName)
+	locksToAcquire := []locks.Lock{
+		{Name: id.VirtualNetworkName, ResourceType: VirtualNetworkResourceName},
+		{Name: id.SubnetName, ResourceType: SubnetResourceName},
+	}
+	if err := locks.MultipleWithContext(ctx, locksToAcquire...); err != nil {
+		return err
+	}
+	defer locks.UnlockMultiple(locksToAcquire...)
 
 	future, err := client.Delete(ctx, id.ResourceGroupName, id.VirtualNetworkName, id.SubnetName)
 	if err != nil {
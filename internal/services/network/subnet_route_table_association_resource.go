@@ -76,15 +76,19 @@ func resourceSubnetRouteTableAssociationCreate(d *pluginsdk.ResourceData, meta i
 		return err
 	}
 
-	locks.ByName(parsedRouteTableId.RouteTableName, routeTableResourceName)
-	defer locks.UnlockByName(parsedRouteTableId.RouteTableName, routeTableResourceName)
-
 	subnetName := parsedSubnetId.SubnetName
 	virtualNetworkName := parsedSubnetId.VirtualNetworkName
 	resourceGroup := parsedSubnetId.ResourceGroupName
 
-	locks.ByName(virtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(virtualNetworkName, VirtualNetworkResourceName)
+	locksToAcquire := []locks.Lock{
+		{Name: parsedRouteTableId.RouteTableName, ResourceType: routeTableResourceName},
+		{Name: virtualNetworkName, ResourceType: VirtualNetworkResourceName},
+		{Name: subnetName, ResourceType: SubnetResourceName},
+	}
+	if err := locks.MultipleWithContext(ctx, locksToAcquire...); err != nil {
+		return err
+	}
+	defer locks.UnlockMultiple(locksToAcquire...)
 
 	subnet, err := client.Get(ctx, resourceGroup, virtualNetworkName, subnetName, "")
 	if err != nil {
@@ -228,11 +232,15 @@ func resourceSubnetRouteTableAssociationDelete(d *pluginsdk.ResourceData, meta i
 		return err
 	}
 
-	locks.ByName(parsedRouteTableId.RouteTableName, routeTableResourceName)
-	defer locks.UnlockByName(parsedRouteTableId.RouteTableName, routeTableResourceName)
-
-	locks.ByName(virtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(virtualNetworkName, VirtualNetworkResourceName)
+	locksToAcquire := []locks.Lock{
+		{Name: parsedRouteTableId.RouteTableName, ResourceType: routeTableResourceName},
+		{Name: virtualNetworkName, ResourceType: VirtualNetworkResourceName},
+		{Name: subnetName, ResourceType: SubnetResourceName},
+	}
+	if err := locks.MultipleWithContext(ctx, locksToAcquire...); err != nil {
+		return err
+	}
+	defer locks.UnlockMultiple(locksToAcquire...)
 
 	// then re-retrieve it to ensure we've got the latest state
 	read, err = client.Get(ctx, resourceGroup, virtualNetworkName, subnetName, "")
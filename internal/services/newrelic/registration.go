@@ -10,9 +10,17 @@ import (
 type Registration struct{}
 
 var (
-	_ sdk.TypedServiceRegistration = Registration{}
+	_ sdk.TypedServiceRegistration                      = Registration{}
+	_ sdk.TypedServiceRegistrationWithResourceProviders = Registration{}
 )
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"NewRelic.Observability",
+	}
+}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "New Relic"
@@ -17,6 +17,13 @@ func (r Registration) WebsiteCategories() []string {
 	}
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.Orbital",
+	}
+}
+
 func (r Registration) Name() string {
 	return "Orbital"
 }
@@ -34,3 +41,4 @@ func (r Registration) Resources() []sdk.Resource {
 }
 
 var _ sdk.TypedServiceRegistration = Registration{}
+var _ sdk.TypedServiceRegistrationWithResourceProviders = Registration{}
@@ -13,9 +13,17 @@ func (r Registration) AssociatedGitHubLabel() string {
 }
 
 var (
-	_ sdk.TypedServiceRegistrationWithAGitHubLabel = Registration{}
+	_ sdk.TypedServiceRegistrationWithAGitHubLabel      = Registration{}
+	_ sdk.TypedServiceRegistrationWithResourceProviders = Registration{}
 )
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"PaloAltoNetworks.Cloudngfw",
+	}
+}
+
 func (r Registration) Name() string {
 	return "Palo Alto"
 }
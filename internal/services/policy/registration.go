@@ -9,8 +9,10 @@ import (
 )
 
 var (
-	_ sdk.TypedServiceRegistrationWithAGitHubLabel = Registration{}
-	_ sdk.UntypedServiceRegistration               = Registration{}
+	_ sdk.TypedServiceRegistrationWithAGitHubLabel        = Registration{}
+	_ sdk.UntypedServiceRegistration                      = Registration{}
+	_ sdk.TypedServiceRegistrationWithResourceProviders   = Registration{}
+	_ sdk.UntypedServiceRegistrationWithResourceProviders = Registration{}
 )
 
 func (r Registration) AssociatedGitHubLabel() string {
@@ -34,6 +36,15 @@ func (r Registration) Resources() []sdk.Resource {
 	}
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.Authorization",
+		"Microsoft.GuestConfiguration",
+		"Microsoft.PolicyInsights",
+	}
+}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "Policy"
@@ -4,15 +4,25 @@
 package powerbi
 
 import (
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
 type Registration struct{}
 
+var _ sdk.UntypedServiceRegistrationWithResourceProviders = Registration{}
+
 func (r Registration) AssociatedGitHubLabel() string {
 	return "service/power-bi"
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.PowerBIDedicated",
+	}
+}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "PowerBI"
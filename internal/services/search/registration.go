@@ -11,14 +11,23 @@ import (
 type Registration struct{}
 
 var (
-	_ sdk.UntypedServiceRegistrationWithAGitHubLabel = Registration{}
-	_ sdk.TypedServiceRegistration                   = Registration{}
+	_ sdk.UntypedServiceRegistrationWithAGitHubLabel      = Registration{}
+	_ sdk.TypedServiceRegistration                        = Registration{}
+	_ sdk.TypedServiceRegistrationWithResourceProviders   = Registration{}
+	_ sdk.UntypedServiceRegistrationWithResourceProviders = Registration{}
 )
 
 func (r Registration) AssociatedGitHubLabel() string {
 	return "service/search"
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.Search",
+	}
+}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "Search"
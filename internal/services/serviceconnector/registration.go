@@ -10,6 +10,7 @@ import (
 type Registration struct{}
 
 var _ sdk.TypedServiceRegistration = Registration{}
+var _ sdk.TypedServiceRegistrationWithResourceProviders = Registration{}
 
 func (r Registration) AssociatedGitHubLabel() string {
 	return "service/service-connector"
@@ -31,6 +32,13 @@ func (r Registration) Resources() []sdk.Resource {
 	}
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.ServiceLinker",
+	}
+}
+
 func (r Registration) Name() string {
 	return "ServiceConnector"
 }
@@ -13,6 +13,8 @@ type Registration struct{}
 var _ sdk.TypedServiceRegistration = Registration{}
 
 var _ sdk.UntypedServiceRegistrationWithAGitHubLabel = Registration{}
+var _ sdk.TypedServiceRegistrationWithResourceProviders = Registration{}
+var _ sdk.UntypedServiceRegistrationWithResourceProviders = Registration{}
 
 func (r Registration) AssociatedGitHubLabel() string {
 	return "service/service-fabric-managed-cluster"
@@ -28,6 +30,13 @@ func (r Registration) Resources() []sdk.Resource {
 	}
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.ServiceFabric",
+	}
+}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "Service Fabric Managed Clusters"
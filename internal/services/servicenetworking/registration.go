@@ -7,6 +7,7 @@ import (
 type Registration struct{}
 
 var _ sdk.TypedServiceRegistration = Registration{}
+var _ sdk.TypedServiceRegistrationWithResourceProviders = Registration{}
 
 func (r Registration) AssociatedGitHubLabel() string {
 	return "service/service-networking"
@@ -23,6 +24,13 @@ func (r Registration) Resources() []sdk.Resource {
 	}
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.ServiceNetworking",
+	}
+}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "Service Networking"
@@ -0,0 +1,411 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/giovanni/storage/2020-08-04/blob/blobs"
+	"github.com/tombuildsstuff/giovanni/storage/2020-08-04/blob/containers"
+)
+
+const (
+	// blobDirectoryBlockSize is the size of each block when uploading a file in blocks, files up to this size
+	// are uploaded in a single request
+	blobDirectoryBlockSize int64 = 4 * 1024 * 1024
+
+	// blobDirectoryMaxBlocks is the maximum number of blocks within a Block Blob
+	blobDirectoryMaxBlocks int64 = 50000
+
+	blobDirectoryDefaultContentType = "application/octet-stream"
+)
+
+// BlobDirectorySync mirrors the files within a local directory into the Blobs within a Storage Container
+// whose names start with Prefix
+type BlobDirectorySync struct {
+	BlobsClient      *blobs.Client
+	ContainersClient *containers.Client
+
+	AccountName   string
+	ContainerName string
+	Prefix        string
+
+	CacheControl string
+	ContentTypes map[string]string
+	Parallelism  int
+	Source       string
+
+	// UpdateProperties specifies that the properties (e.g. the Content Type) of the Blobs whose content
+	// is unchanged should also be updated
+	UpdateProperties bool
+}
+
+// Sync uploads the files within Source whose content differs from the Blob with the same name,
+// and deletes the Blobs which don't exist within Source
+func (s BlobDirectorySync) Sync(ctx context.Context) error {
+	local, err := hashBlobDirectorySource(s.Source)
+	if err != nil {
+		return err
+	}
+
+	remote, err := s.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	toUpload, toDelete, unchanged := diffBlobDirectory(local, remote)
+	log.Printf("[DEBUG] Syncing %q to Container %q / Account %q (Prefix %q): uploading %d files, deleting %d blobs", s.Source, s.ContainerName, s.AccountName, s.Prefix, len(toUpload), len(toDelete))
+
+	uploads := make([]func() error, 0)
+	for _, name := range toUpload {
+		name := name
+		uploads = append(uploads, func() error {
+			return s.upload(ctx, name, local[name])
+		})
+	}
+	if s.UpdateProperties {
+		for _, name := range unchanged {
+			name := name
+			uploads = append(uploads, func() error {
+				return s.setProperties(ctx, name, local[name])
+			})
+		}
+	}
+	if err := s.runInParallel(uploads); err != nil {
+		return err
+	}
+
+	deletes := make([]func() error, 0)
+	for _, name := range toDelete {
+		name := name
+		deletes = append(deletes, func() error {
+			return s.delete(ctx, name)
+		})
+	}
+	return s.runInParallel(deletes)
+}
+
+// List returns the MD5 hash (hex encoded) of each Blob whose name starts with Prefix, keyed by the
+// name of the Blob without the Prefix - the hash is empty when it isn't available
+func (s BlobDirectorySync) List(ctx context.Context) (map[string]string, error) {
+	output := make(map[string]string)
+
+	input := containers.ListBlobsInput{
+		MaxResults: utils.Int(5000),
+	}
+	if s.Prefix != "" {
+		input.Prefix = utils.String(s.Prefix)
+	}
+	for {
+		result, err := s.ContainersClient.ListBlobs(ctx, s.AccountName, s.ContainerName, input)
+		if err != nil {
+			return nil, fmt.Errorf("listing Blobs in Container %q / Account %q (Prefix %q): %+v", s.ContainerName, s.AccountName, s.Prefix, err)
+		}
+
+		for _, blob := range result.Blobs.Blobs {
+			name := strings.TrimPrefix(blob.Name, s.Prefix)
+			hash := ""
+			if blob.Properties != nil && blob.Properties.ContentMD5 != nil && *blob.Properties.ContentMD5 != "" {
+				if v, err := convertBase64ToHexEncoding(*blob.Properties.ContentMD5); err == nil {
+					hash = v
+				}
+			}
+			output[name] = hash
+		}
+
+		if result.NextMarker == nil || *result.NextMarker == "" {
+			break
+		}
+		input.Marker = result.NextMarker
+	}
+
+	return output, nil
+}
+
+// DeleteAll deletes all of the Blobs whose name starts with Prefix
+func (s BlobDirectorySync) DeleteAll(ctx context.Context) error {
+	remote, err := s.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	deletes := make([]func() error, 0)
+	for name := range remote {
+		name := name
+		deletes = append(deletes, func() error {
+			return s.delete(ctx, name)
+		})
+	}
+	return s.runInParallel(deletes)
+}
+
+// blobDirectoryFile is a file within the local directory being synced
+type blobDirectoryFile struct {
+	path string
+	size int64
+	md5  []byte
+}
+
+// hashBlobDirectorySource returns the files within the directory `source` (recursively), keyed by the
+// path of the file relative to `source` using forward slashes - which is the name of the Blob without the Prefix
+func hashBlobDirectorySource(source string) (map[string]blobDirectoryFile, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, fmt.Errorf("reading the source directory %q: %+v", source, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("expected the source %q to be a directory", source)
+	}
+
+	output := make(map[string]blobDirectoryFile)
+	err = filepath.WalkDir(source, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(source, filePath)
+		if err != nil {
+			return err
+		}
+
+		file, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		hash := md5.New() // Azure uses the MD5 hash of the content (`Content-MD5`) for Blobs
+		size, err := io.Copy(hash, file)
+		if err != nil {
+			return fmt.Errorf("hashing %q: %+v", filePath, err)
+		}
+
+		output[filepath.ToSlash(relativePath)] = blobDirectoryFile{
+			path: filePath,
+			size: size,
+			md5:  hash.Sum(nil),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading the source directory %q: %+v", source, err)
+	}
+
+	return output, nil
+}
+
+// flattenBlobDirectoryFiles returns the MD5 hash (hex encoded) of each file, keyed by the name of the Blob without the Prefix
+func flattenBlobDirectoryFiles(input map[string]blobDirectoryFile) map[string]interface{} {
+	output := make(map[string]interface{}, len(input))
+	for name, file := range input {
+		output[name] = hex.EncodeToString(file.md5)
+	}
+	return output
+}
+
+// diffBlobDirectory returns the (sorted) names of the files which need to be uploaded, the Blobs which need to be
+// deleted, and the files whose content is unchanged
+func diffBlobDirectory(local map[string]blobDirectoryFile, remote map[string]string) (toUpload []string, toDelete []string, unchanged []string) {
+	for name, file := range local {
+		if hash, ok := remote[name]; ok && strings.EqualFold(hash, hex.EncodeToString(file.md5)) {
+			unchanged = append(unchanged, name)
+			continue
+		}
+		toUpload = append(toUpload, name)
+	}
+	for name := range remote {
+		if _, ok := local[name]; !ok {
+			toDelete = append(toDelete, name)
+		}
+	}
+
+	sort.Strings(toUpload)
+	sort.Strings(toDelete)
+	sort.Strings(unchanged)
+	return toUpload, toDelete, unchanged
+}
+
+// blobDirectoryContentType returns the Content Type for the Blob `name`, based on its extension - using the
+// overrides in `contentTypes` (keyed by the extension, including the leading `.`) where specified
+func blobDirectoryContentType(name string, contentTypes map[string]string) string {
+	extension := strings.ToLower(path.Ext(name))
+	if extension == "" {
+		return blobDirectoryDefaultContentType
+	}
+
+	for k, v := range contentTypes {
+		if strings.EqualFold(k, extension) {
+			return v
+		}
+	}
+
+	if v := mime.TypeByExtension(extension); v != "" {
+		return v
+	}
+	return blobDirectoryDefaultContentType
+}
+
+func (s BlobDirectorySync) upload(ctx context.Context, name string, file blobDirectoryFile) error {
+	blobName := s.Prefix + name
+	contentMD5 := base64.StdEncoding.EncodeToString(file.md5)
+	contentType := blobDirectoryContentType(name, s.ContentTypes)
+
+	source, err := os.Open(file.path)
+	if err != nil {
+		return fmt.Errorf("opening %q: %+v", file.path, err)
+	}
+	defer source.Close()
+
+	if file.size <= blobDirectoryBlockSize {
+		content, err := io.ReadAll(source)
+		if err != nil {
+			return fmt.Errorf("reading %q: %+v", file.path, err)
+		}
+
+		input := blobs.PutBlockBlobInput{
+			CacheControl: s.cacheControl(),
+			Content:      &content,
+			ContentMD5:   utils.String(contentMD5),
+			ContentType:  utils.String(contentType),
+		}
+		if _, err := s.BlobsClient.PutBlockBlob(ctx, s.AccountName, s.ContainerName, blobName, input); err != nil {
+			return fmt.Errorf("uploading %q to Blob %q (Container %q / Account %q): %+v", file.path, blobName, s.ContainerName, s.AccountName, err)
+		}
+		return nil
+	}
+
+	// the block size is increased for (very) large files, since a Block Blob is limited to 50,000 blocks
+	blockSize := blobDirectoryBlockSize
+	if file.size > blockSize*blobDirectoryMaxBlocks {
+		blockSize = (file.size + blobDirectoryMaxBlocks - 1) / blobDirectoryMaxBlocks
+	}
+
+	blockIds := make([]blobs.BlockID, 0)
+	uploads := make([]func() error, 0)
+	for offset, index := int64(0), 0; offset < file.size; offset, index = offset+blockSize, index+1 {
+		// the Block IDs must all be the same length
+		blockId := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%06d", index)))
+		blockIds = append(blockIds, blobs.BlockID{Value: blockId})
+
+		section := io.NewSectionReader(source, offset, blockSize)
+		uploads = append(uploads, func() error {
+			content, err := io.ReadAll(section)
+			if err != nil {
+				return fmt.Errorf("reading %q: %+v", file.path, err)
+			}
+
+			input := blobs.PutBlockInput{
+				BlockID: blockId,
+				Content: content,
+			}
+			if _, err := s.BlobsClient.PutBlock(ctx, s.AccountName, s.ContainerName, blobName, input); err != nil {
+				return fmt.Errorf("uploading a block of %q to Blob %q (Container %q / Account %q): %+v", file.path, blobName, s.ContainerName, s.AccountName, err)
+			}
+			return nil
+		})
+	}
+	if err := s.runInParallel(uploads); err != nil {
+		return err
+	}
+
+	input := blobs.PutBlockListInput{
+		BlockList: blobs.BlockList{
+			LatestBlockIDs: blockIds,
+		},
+		CacheControl: s.cacheControl(),
+		ContentMD5:   utils.String(contentMD5),
+		ContentType:  utils.String(contentType),
+	}
+	if _, err := s.BlobsClient.PutBlockList(ctx, s.AccountName, s.ContainerName, blobName, input); err != nil {
+		return fmt.Errorf("committing the blocks of %q to Blob %q (Container %q / Account %q): %+v", file.path, blobName, s.ContainerName, s.AccountName, err)
+	}
+
+	return nil
+}
+
+func (s BlobDirectorySync) setProperties(ctx context.Context, name string, file blobDirectoryFile) error {
+	blobName := s.Prefix + name
+
+	// `ContentMD5` must be specified, since otherwise it's removed from the Blob
+	input := blobs.SetPropertiesInput{
+		CacheControl: s.cacheControl(),
+		ContentMD5:   utils.String(base64.StdEncoding.EncodeToString(file.md5)),
+		ContentType:  utils.String(blobDirectoryContentType(name, s.ContentTypes)),
+	}
+	if _, err := s.BlobsClient.SetProperties(ctx, s.AccountName, s.ContainerName, blobName, input); err != nil {
+		return fmt.Errorf("updating the Properties for Blob %q (Container %q / Account %q): %+v", blobName, s.ContainerName, s.AccountName, err)
+	}
+
+	return nil
+}
+
+func (s BlobDirectorySync) delete(ctx context.Context, name string) error {
+	blobName := s.Prefix + name
+
+	input := blobs.DeleteInput{
+		DeleteSnapshots: true,
+	}
+	if resp, err := s.BlobsClient.Delete(ctx, s.AccountName, s.ContainerName, blobName, input); err != nil && !utils.ResponseWasNotFound(resp) {
+		return fmt.Errorf("deleting Blob %q (Container %q / Account %q): %+v", blobName, s.ContainerName, s.AccountName, err)
+	}
+
+	return nil
+}
+
+func (s BlobDirectorySync) cacheControl() *string {
+	if s.CacheControl == "" {
+		return nil
+	}
+	return utils.String(s.CacheControl)
+}
+
+// runInParallel calls each of the functions using (up to) Parallelism workers, returning the first error
+func (s BlobDirectorySync) runInParallel(funcs []func() error) error {
+	workerCount := s.Parallelism
+	if workerCount < 1 {
+		workerCount = 1
+	}
+
+	queue := make(chan func() error, len(funcs))
+	for _, f := range funcs {
+		queue <- f
+	}
+	close(queue)
+
+	errors := make(chan error, len(funcs))
+	wg := &sync.WaitGroup{}
+	for i := 0; i < workerCount; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for f := range queue {
+				if err := f(); err != nil {
+					errors <- err
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errors)
+
+	return <-errors
+}
@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDiffBlobDirectory(t *testing.T) {
+	source := t.TempDir()
+	files := map[string]string{
+		"index.html":       "<html></html>",
+		"css/site.css":     "body {}",
+		"js/nested/app.js": "console.log('hello')",
+	}
+	for name, content := range files {
+		filePath := filepath.Join(source, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+			t.Fatalf("creating directory: %+v", err)
+		}
+		if err := os.WriteFile(filePath, []byte(content), 0o600); err != nil {
+			t.Fatalf("writing file: %+v", err)
+		}
+	}
+
+	local, err := hashBlobDirectorySource(source)
+	if err != nil {
+		t.Fatalf("hashing: %+v", err)
+	}
+	actualFiles := flattenBlobDirectoryFiles(local)
+	if len(actualFiles) != len(files) {
+		t.Fatalf("expected %d files but got %+v", len(files), actualFiles)
+	}
+	if actual := actualFiles["index.html"]; actual != "c83301425b2ad1d496473a5ff3d9ecca" {
+		t.Fatalf("expected the MD5 hash of `index.html` to be %q but got %q", "c83301425b2ad1d496473a5ff3d9ecca", actual)
+	}
+
+	remote := map[string]string{
+		// unchanged (the hash is compared case-insensitively)
+		"index.html": "C83301425B2AD1D496473A5FF3D9ECCA",
+		// changed
+		"css/site.css": "00000000000000000000000000000000",
+		// removed locally
+		"old/removed.txt": "",
+	}
+	toUpload, toDelete, unchanged := diffBlobDirectory(local, remote)
+	if expected := []string{"css/site.css", "js/nested/app.js"}; !reflect.DeepEqual(toUpload, expected) {
+		t.Fatalf("expected %+v to be uploaded but got %+v", expected, toUpload)
+	}
+	if expected := []string{"old/removed.txt"}; !reflect.DeepEqual(toDelete, expected) {
+		t.Fatalf("expected %+v to be deleted but got %+v", expected, toDelete)
+	}
+	if expected := []string{"index.html"}; !reflect.DeepEqual(unchanged, expected) {
+		t.Fatalf("expected %+v to be unchanged but got %+v", expected, unchanged)
+	}
+}
+
+func TestBlobDirectoryContentType(t *testing.T) {
+	contentTypes := map[string]string{
+		".JS":  "text/javascript",
+		".bin": "application/x-example",
+	}
+
+	testData := map[string]string{
+		"index.html":      "text/html; charset=utf-8",
+		"js/app.js":       "text/javascript",
+		"data/large.bin":  "application/x-example",
+		"images/logo.PNG": "image/png",
+		"LICENSE":         "application/octet-stream",
+	}
+	for name, expected := range testData {
+		if actual := blobDirectoryContentType(name, contentTypes); actual != expected {
+			t.Fatalf("expected the Content Type for %q to be %q but got %q", name, expected, actual)
+		}
+	}
+}
@@ -45,6 +45,7 @@ type Client struct {
 
 	ResourceManager *storage_v2023_01_01.Client
 
+	dataPlaneEndpoints        dataPlaneEndpoints
 	resourceManagerAuthorizer autorest.Authorizer
 	storageAdAuth             *autorest.Authorizer
 }
@@ -53,11 +54,18 @@ func NewClient(options *common.ClientOptions) (*Client, error) {
 	accountsClient := storage.NewAccountsClientWithBaseURI(options.ResourceManagerEndpoint, options.SubscriptionId)
 	options.ConfigureClient(&accountsClient.Client, options.ResourceManagerAuthorizer)
 
+	dataPlaneEndpoints, err := newDataPlaneEndpoints(options.AzureEnvironment.StorageEndpointSuffix, options.StorageDataPlane)
+	if err != nil {
+		return nil, fmt.Errorf("building the Data Plane Endpoints for Storage: %+v", err)
+	}
+
 	fileSystemsClient := filesystems.NewWithEnvironment(options.AzureEnvironment)
 	options.ConfigureClient(&fileSystemsClient.Client, options.StorageAuthorizer)
+	dataPlaneEndpoints.configureClient(&fileSystemsClient.Client)
 
 	adlsGen2PathsClient := paths.NewWithEnvironment(options.AzureEnvironment)
 	options.ConfigureClient(&adlsGen2PathsClient.Client, options.StorageAuthorizer)
+	dataPlaneEndpoints.configureClient(&adlsGen2PathsClient.Client)
 
 	blobServicesClient := storage.NewBlobServicesClientWithBaseURI(options.ResourceManagerEndpoint, options.SubscriptionId)
 	options.ConfigureClient(&blobServicesClient.Client, options.ResourceManagerAuthorizer)
@@ -110,6 +118,7 @@ func NewClient(options *common.ClientOptions) (*Client, error) {
 		SyncServiceClient:           syncServiceClient,
 		SyncGroupsClient:            syncGroupsClient,
 
+		dataPlaneEndpoints:        *dataPlaneEndpoints,
 		resourceManagerAuthorizer: options.ResourceManagerAuthorizer,
 	}
 
@@ -124,6 +133,7 @@ func (client Client) AccountsDataPlaneClient(ctx context.Context, account accoun
 	if client.storageAdAuth != nil {
 		accountsClient := accounts.NewWithEnvironment(client.Environment)
 		accountsClient.Client.Authorizer = *client.storageAdAuth
+		client.dataPlaneEndpoints.configureClient(&accountsClient.Client)
 		return &accountsClient, nil
 	}
 
@@ -139,6 +149,7 @@ func (client Client) AccountsDataPlaneClient(ctx context.Context, account accoun
 
 	accountsClient := accounts.NewWithEnvironment(client.Environment)
 	accountsClient.Client.Authorizer = storageAuth
+	client.dataPlaneEndpoints.configureClient(&accountsClient.Client)
 	return &accountsClient, nil
 }
 
@@ -146,6 +157,7 @@ func (client Client) BlobsClient(ctx context.Context, account accountDetails) (*
 	if client.storageAdAuth != nil {
 		blobsClient := blobs.NewWithEnvironment(client.Environment)
 		blobsClient.Client.Authorizer = *client.storageAdAuth
+		client.dataPlaneEndpoints.configureClient(&blobsClient.Client)
 		return &blobsClient, nil
 	}
 
@@ -161,6 +173,7 @@ func (client Client) BlobsClient(ctx context.Context, account accountDetails) (*
 
 	blobsClient := blobs.NewWithEnvironment(client.Environment)
 	blobsClient.Client.Authorizer = storageAuth
+	client.dataPlaneEndpoints.configureClient(&blobsClient.Client)
 	return &blobsClient, nil
 }
 
@@ -168,8 +181,9 @@ func (client Client) ContainersClient(ctx context.Context, account accountDetail
 	if client.storageAdAuth != nil {
 		containersClient := containers.NewWithEnvironment(client.Environment)
 		containersClient.Client.Authorizer = *client.storageAdAuth
-		shim := shim.NewDataPlaneStorageContainerWrapper(&containersClient)
-		return shim, nil
+		client.dataPlaneEndpoints.configureClient(&containersClient.Client)
+		dataPlane := shim.NewDataPlaneStorageContainerWrapper(&containersClient)
+		return shim.NewStorageContainerWrapperWithFallback(dataPlane, client.ResourceManager.BlobContainers, client.SubscriptionId), nil
 	}
 
 	accountKey, err := account.AccountKey(ctx, client)
@@ -184,9 +198,36 @@ func (client Client) ContainersClient(ctx context.Context, account accountDetail
 
 	containersClient := containers.NewWithEnvironment(client.Environment)
 	containersClient.Client.Authorizer = storageAuth
+	client.dataPlaneEndpoints.configureClient(&containersClient.Client)
+
+	dataPlane := shim.NewDataPlaneStorageContainerWrapper(&containersClient)
+	return shim.NewStorageContainerWrapperWithFallback(dataPlane, client.ResourceManager.BlobContainers, client.SubscriptionId), nil
+}
+
+// ContainersDataPlaneClient returns the Data Plane client for Storage Containers, which (unlike ContainersClient) allows
+// the Blobs within a Container to be listed
+func (client Client) ContainersDataPlaneClient(ctx context.Context, account accountDetails) (*containers.Client, error) {
+	if client.storageAdAuth != nil {
+		containersClient := containers.NewWithEnvironment(client.Environment)
+		containersClient.Client.Authorizer = *client.storageAdAuth
+		client.dataPlaneEndpoints.configureClient(&containersClient.Client)
+		return &containersClient, nil
+	}
+
+	accountKey, err := account.AccountKey(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Account Key: %s", err)
+	}
 
-	shim := shim.NewDataPlaneStorageContainerWrapper(&containersClient)
-	return shim, nil
+	storageAuth, err := autorest.NewSharedKeyAuthorizer(account.name, *accountKey, autorest.SharedKey)
+	if err != nil {
+		return nil, fmt.Errorf("building Authorizer: %+v", err)
+	}
+
+	containersClient := containers.NewWithEnvironment(client.Environment)
+	containersClient.Client.Authorizer = storageAuth
+	client.dataPlaneEndpoints.configureClient(&containersClient.Client)
+	return &containersClient, nil
 }
 
 func (client Client) FileShareDirectoriesClient(ctx context.Context, account accountDetails) (*directories.Client, error) {
@@ -204,6 +245,7 @@ func (client Client) FileShareDirectoriesClient(ctx context.Context, account acc
 
 	directoriesClient := directories.NewWithEnvironment(client.Environment)
 	directoriesClient.Client.Authorizer = storageAuth
+	client.dataPlaneEndpoints.configureClient(&directoriesClient.Client)
 	return &directoriesClient, nil
 }
 
@@ -222,6 +264,7 @@ func (client Client) FileShareFilesClient(ctx context.Context, account accountDe
 
 	filesClient := files.NewWithEnvironment(client.Environment)
 	filesClient.Client.Authorizer = storageAuth
+	client.dataPlaneEndpoints.configureClient(&filesClient.Client)
 	return &filesClient, nil
 }
 
@@ -240,15 +283,19 @@ func (client Client) FileSharesClient(ctx context.Context, account accountDetail
 
 	sharesClient := shares.NewWithEnvironment(client.Environment)
 	sharesClient.Client.Authorizer = storageAuth
-	shim := shim.NewDataPlaneStorageShareWrapper(&sharesClient)
-	return shim, nil
+	client.dataPlaneEndpoints.configureClient(&sharesClient.Client)
+	dataPlane := shim.NewDataPlaneStorageShareWrapper(&sharesClient)
+	resourceManager := shim.NewResourceManagerStorageShareWrapper(client.ResourceManager.FileShares, client.SubscriptionId)
+	return shim.NewStorageShareWrapperWithFallback(dataPlane, resourceManager), nil
 }
 
 func (client Client) QueuesClient(ctx context.Context, account accountDetails) (shim.StorageQueuesWrapper, error) {
 	if client.storageAdAuth != nil {
 		queueClient := queues.NewWithEnvironment(client.Environment)
 		queueClient.Client.Authorizer = *client.storageAdAuth
-		return shim.NewDataPlaneStorageQueueWrapper(&queueClient), nil
+		client.dataPlaneEndpoints.configureClient(&queueClient.Client)
+		dataPlane := shim.NewDataPlaneStorageQueueWrapper(&queueClient)
+		return shim.NewStorageQueueWrapperWithFallback(dataPlane, client.ResourceManager.QueueService, client.SubscriptionId), nil
 	}
 
 	accountKey, err := account.AccountKey(ctx, client)
@@ -263,7 +310,9 @@ func (client Client) QueuesClient(ctx context.Context, account accountDetails) (
 
 	queuesClient := queues.NewWithEnvironment(client.Environment)
 	queuesClient.Client.Authorizer = storageAuth
-	return shim.NewDataPlaneStorageQueueWrapper(&queuesClient), nil
+	client.dataPlaneEndpoints.configureClient(&queuesClient.Client)
+	dataPlane := shim.NewDataPlaneStorageQueueWrapper(&queuesClient)
+	return shim.NewStorageQueueWrapperWithFallback(dataPlane, client.ResourceManager.QueueService, client.SubscriptionId), nil
 }
 
 func (client Client) TableEntityClient(ctx context.Context, account accountDetails) (*entities.Client, error) {
@@ -281,6 +330,7 @@ func (client Client) TableEntityClient(ctx context.Context, account accountDetai
 
 	entitiesClient := entities.NewWithEnvironment(client.Environment)
 	entitiesClient.Client.Authorizer = storageAuth
+	client.dataPlaneEndpoints.configureClient(&entitiesClient.Client)
 	return &entitiesClient, nil
 }
 
@@ -299,6 +349,7 @@ func (client Client) TablesClient(ctx context.Context, account accountDetails) (
 
 	tablesClient := tables.NewWithEnvironment(client.Environment)
 	tablesClient.Client.Authorizer = storageAuth
-	shim := shim.NewDataPlaneStorageTableWrapper(&tablesClient)
-	return shim, nil
+	client.dataPlaneEndpoints.configureClient(&tablesClient.Client)
+	dataPlane := shim.NewDataPlaneStorageTableWrapper(&tablesClient)
+	return shim.NewStorageTableWrapperWithFallback(dataPlane, client.ResourceManager.TableService, client.SubscriptionId), nil
 }
@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+)
+
+// dataPlaneEndpoints redirects the requests made to the Storage Data Plane API's (which are built using the
+// Storage Endpoint Suffix for the Environment) to the Domain Suffix/Account Endpoints from the Provider block
+type dataPlaneEndpoints struct {
+	environmentSuffix string
+	domainSuffix      string
+
+	// accounts is keyed by `{accountName}.{service}`, e.g. `example.blob`
+	accounts map[string]*url.URL
+}
+
+func newDataPlaneEndpoints(environmentSuffix string, input common.StorageDataPlaneOptions) (*dataPlaneEndpoints, error) {
+	output := dataPlaneEndpoints{
+		environmentSuffix: strings.ToLower(environmentSuffix),
+		domainSuffix:      strings.ToLower(input.DomainSuffix),
+		accounts:          map[string]*url.URL{},
+	}
+
+	for accountName, endpoints := range input.AccountEndpoints {
+		services := map[string]string{
+			"blob":  endpoints.Blob,
+			"dfs":   endpoints.Dfs,
+			"file":  endpoints.File,
+			"queue": endpoints.Queue,
+			"table": endpoints.Table,
+		}
+		for service, endpoint := range services {
+			if endpoint == "" {
+				continue
+			}
+
+			uri, err := url.Parse(endpoint)
+			if err != nil {
+				return nil, fmt.Errorf("parsing the %s endpoint %q for Storage Account %q: %+v", service, endpoint, accountName, err)
+			}
+			if uri.Scheme == "" || uri.Host == "" {
+				return nil, fmt.Errorf("the %s endpoint %q for Storage Account %q must be an absolute URI", service, endpoint, accountName)
+			}
+
+			output.accounts[strings.ToLower(fmt.Sprintf("%s.%s", accountName, service))] = uri
+		}
+	}
+
+	return &output, nil
+}
+
+// configureClient redirects the requests sent by the specified Data Plane client, as required
+func (e dataPlaneEndpoints) configureClient(c *autorest.Client) {
+	if e.domainSuffix == "" && len(e.accounts) == 0 {
+		return
+	}
+
+	inspector := e.withEndpoint()
+	if existing := c.RequestInspector; existing != nil {
+		c.RequestInspector = func(p autorest.Preparer) autorest.Preparer {
+			return inspector(existing(p))
+		}
+		return
+	}
+	c.RequestInspector = inspector
+}
+
+func (e dataPlaneEndpoints) withEndpoint() autorest.PrepareDecorator {
+	return func(p autorest.Preparer) autorest.Preparer {
+		return autorest.PreparerFunc(func(r *http.Request) (*http.Request, error) {
+			r, err := p.Prepare(r)
+			if err != nil {
+				return r, err
+			}
+
+			// the Shared Key signature is built from the Account Name and the path, so it's unaffected by the host changing
+			if scheme, host := e.resolve(r.URL); host != "" {
+				r.URL.Scheme = scheme
+				r.URL.Host = host
+				r.Host = host
+			}
+			return r, nil
+		})
+	}
+}
+
+// resolve returns the scheme and host which the request to the specified URI should be sent to - or
+// an empty host when the request should be sent as-is
+func (e dataPlaneEndpoints) resolve(uri *url.URL) (string, string) {
+	host := strings.ToLower(uri.Host)
+	accountAndService := strings.TrimSuffix(host, "."+e.environmentSuffix)
+	if e.environmentSuffix == "" || accountAndService == host || strings.Count(accountAndService, ".") != 1 {
+		return "", ""
+	}
+
+	if endpoint, ok := e.accounts[accountAndService]; ok {
+		return endpoint.Scheme, endpoint.Host
+	}
+
+	if e.domainSuffix != "" {
+		return uri.Scheme, fmt.Sprintf("%s.%s", accountAndService, e.domainSuffix)
+	}
+
+	return "", ""
+}
@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+)
+
+func TestDataPlaneEndpointsResolve(t *testing.T) {
+	endpoints, err := newDataPlaneEndpoints("core.windows.net", common.StorageDataPlaneOptions{
+		DomainSuffix: "privatelink.example.com",
+		AccountEndpoints: map[string]common.StorageAccountEndpoints{
+			"overridden": {
+				Blob: "https://overridden-blob.internal.example.com",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("building the Data Plane Endpoints: %+v", err)
+	}
+
+	testData := []struct {
+		Input          string
+		ExpectedScheme string
+		ExpectedHost   string
+	}{
+		{
+			// Domain Suffix
+			Input:          "https://example.blob.core.windows.net/container",
+			ExpectedScheme: "https",
+			ExpectedHost:   "example.blob.privatelink.example.com",
+		},
+		{
+			// Account Endpoint
+			Input:          "https://overridden.blob.core.windows.net/container",
+			ExpectedScheme: "https",
+			ExpectedHost:   "overridden-blob.internal.example.com",
+		},
+		{
+			// Account Endpoint for another service falls back to the Domain Suffix
+			Input:          "https://overridden.queue.core.windows.net/queue",
+			ExpectedScheme: "https",
+			ExpectedHost:   "overridden.queue.privatelink.example.com",
+		},
+		{
+			// not a Storage Endpoint
+			Input:          "https://management.azure.com/subscriptions",
+			ExpectedScheme: "",
+			ExpectedHost:   "",
+		},
+		{
+			// nested subdomain
+			Input:          "https://example.blob.storage.core.windows.net/container",
+			ExpectedScheme: "",
+			ExpectedHost:   "",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		uri, err := url.Parse(v.Input)
+		if err != nil {
+			t.Fatalf("parsing %q: %+v", v.Input, err)
+		}
+
+		scheme, host := endpoints.resolve(uri)
+		if scheme != v.ExpectedScheme {
+			t.Fatalf("expected the scheme to be %q but got %q", v.ExpectedScheme, scheme)
+		}
+		if host != v.ExpectedHost {
+			t.Fatalf("expected the host to be %q but got %q", v.ExpectedHost, host)
+		}
+	}
+}
+
+func TestDataPlaneEndpointsResolveWithoutOverrides(t *testing.T) {
+	endpoints, err := newDataPlaneEndpoints("core.windows.net", common.StorageDataPlaneOptions{})
+	if err != nil {
+		t.Fatalf("building the Data Plane Endpoints: %+v", err)
+	}
+
+	uri, _ := url.Parse("https://example.blob.core.windows.net/container")
+	if _, host := endpoints.resolve(uri); host != "" {
+		t.Fatalf("expected the host not to be overridden but got %q", host)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+)
+
+var _ resourceids.Id = StorageBlobDirectoryDataPlaneId{}
+
+// StorageBlobDirectoryDataPlaneId is the ID of a "directory" of Blobs within a Storage Container, that is
+// the Blobs whose names start with Prefix (which is either empty, or ends with a `/`)
+type StorageBlobDirectoryDataPlaneId struct {
+	AccountName   string
+	DomainSuffix  string
+	ContainerName string
+	Prefix        string
+}
+
+func (id StorageBlobDirectoryDataPlaneId) String() string {
+	components := []string{
+		fmt.Sprintf("Account Name %q", id.AccountName),
+		fmt.Sprintf("Domain Suffix %q", id.DomainSuffix),
+		fmt.Sprintf("Container Name %q", id.ContainerName),
+		fmt.Sprintf("Prefix %q", id.Prefix),
+	}
+	return fmt.Sprintf("Storage Blob Directory %s", strings.Join(components, " / "))
+}
+
+func (id StorageBlobDirectoryDataPlaneId) ID() string {
+	return fmt.Sprintf("https://%s.blob.%s/%s/%s", id.AccountName, id.DomainSuffix, id.ContainerName, id.Prefix)
+}
+
+func NewStorageBlobDirectoryDataPlaneId(accountName, domainSuffix, containerName, prefix string) StorageBlobDirectoryDataPlaneId {
+	return StorageBlobDirectoryDataPlaneId{
+		AccountName:   accountName,
+		DomainSuffix:  domainSuffix,
+		ContainerName: containerName,
+		Prefix:        prefix,
+	}
+}
+
+func StorageBlobDirectoryDataPlaneID(id string) (*StorageBlobDirectoryDataPlaneId, error) {
+	// example: https://foo.blob.core.windows.net/container/path/to/directory/
+	if !strings.HasSuffix(id, "/") {
+		return nil, fmt.Errorf("expected the ID %q to end with a `/`", id)
+	}
+
+	uri, err := url.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q as a URL: %+v", id, err)
+	}
+
+	containerName, prefix, _ := strings.Cut(strings.TrimPrefix(uri.Path, "/"), "/")
+	if containerName == "" {
+		return nil, fmt.Errorf("expected the ID %q to contain a Container Name", id)
+	}
+
+	container, err := StorageContainerDataPlaneID(fmt.Sprintf("%s://%s/%s", uri.Scheme, uri.Host, containerName))
+	if err != nil {
+		return nil, err
+	}
+	if container.AccountName == "" {
+		return nil, fmt.Errorf("expected the ID %q to contain an Account Name", id)
+	}
+
+	return &StorageBlobDirectoryDataPlaneId{
+		AccountName:   container.AccountName,
+		DomainSuffix:  container.DomainSuffix,
+		ContainerName: containerName,
+		Prefix:        prefix,
+	}, nil
+}
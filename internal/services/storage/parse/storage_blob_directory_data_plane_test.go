@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"testing"
+)
+
+func TestStorageBlobDirectoryDataPlaneIDFormatter(t *testing.T) {
+	actual := NewStorageBlobDirectoryDataPlaneId("account1", "core.windows.net", "container1", "path/to/directory/").ID()
+	expected := "https://account1.blob.core.windows.net/container1/path/to/directory/"
+	if actual != expected {
+		t.Fatalf("Expected %q but got %q", expected, actual)
+	}
+}
+
+func TestStorageBlobDirectoryDataPlaneID(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Error    bool
+		Expected *StorageBlobDirectoryDataPlaneId
+	}{
+		{
+			// empty
+			Input: "",
+			Error: true,
+		},
+
+		{
+			// missing ContainerName
+			Input: "https://account1.blob.core.windows.net/",
+			Error: true,
+		},
+
+		{
+			// missing trailing slash
+			Input: "https://account1.blob.core.windows.net/container1/path",
+			Error: true,
+		},
+
+		{
+			// valid without a Prefix
+			Input: "https://account1.blob.core.windows.net/container1/",
+			Expected: &StorageBlobDirectoryDataPlaneId{
+				AccountName:   "account1",
+				DomainSuffix:  "core.windows.net",
+				ContainerName: "container1",
+				Prefix:        "",
+			},
+		},
+
+		{
+			// valid
+			Input: "https://account1.blob.core.chinacloudapi.cn/container1/path/to/directory/",
+			Expected: &StorageBlobDirectoryDataPlaneId{
+				AccountName:   "account1",
+				DomainSuffix:  "core.chinacloudapi.cn",
+				ContainerName: "container1",
+				Prefix:        "path/to/directory/",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual, err := StorageBlobDirectoryDataPlaneID(v.Input)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expect a value but got an error: %s", err)
+		}
+		if v.Error {
+			t.Fatal("Expect an error but didn't get one")
+		}
+
+		if *actual != *v.Expected {
+			t.Fatalf("Expected %+v but got %+v", *v.Expected, *actual)
+		}
+	}
+}
@@ -11,11 +11,30 @@ import (
 type Registration struct{}
 
 var _ sdk.UntypedServiceRegistrationWithAGitHubLabel = Registration{}
+var _ sdk.TypedServiceRegistrationWithEphemeralResources = Registration{}
+var _ sdk.TypedServiceRegistrationWithResourceProviders = Registration{}
+var _ sdk.UntypedServiceRegistrationWithResourceProviders = Registration{}
+var _ sdk.UntypedServiceRegistrationWithListableResources = Registration{}
 
 func (r Registration) AssociatedGitHubLabel() string {
 	return "service/storage"
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.Storage",
+		"Microsoft.StorageSync",
+	}
+}
+
+// ListableResources returns the Untyped Resources within this Service which support being listed
+func (r Registration) ListableResources() []sdk.UntypedResourceList {
+	return []sdk.UntypedResourceList{
+		resourceStorageAccountList(),
+	}
+}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "Storage"
@@ -52,6 +71,7 @@ func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 		"azurerm_storage_account_customer_managed_key": resourceStorageAccountCustomerManagedKey(),
 		"azurerm_storage_account_network_rules":        resourceStorageAccountNetworkRules(),
 		"azurerm_storage_blob":                         resourceStorageBlob(),
+		"azurerm_storage_blob_directory":               resourceStorageBlobDirectory(),
 		"azurerm_storage_blob_inventory_policy":        resourceStorageBlobInventoryPolicy(),
 		"azurerm_storage_container":                    resourceStorageContainer(),
 		"azurerm_storage_encryption_scope":             resourceStorageEncryptionScope(),
@@ -78,5 +98,13 @@ func (r Registration) DataSources() []sdk.DataSource {
 func (r Registration) Resources() []sdk.Resource {
 	return []sdk.Resource{
 		LocalUserResource{},
+		StorageContainerImmutabilityPolicyResource{},
+		StorageContainerLegalHoldResource{},
+	}
+}
+
+func (r Registration) EphemeralResources() []sdk.EphemeralResource {
+	return []sdk.EphemeralResource{
+		StorageAccountSasEphemeralResource{},
 	}
 }
@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package shim
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/blobcontainers"
+	"github.com/tombuildsstuff/giovanni/storage/2020-08-04/blob/containers"
+)
+
+// StorageContainerWrapperWithFallback uses the Data Plane API for all operations, falling back to the
+// Resource Manager API to retrieve a Storage Container when the Data Plane API is unavailable.
+type StorageContainerWrapperWithFallback struct {
+	StorageContainerWrapper
+
+	client         *blobcontainers.BlobContainersClient
+	subscriptionId string
+}
+
+func NewStorageContainerWrapperWithFallback(dataPlane StorageContainerWrapper, client *blobcontainers.BlobContainersClient, subscriptionId string) StorageContainerWrapper {
+	return StorageContainerWrapperWithFallback{
+		StorageContainerWrapper: dataPlane,
+		client:                  client,
+		subscriptionId:          subscriptionId,
+	}
+}
+
+func (w StorageContainerWrapperWithFallback) Exists(ctx context.Context, resourceGroup, accountName, containerName string) (*bool, error) {
+	exists, err := w.StorageContainerWrapper.Exists(ctx, resourceGroup, accountName, containerName)
+	if !dataPlaneIsUnavailable(err) {
+		return exists, err
+	}
+	logFallbackToResourceManager("retrieve the Container", accountName, containerName, err)
+
+	resp, err := w.get(ctx, resourceGroup, accountName, containerName)
+	if err != nil {
+		return nil, err
+	}
+	return pointer.To(resp != nil), nil
+}
+
+func (w StorageContainerWrapperWithFallback) Get(ctx context.Context, resourceGroup, accountName, containerName string) (*StorageContainerProperties, error) {
+	props, err := w.StorageContainerWrapper.Get(ctx, resourceGroup, accountName, containerName)
+	if !dataPlaneIsUnavailable(err) {
+		return props, err
+	}
+	logFallbackToResourceManager("retrieve the Container", accountName, containerName, err)
+
+	container, err := w.get(ctx, resourceGroup, accountName, containerName)
+	if err != nil || container == nil {
+		return nil, err
+	}
+
+	output := StorageContainerProperties{
+		AccessLevel: containers.Private,
+		MetaData:    map[string]string{},
+	}
+	if props := container.Properties; props != nil {
+		switch pointer.From(props.PublicAccess) {
+		case blobcontainers.PublicAccessBlob:
+			output.AccessLevel = containers.Blob
+		case blobcontainers.PublicAccessContainer:
+			output.AccessLevel = containers.Container
+		}
+		if props.Metadata != nil {
+			output.MetaData = *props.Metadata
+		}
+		output.HasImmutabilityPolicy = pointer.From(props.HasImmutabilityPolicy)
+		output.HasLegalHold = pointer.From(props.HasLegalHold)
+	}
+
+	return &output, nil
+}
+
+func (w StorageContainerWrapperWithFallback) get(ctx context.Context, resourceGroup, accountName, containerName string) (*blobcontainers.BlobContainer, error) {
+	id := commonids.NewStorageContainerID(w.subscriptionId, resourceGroup, accountName, containerName)
+	resp, err := w.client.Get(ctx, id)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return resp.Model, nil
+}
@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package shim
+
+import (
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"syscall"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// dataPlaneUnavailableErrorCodes are the error codes returned by the Data Plane API when the request is
+// rejected by the Storage Account's network rules (that is public network access being disabled, or the
+// source IP not being allowed through the firewall)
+// NOTE: `AuthorizationFailure` is intentionally omitted, since this is also returned for missing permissions
+var dataPlaneUnavailableErrorCodes = map[string]struct{}{
+	"AuthorizationSourceIPMismatch": {},
+	"PublicAccessNotPermitted":      {},
+}
+
+// dataPlaneIsUnavailable determines whether the specified error means that the Data Plane API can't be reached
+// from where Terraform is running - in which case the (read-only) Resource Manager API is used instead.
+func dataPlaneIsUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var detailedErr autorest.DetailedError
+	if errors.As(err, &detailedErr) {
+		if resp := detailedErr.Response; resp != nil && resp.StatusCode == http.StatusForbidden {
+			_, ok := dataPlaneUnavailableErrorCodes[resp.Header.Get("x-ms-error-code")]
+			return ok
+		}
+	}
+
+	// the endpoint couldn't be resolved, e.g. the Storage Account is only accessible from a Private Endpoint
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+
+	// the endpoint refused the connection
+	return errors.Is(err, syscall.ECONNREFUSED)
+}
+
+func logFallbackToResourceManager(operation, accountName, name string, err error) {
+	log.Printf("[DEBUG] the Data Plane API for Storage Account %q is unavailable (%+v) - falling back to the Resource Manager API to %s %q", accountName, err, operation, name)
+}
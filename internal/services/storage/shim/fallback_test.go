@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package shim
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+func TestDataPlaneIsUnavailable(t *testing.T) {
+	forbidden := func(errorCode string) error {
+		resp := &http.Response{
+			StatusCode: http.StatusForbidden,
+			Header:     http.Header{},
+		}
+		resp.Header.Set("x-ms-error-code", errorCode)
+		return autorest.NewErrorWithError(fmt.Errorf("forbidden"), "containers.Client", "GetProperties", resp, "Failure responding to request")
+	}
+
+	testData := []struct {
+		Name     string
+		Input    error
+		Expected bool
+	}{
+		{
+			Name:     "no error",
+			Input:    nil,
+			Expected: false,
+		},
+		{
+			Name:     "firewall",
+			Input:    forbidden("AuthorizationSourceIPMismatch"),
+			Expected: true,
+		},
+		{
+			Name:     "authorization failure",
+			Input:    forbidden("AuthorizationFailure"),
+			Expected: false,
+		},
+		{
+			Name:     "public network access disabled",
+			Input:    forbidden("PublicAccessNotPermitted"),
+			Expected: true,
+		},
+		{
+			Name:     "missing permissions",
+			Input:    forbidden("AuthorizationPermissionMismatch"),
+			Expected: false,
+		},
+		{
+			Name: "not found",
+			Input: autorest.NewErrorWithError(fmt.Errorf("not found"), "containers.Client", "GetProperties", &http.Response{
+				StatusCode: http.StatusNotFound,
+			}, "Failure responding to request"),
+			Expected: false,
+		},
+		{
+			Name:     "unresolvable endpoint",
+			Input:    fmt.Errorf("retrieving Container: %w", &net.DNSError{Err: "no such host", Name: "example.blob.core.windows.net", IsNotFound: true}),
+			Expected: true,
+		},
+		{
+			Name: "connection refused",
+			Input: fmt.Errorf("retrieving Container: %w", &url.Error{Op: "Get", URL: "https://example.blob.core.windows.net", Err: &net.OpError{
+				Op:  "dial",
+				Net: "tcp",
+				Err: os.NewSyscallError("connect", syscall.ECONNREFUSED),
+			}}),
+			Expected: true,
+		},
+		{
+			Name:     "timeout",
+			Input:    fmt.Errorf("retrieving Container: %w", &net.OpError{Op: "read", Net: "tcp", Err: os.ErrDeadlineExceeded}),
+			Expected: false,
+		},
+		{
+			Name:     "other error",
+			Input:    fmt.Errorf("something went wrong"),
+			Expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		if actual := dataPlaneIsUnavailable(v.Input); actual != v.Expected {
+			t.Fatalf("expected %t but got %t", v.Expected, actual)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package shim
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/queueservice"
+)
+
+// StorageQueueWrapperWithFallback uses the Data Plane API for all operations, falling back to the
+// Resource Manager API to retrieve a Storage Queue when the Data Plane API is unavailable.
+type StorageQueueWrapperWithFallback struct {
+	StorageQueuesWrapper
+
+	client         *queueservice.QueueServiceClient
+	subscriptionId string
+}
+
+func NewStorageQueueWrapperWithFallback(dataPlane StorageQueuesWrapper, client *queueservice.QueueServiceClient, subscriptionId string) StorageQueuesWrapper {
+	return StorageQueueWrapperWithFallback{
+		StorageQueuesWrapper: dataPlane,
+		client:               client,
+		subscriptionId:       subscriptionId,
+	}
+}
+
+func (w StorageQueueWrapperWithFallback) Exists(ctx context.Context, resourceGroup, accountName, queueName string) (*bool, error) {
+	exists, err := w.StorageQueuesWrapper.Exists(ctx, resourceGroup, accountName, queueName)
+	if !dataPlaneIsUnavailable(err) {
+		return exists, err
+	}
+	logFallbackToResourceManager("retrieve the Queue", accountName, queueName, err)
+
+	queue, err := w.get(ctx, resourceGroup, accountName, queueName)
+	if err != nil {
+		return nil, err
+	}
+	return pointer.To(queue != nil), nil
+}
+
+func (w StorageQueueWrapperWithFallback) Get(ctx context.Context, resourceGroup, accountName, queueName string) (*StorageQueueProperties, error) {
+	props, err := w.StorageQueuesWrapper.Get(ctx, resourceGroup, accountName, queueName)
+	if !dataPlaneIsUnavailable(err) {
+		return props, err
+	}
+	logFallbackToResourceManager("retrieve the Queue", accountName, queueName, err)
+
+	queue, err := w.get(ctx, resourceGroup, accountName, queueName)
+	if err != nil || queue == nil {
+		return nil, err
+	}
+
+	output := StorageQueueProperties{
+		MetaData: map[string]string{},
+	}
+	if queue.Properties != nil && queue.Properties.Metadata != nil {
+		output.MetaData = *queue.Properties.Metadata
+	}
+
+	return &output, nil
+}
+
+func (w StorageQueueWrapperWithFallback) get(ctx context.Context, resourceGroup, accountName, queueName string) (*queueservice.StorageQueue, error) {
+	id := queueservice.NewQueueID(w.subscriptionId, resourceGroup, accountName, queueName)
+	resp, err := w.client.QueueGet(ctx, id)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return resp.Model, nil
+}
@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package shim
+
+import (
+	"context"
+)
+
+// StorageShareWrapperWithFallback uses the Data Plane API for all operations, falling back to the
+// Resource Manager API to retrieve a Storage Share when the Data Plane API is unavailable.
+type StorageShareWrapperWithFallback struct {
+	StorageShareWrapper
+
+	resourceManager StorageShareWrapper
+}
+
+func NewStorageShareWrapperWithFallback(dataPlane StorageShareWrapper, resourceManager StorageShareWrapper) StorageShareWrapper {
+	return StorageShareWrapperWithFallback{
+		StorageShareWrapper: dataPlane,
+		resourceManager:     resourceManager,
+	}
+}
+
+func (w StorageShareWrapperWithFallback) Exists(ctx context.Context, resourceGroup, accountName, shareName string) (*bool, error) {
+	exists, err := w.StorageShareWrapper.Exists(ctx, resourceGroup, accountName, shareName)
+	if !dataPlaneIsUnavailable(err) {
+		return exists, err
+	}
+	logFallbackToResourceManager("retrieve the Share", accountName, shareName, err)
+
+	return w.resourceManager.Exists(ctx, resourceGroup, accountName, shareName)
+}
+
+func (w StorageShareWrapperWithFallback) Get(ctx context.Context, resourceGroup, accountName, shareName string) (*StorageShareProperties, error) {
+	props, err := w.StorageShareWrapper.Get(ctx, resourceGroup, accountName, shareName)
+	if !dataPlaneIsUnavailable(err) {
+		return props, err
+	}
+	logFallbackToResourceManager("retrieve the Share", accountName, shareName, err)
+
+	return w.resourceManager.Get(ctx, resourceGroup, accountName, shareName)
+}
@@ -0,0 +1,167 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package shim
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/fileshares"
+	"github.com/tombuildsstuff/giovanni/storage/2020-08-04/file/shares"
+)
+
+// ResourceManagerStorageShareWrapper manages Storage Shares using the Resource Manager API, which (unlike
+// the Data Plane API) supports authenticating using AzureAD
+type ResourceManagerStorageShareWrapper struct {
+	client         *fileshares.FileSharesClient
+	subscriptionId string
+}
+
+func NewResourceManagerStorageShareWrapper(client *fileshares.FileSharesClient, subscriptionId string) StorageShareWrapper {
+	return ResourceManagerStorageShareWrapper{
+		client:         client,
+		subscriptionId: subscriptionId,
+	}
+}
+
+func (w ResourceManagerStorageShareWrapper) Create(ctx context.Context, resourceGroup, accountName, shareName string, input shares.CreateInput) error {
+	id := fileshares.NewShareID(w.subscriptionId, resourceGroup, accountName, shareName)
+	payload := fileshares.FileShare{
+		Properties: &fileshares.FileShareProperties{
+			Metadata:   pointer.To(input.MetaData),
+			ShareQuota: pointer.To(int64(input.QuotaInGB)),
+		},
+	}
+	if input.EnabledProtocol != "" {
+		payload.Properties.EnabledProtocols = pointer.To(fileshares.EnabledProtocols(input.EnabledProtocol))
+	}
+	if input.AccessTier != nil {
+		payload.Properties.AccessTier = pointer.To(fileshares.ShareAccessTier(*input.AccessTier))
+	}
+
+	_, err := w.client.Create(ctx, id, payload, fileshares.DefaultCreateOperationOptions())
+	return err
+}
+
+func (w ResourceManagerStorageShareWrapper) Delete(ctx context.Context, resourceGroup, accountName, shareName string) error {
+	id := fileshares.NewShareID(w.subscriptionId, resourceGroup, accountName, shareName)
+	options := fileshares.DeleteOperationOptions{
+		Include: pointer.To("snapshots"),
+	}
+	resp, err := w.client.Delete(ctx, id, options)
+	if response.WasNotFound(resp.HttpResponse) {
+		return nil
+	}
+
+	return err
+}
+
+func (w ResourceManagerStorageShareWrapper) Exists(ctx context.Context, resourceGroup, accountName, shareName string) (*bool, error) {
+	share, err := w.get(ctx, resourceGroup, accountName, shareName)
+	if err != nil {
+		return nil, err
+	}
+	if share == nil {
+		return nil, nil
+	}
+
+	return pointer.To(true), nil
+}
+
+func (w ResourceManagerStorageShareWrapper) Get(ctx context.Context, resourceGroup, accountName, shareName string) (*StorageShareProperties, error) {
+	share, err := w.get(ctx, resourceGroup, accountName, shareName)
+	if err != nil || share == nil {
+		return nil, err
+	}
+
+	output := StorageShareProperties{
+		ACLs:     []shares.SignedIdentifier{},
+		MetaData: map[string]string{},
+	}
+	if props := share.Properties; props != nil {
+		if props.SignedIdentifiers != nil {
+			for _, v := range *props.SignedIdentifiers {
+				acl := shares.SignedIdentifier{
+					Id: pointer.From(v.Id),
+				}
+				if policy := v.AccessPolicy; policy != nil {
+					acl.AccessPolicy = shares.AccessPolicy{
+						Start:      pointer.From(policy.StartTime),
+						Expiry:     pointer.From(policy.ExpiryTime),
+						Permission: pointer.From(policy.Permission),
+					}
+				}
+				output.ACLs = append(output.ACLs, acl)
+			}
+		}
+		if props.Metadata != nil {
+			output.MetaData = *props.Metadata
+		}
+		output.QuotaGB = int(pointer.From(props.ShareQuota))
+		output.EnabledProtocol = shares.ShareProtocol(pointer.From(props.EnabledProtocols))
+		if props.AccessTier != nil {
+			output.AccessTier = pointer.To(shares.AccessTier(*props.AccessTier))
+		}
+	}
+
+	return &output, nil
+}
+
+func (w ResourceManagerStorageShareWrapper) UpdateACLs(ctx context.Context, resourceGroup, accountName, shareName string, acls []shares.SignedIdentifier) error {
+	signedIdentifiers := make([]fileshares.SignedIdentifier, 0)
+	for _, v := range acls {
+		signedIdentifiers = append(signedIdentifiers, fileshares.SignedIdentifier{
+			Id: pointer.To(v.Id),
+			AccessPolicy: &fileshares.AccessPolicy{
+				StartTime:  pointer.To(v.AccessPolicy.Start),
+				ExpiryTime: pointer.To(v.AccessPolicy.Expiry),
+				Permission: pointer.To(v.AccessPolicy.Permission),
+			},
+		})
+	}
+
+	return w.update(ctx, resourceGroup, accountName, shareName, fileshares.FileShareProperties{
+		SignedIdentifiers: &signedIdentifiers,
+	})
+}
+
+func (w ResourceManagerStorageShareWrapper) UpdateMetaData(ctx context.Context, resourceGroup, accountName, shareName string, metaData map[string]string) error {
+	return w.update(ctx, resourceGroup, accountName, shareName, fileshares.FileShareProperties{
+		Metadata: pointer.To(metaData),
+	})
+}
+
+func (w ResourceManagerStorageShareWrapper) UpdateQuota(ctx context.Context, resourceGroup, accountName, shareName string, quotaGB int) error {
+	return w.update(ctx, resourceGroup, accountName, shareName, fileshares.FileShareProperties{
+		ShareQuota: pointer.To(int64(quotaGB)),
+	})
+}
+
+func (w ResourceManagerStorageShareWrapper) UpdateTier(ctx context.Context, resourceGroup, accountName, shareName string, tier shares.AccessTier) error {
+	return w.update(ctx, resourceGroup, accountName, shareName, fileshares.FileShareProperties{
+		AccessTier: pointer.To(fileshares.ShareAccessTier(tier)),
+	})
+}
+
+func (w ResourceManagerStorageShareWrapper) get(ctx context.Context, resourceGroup, accountName, shareName string) (*fileshares.FileShare, error) {
+	id := fileshares.NewShareID(w.subscriptionId, resourceGroup, accountName, shareName)
+	resp, err := w.client.Get(ctx, id, fileshares.DefaultGetOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return resp.Model, nil
+}
+
+func (w ResourceManagerStorageShareWrapper) update(ctx context.Context, resourceGroup, accountName, shareName string, props fileshares.FileShareProperties) error {
+	id := fileshares.NewShareID(w.subscriptionId, resourceGroup, accountName, shareName)
+	_, err := w.client.Update(ctx, id, fileshares.FileShare{
+		Properties: &props,
+	})
+	return err
+}
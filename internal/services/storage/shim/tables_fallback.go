@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package shim
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/tableservice"
+	"github.com/tombuildsstuff/giovanni/storage/2020-08-04/table/tables"
+)
+
+// StorageTableWrapperWithFallback uses the Data Plane API for all operations, falling back to the
+// Resource Manager API to retrieve a Storage Table when the Data Plane API is unavailable.
+type StorageTableWrapperWithFallback struct {
+	StorageTableWrapper
+
+	client         *tableservice.TableServiceClient
+	subscriptionId string
+}
+
+func NewStorageTableWrapperWithFallback(dataPlane StorageTableWrapper, client *tableservice.TableServiceClient, subscriptionId string) StorageTableWrapper {
+	return StorageTableWrapperWithFallback{
+		StorageTableWrapper: dataPlane,
+		client:              client,
+		subscriptionId:      subscriptionId,
+	}
+}
+
+func (w StorageTableWrapperWithFallback) Exists(ctx context.Context, resourceGroup string, accountName string, tableName string) (*bool, error) {
+	exists, err := w.StorageTableWrapper.Exists(ctx, resourceGroup, accountName, tableName)
+	if !dataPlaneIsUnavailable(err) {
+		return exists, err
+	}
+	logFallbackToResourceManager("retrieve the Table", accountName, tableName, err)
+
+	table, err := w.get(ctx, resourceGroup, accountName, tableName)
+	if err != nil {
+		return nil, err
+	}
+	return pointer.To(table != nil), nil
+}
+
+func (w StorageTableWrapperWithFallback) GetACLs(ctx context.Context, resourceGroup string, accountName string, tableName string) (*[]tables.SignedIdentifier, error) {
+	acls, err := w.StorageTableWrapper.GetACLs(ctx, resourceGroup, accountName, tableName)
+	if !dataPlaneIsUnavailable(err) {
+		return acls, err
+	}
+	logFallbackToResourceManager("retrieve the ACLs for the Table", accountName, tableName, err)
+
+	table, err := w.get(ctx, resourceGroup, accountName, tableName)
+	if err != nil || table == nil {
+		return nil, err
+	}
+
+	output := make([]tables.SignedIdentifier, 0)
+	if table.Properties != nil && table.Properties.SignedIdentifiers != nil {
+		for _, v := range *table.Properties.SignedIdentifiers {
+			acl := tables.SignedIdentifier{
+				Id: v.Id,
+			}
+			if policy := v.AccessPolicy; policy != nil {
+				acl.AccessPolicy = tables.AccessPolicy{
+					Start:      pointer.From(policy.StartTime),
+					Expiry:     pointer.From(policy.ExpiryTime),
+					Permission: policy.Permission,
+				}
+			}
+			output = append(output, acl)
+		}
+	}
+
+	return &output, nil
+}
+
+func (w StorageTableWrapperWithFallback) get(ctx context.Context, resourceGroup, accountName, tableName string) (*tableservice.Table, error) {
+	id := tableservice.NewTableID(w.subscriptionId, resourceGroup, accountName, tableName)
+	resp, err := w.client.TableGet(ctx, id)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return resp.Model, nil
+}
@@ -20,12 +20,14 @@ import (
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/edgezones"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/identity"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	keyvault "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/client"
 	keyVaultParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
 	keyVaultValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
@@ -3732,3 +3734,29 @@ func flattenStorageAccountSASPolicy(input *storage.SasPolicy) []interface{} {
 		},
 	}
 }
+
+func resourceStorageAccountList() sdk.UntypedResourceList {
+	return sdk.UntypedResourceList{
+		ResourceType:     "azurerm_storage_account",
+		IDValidationFunc: commonids.ValidateStorageAccountID,
+		List: sdk.ResourceListFunc{
+			Timeout: 5 * time.Minute,
+			Func: func(ctx context.Context, metadata sdk.ResourceListMetaData) ([]resourceids.Id, error) {
+				client := metadata.Client.Storage.ResourceManager.StorageAccounts
+
+				return sdk.ListResourceIdsWithinScope(ctx, metadata,
+					func(ctx context.Context, id commonids.ResourceGroupId) ([]storageaccounts.StorageAccount, error) {
+						resp, err := client.ListByResourceGroupComplete(ctx, id)
+						return resp.Items, err
+					},
+					func(ctx context.Context, id commonids.SubscriptionId) ([]storageaccounts.StorageAccount, error) {
+						resp, err := client.ListComplete(ctx, id)
+						return resp.Items, err
+					},
+					func(item storageaccounts.StorageAccount) *string { return item.Id },
+					commonids.ParseStorageAccountIDInsensitively,
+				)
+			},
+		},
+	}
+}
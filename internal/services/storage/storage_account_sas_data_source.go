@@ -208,10 +208,24 @@ func dataSourceStorageAccountSasRead(d *pluginsdk.ResourceData, _ interface{}) e
 	services := BuildServicesString(servicesIface[0].(map[string]interface{}))
 	permissions := BuildPermissionsString(permissionsIface[0].(map[string]interface{}))
 
+	sasToken, err := computeStorageAccountSasToken(connString, httpsOnly, ipAddresses, signedVersion, permissions, services, resourceTypes, start, expiry)
+	if err != nil {
+		return err
+	}
+
+	d.Set("sas", sasToken)
+	tokenHash := sha256.Sum256([]byte(sasToken))
+	d.SetId(hex.EncodeToString(tokenHash[:]))
+
+	return nil
+}
+
+// computeStorageAccountSasToken computes an Account SAS Token using the Account Name and Key from `connString`
+func computeStorageAccountSasToken(connString string, httpsOnly bool, ipAddresses, signedVersion, permissions, services, resourceTypes, start, expiry string) (string, error) {
 	// Parse the connection string
 	kvp, err := storage.ParseAccountSASConnectionString(connString)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	// Create the string to sign with the key...
@@ -228,17 +242,8 @@ func dataSourceStorageAccountSasRead(d *pluginsdk.ResourceData, _ interface{}) e
 	// TODO: implement support for signedEncryptionScope
 	signedEncryptionScope := ""
 
-	sasToken, err := storage.ComputeAccountSASToken(accountName, accountKey, permissions, services, resourceTypes,
+	return storage.ComputeAccountSASToken(accountName, accountKey, permissions, services, resourceTypes,
 		start, expiry, signedProtocol, ipAddresses, signedVersion, signedEncryptionScope)
-	if err != nil {
-		return err
-	}
-
-	d.Set("sas", sasToken)
-	tokenHash := sha256.Sum256([]byte(sasToken))
-	d.SetId(hex.EncodeToString(tokenHash[:]))
-
-	return nil
 }
 
 func BuildPermissionsString(perms map[string]interface{}) string {
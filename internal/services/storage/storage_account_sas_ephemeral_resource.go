@@ -0,0 +1,148 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+var _ sdk.EphemeralResource = StorageAccountSasEphemeralResource{}
+
+// StorageAccountSasEphemeralResource exposes the same Shared Access Signature as the Data Source
+// `azurerm_storage_account_sas` - without persisting the generated token into the Plan or State.
+type StorageAccountSasEphemeralResource struct{}
+
+type StorageAccountSasEphemeralResourceModel struct {
+	ConnectionString string                                `tfschema:"connection_string"`
+	HttpsOnly        bool                                  `tfschema:"https_only"`
+	IPAddresses      string                                `tfschema:"ip_addresses"`
+	SignedVersion    string                                `tfschema:"signed_version"`
+	ResourceTypes    []StorageAccountSasResourceTypesModel `tfschema:"resource_types"`
+	Services         []StorageAccountSasServicesModel      `tfschema:"services"`
+	Start            string                                `tfschema:"start"`
+	Expiry           string                                `tfschema:"expiry"`
+	Permissions      []StorageAccountSasPermissionsModel   `tfschema:"permissions"`
+	Sas              string                                `tfschema:"sas"`
+}
+
+type StorageAccountSasResourceTypesModel struct {
+	Service   bool `tfschema:"service"`
+	Container bool `tfschema:"container"`
+	Object    bool `tfschema:"object"`
+}
+
+type StorageAccountSasServicesModel struct {
+	Blob  bool `tfschema:"blob"`
+	Queue bool `tfschema:"queue"`
+	Table bool `tfschema:"table"`
+	File  bool `tfschema:"file"`
+}
+
+type StorageAccountSasPermissionsModel struct {
+	Read    bool `tfschema:"read"`
+	Write   bool `tfschema:"write"`
+	Delete  bool `tfschema:"delete"`
+	List    bool `tfschema:"list"`
+	Add     bool `tfschema:"add"`
+	Create  bool `tfschema:"create"`
+	Update  bool `tfschema:"update"`
+	Process bool `tfschema:"process"`
+	Tag     bool `tfschema:"tag"`
+	Filter  bool `tfschema:"filter"`
+}
+
+func (StorageAccountSasEphemeralResource) Arguments() map[string]*pluginsdk.Schema {
+	arguments := make(map[string]*pluginsdk.Schema)
+	for k, v := range dataSourceStorageAccountSharedAccessSignature().Schema {
+		if k == "sas" {
+			continue
+		}
+		arguments[k] = v
+	}
+	return arguments
+}
+
+func (StorageAccountSasEphemeralResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"sas": dataSourceStorageAccountSharedAccessSignature().Schema["sas"],
+	}
+}
+
+func (StorageAccountSasEphemeralResource) ModelObject() interface{} {
+	return &StorageAccountSasEphemeralResourceModel{}
+}
+
+func (StorageAccountSasEphemeralResource) ResourceType() string {
+	return "azurerm_storage_account_sas"
+}
+
+func (StorageAccountSasEphemeralResource) Open() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(_ context.Context, metadata sdk.ResourceMetaData) error {
+			var model StorageAccountSasEphemeralResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			resourceTypes := ""
+			if len(model.ResourceTypes) > 0 {
+				resourceTypes = model.ResourceTypes[0].String()
+			}
+			services := ""
+			if len(model.Services) > 0 {
+				services = model.Services[0].String()
+			}
+			permissions := ""
+			if len(model.Permissions) > 0 {
+				permissions = model.Permissions[0].String()
+			}
+
+			sasToken, err := computeStorageAccountSasToken(model.ConnectionString, model.HttpsOnly, model.IPAddresses, model.SignedVersion, permissions, services, resourceTypes, model.Start, model.Expiry)
+			if err != nil {
+				return fmt.Errorf("computing the Shared Access Signature: %+v", err)
+			}
+			model.Sas = sasToken
+
+			return metadata.Encode(&model)
+		},
+	}
+}
+
+func (m StorageAccountSasResourceTypesModel) String() string {
+	return BuildResourceTypesString(map[string]interface{}{
+		"service":   m.Service,
+		"container": m.Container,
+		"object":    m.Object,
+	})
+}
+
+func (m StorageAccountSasServicesModel) String() string {
+	return BuildServicesString(map[string]interface{}{
+		"blob":  m.Blob,
+		"queue": m.Queue,
+		"table": m.Table,
+		"file":  m.File,
+	})
+}
+
+func (m StorageAccountSasPermissionsModel) String() string {
+	return BuildPermissionsString(map[string]interface{}{
+		"read":    m.Read,
+		"write":   m.Write,
+		"delete":  m.Delete,
+		"list":    m.List,
+		"add":     m.Add,
+		"create":  m.Create,
+		"update":  m.Update,
+		"process": m.Process,
+		"tag":     m.Tag,
+		"filter":  m.Filter,
+	})
+}
@@ -0,0 +1,353 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/giovanni/storage/2020-08-04/blob/blobs"
+	"github.com/tombuildsstuff/giovanni/storage/2020-08-04/blob/containers"
+)
+
+func resourceStorageBlobDirectory() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceStorageBlobDirectoryCreate,
+		Read:   resourceStorageBlobDirectoryRead,
+		Update: resourceStorageBlobDirectoryUpdate,
+		Delete: resourceStorageBlobDirectoryDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.StorageBlobDirectoryDataPlaneID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(60 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(60 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"storage_account_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.StorageAccountName,
+			},
+
+			"storage_container_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.StorageContainerName,
+			},
+
+			"prefix": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "",
+				ValidateFunc: validateStorageBlobDirectoryPrefix,
+			},
+
+			"source": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"cache_control": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+			},
+
+			"content_types": {
+				Type:     pluginsdk.TypeMap,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+
+			"parallelism": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				Default:      8,
+				ValidateFunc: validation.IntBetween(1, 64),
+			},
+
+			"files": {
+				Type:     pluginsdk.TypeMap,
+				Computed: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+		},
+
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(resourceStorageBlobDirectoryCustomizeDiff),
+	}
+}
+
+func validateStorageBlobDirectoryPrefix(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return warnings, errors
+	}
+
+	if v == "" {
+		return warnings, errors
+	}
+	if strings.HasPrefix(v, "/") {
+		errors = append(errors, fmt.Errorf("%s must not start with a `/`", k))
+	}
+	if !strings.HasSuffix(v, "/") {
+		errors = append(errors, fmt.Errorf("%s must end with a `/`", k))
+	}
+	if len(v) > 1024 {
+		errors = append(errors, fmt.Errorf("%s must be at most 1024 characters", k))
+	}
+
+	return warnings, errors
+}
+
+// resourceStorageBlobDirectoryCustomizeDiff hashes the files within `source` so that changes to these are planned
+func resourceStorageBlobDirectoryCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("source") {
+		return d.SetNewComputed("files")
+	}
+
+	local, err := hashBlobDirectorySource(d.Get("source").(string))
+	if err != nil {
+		return err
+	}
+
+	files := flattenBlobDirectoryFiles(local)
+	if existing, ok := d.Get("files").(map[string]interface{}); ok && d.Id() != "" && reflect.DeepEqual(existing, files) {
+		return nil
+	}
+	return d.SetNew("files", files)
+}
+
+func resourceStorageBlobDirectoryCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	accountName := d.Get("storage_account_name").(string)
+	containerName := d.Get("storage_container_name").(string)
+	prefix := d.Get("prefix").(string)
+
+	account, err := storageClient.FindAccount(ctx, accountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for Blob Directory %q (Container %q): %s", accountName, prefix, containerName, err)
+	}
+	if account == nil {
+		return fmt.Errorf("Unable to locate Storage Account %q!", accountName)
+	}
+
+	blobsClient, err := storageClient.BlobsClient(ctx, *account)
+	if err != nil {
+		return fmt.Errorf("building Blobs Client: %s", err)
+	}
+	containersClient, err := storageClient.ContainersDataPlaneClient(ctx, *account)
+	if err != nil {
+		return fmt.Errorf("building Containers Client: %s", err)
+	}
+
+	directory := expandStorageBlobDirectorySync(d, blobsClient, containersClient, accountName, containerName, prefix)
+	id := parse.NewStorageBlobDirectoryDataPlaneId(accountName, storageClient.Environment.StorageEndpointSuffix, containerName, prefix)
+
+	// since Blobs which don't exist within the `source` are deleted, the existing Blobs must be imported first
+	existing, err := directory.List(ctx)
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 {
+		return tf.ImportAsExistsError("azurerm_storage_blob_directory", id.ID())
+	}
+
+	log.Printf("[DEBUG] Creating %s..", id)
+	if err := directory.Sync(ctx); err != nil {
+		return fmt.Errorf("creating %s: %+v", id, err)
+	}
+	log.Printf("[DEBUG] Created %s.", id)
+
+	d.SetId(id.ID())
+
+	return resourceStorageBlobDirectoryRead(d, meta)
+}
+
+func resourceStorageBlobDirectoryUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.StorageBlobDirectoryDataPlaneID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for %s: %s", id.AccountName, id, err)
+	}
+	if account == nil {
+		return fmt.Errorf("Unable to locate Storage Account %q!", id.AccountName)
+	}
+
+	if d.HasChanges("source", "files", "cache_control", "content_types") {
+		blobsClient, err := storageClient.BlobsClient(ctx, *account)
+		if err != nil {
+			return fmt.Errorf("building Blobs Client: %s", err)
+		}
+		containersClient, err := storageClient.ContainersDataPlaneClient(ctx, *account)
+		if err != nil {
+			return fmt.Errorf("building Containers Client: %s", err)
+		}
+
+		directory := expandStorageBlobDirectorySync(d, blobsClient, containersClient, id.AccountName, id.ContainerName, id.Prefix)
+
+		// the properties of the Blobs whose content is unchanged only need to be updated when these have changed
+		directory.UpdateProperties = d.HasChanges("cache_control", "content_types")
+
+		log.Printf("[DEBUG] Updating %s..", id)
+		if err := directory.Sync(ctx); err != nil {
+			return fmt.Errorf("updating %s: %+v", id, err)
+		}
+		log.Printf("[DEBUG] Updated %s.", id)
+	}
+
+	return resourceStorageBlobDirectoryRead(d, meta)
+}
+
+func resourceStorageBlobDirectoryRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.StorageBlobDirectoryDataPlaneID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for %s: %s", id.AccountName, id, err)
+	}
+	if account == nil {
+		log.Printf("[DEBUG] Unable to locate Account %q for %s - assuming removed & removing from state!", id.AccountName, id)
+		d.SetId("")
+		return nil
+	}
+
+	containersClient, err := storageClient.ContainersDataPlaneClient(ctx, *account)
+	if err != nil {
+		return fmt.Errorf("building Containers Client: %s", err)
+	}
+
+	directory := BlobDirectorySync{
+		ContainersClient: containersClient,
+		AccountName:      id.AccountName,
+		ContainerName:    id.ContainerName,
+		Prefix:           id.Prefix,
+	}
+	remote, err := directory.List(ctx)
+	if err != nil {
+		if resp, getErr := containersClient.GetProperties(ctx, id.AccountName, id.ContainerName); getErr != nil && utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] Container %q was not found in Account %q - assuming removed & removing from state!", id.ContainerName, id.AccountName)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	d.Set("storage_account_name", id.AccountName)
+	d.Set("storage_container_name", id.ContainerName)
+	d.Set("prefix", id.Prefix)
+
+	files := make(map[string]interface{}, len(remote))
+	for name, hash := range remote {
+		files[name] = hash
+	}
+	if err := d.Set("files", files); err != nil {
+		return fmt.Errorf("setting `files`: %+v", err)
+	}
+
+	return nil
+}
+
+func resourceStorageBlobDirectoryDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.StorageBlobDirectoryDataPlaneID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for %s: %s", id.AccountName, id, err)
+	}
+	if account == nil {
+		return fmt.Errorf("Unable to locate Storage Account %q!", id.AccountName)
+	}
+
+	blobsClient, err := storageClient.BlobsClient(ctx, *account)
+	if err != nil {
+		return fmt.Errorf("building Blobs Client: %s", err)
+	}
+	containersClient, err := storageClient.ContainersDataPlaneClient(ctx, *account)
+	if err != nil {
+		return fmt.Errorf("building Containers Client: %s", err)
+	}
+
+	directory := expandStorageBlobDirectorySync(d, blobsClient, containersClient, id.AccountName, id.ContainerName, id.Prefix)
+
+	log.Printf("[INFO] Deleting %s", id)
+	if err := directory.DeleteAll(ctx); err != nil {
+		return fmt.Errorf("deleting %s: %+v", id, err)
+	}
+
+	return nil
+}
+
+func expandStorageBlobDirectorySync(d *pluginsdk.ResourceData, blobsClient *blobs.Client, containersClient *containers.Client, accountName, containerName, prefix string) BlobDirectorySync {
+	contentTypes := make(map[string]string)
+	for k, v := range d.Get("content_types").(map[string]interface{}) {
+		contentTypes[k] = v.(string)
+	}
+
+	return BlobDirectorySync{
+		BlobsClient:      blobsClient,
+		ContainersClient: containersClient,
+
+		AccountName:   accountName,
+		ContainerName: containerName,
+		Prefix:        prefix,
+
+		CacheControl: d.Get("cache_control").(string),
+		ContentTypes: contentTypes,
+		Parallelism:  d.Get("parallelism").(int),
+		Source:       d.Get("source").(string),
+	}
+}
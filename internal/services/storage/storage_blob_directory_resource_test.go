@@ -0,0 +1,207 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/giovanni/storage/2020-08-04/blob/containers"
+)
+
+type StorageBlobDirectoryResource struct{}
+
+func TestAccStorageBlobDirectory_basic(t *testing.T) {
+	source := t.TempDir()
+	writeStorageBlobDirectoryFiles(t, source, map[string]string{
+		"index.html":       "<html></html>",
+		"css/site.css":     "body {}",
+		"js/nested/app.js": "console.log('hello')",
+	})
+
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob_directory", "test")
+	r := StorageBlobDirectoryResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, source),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("files.%").HasValue("3"),
+			),
+		},
+		data.ImportStep("parallelism", "source"),
+	})
+}
+
+func TestAccStorageBlobDirectory_requiresImport(t *testing.T) {
+	source := t.TempDir()
+	writeStorageBlobDirectoryFiles(t, source, map[string]string{
+		"index.html": "<html></html>",
+	})
+
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob_directory", "test")
+	r := StorageBlobDirectoryResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, source),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(func(data acceptance.TestData) string {
+			return r.requiresImport(data, source)
+		}),
+	})
+}
+
+func TestAccStorageBlobDirectory_sync(t *testing.T) {
+	source := t.TempDir()
+	writeStorageBlobDirectoryFiles(t, source, map[string]string{
+		"index.html":   "<html></html>",
+		"css/site.css": "body {}",
+	})
+
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob_directory", "test")
+	r := StorageBlobDirectoryResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data, source),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("files.%").HasValue("2"),
+			),
+		},
+		data.ImportStep("cache_control", "content_types", "parallelism", "source"),
+		{
+			PreConfig: func() {
+				// change a file, add a file (larger than a single block) and remove a file
+				writeStorageBlobDirectoryFiles(t, source, map[string]string{
+					"index.html":     "<html><body></body></html>",
+					"data/large.bin": string(make([]byte, 9*1024*1024)),
+				})
+				if err := os.Remove(filepath.Join(source, "css", "site.css")); err != nil {
+					t.Fatalf("removing file: %+v", err)
+				}
+			},
+			Config: r.complete(data, source),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("files.%").HasValue("2"),
+				check.That(data.ResourceName).Key("files.data/large.bin").IsSet(),
+			),
+		},
+		data.ImportStep("cache_control", "content_types", "parallelism", "source"),
+	})
+}
+
+func writeStorageBlobDirectoryFiles(t *testing.T, source string, files map[string]string) {
+	for name, content := range files {
+		filePath := filepath.Join(source, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+			t.Fatalf("creating directory for %q: %+v", name, err)
+		}
+		if err := os.WriteFile(filePath, []byte(content), 0o600); err != nil {
+			t.Fatalf("writing %q: %+v", name, err)
+		}
+	}
+}
+
+func (r StorageBlobDirectoryResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.StorageBlobDirectoryDataPlaneID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+	account, err := client.Storage.FindAccount(ctx, id.AccountName)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, fmt.Errorf("unable to locate Account %q for %s", id.AccountName, id)
+	}
+	containersClient, err := client.Storage.ContainersDataPlaneClient(ctx, *account)
+	if err != nil {
+		return nil, fmt.Errorf("building Containers Client: %+v", err)
+	}
+	input := containers.ListBlobsInput{
+		MaxResults: utils.Int(1),
+	}
+	if id.Prefix != "" {
+		input.Prefix = utils.String(id.Prefix)
+	}
+	resp, err := containersClient.ListBlobs(ctx, id.AccountName, id.ContainerName, input)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("listing Blobs for %s: %+v", id, err)
+	}
+	return utils.Bool(len(resp.Blobs.Blobs) > 0), nil
+}
+
+func (r StorageBlobDirectoryResource) basic(data acceptance.TestData, source string) string {
+	template := StorageBlobResource{}.template(data, "private")
+	return fmt.Sprintf(`
+%s
+
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_storage_blob_directory" "test" {
+  storage_account_name   = azurerm_storage_account.test.name
+  storage_container_name = azurerm_storage_container.test.name
+  prefix                 = "site/"
+  source                 = "%s"
+}
+`, template, filepath.ToSlash(source))
+}
+
+func (r StorageBlobDirectoryResource) requiresImport(data acceptance.TestData, source string) string {
+	template := r.basic(data, source)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_blob_directory" "import" {
+  storage_account_name   = azurerm_storage_blob_directory.test.storage_account_name
+  storage_container_name = azurerm_storage_blob_directory.test.storage_container_name
+  prefix                 = azurerm_storage_blob_directory.test.prefix
+  source                 = azurerm_storage_blob_directory.test.source
+}
+`, template)
+}
+
+func (r StorageBlobDirectoryResource) complete(data acceptance.TestData, source string) string {
+	template := StorageBlobResource{}.template(data, "private")
+	return fmt.Sprintf(`
+%s
+
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_storage_blob_directory" "test" {
+  storage_account_name   = azurerm_storage_account.test.name
+  storage_container_name = azurerm_storage_container.test.name
+  source                 = "%s"
+  cache_control          = "public, max-age=3600"
+  parallelism            = 4
+
+  content_types = {
+    ".bin" = "application/x-example"
+  }
+}
+`, template, filepath.ToSlash(source))
+}
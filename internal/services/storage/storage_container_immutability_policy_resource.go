@@ -0,0 +1,347 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/blobcontainers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+type StorageContainerImmutabilityPolicyResource struct{}
+
+var (
+	_ sdk.ResourceWithUpdate        = StorageContainerImmutabilityPolicyResource{}
+	_ sdk.ResourceWithCustomizeDiff = StorageContainerImmutabilityPolicyResource{}
+)
+
+type StorageContainerImmutabilityPolicyModel struct {
+	StorageContainerResourceManagerId string `tfschema:"storage_container_resource_manager_id"`
+	ImmutabilityPeriodInDays          int64  `tfschema:"immutability_period_in_days"`
+	Locked                            bool   `tfschema:"locked"`
+	ProtectedAppendWritesEnabled      bool   `tfschema:"protected_append_writes_enabled"`
+	ProtectedAppendWritesAllEnabled   bool   `tfschema:"protected_append_writes_all_enabled"`
+}
+
+func (r StorageContainerImmutabilityPolicyResource) ResourceType() string {
+	return "azurerm_storage_container_immutability_policy"
+}
+
+func (r StorageContainerImmutabilityPolicyResource) ModelObject() interface{} {
+	return &StorageContainerImmutabilityPolicyModel{}
+}
+
+func (r StorageContainerImmutabilityPolicyResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.StorageContainerImmutabilityPolicyID
+}
+
+func (r StorageContainerImmutabilityPolicyResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"storage_container_resource_manager_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: commonids.ValidateStorageContainerID,
+		},
+
+		"immutability_period_in_days": {
+			Type:         pluginsdk.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntBetween(1, 146000),
+		},
+
+		"locked": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"protected_append_writes_enabled": {
+			Type:          pluginsdk.TypeBool,
+			Optional:      true,
+			Default:       false,
+			ConflictsWith: []string{"protected_append_writes_all_enabled"},
+		},
+
+		"protected_append_writes_all_enabled": {
+			Type:          pluginsdk.TypeBool,
+			Optional:      true,
+			Default:       false,
+			ConflictsWith: []string{"protected_append_writes_enabled"},
+		},
+	}
+}
+
+func (r StorageContainerImmutabilityPolicyResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r StorageContainerImmutabilityPolicyResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			diff := metadata.ResourceDiff
+			if diff.Id() == "" {
+				return nil
+			}
+
+			// once an Immutability Policy has been locked it can't be unlocked, and can only be extended
+			oldLocked, _ := diff.GetChange("locked")
+			if !oldLocked.(bool) {
+				return nil
+			}
+
+			if diff.HasChange("locked") {
+				return fmt.Errorf("a locked Immutability Policy can't be unlocked - `locked` can't be changed from `true` to `false`")
+			}
+
+			if diff.HasChange("immutability_period_in_days") {
+				oldPeriod, newPeriod := diff.GetChange("immutability_period_in_days")
+				if newPeriod.(int) < oldPeriod.(int) {
+					return fmt.Errorf("the `immutability_period_in_days` of a locked Immutability Policy can only be increased, but it was changed from %d to %d", oldPeriod.(int), newPeriod.(int))
+				}
+			}
+
+			for _, key := range []string{"protected_append_writes_enabled", "protected_append_writes_all_enabled"} {
+				if diff.HasChange(key) {
+					return fmt.Errorf("`%s` can't be changed once the Immutability Policy has been locked", key)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r StorageContainerImmutabilityPolicyResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Storage.ResourceManager.BlobContainers
+
+			var plan StorageContainerImmutabilityPolicyModel
+			if err := metadata.Decode(&plan); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			containerId, err := commonids.ParseStorageContainerID(plan.StorageContainerResourceManagerId)
+			if err != nil {
+				return err
+			}
+
+			id := parse.NewStorageContainerImmutabilityPolicyID(containerId.SubscriptionId, containerId.ResourceGroupName, containerId.StorageAccountName, "default", containerId.ContainerName, "default")
+
+			existing, err := client.GetImmutabilityPolicy(ctx, *containerId, blobcontainers.DefaultGetImmutabilityPolicyOperationOptions())
+			if err != nil {
+				if !response.WasNotFound(existing.HttpResponse) {
+					return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+				}
+			}
+			if storageContainerImmutabilityPolicyExists(existing.Model) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			resp, err := client.CreateOrUpdateImmutabilityPolicy(ctx, *containerId, expandStorageContainerImmutabilityPolicy(plan), blobcontainers.DefaultCreateOrUpdateImmutabilityPolicyOperationOptions())
+			if err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			if plan.Locked {
+				if resp.Model == nil || resp.Model.Etag == nil {
+					return fmt.Errorf("retrieving the etag for %s: `etag` was nil", id)
+				}
+
+				options := blobcontainers.LockImmutabilityPolicyOperationOptions{
+					IfMatch: resp.Model.Etag,
+				}
+				if _, err := client.LockImmutabilityPolicy(ctx, *containerId, options); err != nil {
+					return fmt.Errorf("locking %s: %+v", id, err)
+				}
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r StorageContainerImmutabilityPolicyResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Storage.ResourceManager.BlobContainers
+
+			id, err := parse.StorageContainerImmutabilityPolicyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			containerId := commonids.NewStorageContainerID(id.SubscriptionId, id.ResourceGroup, id.StorageAccountName, id.ContainerName)
+
+			resp, err := client.GetImmutabilityPolicy(ctx, containerId, blobcontainers.DefaultGetImmutabilityPolicyOperationOptions())
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			// the API returns an empty Immutability Policy when one doesn't exist for this Container
+			if !storageContainerImmutabilityPolicyExists(resp.Model) {
+				return metadata.MarkAsGone(id)
+			}
+
+			props := resp.Model.Properties
+			state := StorageContainerImmutabilityPolicyModel{
+				StorageContainerResourceManagerId: containerId.ID(),
+				ImmutabilityPeriodInDays:          pointer.From(props.ImmutabilityPeriodSinceCreationInDays),
+				Locked:                            pointer.From(props.State) == blobcontainers.ImmutabilityPolicyStateLocked,
+				ProtectedAppendWritesEnabled:      pointer.From(props.AllowProtectedAppendWrites),
+				ProtectedAppendWritesAllEnabled:   pointer.From(props.AllowProtectedAppendWritesAll),
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r StorageContainerImmutabilityPolicyResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Storage.ResourceManager.BlobContainers
+
+			id, err := parse.StorageContainerImmutabilityPolicyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var plan StorageContainerImmutabilityPolicyModel
+			if err := metadata.Decode(&plan); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			containerId := commonids.NewStorageContainerID(id.SubscriptionId, id.ResourceGroup, id.StorageAccountName, id.ContainerName)
+
+			existing, err := client.GetImmutabilityPolicy(ctx, containerId, blobcontainers.DefaultGetImmutabilityPolicyOperationOptions())
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+			if !storageContainerImmutabilityPolicyExists(existing.Model) || existing.Model.Etag == nil {
+				return fmt.Errorf("retrieving %s: `model` or `etag` was nil", id)
+			}
+			etag := existing.Model.Etag
+
+			if pointer.From(existing.Model.Properties.State) == blobcontainers.ImmutabilityPolicyStateLocked {
+				// a locked Immutability Policy can only be extended, which the Custom Diff ensures
+				if metadata.ResourceData.HasChange("immutability_period_in_days") {
+					options := blobcontainers.ExtendImmutabilityPolicyOperationOptions{
+						IfMatch: etag,
+					}
+					if _, err := client.ExtendImmutabilityPolicy(ctx, containerId, expandStorageContainerImmutabilityPolicy(plan), options); err != nil {
+						return fmt.Errorf("extending %s: %+v", id, err)
+					}
+				}
+
+				return nil
+			}
+
+			if metadata.ResourceData.HasChanges("immutability_period_in_days", "protected_append_writes_enabled", "protected_append_writes_all_enabled") {
+				options := blobcontainers.CreateOrUpdateImmutabilityPolicyOperationOptions{
+					IfMatch: etag,
+				}
+				resp, err := client.CreateOrUpdateImmutabilityPolicy(ctx, containerId, expandStorageContainerImmutabilityPolicy(plan), options)
+				if err != nil {
+					return fmt.Errorf("updating %s: %+v", id, err)
+				}
+				if resp.Model == nil || resp.Model.Etag == nil {
+					return fmt.Errorf("retrieving the etag for %s: `etag` was nil", id)
+				}
+				etag = resp.Model.Etag
+			}
+
+			if plan.Locked {
+				options := blobcontainers.LockImmutabilityPolicyOperationOptions{
+					IfMatch: etag,
+				}
+				if _, err := client.LockImmutabilityPolicy(ctx, containerId, options); err != nil {
+					return fmt.Errorf("locking %s: %+v", id, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r StorageContainerImmutabilityPolicyResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Storage.ResourceManager.BlobContainers
+
+			id, err := parse.StorageContainerImmutabilityPolicyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			containerId := commonids.NewStorageContainerID(id.SubscriptionId, id.ResourceGroup, id.StorageAccountName, id.ContainerName)
+
+			existing, err := client.GetImmutabilityPolicy(ctx, containerId, blobcontainers.DefaultGetImmutabilityPolicyOperationOptions())
+			if err != nil {
+				if response.WasNotFound(existing.HttpResponse) {
+					return nil
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+			if !storageContainerImmutabilityPolicyExists(existing.Model) {
+				return nil
+			}
+
+			// a locked Immutability Policy can't be deleted, it's only removed when the (empty) Container is deleted
+			if pointer.From(existing.Model.Properties.State) == blobcontainers.ImmutabilityPolicyStateLocked {
+				if metadata.Client.Features.Storage.PreventDeletionOfLockedImmutabilityPolicies {
+					return fmt.Errorf("deleting %s: the Immutability Policy is locked and can't be deleted.\n\nTo remove it from the Terraform State (leaving it in place until the Container is deleted), set `prevent_deletion_of_locked_immutability_policies` to `false` within the `storage` block of the `features` block in the Provider block", id)
+				}
+
+				log.Printf("[WARN] %s is locked and can't be deleted - removing it from the state, it'll be removed when the Container is deleted", id)
+				return nil
+			}
+
+			options := blobcontainers.DeleteImmutabilityPolicyOperationOptions{
+				IfMatch: existing.Model.Etag,
+			}
+			if _, err := client.DeleteImmutabilityPolicy(ctx, containerId, options); err != nil {
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func expandStorageContainerImmutabilityPolicy(input StorageContainerImmutabilityPolicyModel) blobcontainers.ImmutabilityPolicy {
+	return blobcontainers.ImmutabilityPolicy{
+		Properties: blobcontainers.ImmutabilityPolicyProperty{
+			ImmutabilityPeriodSinceCreationInDays: pointer.To(input.ImmutabilityPeriodInDays),
+			AllowProtectedAppendWrites:            pointer.To(input.ProtectedAppendWritesEnabled),
+			AllowProtectedAppendWritesAll:         pointer.To(input.ProtectedAppendWritesAllEnabled),
+		},
+	}
+}
+
+func storageContainerImmutabilityPolicyExists(input *blobcontainers.ImmutabilityPolicy) bool {
+	return input != nil && input.Properties.State != nil && input.Properties.ImmutabilityPeriodSinceCreationInDays != nil
+}
@@ -0,0 +1,250 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/blobcontainers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type StorageContainerLegalHoldResource struct{}
+
+var _ sdk.ResourceWithUpdate = StorageContainerLegalHoldResource{}
+
+type StorageContainerLegalHoldModel struct {
+	StorageContainerResourceManagerId string   `tfschema:"storage_container_resource_manager_id"`
+	Tags                              []string `tfschema:"tags"`
+	ProtectedAppendWritesAllEnabled   bool     `tfschema:"protected_append_writes_all_enabled"`
+}
+
+func (r StorageContainerLegalHoldResource) ResourceType() string {
+	return "azurerm_storage_container_legal_hold"
+}
+
+func (r StorageContainerLegalHoldResource) ModelObject() interface{} {
+	return &StorageContainerLegalHoldModel{}
+}
+
+func (r StorageContainerLegalHoldResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return commonids.ValidateStorageContainerID
+}
+
+func (r StorageContainerLegalHoldResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"storage_container_resource_manager_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: commonids.ValidateStorageContainerID,
+		},
+
+		"tags": {
+			Type:     pluginsdk.TypeSet,
+			Required: true,
+			MinItems: 1,
+			MaxItems: 10,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+				// the API normalises the tags to lower case
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile(`^[a-z0-9]{3,23}$`),
+					"a legal hold tag must be between 3 and 23 lower case alphanumeric characters",
+				),
+			},
+		},
+
+		"protected_append_writes_all_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+	}
+}
+
+func (r StorageContainerLegalHoldResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r StorageContainerLegalHoldResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Storage.ResourceManager.BlobContainers
+
+			var plan StorageContainerLegalHoldModel
+			if err := metadata.Decode(&plan); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id, err := commonids.ParseStorageContainerID(plan.StorageContainerResourceManagerId)
+			if err != nil {
+				return err
+			}
+
+			existing, err := client.Get(ctx, *id)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+			if len(flattenStorageContainerLegalHoldTags(existing.Model)) > 0 {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			input := blobcontainers.LegalHold{
+				Tags:                          plan.Tags,
+				AllowProtectedAppendWritesAll: pointer.To(plan.ProtectedAppendWritesAllEnabled),
+			}
+			if _, err := client.SetLegalHold(ctx, *id, input); err != nil {
+				return fmt.Errorf("setting the Legal Hold for %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r StorageContainerLegalHoldResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Storage.ResourceManager.BlobContainers
+
+			id, err := commonids.ParseStorageContainerID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			tags := flattenStorageContainerLegalHoldTags(resp.Model)
+			if len(tags) == 0 {
+				return metadata.MarkAsGone(id)
+			}
+
+			state := StorageContainerLegalHoldModel{
+				StorageContainerResourceManagerId: id.ID(),
+				Tags:                              tags,
+			}
+			if props := resp.Model.Properties; props != nil && props.LegalHold != nil && props.LegalHold.ProtectedAppendWritesHistory != nil {
+				state.ProtectedAppendWritesAllEnabled = pointer.From(props.LegalHold.ProtectedAppendWritesHistory.AllowProtectedAppendWritesAll)
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r StorageContainerLegalHoldResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Storage.ResourceManager.BlobContainers
+
+			id, err := commonids.ParseStorageContainerID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var plan StorageContainerLegalHoldModel
+			if err := metadata.Decode(&plan); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// the new tags are set prior to clearing the removed tags, so that the Container remains under a Legal Hold throughout
+			input := blobcontainers.LegalHold{
+				Tags:                          plan.Tags,
+				AllowProtectedAppendWritesAll: pointer.To(plan.ProtectedAppendWritesAllEnabled),
+			}
+			if _, err := client.SetLegalHold(ctx, *id, input); err != nil {
+				return fmt.Errorf("setting the Legal Hold for %s: %+v", id, err)
+			}
+
+			if metadata.ResourceData.HasChange("tags") {
+				oldRaw, newRaw := metadata.ResourceData.GetChange("tags")
+				removed := oldRaw.(*pluginsdk.Set).Difference(newRaw.(*pluginsdk.Set))
+				if removed.Len() > 0 {
+					input := blobcontainers.LegalHold{
+						Tags: *utils.ExpandStringSlice(removed.List()),
+					}
+					if _, err := client.ClearLegalHold(ctx, *id, input); err != nil {
+						return fmt.Errorf("clearing the removed tags from the Legal Hold for %s: %+v", id, err)
+					}
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r StorageContainerLegalHoldResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Storage.ResourceManager.BlobContainers
+
+			id, err := commonids.ParseStorageContainerID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			existing, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(existing.HttpResponse) {
+					return nil
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			// clear all of the tags, including any added outside of Terraform, since otherwise the Legal Hold remains
+			tags := flattenStorageContainerLegalHoldTags(existing.Model)
+			if len(tags) == 0 {
+				return nil
+			}
+
+			input := blobcontainers.LegalHold{
+				Tags: tags,
+			}
+			if _, err := client.ClearLegalHold(ctx, *id, input); err != nil {
+				return fmt.Errorf("clearing the Legal Hold for %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func flattenStorageContainerLegalHoldTags(input *blobcontainers.BlobContainer) []string {
+	output := make([]string, 0)
+	if input == nil || input.Properties == nil || input.Properties.LegalHold == nil || input.Properties.LegalHold.Tags == nil {
+		return output
+	}
+
+	for _, tag := range *input.Properties.LegalHold.Tags {
+		if tag.Tag != nil && *tag.Tag != "" {
+			output = append(output, *tag.Tag)
+		}
+	}
+	sort.Strings(output)
+
+	return output
+}
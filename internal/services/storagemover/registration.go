@@ -10,13 +10,21 @@ import (
 type Registration struct{}
 
 var (
-	_ sdk.TypedServiceRegistration = Registration{}
+	_ sdk.TypedServiceRegistration                      = Registration{}
+	_ sdk.TypedServiceRegistrationWithResourceProviders = Registration{}
 )
 
 func (r Registration) AssociatedGitHubLabel() string {
 	return "service/storagemover"
 }
 
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.StorageMover",
+	}
+}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "Storage Mover"
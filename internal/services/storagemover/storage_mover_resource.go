@@ -9,8 +9,10 @@ import (
 	"time"
 
 	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/storagemover/2023-03-01/storagemovers"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
@@ -28,6 +30,7 @@ type StorageMoverModel struct {
 type StorageMoverResource struct{}
 
 var _ sdk.ResourceWithUpdate = StorageMoverResource{}
+var _ sdk.ResourceWithList = StorageMoverResource{}
 
 func (r StorageMoverResource) ResourceType() string {
 	return "azurerm_storage_mover"
@@ -112,6 +115,28 @@ func (r StorageMoverResource) Create() sdk.ResourceFunc {
 	}
 }
 
+func (r StorageMoverResource) List() sdk.ResourceListFunc {
+	return sdk.ResourceListFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceListMetaData) ([]resourceids.Id, error) {
+			client := metadata.Client.StorageMover.StorageMoversClient
+
+			return sdk.ListResourceIdsWithinScope(ctx, metadata,
+				func(ctx context.Context, id commonids.ResourceGroupId) ([]storagemovers.StorageMover, error) {
+					resp, err := client.ListComplete(ctx, id)
+					return resp.Items, err
+				},
+				func(ctx context.Context, id commonids.SubscriptionId) ([]storagemovers.StorageMover, error) {
+					resp, err := client.ListBySubscriptionComplete(ctx, id)
+					return resp.Items, err
+				},
+				func(item storagemovers.StorageMover) *string { return item.Id },
+				storagemovers.ParseStorageMoverIDInsensitively,
+			)
+		},
+	}
+}
+
 func (r StorageMoverResource) Update() sdk.ResourceFunc {
 	return sdk.ResourceFunc{
 		Timeout: 30 * time.Minute,
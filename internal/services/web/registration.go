@@ -10,6 +10,17 @@ import (
 
 type Registration struct{}
 
+var _ sdk.TypedServiceRegistrationWithResourceProviders = Registration{}
+var _ sdk.UntypedServiceRegistrationWithResourceProviders = Registration{}
+
+// ResourceProviders returns the Resource Providers used by this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.CertificateRegistration",
+		"Microsoft.Web",
+	}
+}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "Web"
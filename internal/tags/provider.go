@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tags
+
+import (
+	"strings"
+)
+
+// ProviderTags are the Tags configured within the Provider block, which apply to every Resource supporting Tags
+type ProviderTags struct {
+	// DefaultTags are applied to every Resource, unless the Resource specifies a Tag with the same key
+	DefaultTags map[string]string
+
+	// IgnoreKeys are the keys of Tags which should be ignored when reading a Resource (for example those
+	// applied by Azure Policy) - these are compared case-insensitively
+	IgnoreKeys []string
+
+	// IgnoreKeyPrefixes are the prefixes of the keys of Tags which should be ignored when reading a Resource,
+	// these are compared case-insensitively
+	IgnoreKeyPrefixes []string
+
+	// Policy is the Tag Policy which the Tags of every Resource must satisfy
+	Policy Policy
+}
+
+// IsIgnored returns whether the Tag with the key `key` should be ignored
+func (p ProviderTags) IsIgnored(key string) bool {
+	for _, v := range p.IgnoreKeys {
+		if strings.EqualFold(v, key) {
+			return true
+		}
+	}
+	for _, v := range p.IgnoreKeyPrefixes {
+		if v != "" && strings.HasPrefix(strings.ToLower(key), strings.ToLower(v)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Merge returns the Tags which should be applied to a Resource - that is the Default Tags which aren't ignored,
+// overridden by the Tags configured on the Resource (`configured`).
+func (p ProviderTags) Merge(configured map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{}, len(p.DefaultTags)+len(configured))
+
+	for k, v := range p.DefaultTags {
+		if p.IsIgnored(k) || containsKey(configured, k) {
+			continue
+		}
+		output[k] = v
+	}
+	for k, v := range configured {
+		output[k] = v
+	}
+
+	return output
+}
+
+// RemoveIgnored returns the Tags read from a Resource (`all`) without the Tags which are ignored, unless these
+// are configured on the Resource (`configured`).
+func (p ProviderTags) RemoveIgnored(all, configured map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{}, len(all))
+
+	for k, v := range all {
+		if p.IsIgnored(k) && !containsKey(configured, k) {
+			continue
+		}
+		output[k] = v
+	}
+
+	return output
+}
+
+// RemoveDefaults returns the Tags read from a Resource (`all`) without the Default Tags, unless these are
+// configured on the Resource (`configured`) - so that the `tags` field only contains the Tags from the configuration.
+func (p ProviderTags) RemoveDefaults(all, configured map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{}, len(all))
+
+	for k, v := range all {
+		if defaultValue, ok := p.DefaultTags[k]; ok && !containsKey(configured, k) {
+			if value, err := TagValueToString(v); err == nil && value == defaultValue {
+				continue
+			}
+		}
+		output[k] = v
+	}
+
+	return output
+}
+
+// Changes returns the Tags which need to be added (or updated) and removed so that the Tags applied to a Resource
+// (`applied`) match the Tags which are planned (`planned`). Only the Tags which were previously applied by the
+// Provider (`previous`) are removed, so that any other Tags on the Resource (e.g. those which are ignored) are retained.
+func Changes(applied, planned, previous map[string]interface{}) (merge map[string]interface{}, remove map[string]interface{}) {
+	merge = make(map[string]interface{})
+	for k, v := range planned {
+		if existing, ok := valueForKey(applied, k); !ok || existing != v {
+			merge[k] = v
+		}
+	}
+
+	remove = make(map[string]interface{})
+	for k := range previous {
+		if containsKey(planned, k) {
+			continue
+		}
+		if existing, ok := valueForKey(applied, k); ok {
+			remove[k] = existing
+		}
+	}
+
+	return merge, remove
+}
+
+// containsKey returns whether `input` contains the key `key` - since Tag keys are case-insensitive in Azure
+func containsKey(input map[string]interface{}, key string) bool {
+	for k := range input {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// valueForKey returns the value for the key `key` within `input`, comparing the keys case-insensitively
+func valueForKey(input map[string]interface{}, key string) (interface{}, bool) {
+	for k, v := range input {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tags
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProviderTagsMerge(t *testing.T) {
+	providerTags := ProviderTags{
+		DefaultTags: map[string]string{
+			"environment": "production",
+			"owner":       "platform",
+			"hidden-team": "networking",
+		},
+		IgnoreKeyPrefixes: []string{"hidden-"},
+	}
+
+	merged := providerTags.Merge(map[string]interface{}{
+		"Owner": "storage",
+		"cost":  "123",
+	})
+	expected := map[string]interface{}{
+		"environment": "production",
+		"Owner":       "storage",
+		"cost":        "123",
+	}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, merged)
+	}
+}
+
+func TestProviderTagsRemoveIgnored(t *testing.T) {
+	providerTags := ProviderTags{
+		IgnoreKeys:        []string{"CreatedBy"},
+		IgnoreKeyPrefixes: []string{"policy-"},
+	}
+
+	all := map[string]interface{}{
+		"createdby":      "azure-policy",
+		"Policy-Version": "2",
+		"policy-owner":   "someone",
+		"environment":    "production",
+	}
+	configured := map[string]interface{}{
+		"policy-owner": "someone",
+	}
+	expected := map[string]interface{}{
+		"policy-owner": "someone",
+		"environment":  "production",
+	}
+	if actual := providerTags.RemoveIgnored(all, configured); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, actual)
+	}
+}
+
+func TestProviderTagsRemoveDefaults(t *testing.T) {
+	providerTags := ProviderTags{
+		DefaultTags: map[string]string{
+			"environment": "production",
+			"owner":       "platform",
+			"cost":        "123",
+		},
+	}
+
+	all := map[string]interface{}{
+		"environment": "production",
+		"owner":       "storage",
+		"cost":        "123",
+		"application": "example",
+	}
+	configured := map[string]interface{}{
+		"cost":        "123",
+		"application": "example",
+	}
+	// `owner` has been overridden outside of Terraform, so should show up as a diff
+	expected := map[string]interface{}{
+		"owner":       "storage",
+		"cost":        "123",
+		"application": "example",
+	}
+	if actual := providerTags.RemoveDefaults(all, configured); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, actual)
+	}
+}
+
+func TestChanges(t *testing.T) {
+	applied := map[string]interface{}{
+		"Environment":    "production",
+		"owner":          "platform",
+		"cost":           "123",
+		"policy-version": "2",
+	}
+	planned := map[string]interface{}{
+		"environment": "production",
+		"owner":       "storage",
+		"application": "example",
+	}
+	previous := map[string]interface{}{
+		"environment": "production",
+		"owner":       "platform",
+		"cost":        "123",
+	}
+
+	merge, remove := Changes(applied, planned, previous)
+	expectedMerge := map[string]interface{}{
+		"owner":       "storage",
+		"application": "example",
+	}
+	if !reflect.DeepEqual(merge, expectedMerge) {
+		t.Fatalf("expected the Tags to merge to be %+v but got %+v", expectedMerge, merge)
+	}
+
+	// `policy-version` wasn't previously applied by the Provider, so is retained
+	expectedRemove := map[string]interface{}{
+		"cost": "123",
+	}
+	if !reflect.DeepEqual(remove, expectedRemove) {
+		t.Fatalf("expected the Tags to remove to be %+v but got %+v", expectedRemove, remove)
+	}
+}
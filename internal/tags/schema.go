@@ -20,10 +20,11 @@ func SchemaDataSource() *pluginsdk.Schema {
 // require recreation of the resource
 func ForceNewSchema() *pluginsdk.Schema {
 	return &pluginsdk.Schema{
-		Type:         pluginsdk.TypeMap,
-		Optional:     true,
-		ForceNew:     true,
-		ValidateFunc: Validate,
+		Type:             pluginsdk.TypeMap,
+		Optional:         true,
+		ForceNew:         true,
+		ValidateFunc:     Validate,
+		DiffSuppressFunc: SuppressCaseOnlyKeyDiff,
 		Elem: &pluginsdk.Schema{
 			Type: pluginsdk.TypeString,
 		},
@@ -33,9 +34,10 @@ func ForceNewSchema() *pluginsdk.Schema {
 // Schema returns the Schema used for Tags
 func Schema() *pluginsdk.Schema {
 	return &pluginsdk.Schema{
-		Type:         pluginsdk.TypeMap,
-		Optional:     true,
-		ValidateFunc: Validate,
+		Type:             pluginsdk.TypeMap,
+		Optional:         true,
+		ValidateFunc:     Validate,
+		DiffSuppressFunc: SuppressCaseOnlyKeyDiff,
 		Elem: &pluginsdk.Schema{
 			Type: pluginsdk.TypeString,
 		},
@@ -45,9 +47,10 @@ func Schema() *pluginsdk.Schema {
 // SchemaWithMax returns the Schema with the maximum used for Tags
 func SchemaWithMax(max int) *pluginsdk.Schema {
 	return &pluginsdk.Schema{
-		Type:         pluginsdk.TypeMap,
-		Optional:     true,
-		ValidateFunc: ValidateWithMax(max),
+		Type:             pluginsdk.TypeMap,
+		Optional:         true,
+		ValidateFunc:     ValidateWithMax(max),
+		DiffSuppressFunc: SuppressCaseOnlyKeyDiff,
 		Elem: &pluginsdk.Schema{
 			Type: pluginsdk.TypeString,
 		},
@@ -65,3 +68,15 @@ func SchemaEnforceLowerCaseKeys() *pluginsdk.Schema {
 		},
 	}
 }
+
+// SchemaTagsAll returns the Schema used for `tags_all`, which contains all of the Tags applied to a Resource
+// (including the Default Tags configured within the Provider block)
+func SchemaTagsAll() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeMap,
+		Computed: true,
+		Elem: &pluginsdk.Schema{
+			Type: pluginsdk.TypeString,
+		},
+	}
+}
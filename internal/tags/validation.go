@@ -5,6 +5,8 @@ package tags
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -105,3 +107,110 @@ func EnforceLowerCaseKeys(i interface{}, k string) (warnings []string, errors []
 
 	return warnings, errors
 }
+
+// Policy is the Tag Policy configured within the Provider block, which is checked when planning every Resource supporting Tags
+type Policy struct {
+	// RequiredKeys are the keys of the Tags which must be applied to every Resource
+	RequiredKeys []string
+
+	// AllowedValues are the regular expressions which the entire value of a Tag must match, keyed by the key of the Tag
+	AllowedValues map[string]*regexp.Regexp
+
+	// ReservedKeyPrefixes are the prefixes which the keys of Tags mustn't use (e.g. `hidden-` and `link:`)
+	ReservedKeyPrefixes []string
+}
+
+// Validate returns the violations of the Tag Policy by the Tags `tagsMap` - keys are compared case-insensitively
+func (p Policy) Validate(tagsMap map[string]interface{}) (errors []error) {
+	for _, key := range p.RequiredKeys {
+		if !containsKey(tagsMap, key) {
+			errors = append(errors, fmt.Errorf("the tag %q is required by the tag policy", key))
+		}
+	}
+
+	keys := make([]string, 0, len(tagsMap))
+	for k := range tagsMap {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		for _, prefix := range p.ReservedKeyPrefixes {
+			if prefix != "" && strings.HasPrefix(strings.ToLower(key), strings.ToLower(prefix)) {
+				errors = append(errors, fmt.Errorf("the tag %q uses the prefix %q which is reserved by the tag policy", key, prefix))
+			}
+		}
+
+		for allowedKey, regex := range p.AllowedValues {
+			if !strings.EqualFold(allowedKey, key) || regex == nil {
+				continue
+			}
+			value, err := TagValueToString(tagsMap[key])
+			if err != nil {
+				errors = append(errors, err)
+				continue
+			}
+			if !regex.MatchString(value) {
+				errors = append(errors, fmt.Errorf("the value %q for the tag %q must match %q to satisfy the tag policy", value, key, regex.String()))
+			}
+		}
+	}
+
+	return errors
+}
+
+// CompileAllowedValue compiles the regular expression `input` so that it must match the entire value of a Tag
+func CompileAllowedValue(input string) (*regexp.Regexp, error) {
+	return regexp.Compile(fmt.Sprintf("^(?:%s)$", input))
+}
+
+// SuppressCaseOnlyKeyDiff suppresses the diff for a Tag whose key has only changed case (with the same value),
+// since Azure treats the keys of Tags case-insensitively - which otherwise causes a perpetual diff
+func SuppressCaseOnlyKeyDiff(k, old, new string, d *schema.ResourceData) bool {
+	field, key, ok := strings.Cut(k, ".")
+	if !ok || key == "%" || (old != "" && new != "") {
+		return false
+	}
+
+	rawOld, rawNew := d.GetChange(field)
+	oldTags, _ := rawOld.(map[string]interface{})
+	newTags, _ := rawNew.(map[string]interface{})
+
+	// either the key is being added (and was removed with a different case), or is being removed (and is being added with a different case)
+	other, value := oldTags, new
+	if new == "" {
+		other, value = newTags, old
+	}
+	for otherKey, otherValue := range other {
+		if otherKey == key || !strings.EqualFold(otherKey, key) {
+			continue
+		}
+		if v, err := TagValueToString(otherValue); err == nil && v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// EqualFold returns whether the Tags `a` and `b` are equal, comparing the keys case-insensitively
+func EqualFold(a, b map[string]interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for k, v := range a {
+		found := false
+		for otherKey, otherValue := range b {
+			if strings.EqualFold(k, otherKey) && otherValue == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
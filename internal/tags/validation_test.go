@@ -4,9 +4,14 @@
 package tags
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
 func TestValidateMaximumNumberOfTags(t *testing.T) {
@@ -88,3 +93,132 @@ func TestValidateTagMaxValueLength(t *testing.T) {
 		t.Fatal("Expected the length in the validation error for value")
 	}
 }
+
+func TestPolicyValidate(t *testing.T) {
+	costCentre, err := CompileAllowedValue("[0-9]{4}")
+	if err != nil {
+		t.Fatalf("compiling: %+v", err)
+	}
+	policy := Policy{
+		RequiredKeys: []string{"environment", "owner"},
+		AllowedValues: map[string]*regexp.Regexp{
+			"cost-centre": costCentre,
+		},
+		ReservedKeyPrefixes: []string{"hidden-", "link:"},
+	}
+
+	cases := []struct {
+		tags     map[string]interface{}
+		expected int
+	}{
+		{
+			tags: map[string]interface{}{
+				"Environment": "production",
+				"owner":       "platform",
+				"Cost-Centre": "1234",
+			},
+			expected: 0,
+		},
+		{
+			tags: map[string]interface{}{
+				"environment": "production",
+			},
+			expected: 1,
+		},
+		{
+			tags: map[string]interface{}{
+				"environment":    "production",
+				"owner":          "platform",
+				"Hidden-Title":   "example",
+				"link:something": "example",
+			},
+			expected: 2,
+		},
+		{
+			tags: map[string]interface{}{
+				"environment": "production",
+				"owner":       "platform",
+				"cost-centre": "12345",
+			},
+			expected: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		if errors := policy.Validate(tc.tags); len(errors) != tc.expected {
+			t.Fatalf("expected %d errors for %+v but got %d: %+v", tc.expected, tc.tags, len(errors), errors)
+		}
+	}
+}
+
+func TestSuppressCaseOnlyKeyDiff(t *testing.T) {
+	resource := &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"tags": Schema(),
+		},
+	}
+	state := &terraform.InstanceState{
+		ID: "example",
+		Attributes: map[string]string{
+			"id":               "example",
+			"tags.%":           "2",
+			"tags.Environment": "production",
+			"tags.owner":       "platform",
+		},
+	}
+
+	cases := []struct {
+		tags     map[string]interface{}
+		expected bool
+	}{
+		{
+			tags: map[string]interface{}{
+				"environment": "production",
+				"owner":       "platform",
+			},
+			expected: false,
+		},
+		{
+			tags: map[string]interface{}{
+				"environment": "staging",
+				"owner":       "platform",
+			},
+			expected: true,
+		},
+		{
+			tags: map[string]interface{}{
+				"Environment": "production",
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range cases {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"tags": tc.tags,
+		})
+		diff, err := resource.Diff(context.TODO(), state, config, nil)
+		if err != nil {
+			t.Fatalf("diffing %+v: %+v", tc.tags, err)
+		}
+		if hasDiff := diff != nil && len(diff.Attributes) > 0; hasDiff != tc.expected {
+			t.Fatalf("expected a diff to be %t for %+v but got %+v", tc.expected, tc.tags, diff)
+		}
+	}
+}
+
+func TestEqualFold(t *testing.T) {
+	a := map[string]interface{}{
+		"Environment": "production",
+		"owner":       "platform",
+	}
+	if !EqualFold(a, map[string]interface{}{"environment": "production", "Owner": "platform"}) {
+		t.Fatalf("expected the tags to be equal when only the case of the keys differs")
+	}
+	if EqualFold(a, map[string]interface{}{"environment": "Production", "owner": "platform"}) {
+		t.Fatalf("expected the tags not to be equal when the case of a value differs")
+	}
+	if EqualFold(a, map[string]interface{}{"environment": "production"}) {
+		t.Fatalf("expected the tags not to be equal when a tag has been removed")
+	}
+}
@@ -0,0 +1,109 @@
+package check
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/model"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/schema"
+)
+
+type AttributeIssue int
+
+func (a AttributeIssue) String() string {
+	return []string{"ok", "missing", "not computed"}[a]
+}
+
+const (
+	AttributeOK          AttributeIssue = iota
+	AttributeMissInDoc                  // computed attribute not listed in the Attributes Reference
+	AttributeNotComputed                // listed in the Attributes Reference but not computed in the schema
+)
+
+type attributeDiff struct {
+	checkBase
+	Issue AttributeIssue
+}
+
+func newAttributeDiff(checkBase checkBase, issue AttributeIssue) *attributeDiff {
+	return &attributeDiff{checkBase: checkBase, Issue: issue}
+}
+
+func (c attributeDiff) String() string {
+	if c.Issue == AttributeNotComputed {
+		return fmt.Sprintf("%s is not a Computed attribute and should be documented in the Arguments Reference", c.checkBase.Str())
+	}
+	return fmt.Sprintf("%s is a Computed attribute which is missing in the Attributes Reference", c.checkBase.Str())
+}
+
+func (c attributeDiff) Fix(line string) (result string, err error) {
+	return line, nil
+}
+
+var _ Checker = (*attributeDiff)(nil)
+
+// checkAttributes cross-checks the Attributes Reference of a document with the Computed attributes of the schema,
+// including the Computed attributes nested in blocks
+func checkAttributes(r *schema.Resource, md *model.ResourceDoc) (res []Checker) {
+	attrs := model.Properties{}
+	for key, f := range md.Attr {
+		attrs[key] = f
+	}
+	// a field documented in both the Arguments and the Attributes Reference is kept in Args
+	for key, f := range md.Args {
+		if f.SameNameAttr != nil {
+			attrs[key] = f.SameNameAttr
+		}
+	}
+	return diffAttributes(r.ResourceType, "", r.Schema.Schema, attrs, false)
+}
+
+func diffAttributes(rt, parent string, sch map[string]*pluginsdk.Schema, attrs model.Properties, parentComputed bool) (res []Checker) {
+	for key, s := range sch {
+		path := strings.TrimPrefix(parent+"."+key, ".")
+		if shouldSkipDocProp(rt, path) || isSkipProp(rt, path) || s.Deprecated != "" {
+			continue
+		}
+
+		computedOnly := parentComputed || (s.Computed && !s.Optional && !s.Required)
+		f := attrs[key]
+		if computedOnly && f == nil {
+			f2 := &model.Field{
+				Name:    key,
+				Path:    path,
+				Pos:     model.PosAttr,
+				Content: s.GoString(),
+			}
+			res = append(res, newAttributeDiff(newCheckBase(0, path, f2), AttributeMissInDoc))
+			// a missing block is reported once rather than for each of the nested attributes
+			continue
+		}
+
+		// a block without nested fields is a format error, which is reported by crossCheckProperty
+		if sub, ok := s.Elem.(*pluginsdk.Resource); ok && (f == nil || f.Subs != nil) {
+			var subAttrs model.Properties
+			if f != nil {
+				subAttrs = f.Subs
+			}
+			res = append(res, diffAttributes(rt, path, sub.Schema, subAttrs, computedOnly)...)
+		}
+	}
+
+	// blocks in the Attributes Reference commonly describe their arguments too, so only top-level fields are checked
+	if parent != "" {
+		return res
+	}
+	for key, f := range attrs {
+		s, ok := sch[key]
+		if !ok || s.Computed || isSkipProp(rt, key) {
+			continue
+		}
+		// arguments which are blocks are listed in the Attributes Reference to document their Computed attributes
+		if _, isBlock := s.Elem.(*pluginsdk.Resource); isBlock {
+			continue
+		}
+		res = append(res, newAttributeDiff(newCheckBase(f.Line, key, f), AttributeNotComputed))
+	}
+	return res
+}
@@ -0,0 +1,95 @@
+package check
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/model"
+)
+
+func TestDiffAttributes(t *testing.T) {
+	sch := map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+		},
+		"endpoint": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+		"secondary_endpoint": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+		"identity": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"type": {
+						Type:     pluginsdk.TypeString,
+						Required: true,
+					},
+					"principal_id": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+					"tenant_id": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+		"replica": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"location": {
+						Type: pluginsdk.TypeString,
+					},
+				},
+			},
+		},
+	}
+
+	attrs := model.Properties{
+		"name": {
+			Name: "name",
+			Line: 10,
+		},
+		"endpoint": {
+			Name: "endpoint",
+			Line: 11,
+		},
+		"identity": {
+			Name: "identity",
+			Line: 12,
+			Subs: model.Properties{
+				"principal_id": {
+					Name: "principal_id",
+					Line: 14,
+				},
+			},
+		},
+	}
+
+	expected := map[string]AttributeIssue{
+		"name":               AttributeNotComputed,
+		"secondary_endpoint": AttributeMissInDoc,
+		"identity.tenant_id": AttributeMissInDoc,
+		"replica":            AttributeMissInDoc,
+	}
+
+	res := diffAttributes("azurerm_example", "", sch, attrs, false)
+	if len(res) != len(expected) {
+		t.Fatalf("expected %d issues but got %d: %+v", len(expected), len(res), res)
+	}
+	for _, item := range res {
+		diff := item.(*attributeDiff)
+		if issue, ok := expected[diff.Key()]; !ok || issue != diff.Issue {
+			t.Fatalf("unexpected issue %q (%s)", diff.Key(), diff.Issue)
+		}
+	}
+}
@@ -0,0 +1,386 @@
+package check
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/model"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/util"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+type ExampleIssue int
+
+func (e ExampleIssue) String() string {
+	return []string{"invalid hcl", "unknown resource", "unknown argument", "missing required argument", "wrong type"}[e]
+}
+
+const (
+	ExampleInvalidHCL ExampleIssue = iota
+	ExampleUnknownResource
+	ExampleUnknownArgument
+	ExampleMissingRequired
+	ExampleWrongType
+)
+
+// meta-arguments and blocks which are handled by Terraform rather than the resource schema
+var exampleMetaArguments = map[string]struct{}{
+	"count":      {},
+	"depends_on": {},
+	"for_each":   {},
+	"provider":   {},
+}
+
+var exampleMetaBlocks = map[string]struct{}{
+	"connection":  {},
+	"lifecycle":   {},
+	"provisioner": {},
+	"timeouts":    {},
+}
+
+type exampleDiff struct {
+	checkBase
+	Issue       ExampleIssue
+	detail      string
+	correctName string // for unknown argument only
+}
+
+func newExampleDiff(line int, key string, name string, issue ExampleIssue) *exampleDiff {
+	f := &model.Field{
+		Name: name,
+		Path: key,
+		Line: line,
+		Pos:  model.PosExample,
+	}
+	return &exampleDiff{
+		checkBase: newCheckBase(line, key, f),
+		Issue:     issue,
+	}
+}
+
+func (c exampleDiff) String() string {
+	switch c.Issue {
+	case ExampleInvalidHCL:
+		return fmt.Sprintf("%s example is not valid HCL: %s", c.checkBase.Str(), c.detail)
+	case ExampleUnknownResource:
+		return fmt.Sprintf("%s example uses a resource or data source which does not exist in the provider", c.checkBase.Str())
+	case ExampleUnknownArgument:
+		if c.correctName != "" {
+			return fmt.Sprintf("%s example uses an argument which does not exist in the schema - should this be %s?", c.checkBase.Str(), util.FixedCode(c.correctName))
+		}
+		return fmt.Sprintf("%s example uses an argument which does not exist in the schema", c.checkBase.Str())
+	case ExampleMissingRequired:
+		return fmt.Sprintf("%s example is missing the required argument %s", c.checkBase.Str(), util.ItalicCode(c.detail))
+	}
+	return fmt.Sprintf("%s example %s", c.checkBase.Str(), c.detail)
+}
+
+// Fix renames a misspelled argument or block in the example
+func (c exampleDiff) Fix(line string) (result string, err error) {
+	if c.Issue != ExampleUnknownArgument || c.correctName == "" {
+		return line, nil
+	}
+	reg, err := regexp.Compile(`^(\s*)` + regexp.QuoteMeta(c.mdField.Name) + `(\s*[={])`)
+	if err != nil {
+		return line, err
+	}
+	return reg.ReplaceAllString(line, "${1}"+c.correctName+"${2}"), nil
+}
+
+var _ Checker = (*exampleDiff)(nil)
+
+type exampleHCL struct {
+	firstLine int // index of the first line of code in the markdown file
+	content   string
+}
+
+// extractExamples returns the HCL code blocks within the `Example Usage` sections of a document
+func extractExamples(lines []string) (res []exampleHCL) {
+	var inExample, inCode, isHCL bool
+	var code []string
+	var firstLine int
+	for idx, line := range lines {
+		switch {
+		case inCode:
+			if !strings.HasPrefix(line, "```") {
+				code = append(code, line)
+				continue
+			}
+			if isHCL {
+				res = append(res, exampleHCL{
+					firstLine: firstLine,
+					content:   strings.Join(code, "\n"),
+				})
+			}
+			inCode = false
+		case strings.HasPrefix(line, "## "):
+			inExample = strings.HasPrefix(line, "## Example")
+		case strings.HasPrefix(line, "```"):
+			lang := strings.TrimSpace(strings.TrimPrefix(line, "```"))
+			inCode = true
+			isHCL = inExample && (lang == "" || lang == "hcl" || lang == "terraform")
+			firstLine = idx + 1
+			code = nil
+		}
+	}
+	return res
+}
+
+// checkExamples parses the examples of a document and validates every azurerm_* block against the provider schema
+func checkExamples(mdFile string) (res []Checker) {
+	content, err := os.ReadFile(mdFile)
+	if err != nil {
+		return nil
+	}
+
+	for _, example := range extractExamples(strings.Split(string(content), "\n")) {
+		res = append(res, checkExample(mdFile, example)...)
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Line() < res[j].Line()
+	})
+	return res
+}
+
+func checkExample(mdFile string, example exampleHCL) (res []Checker) {
+	file, diags := hclsyntax.ParseConfig([]byte(example.content), mdFile, hcl.Pos{Line: example.firstLine + 1, Column: 1})
+	if diags.HasErrors() {
+		line := example.firstLine
+		if subject := diags[0].Subject; subject != nil {
+			line = subject.Start.Line - 1
+		}
+		item := newExampleDiff(line, "example", "", ExampleInvalidHCL)
+		item.detail = diags[0].Summary
+		return []Checker{item}
+	}
+
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil
+	}
+	for _, block := range body.Blocks {
+		if len(block.Labels) != 2 || !strings.HasPrefix(block.Labels[0], "azurerm_") {
+			continue
+		}
+
+		var resource *pluginsdk.Resource
+		var exists bool
+		address := block.Labels[0] + "." + block.Labels[1]
+		switch block.Type {
+		case "resource":
+			resource, exists = schema.ResourceSchemaFor(block.Labels[0])
+		case "data":
+			resource, exists = schema.DataSourceSchemaFor(block.Labels[0])
+			address = "data." + address
+		default:
+			continue
+		}
+
+		line := block.TypeRange.Start.Line - 1
+		if !exists {
+			res = append(res, newExampleDiff(line, address, block.Labels[0], ExampleUnknownResource))
+			continue
+		}
+		res = append(res, checkExampleBody(address, line, block.Body, resource.Schema, true)...)
+	}
+	return res
+}
+
+func checkExampleBody(path string, line int, body *hclsyntax.Body, sch map[string]*pluginsdk.Schema, topLevel bool) (res []Checker) {
+	specified := map[string]struct{}{}
+
+	for name, attr := range body.Attributes {
+		if _, ok := exampleMetaArguments[name]; ok && topLevel {
+			continue
+		}
+		specified[name] = struct{}{}
+
+		key := path + "." + name
+		attrLine := attr.NameRange.Start.Line - 1
+		s, ok := sch[name]
+		switch {
+		case !ok:
+			item := newExampleDiff(attrLine, key, name, ExampleUnknownArgument)
+			item.correctName = closestArgument(name, sch, body)
+			res = append(res, item)
+		case !s.Optional && !s.Required:
+			item := newExampleDiff(attrLine, key, name, ExampleWrongType)
+			item.detail = "sets a Computed only attribute"
+			res = append(res, item)
+		case isBlockSchema(s) && s.ConfigMode != pluginsdk.SchemaConfigModeAttr:
+			item := newExampleDiff(attrLine, key, name, ExampleWrongType)
+			item.detail = "should be specified as a block"
+			res = append(res, item)
+		default:
+			if msg := checkExampleValue(attr.Expr, s); msg != "" {
+				item := newExampleDiff(attrLine, key, name, ExampleWrongType)
+				item.detail = msg
+				res = append(res, item)
+			}
+		}
+	}
+
+	for _, block := range body.Blocks {
+		name := block.Type
+		blockBody := block.Body
+		if name == "dynamic" && len(block.Labels) == 1 {
+			// the content of a dynamic block is validated as the block it generates
+			name = block.Labels[0]
+			blockBody = nil
+			for _, b := range block.Body.Blocks {
+				if b.Type == "content" {
+					blockBody = b.Body
+				}
+			}
+		}
+		if _, ok := exampleMetaBlocks[name]; ok && topLevel {
+			continue
+		}
+		specified[name] = struct{}{}
+
+		key := path + "." + name
+		blockLine := block.TypeRange.Start.Line - 1
+		s, ok := sch[name]
+		switch {
+		case !ok:
+			item := newExampleDiff(blockLine, key, name, ExampleUnknownArgument)
+			item.correctName = closestArgument(name, sch, body)
+			res = append(res, item)
+		case !s.Optional && !s.Required:
+			item := newExampleDiff(blockLine, key, name, ExampleWrongType)
+			item.detail = "sets a Computed only attribute"
+			res = append(res, item)
+		case !isBlockSchema(s):
+			item := newExampleDiff(blockLine, key, name, ExampleWrongType)
+			item.detail = "should be specified as an argument rather than a block"
+			res = append(res, item)
+		case blockBody != nil:
+			res = append(res, checkExampleBody(key, blockLine, blockBody, s.Elem.(*pluginsdk.Resource).Schema, false)...)
+		}
+	}
+
+	var missing []string
+	for name, s := range sch {
+		if _, ok := specified[name]; !ok && s.Required {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	for _, name := range missing {
+		item := newExampleDiff(line, path, name, ExampleMissingRequired)
+		item.detail = name
+		res = append(res, item)
+	}
+	return res
+}
+
+func isBlockSchema(s *pluginsdk.Schema) bool {
+	if s.Type != pluginsdk.TypeList && s.Type != pluginsdk.TypeSet {
+		return false
+	}
+	_, ok := s.Elem.(*pluginsdk.Resource)
+	return ok
+}
+
+// checkExampleValue returns a message if a literal value can not be converted to the type of the schema,
+// values referencing other resources or calling functions can't be evaluated and are skipped
+func checkExampleValue(expr hclsyntax.Expression, s *pluginsdk.Schema) string {
+	if len(expr.Variables()) > 0 {
+		return ""
+	}
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() || val.IsNull() || !val.IsWhollyKnown() {
+		return ""
+	}
+	want := exampleImpliedType(s)
+	if want == cty.NilType {
+		return ""
+	}
+	if _, err := convert.Convert(val, want); err != nil {
+		return fmt.Sprintf("should be a %s but got a %s", want.FriendlyName(), val.Type().FriendlyName())
+	}
+	return ""
+}
+
+func exampleImpliedType(s *pluginsdk.Schema) cty.Type {
+	switch s.Type {
+	case pluginsdk.TypeString:
+		return cty.String
+	case pluginsdk.TypeInt, pluginsdk.TypeFloat:
+		return cty.Number
+	case pluginsdk.TypeBool:
+		return cty.Bool
+	case pluginsdk.TypeList, pluginsdk.TypeSet:
+		if elem, ok := s.Elem.(*pluginsdk.Schema); ok {
+			if elemType := exampleImpliedType(elem); elemType != cty.NilType {
+				return cty.List(elemType)
+			}
+		}
+	case pluginsdk.TypeMap:
+		if elem, ok := s.Elem.(*pluginsdk.Schema); ok {
+			if elemType := exampleImpliedType(elem); elemType != cty.NilType {
+				return cty.Map(elemType)
+			}
+		}
+		return cty.Map(cty.String)
+	}
+	return cty.NilType
+}
+
+// closestArgument returns the argument in the schema which an unknown argument is most likely a misspelling or
+// an old name of, e.g. `enable_https_traffic_only` for `https_traffic_only_enabled`
+func closestArgument(name string, sch map[string]*pluginsdk.Schema, body *hclsyntax.Body) (res string) {
+	candidates := make([]string, 0)
+	for key := range sch {
+		_, isAttr := body.Attributes[key]
+		if !isAttr && !blockExists(body, key) {
+			candidates = append(candidates, key)
+		}
+	}
+	sort.Strings(candidates)
+
+	words := renameWords(name)
+	minDist := 3
+	for _, key := range candidates {
+		if words != "" && renameWords(key) == words {
+			return key
+		}
+		if dist := levenshteinDist(name, key); dist <= minDist && dist < len(name)/2 {
+			minDist = dist
+			res = key
+		}
+	}
+	return res
+}
+
+func blockExists(body *hclsyntax.Body, name string) bool {
+	for _, b := range body.Blocks {
+		if b.Type == name || (b.Type == "dynamic" && len(b.Labels) == 1 && b.Labels[0] == name) {
+			return true
+		}
+	}
+	return false
+}
+
+// renameWords normalises a name to its sorted words without the enable/enabled/is prefixes and suffixes
+func renameWords(name string) string {
+	var words []string
+	for _, word := range strings.Split(name, "_") {
+		switch word {
+		case "enable", "enabled", "is":
+			continue
+		}
+		words = append(words, word)
+	}
+	sort.Strings(words)
+	return strings.Join(words, "_")
+}
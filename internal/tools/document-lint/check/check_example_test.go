@@ -0,0 +1,144 @@
+package check
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestExtractExamples(t *testing.T) {
+	doc := "# azurerm_example\n" +
+		"\n" +
+		"## Example Usage\n" +
+		"\n" +
+		"```hcl\n" +
+		"resource \"azurerm_example\" \"example\" {}\n" +
+		"```\n" +
+		"\n" +
+		"```shell\n" +
+		"terraform apply\n" +
+		"```\n" +
+		"\n" +
+		"## Arguments Reference\n" +
+		"\n" +
+		"```hcl\n" +
+		"not_an_example = true\n" +
+		"```\n"
+
+	examples := extractExamples(strings.Split(doc, "\n"))
+	if len(examples) != 1 {
+		t.Fatalf("expected 1 example but got %d: %+v", len(examples), examples)
+	}
+	if examples[0].firstLine != 5 {
+		t.Fatalf("expected the example to start at line index 5 but got %d", examples[0].firstLine)
+	}
+	if examples[0].content != `resource "azurerm_example" "example" {}` {
+		t.Fatalf("unexpected example content %q", examples[0].content)
+	}
+}
+
+func TestCheckExampleBody(t *testing.T) {
+	sch := map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+		},
+		"location": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+		},
+		"instance_count": {
+			Type:     pluginsdk.TypeInt,
+			Optional: true,
+		},
+		"https_traffic_only_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+		},
+		"endpoint": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+		"network_rules": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"default_action": {
+						Type:     pluginsdk.TypeString,
+						Required: true,
+					},
+					"ip_rules": {
+						Type:     pluginsdk.TypeSet,
+						Optional: true,
+						Elem: &pluginsdk.Schema{
+							Type: pluginsdk.TypeString,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	config := `resource "azurerm_example" "example" {
+  count                     = 2
+  name                      = "example"
+  instance_count            = "two"
+  enable_https_traffic_only = true
+  endpoint                  = "https://example.com"
+
+  network_rules {
+    ip_rules = "10.0.0.0/24"
+  }
+
+  lifecycle {
+    ignore_changes = [tags]
+  }
+}
+`
+	file, diags := hclsyntax.ParseConfig([]byte(config), "example.tf", hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		t.Fatalf("parsing config: %+v", diags)
+	}
+	block := file.Body.(*hclsyntax.Body).Blocks[0]
+
+	expected := map[string]ExampleIssue{
+		"azurerm_example.example.instance_count":               ExampleWrongType,
+		"azurerm_example.example.enable_https_traffic_only":    ExampleUnknownArgument,
+		"azurerm_example.example.endpoint":                     ExampleWrongType,
+		"azurerm_example.example.network_rules.ip_rules":       ExampleWrongType,
+		"azurerm_example.example.network_rules:default_action": ExampleMissingRequired,
+		"azurerm_example.example:location":                     ExampleMissingRequired,
+	}
+
+	res := checkExampleBody("azurerm_example.example", 0, block.Body, sch, true)
+	if len(res) != len(expected) {
+		t.Fatalf("expected %d issues but got %d: %+v", len(expected), len(res), res)
+	}
+	for _, item := range res {
+		diff := item.(*exampleDiff)
+		key := diff.Key()
+		if diff.Issue == ExampleMissingRequired {
+			key += ":" + diff.detail
+		}
+		if issue, ok := expected[key]; !ok || issue != diff.Issue {
+			t.Fatalf("unexpected issue %q (%s)", key, diff.Issue)
+		}
+
+		if diff.Issue == ExampleUnknownArgument {
+			if diff.correctName != "https_traffic_only_enabled" {
+				t.Fatalf("expected `enable_https_traffic_only` to be renamed to `https_traffic_only_enabled` but got %q", diff.correctName)
+			}
+			fixed, err := diff.Fix("  enable_https_traffic_only = true")
+			if err != nil {
+				t.Fatalf("fixing line: %+v", err)
+			}
+			if fixed != "  https_traffic_only_enabled = true" {
+				t.Fatalf("unexpected fixed line %q", fixed)
+			}
+		}
+	}
+}
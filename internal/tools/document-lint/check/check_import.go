@@ -0,0 +1,57 @@
+package check
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/model"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/util"
+)
+
+type importDiff struct {
+	checkBase
+	err error
+}
+
+func newImportDiff(checkBase checkBase, err error) *importDiff {
+	return &importDiff{checkBase: checkBase, err: err}
+}
+
+func (c importDiff) String() string {
+	if c.err == nil {
+		return fmt.Sprintf("%s Document has no `terraform import` example in the Import section", c.checkBase.Str())
+	}
+	return fmt.Sprintf("%s sample ID in the Import section is not valid for the resource: %s", c.checkBase.Str(), util.IssueLine(c.err.Error()))
+}
+
+func (c importDiff) Fix(line string) (result string, err error) {
+	return line, nil
+}
+
+var _ Checker = (*importDiff)(nil)
+
+// checkImport validates the sample ID of the `terraform import` command against the resource's ID validation,
+// so that the ID formats in the documents always round-trip
+func checkImport(r *schema.Resource, md *model.ResourceDoc) (res []Checker) {
+	if r.IsDataSource || r.Schema.Importer == nil {
+		return nil
+	}
+
+	f := &model.Field{
+		Name: "import",
+		Path: r.ResourceType,
+		Line: md.Import.Line,
+		Pos:  model.PosImport,
+	}
+	if md.Import.Line == 0 {
+		return []Checker{newImportDiff(newCheckBase(0, r.ResourceType, f), nil)}
+	}
+	if md.Import.ResourceType != r.ResourceType {
+		err := fmt.Errorf("the import command is for %s rather than %s", md.Import.ResourceType, r.ResourceType)
+		return []Checker{newImportDiff(newCheckBase(md.Import.Line, r.ResourceType, f), err)}
+	}
+	if err := r.ValidateImportID(md.Import.ResourceID); err != nil {
+		return []Checker{newImportDiff(newCheckBase(md.Import.Line, r.ResourceType, f), err)}
+	}
+	return nil
+}
@@ -30,9 +30,13 @@ func (d *ResourceDiff) Diffs() []Checker {
 func (d *ResourceDiff) ToString() string {
 	var bs strings.Builder
 
+	name := d.tf.ResourceType
+	if d.tf.IsDataSource {
+		name = "data." + name
+	}
 	bs.WriteString(
 		fmt.Sprintf("%s: %s:1 has %d issue[s]:\n",
-			util.Bold(d.tf.ResourceType),
+			util.Bold(name),
 			d.tf.FilePathRel(),
 			len(d.Diff),
 		),
@@ -66,7 +70,11 @@ func NewResourceDiff(tf *schema.Resource) *ResourceDiff {
 	}
 	// try to detect Markdown path from resource
 	// can set it if not a regular MD path
-	r.MDFile = md.MDPathFor(tf.ResourceType)
+	if tf.IsDataSource {
+		r.MDFile = md.MDPathForDataSource(tf.ResourceType)
+	} else {
+		r.MDFile = md.MDPathFor(tf.ResourceType)
+	}
 	return r
 }
 
@@ -83,4 +91,13 @@ func (r *ResourceDiff) DiffAll() {
 
 	timeouts := diffTimeout(r.tf, r.md)
 	r.Diff = append(r.Diff, timeouts...)
+
+	attributes := checkAttributes(r.tf, r.md)
+	r.Diff = append(r.Diff, attributes...)
+
+	imports := checkImport(r.tf, r.md)
+	r.Diff = append(r.Diff, imports...)
+
+	examples := checkExamples(r.MDFile)
+	r.Diff = append(r.Diff, examples...)
 }
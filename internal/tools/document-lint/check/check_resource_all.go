@@ -121,7 +121,12 @@ func DiffAll(regs Resources, dryRun bool) *DiffResult {
 		var rds []*ResourceDiff
 		var catName string
 
-		sch := schema.NewResource(res.schema, res.name)
+		var sch *schema.Resource
+		if res.isDataSource {
+			sch = schema.NewDataSource(res.schema, res.name)
+		} else {
+			sch = schema.NewResource(res.schema, res.name)
+		}
 		rd := NewResourceDiff(sch)
 		if !dryRun {
 			md.FixFileNormalize(rd.MDFile)
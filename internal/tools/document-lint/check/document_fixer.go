@@ -120,6 +120,13 @@ func (f *Fixer) TryFix() (err error) {
 			return err
 		}
 
+		// these lines are HCL, commands or missing rather than sentences
+		switch item.(type) {
+		case *exampleDiff, *attributeDiff, *importDiff:
+			lines[lineIdx] = line
+			continue
+		}
+
 		if suf := strings.TrimSuffix(line, " "); suf != "" {
 			if ch := suf[len(suf)-1]; ch != '.' && ch != '?' {
 				line = suf + "."
@@ -4,11 +4,13 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 )
 
 type resource struct {
-	name   string
-	schema interface{}
+	name         string
+	schema       interface{}
+	isDataSource bool
 }
 
 type Resources struct {
@@ -70,7 +72,7 @@ func AzurermAllResources(service, skipService string, resources, skipResources s
 		if shouldSkipRP(name) {
 			continue
 		}
-		for _, svc := range r.Resources() {
+		for _, svc := range sdk.TypedResourcesForService(r) {
 			if shouldSKipResource(svc.ResourceType()) {
 				continue
 			}
@@ -79,6 +81,16 @@ func AzurermAllResources(service, skipService string, resources, skipResources s
 				schema: svc,
 			})
 		}
+		for _, ds := range r.DataSources() {
+			if shouldSKipResource(ds.ResourceType()) {
+				continue
+			}
+			res.resources = append(res.resources, resource{
+				name:         ds.ResourceType(),
+				schema:       ds,
+				isDataSource: true,
+			})
+		}
 	}
 
 	for _, r := range provider.SupportedUntypedServices() {
@@ -95,6 +107,16 @@ func AzurermAllResources(service, skipService string, resources, skipResources s
 				schema: svc,
 			})
 		}
+		for name, ds := range r.SupportedDataSources() {
+			if shouldSKipResource(name) {
+				continue
+			}
+			res.resources = append(res.resources, resource{
+				name:         name,
+				schema:       ds,
+				isDataSource: true,
+			})
+		}
 	}
 	return res
 }
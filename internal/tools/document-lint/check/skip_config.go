@@ -6,6 +6,7 @@ var skipProps = []string{
 	"all.timezone",
 	"all.time_zone",
 	"all.time_zone_id",
+	"all.tags_all",                           // added to every resource supporting default tags, so is documented once for the Provider block
 	"azurerm_nginx_deployment.identity.type", // there is a diff between real supported values and common identity schema
 }
 
@@ -31,8 +31,8 @@ var (
 
 func parseArgs() {
 	fs := flag.NewFlagSet("azdoc-check", flag.ExitOnError)
-	fs.StringVar(&resource, "resource", os.Getenv("ONLY_RESOURCE"), "a list of resource names to check")
-	fs.StringVar(&skipResource, "skip-resource", os.Getenv("SKIP_RESOURCE"), "a list of resource names to skip the check")
+	fs.StringVar(&resource, "resource", os.Getenv("ONLY_RESOURCE"), "a list of resource and data source names to check")
+	fs.StringVar(&skipResource, "skip-resource", os.Getenv("SKIP_RESOURCE"), "a list of resource and data source names to skip the check")
 	fs.StringVar(&service, "service", os.Getenv("ONLY_SERVICE"), "a list of services names to check")
 	fs.StringVar(&skipService, "skip-service", os.Getenv("SKIP_SERVICE"), "a list of service names to skip the check")
 
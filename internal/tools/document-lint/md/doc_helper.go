@@ -13,45 +13,56 @@ import (
 )
 
 var (
-	docRDir             string
-	resourceFilePathMap map[string]string
-	file2Reosurce       = map[string]string{}
-	once                sync.Once
+	docRDir       string
+	docDDir       string
+	filePathMaps  = map[string]map[string]string{} // document dir => resource type => file path
+	file2Reosurce = map[string]string{}
+	mappingLock   sync.Mutex
 )
 
 // MDPathFor return full path of markdown file of resource
 func MDPathFor(resourceType string) string {
+	return mdPathIn(ResourceDir(), resourceType)
+}
+
+// MDPathForDataSource return full path of markdown file of data source
+func MDPathForDataSource(dataSourceType string) string {
+	return mdPathIn(DataSourceDir(), dataSourceType)
+}
+
+func mdPathIn(dir, resourceType string) string {
 	// find source
-	fullPath := path.Join(ResourceDir(), fmt.Sprintf("%s.html.markdown", strings.TrimPrefix(resourceType, "azurerm_")))
+	fullPath := path.Join(dir, fmt.Sprintf("%s.html.markdown", strings.TrimPrefix(resourceType, "azurerm_")))
 	// check if file exists
 	if _, err := os.Stat(fullPath); os.IsNotExist(err) {
-		return getMappingPath(resourceType)
+		return getMappingPath(dir, resourceType)
 	}
 	return fullPath
 }
 
-func getMappingPath(resourceName string) (res string) {
-	if resourceFilePathMap == nil {
-		once.Do(func() {
-			tmpMap := map[string]string{}
+func getMappingPath(dirPath, resourceName string) (res string) {
+	mappingLock.Lock()
+	defer mappingLock.Unlock()
+
+	if _, ok := filePathMaps[dirPath]; !ok {
+		tmpMap := map[string]string{}
 
-			dir, err := os.ReadDir(ResourceDir())
-			_ = err
-			for _, en := range dir {
-				if en.IsDir() {
-					continue
-				}
-				fullPath := path.Join(ResourceDir(), en.Name())
-				name := fileResource(fullPath)
-				tmpMap[name] = fullPath
-				if _, ok := file2Reosurce[fullPath]; !ok {
-					file2Reosurce[fullPath] = name
-				}
+		dir, err := os.ReadDir(dirPath)
+		_ = err
+		for _, en := range dir {
+			if en.IsDir() {
+				continue
 			}
-			resourceFilePathMap = tmpMap
-		})
+			fullPath := path.Join(dirPath, en.Name())
+			name := fileResource(fullPath)
+			tmpMap[name] = fullPath
+			if _, ok := file2Reosurce[fullPath]; !ok {
+				file2Reosurce[fullPath] = name
+			}
+		}
+		filePathMaps[dirPath] = tmpMap
 	}
-	return resourceFilePathMap[resourceName]
+	return filePathMaps[dirPath][resourceName]
 }
 
 var titleReg = regexp.MustCompile(`\npage_title:[^\n]*(azurerm_[a-zA-Z0-9_]+)"?`)
@@ -77,3 +88,10 @@ func ResourceDir() string {
 	}
 	return docRDir
 }
+
+func DataSourceDir() string {
+	if docDDir == "" {
+		docDDir = path.Join(docDir(), "d")
+	}
+	return docDDir
+}
@@ -93,6 +93,7 @@ type Mark struct {
 	ResourceType string // azurerm_xxx
 	Blocks       []Block
 	Fields       map[string]*model.Field
+	Import       model.Import
 }
 
 func (m *Mark) lastItem() *MarkItem {
@@ -210,9 +211,14 @@ func (m *Mark) buildField() {
 				}
 				return false
 			})
+			trimmed = strings.TrimPrefix(trimmed, "Data Source: ")
 			if !strings.Contains(trimmed, " ") {
 				m.ResourceType = trimmed
 			}
+		case ItemExample:
+			if pos == model.PosImport {
+				m.parseImport(item)
+			}
 		case ItemField:
 			if pos == model.PosTimeout {
 				item.Type = ItemTimeout
@@ -287,6 +293,22 @@ func (m *Mark) buildField() {
 	}
 }
 
+// parseImport parses the sample ID from a `terraform import azurerm_xxx.example <id>` line
+func (m *Mark) parseImport(item *MarkItem) {
+	for idx, line := range item.lines {
+		fields := strings.Fields(line)
+		if len(fields) < 4 || fields[0] != "terraform" || fields[1] != "import" {
+			continue
+		}
+		m.Import = model.Import{
+			ResourceType: strings.Split(fields[2], ".")[0],
+			ResourceID:   strings.Trim(strings.Join(fields[3:], " "), `"'`),
+			Line:         item.FromLine + idx,
+		}
+		return
+	}
+}
+
 // it may be an Argument block or an Attribute block
 func (m *Mark) blockOfName(name string, parent string, pos model.PosType) (b *Block, msg string) {
 	var res []Block
@@ -341,6 +363,9 @@ func (m *Mark) buildStruct() {
 
 	for _, f := range m.Fields {
 		fillField(f, "")
+		if f.SameNameAttr != nil {
+			fillField(f.SameNameAttr, "")
+		}
 	}
 
 	// build for block fields
@@ -362,6 +387,7 @@ func (m *Mark) BuildResourceDoc() *model.ResourceDoc {
 	}
 
 	doc.ResourceName = m.ResourceType
+	doc.Import = m.Import
 	for _, item := range m.Items {
 		if item.Type == ItemExample {
 			doc.ExampleHCL = item.content()
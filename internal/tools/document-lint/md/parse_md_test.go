@@ -35,3 +35,24 @@ func Test_unmarshalFile(t *testing.T) {
 
 	}
 }
+
+func Test_parseImport(t *testing.T) {
+	m := MustNewMarkFromFile(filepath.Join(testDir, "key_vault.html.markdown"))
+	doc := m.BuildResourceDoc()
+	if doc.Import.ResourceType != "azurerm_key_vault" {
+		t.Fatalf("expect import resource type: azurerm_key_vault, got: %s", doc.Import.ResourceType)
+	}
+	if want := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/mygroup1/providers/Microsoft.KeyVault/vaults/vault1"; doc.Import.ResourceID != want {
+		t.Fatalf("expect import resource id: %s, got: %s", want, doc.Import.ResourceID)
+	}
+	if doc.Import.Line != 177 {
+		t.Fatalf("expect import line: 177, got: %d", doc.Import.Line)
+	}
+}
+
+func Test_dataSourceTitle(t *testing.T) {
+	m := newMarkFromString("# Data Source: azurerm_resource_group\n\nUse this data source to access information about an existing Resource Group.\n", "resource_group.html.markdown")
+	if m.ResourceType != "azurerm_resource_group" {
+		t.Fatalf("expect resource type: azurerm_resource_group, got: %s", m.ResourceType)
+	}
+}
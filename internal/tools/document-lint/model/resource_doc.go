@@ -167,6 +167,7 @@ type Timeouts struct {
 type Import struct {
 	ResourceType string
 	ResourceID   string
+	Line         int // line of the `terraform import` command, 0 if no such command in document
 }
 
 func (p Properties) FindField(name string) *Field {
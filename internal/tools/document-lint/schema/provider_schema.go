@@ -0,0 +1,32 @@
+package schema
+
+import (
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+)
+
+var (
+	providerSchema     *schema.Provider
+	providerSchemaOnce sync.Once
+)
+
+func azurermProvider() *schema.Provider {
+	providerSchemaOnce.Do(func() {
+		providerSchema = provider.AzureProviderSchema()
+	})
+	return providerSchema
+}
+
+// ResourceSchemaFor returns the schema of a resource by resource type (azurerm_xxx), including typed sdk resources
+func ResourceSchemaFor(resourceType string) (*schema.Resource, bool) {
+	r, ok := azurermProvider().ResourcesMap[resourceType]
+	return r, ok
+}
+
+// DataSourceSchemaFor returns the schema of a data source by data source type (azurerm_xxx)
+func DataSourceSchemaFor(dataSourceType string) (*schema.Resource, bool) {
+	r, ok := azurermProvider().DataSourcesMap[dataSourceType]
+	return r, ok
+}
@@ -0,0 +1,40 @@
+package schema
+
+import (
+	"context"
+	"io"
+	"log"
+)
+
+// ValidateImportID validates an ID by the IDValidationFunc of a typed sdk resource, or the Importer of an untyped resource
+func (r *Resource) ValidateImportID(id string) (err error) {
+	if r.IsDataSource {
+		return nil
+	}
+	if r.SDKResource != nil {
+		if _, errs := r.SDKResource.IDValidationFunc()(id, "id"); len(errs) > 0 {
+			return errs[0]
+		}
+		return nil
+	}
+	if r.Schema == nil || r.Schema.Importer == nil || r.Schema.Importer.StateContext == nil {
+		return nil
+	}
+
+	// the ID is validated before running a custom import function, which may panic without a configured client
+	defer func() {
+		if e := recover(); e != nil {
+			err = nil
+		}
+	}()
+
+	// the importer logs the ID which is being parsed
+	writer := log.Writer()
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(writer)
+
+	d := r.Schema.TestResourceData()
+	d.SetId(id)
+	_, err = r.Schema.Importer.StateContext(context.Background(), d, nil)
+	return err
+}
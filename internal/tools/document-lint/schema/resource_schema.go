@@ -22,9 +22,11 @@ type Resource struct {
 	FilePath     string
 	ResourceType string // azurerm_xxx
 
-	// one of Schema or SDKResource must use
-	Schema      *schema.Resource `json:"-"`
-	SDKResource sdk.Resource     `json:"-"`
+	// one of Schema, SDKResource or SDKDataSource must use
+	Schema        *schema.Resource `json:"-"`
+	SDKResource   sdk.Resource     `json:"-"`
+	SDKDataSource sdk.DataSource   `json:"-"`
+	IsDataSource  bool
 
 	PossibleValues map[string][]string // possible values for key(property path)
 }
@@ -35,6 +37,12 @@ func ResourceForSDKType(res sdk.Resource) *schema.Resource {
 	return ins
 }
 
+func DataSourceForSDKType(ds sdk.DataSource) *schema.Resource {
+	r := sdk.NewDataSourceWrapper(ds)
+	ins, _ := r.DataSource()
+	return ins
+}
+
 // NewResourceByTyped NewResource ...
 // r is Schema.Resource or Typed SDK Resource
 func NewResourceByTyped(r sdk.Resource) *Resource {
@@ -64,8 +72,42 @@ func NewResource(r interface{}, rType string) *Resource {
 	return nil
 }
 
+// NewDataSourceByTyped ...
+func NewDataSourceByTyped(ds sdk.DataSource) *Resource {
+	s := &Resource{}
+	s.SDKDataSource = ds
+	s.Schema = DataSourceForSDKType(ds)
+	s.ResourceType = ds.ResourceType()
+	s.IsDataSource = true
+	s.Init()
+	return s
+}
+
+func NewDataSourceByUntyped(r *schema.Resource, rType string) *Resource {
+	s := &Resource{}
+	s.Schema = r
+	s.ResourceType = rType
+	s.IsDataSource = true
+	s.Init()
+	return s
+}
+
+// NewDataSource ...
+// r is Schema.Resource or Typed SDK DataSource
+func NewDataSource(r interface{}, rType string) *Resource {
+	switch ins := r.(type) {
+	case sdk.DataSource:
+		return NewDataSourceByTyped(ins)
+	case *schema.Resource:
+		return NewDataSourceByUntyped(ins, rType)
+	}
+	return nil
+}
+
 func (r *Resource) Init() {
-	if r.SDKResource != nil {
+	if r.SDKDataSource != nil {
+		r.FilePath = FileForResource(r.SDKDataSource.Read().Func)
+	} else if r.SDKResource != nil {
 		// SDKResource is a type of interface, have to get the real
 		// vd := reflect.ValueOf(r.SDKResource).Interface()
 		// vd = reflect.ValueOf(vd).MethodByName("Arguments")
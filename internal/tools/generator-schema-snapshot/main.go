@@ -4,35 +4,87 @@
 package main
 
 import (
+	"bytes"
+	"flag"
 	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
 	"log"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 
 	. "github.com/dave/jennifer/jen"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/differ"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/imports"
 )
 
 const (
 	SchemaPath = "github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
+// NOTE: since we're using `go run` for these tools all of the code needs to live within the main.go
+
 func main() {
-	if len(os.Args) != 2 {
-		log.Fatal("Usage: generator-schema-snapshot <reource_type>")
+	from := flag.String("from", "", "The git revision containing the previous schema of the resource, to generate a State Upgrade from")
+	to := flag.String("to", "", "The git revision containing the new schema of the resource, defaults to the current working tree")
+	baseline := flag.String("baseline", "", "A schema dump from the `schema-api` tool containing the previous schema of the resource, as an alternative to `-from`")
+	oldIdType := flag.String("old-id-type", "", "The previous Resource ID type when the Resource ID is rewritten, as `<import path>.<type>`")
+	newIdType := flag.String("new-id-type", "", "The new Resource ID type when the Resource ID is rewritten, as `<import path>.<type>`")
+	rootDir := flag.String("root-dir", "../../..", "The path to the project root")
+	dryRun := flag.Bool("dry-run", false, "Print the generated State Upgrade rather than writing it and bumping the `SchemaVersion` of the resource")
+	renames := keyValueFlag{}
+	flag.Var(renames, "rename", "A property which has been renamed, as `old_path=new_name` (e.g. `network_rules.ip_rules=ip_addresses`) - can be specified multiple times")
+	idSegmentNames := keyValueFlag{}
+	flag.Var(idSegmentNames, "id-segment-name", "A Resource ID segment which has been renamed, as `new_name=old_name` - can be specified multiple times")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: generator-schema-snapshot <resource_type>")
+		fmt.Fprintln(os.Stderr, "       generator-schema-snapshot [-from <revision> [-to <revision>] | -baseline <schema dump>] [-rename old_path=new_name] [-old-id-type <type> -new-id-type <type>] [-dry-run] <resource_type>")
+		os.Exit(1)
 	}
-	rt := os.Args[1]
-	res, ok := provider.AzureProvider().ResourcesMap[rt]
+	rt := flag.Arg(0)
+	res, ok := provider.AzureProviderSchema().ResourcesMap[rt]
 	if !ok {
 		log.Fatalf("unknown resource type %q", rt)
 	}
 
-	f := NewFile("main")
-	f.ImportName("github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk", "")
+	if *from == "" && *baseline == "" && *oldIdType == "" && *newIdType == "" {
+		f := NewFile("main")
+		f.ImportName("github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk", "")
 
-	f.Var().Id("_").Op("=").Add(SchemaMap(res.Schema))
+		f.Var().Id("_").Op("=").Add(SchemaMap(res.Schema))
 
-	fmt.Printf("%#v", f)
+		fmt.Printf("%#v", f)
+		return
+	}
+
+	opts := stateUpgradeOptions{
+		ResourceType:   rt,
+		RootDir:        *rootDir,
+		FromRevision:   *from,
+		ToRevision:     *to,
+		Baseline:       *baseline,
+		OldIdType:      *oldIdType,
+		NewIdType:      *newIdType,
+		Renames:        renames,
+		IdSegmentNames: idSegmentNames,
+		DryRun:         *dryRun,
+	}
+	if err := runStateUpgrade(opts, res); err != nil {
+		log.Fatal(err)
+	}
 }
 
 func ResourceValue(res *pluginsdk.Resource) Dict {
@@ -87,6 +139,19 @@ func SchemaValue(sch *pluginsdk.Schema) Dict {
 		out[Id("ForceNew")] = True()
 	}
 
+	if sch.MaxItems != 0 {
+		out[Id("MaxItems")] = Lit(sch.MaxItems)
+	}
+
+	if sch.MinItems != 0 {
+		out[Id("MinItems")] = Lit(sch.MinItems)
+	}
+
+	switch sch.Default.(type) {
+	case bool, int, float64, string:
+		out[Id("Default")] = Lit(sch.Default)
+	}
+
 	switch sch.ConfigMode {
 	case pluginsdk.SchemaConfigModeAttr:
 		out[Id("ConfigMode")] = Qual(SchemaPath, "SchemaConfigModeAttr")
@@ -107,3 +172,1237 @@ func SchemaValue(sch *pluginsdk.Schema) Dict {
 
 	return out
 }
+
+const modulePath = "github.com/hashicorp/terraform-provider-azurerm"
+
+// keyValueFlag is a repeatable flag in the format `key=value`
+type keyValueFlag map[string]string
+
+func (f keyValueFlag) String() string {
+	items := make([]string, 0)
+	for k, v := range f {
+		items = append(items, k+"="+v)
+	}
+	sort.Strings(items)
+	return strings.Join(items, ",")
+}
+
+func (f keyValueFlag) Set(value string) error {
+	k, v, ok := strings.Cut(value, "=")
+	if !ok || k == "" || v == "" {
+		return fmt.Errorf("expected a value in the format `key=value` but got %q", value)
+	}
+	f[k] = v
+	return nil
+}
+
+type stateUpgradeOptions struct {
+	ResourceType   string
+	RootDir        string
+	FromRevision   string
+	ToRevision     string
+	Baseline       string
+	OldIdType      string
+	NewIdType      string
+	Renames        map[string]string
+	IdSegmentNames map[string]string
+	DryRun         bool
+}
+
+func runStateUpgrade(opts stateUpgradeOptions, res *pluginsdk.Resource) error {
+	if (opts.OldIdType == "") != (opts.NewIdType == "") {
+		return fmt.Errorf("both `-old-id-type` and `-new-id-type` must be specified to rewrite the Resource ID")
+	}
+	if opts.FromRevision != "" && opts.Baseline != "" {
+		return fmt.Errorf("only one of `-from` and `-baseline` can be specified")
+	}
+
+	var err error
+	oldSchema := res.Schema
+	switch {
+	case opts.FromRevision != "":
+		oldSchema, err = schemaFromRevision(opts.RootDir, opts.FromRevision, opts.ResourceType)
+	case opts.Baseline != "":
+		oldSchema, err = schemaFromDump(opts.Baseline, opts.ResourceType)
+	}
+	if err != nil {
+		return fmt.Errorf("loading the previous schema: %+v", err)
+	}
+
+	newSchema := res.Schema
+	if opts.ToRevision != "" {
+		if newSchema, err = schemaFromRevision(opts.RootDir, opts.ToRevision, opts.ResourceType); err != nil {
+			return fmt.Errorf("loading the new schema: %+v", err)
+		}
+	}
+
+	changes, err := diffSchemas(nil, oldSchema, newSchema, opts.Renames)
+	if err != nil {
+		return err
+	}
+
+	upgrade := stateUpgrade{
+		ResourceType: opts.ResourceType,
+		Version:      res.SchemaVersion,
+		OldSchema:    oldSchema,
+		Changes:      changes,
+	}
+	if opts.OldIdType != "" {
+		if upgrade.IdRewrite, err = newIdRewrite(opts.RootDir, opts.OldIdType, opts.NewIdType, opts.IdSegmentNames); err != nil {
+			return err
+		}
+	}
+	if len(upgrade.Changes) == 0 && upgrade.IdRewrite == nil {
+		return fmt.Errorf("no changes were found between the previous and the new schema of %q", opts.ResourceType)
+	}
+	for _, change := range upgrade.Changes {
+		log.Printf("[INFO] %s", change)
+	}
+
+	code, err := upgrade.Code()
+	if err != nil {
+		return fmt.Errorf("generating the State Upgrade: %+v", err)
+	}
+	testCode, err := upgrade.TestCode()
+	if err != nil {
+		return fmt.Errorf("generating the State Upgrade test: %+v", err)
+	}
+	if opts.DryRun {
+		fmt.Printf("%s\n%s", code, testCode)
+		return nil
+	}
+
+	source, err := findResourceSource(opts.RootDir, opts.ResourceType)
+	if err != nil {
+		return err
+	}
+
+	migrationDir := filepath.Join(filepath.Dir(source.FilePath), "migration")
+	if err := os.MkdirAll(migrationDir, 0o755); err != nil {
+		return fmt.Errorf("creating %q: %+v", migrationDir, err)
+	}
+	fileName := fmt.Sprintf("%s_v%d_to_v%d", strings.TrimPrefix(opts.ResourceType, "azurerm_"), upgrade.Version, upgrade.Version+1)
+	files := map[string][]byte{
+		fileName + ".go":      code,
+		fileName + "_test.go": testCode,
+	}
+	for name, contents := range files {
+		filePath := filepath.Join(migrationDir, name)
+		if _, err := os.Stat(filePath); err == nil {
+			return fmt.Errorf("%q already exists", filePath)
+		}
+		if err := os.WriteFile(filePath, contents, 0o644); err != nil {
+			return fmt.Errorf("writing %q: %+v", filePath, err)
+		}
+	}
+
+	if err := source.bumpSchemaVersion(upgrade.Version, upgrade.TypeName()); err != nil {
+		log.Printf("[WARN] bumping the SchemaVersion of %q: %+v - `SchemaVersion` needs to be set to %d and `%d: migration.%s{}` registered by hand", source.FilePath, err, upgrade.Version+1, upgrade.Version, upgrade.TypeName())
+	}
+
+	return nil
+}
+
+// schemaFromRevision exports the schema at the git revision `revision` using the `schema-api` tool within a temporary worktree
+func schemaFromRevision(rootDir, revision, resourceType string) (map[string]*pluginsdk.Schema, error) {
+	tempDir, err := os.MkdirTemp("", "schema-snapshot-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tempDir)
+
+	worktree := filepath.Join(tempDir, "worktree")
+	if out, err := exec.Command("git", "-C", rootDir, "worktree", "add", "--detach", worktree, revision).CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("checking out %q: %+v\n%s", revision, err, out)
+	}
+	defer func() {
+		_ = exec.Command("git", "-C", rootDir, "worktree", "remove", "--force", worktree).Run()
+	}()
+
+	log.Printf("[INFO] exporting the schema at %q..", revision)
+	dumpFile := filepath.Join(tempDir, "schema.json")
+	cmd := exec.Command("go", "run", "./internal/tools/schema-api", "-export", dumpFile)
+	cmd.Dir = worktree
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("exporting the schema at %q: %+v", revision, err)
+	}
+
+	return schemaFromDump(dumpFile, resourceType)
+}
+
+func schemaFromDump(fileName, resourceType string) (map[string]*pluginsdk.Schema, error) {
+	dump, err := differ.LoadSchemaDump(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("loading %q: %+v", fileName, err)
+	}
+	if dump.ProviderSchema == nil {
+		return nil, fmt.Errorf("%q contains no provider schema", fileName)
+	}
+	r, ok := dump.ProviderSchema.ResourcesMap[resourceType]
+	if !ok {
+		return nil, fmt.Errorf("%q was not found in %q", resourceType, fileName)
+	}
+	return schemaMapFromJSON(r.Schema), nil
+}
+
+func schemaMapFromJSON(input map[string]providerjson.SchemaJSON) map[string]*pluginsdk.Schema {
+	out := make(map[string]*pluginsdk.Schema, len(input))
+	for k, v := range input {
+		out[k] = schemaFromJSON(v)
+	}
+	return out
+}
+
+func schemaFromJSON(input providerjson.SchemaJSON) *pluginsdk.Schema {
+	out := &pluginsdk.Schema{
+		Type:     valueTypeFromJSON(input.Type),
+		Optional: input.Optional,
+		Required: input.Required,
+		Computed: input.Computed,
+		ForceNew: input.ForceNew,
+		MaxItems: input.MaxItems,
+		MinItems: input.MinItems,
+	}
+
+	switch input.ConfigMode {
+	case "Attribute":
+		out.ConfigMode = pluginsdk.SchemaConfigModeAttr
+	case "Block":
+		out.ConfigMode = pluginsdk.SchemaConfigModeBlock
+	}
+
+	out.Default = input.Default
+	if v, ok := input.Default.(float64); ok && out.Type == pluginsdk.TypeInt {
+		out.Default = int(v)
+	}
+
+	switch elem := input.Elem.(type) {
+	case providerjson.ResourceJSON:
+		out.Elem = &pluginsdk.Resource{Schema: schemaMapFromJSON(elem.Schema)}
+	case *providerjson.ResourceJSON:
+		out.Elem = &pluginsdk.Resource{Schema: schemaMapFromJSON(elem.Schema)}
+	case providerjson.SchemaJSON:
+		out.Elem = &pluginsdk.Schema{Type: valueTypeFromJSON(elem.Type)}
+	case string:
+		out.Elem = &pluginsdk.Schema{Type: valueTypeFromJSON(elem)}
+	}
+
+	return out
+}
+
+func valueTypeFromJSON(input string) pluginsdk.ValueType {
+	switch input {
+	case "TypeBool", providerjson.SchemaTypeBool:
+		return pluginsdk.TypeBool
+	case "TypeInt":
+		return pluginsdk.TypeInt
+	case "TypeFloat", providerjson.SchemaTypeFloat:
+		return pluginsdk.TypeFloat
+	case "TypeList":
+		return pluginsdk.TypeList
+	case "TypeMap":
+		return pluginsdk.TypeMap
+	case "TypeSet":
+		return pluginsdk.TypeSet
+	}
+	return pluginsdk.TypeString
+}
+
+type changeKind int
+
+const (
+	changeRemoved changeKind = iota
+	changeRenamed
+	changeTypeChanged
+)
+
+// schemaChange is a change to a property between the previous and the new schema which requires the State to be upgraded
+type schemaChange struct {
+	Kind changeKind
+
+	// Parent is the path to the block containing this property, using the names within the previous schema
+	Parent []string
+
+	// Name is the name of the property within the previous schema
+	Name string
+
+	// NewName is the name of the property within the new schema, when it's been renamed
+	NewName string
+
+	Old *pluginsdk.Schema
+	New *pluginsdk.Schema
+}
+
+func (c schemaChange) Path() string {
+	return strings.Join(append(append([]string{}, c.Parent...), c.Name), ".")
+}
+
+func (c schemaChange) String() string {
+	switch c.Kind {
+	case changeRenamed:
+		return fmt.Sprintf("`%s` has been renamed to `%s`", c.Path(), c.NewName)
+	case changeTypeChanged:
+		return fmt.Sprintf("`%s` has changed from %s to %s", c.Path(), shape(c.Old), shape(c.New))
+	}
+	return fmt.Sprintf("`%s` has been removed", c.Path())
+}
+
+// shape describes the type of the property as it's stored within the State
+func shape(s *pluginsdk.Schema) string {
+	name := strings.ToLower(strings.TrimPrefix(s.Type.String(), "Type"))
+	switch elem := s.Elem.(type) {
+	case *pluginsdk.Resource:
+		return name + "(block)"
+	case *pluginsdk.Schema:
+		return name + "(" + shape(elem) + ")"
+	}
+	return name
+}
+
+// signature is used to detect renamed properties, which are expected to be stored identically within the State
+func signature(s *pluginsdk.Schema) string {
+	if elem, ok := s.Elem.(*pluginsdk.Resource); ok {
+		keys := make([]string, 0)
+		for k, v := range elem.Schema {
+			keys = append(keys, k+":"+signature(v))
+		}
+		sort.Strings(keys)
+		return shape(s) + "{" + strings.Join(keys, ",") + "}"
+	}
+	return shape(s)
+}
+
+func isBlock(s *pluginsdk.Schema) bool {
+	_, ok := s.Elem.(*pluginsdk.Resource)
+	return ok && (s.Type == pluginsdk.TypeList || s.Type == pluginsdk.TypeSet)
+}
+
+func sortedKeys[T any](input map[string]T) []string {
+	keys := make([]string, 0, len(input))
+	for k := range input {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// diffSchemas returns the changes between the `old` and `new` schemas (for the block at `parent`) which require the State to be upgraded.
+// Properties are renamed either explicitly via `renames` (a map of the old path to the new name) or when a single removed property
+// has the same type as a single added property. Changes within nested blocks are returned ahead of the changes to the blocks themselves,
+// so that they can be applied using the names within the previous schema.
+func diffSchemas(parent []string, old, new map[string]*pluginsdk.Schema, renames map[string]string) ([]schemaChange, error) {
+	pathFor := func(name string) string {
+		return strings.Join(append(append([]string{}, parent...), name), ".")
+	}
+
+	removed := make([]string, 0)
+	for _, k := range sortedKeys(old) {
+		if _, ok := new[k]; !ok {
+			removed = append(removed, k)
+		}
+	}
+	added := map[string]bool{}
+	for k := range new {
+		if _, ok := old[k]; !ok {
+			added[k] = true
+		}
+	}
+
+	// the names of the properties within the new schema, keyed by the name within the previous schema
+	renamed := map[string]string{}
+	for _, k := range removed {
+		newName, ok := renames[pathFor(k)]
+		if !ok {
+			continue
+		}
+		newName = newName[strings.LastIndex(newName, ".")+1:]
+		if !added[newName] {
+			return nil, fmt.Errorf("`%s` was renamed to `%s` but that isn't a new property", pathFor(k), newName)
+		}
+		renamed[k] = newName
+		delete(added, newName)
+	}
+	for _, k := range removed {
+		if _, ok := renamed[k]; ok {
+			continue
+		}
+		candidates := make([]string, 0)
+		for a := range added {
+			if signature(old[k]) == signature(new[a]) {
+				candidates = append(candidates, a)
+			}
+		}
+		if len(candidates) != 1 {
+			continue
+		}
+		ambiguous := false
+		for _, other := range removed {
+			if _, done := renamed[other]; other != k && !done && signature(old[other]) == signature(new[candidates[0]]) {
+				ambiguous = true
+			}
+		}
+		if !ambiguous {
+			renamed[k] = candidates[0]
+			delete(added, candidates[0])
+		}
+	}
+
+	nested := make([]schemaChange, 0)
+	changes := make([]schemaChange, 0)
+	for _, k := range sortedKeys(old) {
+		newName := k
+		if v, ok := renamed[k]; ok {
+			newName = v
+		}
+		n, ok := new[newName]
+		if !ok {
+			changes = append(changes, schemaChange{Kind: changeRemoved, Parent: parent, Name: k, Old: old[k]})
+			continue
+		}
+
+		o := old[k]
+		if isBlock(o) && isBlock(n) {
+			v, err := diffSchemas(append(append([]string{}, parent...), k), o.Elem.(*pluginsdk.Resource).Schema, n.Elem.(*pluginsdk.Resource).Schema, renames)
+			if err != nil {
+				return nil, err
+			}
+			nested = append(nested, v...)
+		} else if shape(o) != shape(n) {
+			changes = append(changes, schemaChange{Kind: changeTypeChanged, Parent: parent, Name: k, Old: o, New: n})
+		}
+
+		if newName != k {
+			changes = append(changes, schemaChange{Kind: changeRenamed, Parent: parent, Name: k, NewName: newName, Old: o, New: n})
+		}
+	}
+
+	return append(nested, changes...), nil
+}
+
+// conversion describes how a property is converted between two types within the State
+type conversion int
+
+const (
+	conversionNone conversion = iota
+	conversionUnsupported
+	conversionToString
+	conversionToInt
+	conversionToFloat
+	conversionToBool
+	conversionWrap
+	conversionUnwrap
+)
+
+func isPrimitive(s *pluginsdk.Schema) bool {
+	switch s.Type {
+	case pluginsdk.TypeBool, pluginsdk.TypeInt, pluginsdk.TypeFloat, pluginsdk.TypeString:
+		return true
+	}
+	return false
+}
+
+func isPrimitiveCollection(s *pluginsdk.Schema) (*pluginsdk.Schema, bool) {
+	elem, ok := s.Elem.(*pluginsdk.Schema)
+	if ok && (s.Type == pluginsdk.TypeList || s.Type == pluginsdk.TypeSet) && isPrimitive(elem) {
+		return elem, true
+	}
+	return nil, false
+}
+
+func conversionFor(old, new *pluginsdk.Schema) conversion {
+	if isPrimitive(old) && isPrimitive(new) {
+		switch {
+		case new.Type == pluginsdk.TypeString:
+			return conversionToString
+		case new.Type == pluginsdk.TypeInt && old.Type != pluginsdk.TypeBool:
+			return conversionToInt
+		case new.Type == pluginsdk.TypeFloat && old.Type != pluginsdk.TypeBool:
+			return conversionToFloat
+		case new.Type == pluginsdk.TypeBool && old.Type == pluginsdk.TypeString:
+			return conversionToBool
+		}
+		return conversionUnsupported
+	}
+
+	oldElem, oldIsCollection := isPrimitiveCollection(old)
+	newElem, newIsCollection := isPrimitiveCollection(new)
+	switch {
+	case oldIsCollection && newIsCollection && oldElem.Type == newElem.Type:
+		// Lists and Sets are both stored as a list within the State
+		return conversionNone
+	case isPrimitive(old) && newIsCollection && newElem.Type == old.Type:
+		return conversionWrap
+	case oldIsCollection && isPrimitive(new) && oldElem.Type == new.Type:
+		return conversionUnwrap
+	}
+	return conversionUnsupported
+}
+
+// convert converts `input` in the same way as the code generated for the conversion
+func convert(c conversion, input interface{}) (interface{}, error) {
+	switch c {
+	case conversionToString:
+		return fmt.Sprint(input), nil
+	case conversionToInt:
+		return strconv.ParseInt(fmt.Sprint(input), 10, 64)
+	case conversionToFloat:
+		return strconv.ParseFloat(fmt.Sprint(input), 64)
+	case conversionToBool:
+		return strconv.ParseBool(fmt.Sprint(input))
+	case conversionWrap:
+		return []interface{}{input}, nil
+	case conversionUnwrap:
+		if v, ok := input.([]interface{}); ok && len(v) > 0 {
+			return v[0], nil
+		}
+		return nil, nil
+	}
+	return input, nil
+}
+
+// idRewrite rewrites the Resource ID from the previous Resource ID type into the new Resource ID type, see sdk.ResourceIDUpgrade
+type idRewrite struct {
+	Old          resourceIdType
+	New          resourceIdType
+	SegmentNames map[string]string
+
+	OldExample string
+	NewExample string
+}
+
+type resourceIdType struct {
+	ImportPath string
+	Package    string
+	Alias      string
+	Name       string
+}
+
+func (t resourceIdType) qualifier() string {
+	if t.Alias != "" {
+		return t.Alias
+	}
+	return t.Package
+}
+
+type resourceIdSegment struct {
+	Name    string
+	Static  bool
+	Scope   bool
+	Value   string
+	Example string
+}
+
+func newIdRewrite(rootDir, oldType, newType string, segmentNames map[string]string) (*idRewrite, error) {
+	oldId, oldSegments, err := loadResourceIdType(rootDir, oldType)
+	if err != nil {
+		return nil, err
+	}
+	newId, newSegments, err := loadResourceIdType(rootDir, newType)
+	if err != nil {
+		return nil, err
+	}
+	if oldId.ImportPath != newId.ImportPath && oldId.Package == newId.Package {
+		oldId.Alias = "old" + oldId.Package
+	}
+
+	rewrite := &idRewrite{
+		Old:          *oldId,
+		New:          *newId,
+		SegmentNames: segmentNames,
+	}
+
+	oldValues := map[string]string{}
+	components := make([]string, 0)
+	for _, segment := range oldSegments {
+		oldValues[segment.Name] = segment.Example
+		components = append(components, strings.Trim(segment.Example, "/"))
+	}
+	rewrite.OldExample = "/" + strings.Join(components, "/")
+
+	components = make([]string, 0)
+	for _, segment := range newSegments {
+		if segment.Static {
+			components = append(components, segment.Value)
+			continue
+		}
+		oldName := segment.Name
+		if v, ok := segmentNames[segment.Name]; ok {
+			oldName = v
+		}
+		value, ok := oldValues[oldName]
+		if !ok {
+			return nil, fmt.Errorf("the segment %q of %s was not found in %s - use `-id-segment-name` when it's been renamed", oldName, newType, oldType)
+		}
+		components = append(components, strings.Trim(value, "/"))
+	}
+	rewrite.NewExample = "/" + strings.Join(components, "/")
+
+	return rewrite, nil
+}
+
+// loadResourceIdType parses the Segments of the Resource ID type `input` (`<import path>.<type>`) from either the vendored or the provider's source
+func loadResourceIdType(rootDir, input string) (*resourceIdType, []resourceIdSegment, error) {
+	idx := strings.LastIndex(input, ".")
+	if idx == -1 || !strings.Contains(input[:idx], "/") {
+		return nil, nil, fmt.Errorf("expected the Resource ID type in the format `<import path>.<type>` but got %q", input)
+	}
+	t := &resourceIdType{
+		ImportPath: input[:idx],
+		Name:       input[idx+1:],
+	}
+
+	dir := filepath.Join(rootDir, "vendor", t.ImportPath)
+	if rel, ok := strings.CutPrefix(t.ImportPath, modulePath+"/"); ok {
+		dir = filepath.Join(rootDir, rel)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading the package for %q: %+v", input, err)
+	}
+
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, entry.Name()), nil, 0)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parsing %q: %+v", entry.Name(), err)
+		}
+		t.Package = file.Name.Name
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || fn.Name.Name != "Segments" {
+				continue
+			}
+			recvType := fn.Recv.List[0].Type
+			if star, ok := recvType.(*ast.StarExpr); ok {
+				recvType = star.X
+			}
+			if recv, ok := recvType.(*ast.Ident); !ok || recv.Name != t.Name {
+				continue
+			}
+			segments := segmentsFromFunc(fn)
+			if len(segments) == 0 {
+				return nil, nil, fmt.Errorf("no Segments were found for %q", input)
+			}
+			return t, segments, nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("the Resource ID type %q (implementing `Segments()`) was not found in %q", t.Name, dir)
+}
+
+func segmentsFromFunc(fn *ast.FuncDecl) []resourceIdSegment {
+	segments := make([]resourceIdSegment, 0)
+	ast.Inspect(fn.Body, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || !strings.HasSuffix(sel.Sel.Name, "Segment") || len(call.Args) < 2 {
+			return true
+		}
+		args := make([]string, 0)
+		for _, arg := range call.Args {
+			value := ""
+			if lit, ok := arg.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+				value, _ = strconv.Unquote(lit.Value)
+			}
+			args = append(args, value)
+		}
+		segment := resourceIdSegment{
+			Name:    args[0],
+			Example: args[len(args)-1],
+			Scope:   sel.Sel.Name == "ScopeSegment",
+		}
+		if sel.Sel.Name == "StaticSegment" || sel.Sel.Name == "ResourceProviderSegment" {
+			segment.Static = true
+			segment.Value = args[1]
+		}
+		segments = append(segments, segment)
+		return false
+	})
+	return segments
+}
+
+// stateUpgrade is the State Upgrade which is generated from the changes between the previous and the new schema
+type stateUpgrade struct {
+	ResourceType string
+	Version      int
+	OldSchema    map[string]*pluginsdk.Schema
+	Changes      []schemaChange
+	IdRewrite    *idRewrite
+}
+
+func (u stateUpgrade) TypeName() string {
+	return fmt.Sprintf("%sV%dToV%d", snake2Camel(strings.TrimPrefix(u.ResourceType, "azurerm_")), u.Version, u.Version+1)
+}
+
+func snake2Camel(input string) string {
+	out := ""
+	for _, seg := range strings.Split(input, "_") {
+		if seg != "" {
+			out += strings.ToUpper(seg[:1]) + seg[1:]
+		}
+	}
+	return out
+}
+
+// forEachBlock returns the code running `body` for each of the blocks at `path` within the map `v`,
+// `body` is given the name of the variable containing each block
+func forEachBlock(v string, path []string, body func(block string) string) string {
+	if len(path) == 0 {
+		return body(v)
+	}
+	depth := strconv.Itoa(strings.Count(v, "block"))
+	blocks := "blocks" + depth
+	block := "block" + depth
+	return fmt.Sprintf(`if %[1]s, ok := %[2]s[%[3]q].([]interface{}); ok {
+	for _, raw := range %[1]s {
+		%[4]s, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		%[5]s
+	}
+}
+`, blocks, v, path[0], block, strings.TrimSuffix(forEachBlock(block, path[1:], body), "\n"))
+}
+
+func (c schemaChange) code() string {
+	return forEachBlock("rawState", c.Parent, func(block string) string {
+		switch c.Kind {
+		case changeRemoved:
+			return fmt.Sprintf("delete(%s, %q)\n", block, c.Name)
+
+		case changeRenamed:
+			return fmt.Sprintf(`if v, ok := %[1]s[%[2]q]; ok {
+	%[1]s[%[3]q] = v
+	delete(%[1]s, %[2]q)
+}
+`, block, c.Name, c.NewName)
+		}
+
+		target := fmt.Sprintf("%s[%q]", block, c.Name)
+		switch conversionFor(c.Old, c.New) {
+		case conversionNone:
+			return "// Lists and Sets are stored in the same way within the State, so no changes are required\n"
+		case conversionToString:
+			return fmt.Sprintf("if v, ok := %[1]s; ok && v != nil {\n%[1]s = fmt.Sprint(v)\n}\n", target)
+		case conversionWrap:
+			return fmt.Sprintf("if v, ok := %[1]s; ok && v != nil {\n%[1]s = []interface{}{v}\n}\n", target)
+		case conversionUnwrap:
+			return fmt.Sprintf(`if v, ok := %[1]s.([]interface{}); ok {
+	if len(v) > 0 {
+		%[1]s = v[0]
+	} else {
+		delete(%[2]s, %[3]q)
+	}
+}
+`, target, block, c.Name)
+		case conversionToInt, conversionToFloat, conversionToBool:
+			parse := map[conversion]string{
+				conversionToInt:   "strconv.ParseInt(fmt.Sprint(v), 10, 64)",
+				conversionToFloat: "strconv.ParseFloat(fmt.Sprint(v), 64)",
+				conversionToBool:  "strconv.ParseBool(fmt.Sprint(v))",
+			}[conversionFor(c.Old, c.New)]
+			return fmt.Sprintf(`if v, ok := %[1]s; ok && v != nil {
+	converted, err := %[2]s
+	if err != nil {
+		return rawState, fmt.Errorf("converting %[3]s: %%+v", err)
+	}
+	%[1]s = converted
+}
+`, target, parse, "`"+c.Path()+"`")
+		}
+		return fmt.Sprintf("// TODO: convert the value of `%s` from %s to %s\n", c.Path(), shape(c.Old), shape(c.New))
+	})
+}
+
+func (u stateUpgrade) Code() ([]byte, error) {
+	var body strings.Builder
+	if r := u.IdRewrite; r != nil {
+		segmentNames := ""
+		if len(r.SegmentNames) > 0 {
+			segmentNames = "SegmentNames: map[string]string{\n"
+			for _, k := range sortedKeys(r.SegmentNames) {
+				segmentNames += fmt.Sprintf("%q: %q,\n", k, r.SegmentNames[k])
+			}
+			segmentNames += "},\n"
+		}
+		fmt.Fprintf(&body, `// old:
+// 	%[1]s
+// new:
+// 	%[2]s
+oldId, ok := rawState["id"].(string)
+if !ok {
+	return rawState, fmt.Errorf("the `+"`id`"+` field was missing from the State")
+}
+newId, err := sdk.ResourceIDUpgrade{
+	Old: &%[3]s.%[4]s{},
+	New: &%[5]s.%[6]s{},
+	%[7]s}.Upgrade(oldId)
+if err != nil {
+	return rawState, fmt.Errorf("upgrading the Resource ID: %%+v", err)
+}
+log.Printf("[DEBUG] Updating the Resource ID from %%q to %%q", oldId, newId)
+rawState["id"] = newId
+
+`, r.OldExample, r.NewExample, r.Old.qualifier(), r.Old.Name, r.New.qualifier(), r.New.Name, segmentNames)
+	}
+	for _, change := range u.Changes {
+		fmt.Fprintf(&body, "// %s\n%s\n", change, change.code())
+	}
+
+	var idImports string
+	if r := u.IdRewrite; r != nil {
+		idImports = strings.TrimSpace(fmt.Sprintf("%s %q", r.Old.Alias, r.Old.ImportPath)) + "\n"
+		if r.New.ImportPath != r.Old.ImportPath {
+			idImports += fmt.Sprintf("%q\n", r.New.ImportPath)
+		}
+	}
+
+	code := fmt.Sprintf(`// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package migration
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	%[1]s"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+var _ pluginsdk.StateUpgrade = %[2]s{}
+
+type %[2]s struct{}
+
+func (%[2]s) Schema() map[string]*pluginsdk.Schema {
+	return %[3]s
+}
+
+func (%[2]s) UpgradeFunc() pluginsdk.StateUpgraderFunc {
+	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+		%[4]s
+		return rawState, nil
+	}
+}
+`, idImports, u.TypeName(), fmt.Sprintf("%#v", SchemaMap(u.OldSchema)), body.String())
+
+	return imports.Process(u.TypeName()+".go", []byte(code), nil)
+}
+
+// exampleValue returns an example of the value for the property within the State
+func exampleValue(s *pluginsdk.Schema) interface{} {
+	switch s.Type {
+	case pluginsdk.TypeBool:
+		return true
+	case pluginsdk.TypeInt:
+		return 1
+	case pluginsdk.TypeFloat:
+		return 1.5
+	case pluginsdk.TypeString:
+		return "example"
+	case pluginsdk.TypeMap:
+		return map[string]interface{}{"key": "value"}
+	}
+	if elem, ok := s.Elem.(*pluginsdk.Schema); ok {
+		return []interface{}{exampleValue(elem)}
+	}
+	return []interface{}{map[string]interface{}{}}
+}
+
+// exampleValueFor returns an example of the value for the property which can be converted to the new type
+func exampleValueFor(c schemaChange) interface{} {
+	if c.Kind != changeTypeChanged {
+		return exampleValue(c.Old)
+	}
+	switch conversionFor(c.Old, c.New) {
+	case conversionToInt:
+		if c.Old.Type == pluginsdk.TypeFloat {
+			return float64(2)
+		}
+		if c.Old.Type == pluginsdk.TypeString {
+			return "1"
+		}
+	case conversionToFloat:
+		if c.Old.Type == pluginsdk.TypeString {
+			return "1.5"
+		}
+	case conversionToBool:
+		return "true"
+	}
+	return exampleValue(c.Old)
+}
+
+// blocksAt returns the blocks at `path` within `state`, creating a single block for each missing level
+func blocksAt(state map[string]interface{}, path []string) []map[string]interface{} {
+	if len(path) == 0 {
+		return []map[string]interface{}{state}
+	}
+	raw, ok := state[path[0]].([]interface{})
+	if !ok || len(raw) == 0 {
+		raw = []interface{}{map[string]interface{}{}}
+		state[path[0]] = raw
+	}
+	out := make([]map[string]interface{}, 0)
+	for _, item := range raw {
+		if block, ok := item.(map[string]interface{}); ok {
+			out = append(out, blocksAt(block, path[1:])...)
+		}
+	}
+	return out
+}
+
+// apply applies the change to `state` in the same way as the generated code
+func (c schemaChange) apply(state map[string]interface{}) error {
+	for _, block := range blocksAt(state, c.Parent) {
+		v, ok := block[c.Name]
+		if !ok {
+			continue
+		}
+		switch c.Kind {
+		case changeRemoved:
+			delete(block, c.Name)
+		case changeRenamed:
+			block[c.NewName] = v
+			delete(block, c.Name)
+		case changeTypeChanged:
+			conv := conversionFor(c.Old, c.New)
+			if conv == conversionUnsupported {
+				continue
+			}
+			converted, err := convert(conv, v)
+			if err != nil {
+				return err
+			}
+			if converted == nil {
+				delete(block, c.Name)
+				continue
+			}
+			block[c.Name] = converted
+		}
+	}
+	return nil
+}
+
+func copyValue(input interface{}) interface{} {
+	switch v := input.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			out[k] = copyValue(item)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			out = append(out, copyValue(item))
+		}
+		return out
+	}
+	return input
+}
+
+// goValue renders `input` as a Go literal
+func goValue(input interface{}) string {
+	switch v := input.(type) {
+	case string:
+		return strconv.Quote(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return fmt.Sprintf("int64(%d)", v)
+	case float64:
+		return fmt.Sprintf("float64(%s)", strconv.FormatFloat(v, 'g', -1, 64))
+	case []interface{}:
+		var b strings.Builder
+		b.WriteString("[]interface{}{\n")
+		for _, item := range v {
+			b.WriteString(goValue(item) + ",\n")
+		}
+		b.WriteString("}")
+		return b.String()
+	case map[string]interface{}:
+		var b strings.Builder
+		b.WriteString("map[string]interface{}{\n")
+		for _, k := range sortedKeys(v) {
+			fmt.Fprintf(&b, "%q: %s,\n", k, goValue(v[k]))
+		}
+		b.WriteString("}")
+		return b.String()
+	}
+	return "nil"
+}
+
+func (u stateUpgrade) TestCode() ([]byte, error) {
+	oldId := "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resource-group/providers/Microsoft.Example/examples/example"
+	newId := oldId
+	if u.IdRewrite != nil {
+		oldId = u.IdRewrite.OldExample
+		newId = u.IdRewrite.NewExample
+	}
+
+	input := map[string]interface{}{
+		"id": oldId,
+	}
+	for _, change := range u.Changes {
+		if change.Kind == changeRenamed && change.Old != nil && isBlock(change.Old) {
+			// the nested changes create this block where required
+			blocksAt(input, append(append([]string{}, change.Parent...), change.Name))
+			continue
+		}
+		for _, block := range blocksAt(input, change.Parent) {
+			if _, ok := block[change.Name]; !ok {
+				block[change.Name] = exampleValueFor(change)
+			}
+		}
+	}
+
+	expected := copyValue(input).(map[string]interface{})
+	expected["id"] = newId
+	for _, change := range u.Changes {
+		if err := change.apply(expected); err != nil {
+			return nil, fmt.Errorf("applying %s: %+v", change, err)
+		}
+	}
+
+	testCases := []string{
+		fmt.Sprintf("{\nName: %q,\nInput: %s,\nExpected: %s,\n},\n", "changed properties", goValue(input), goValue(expected)),
+		fmt.Sprintf("{\nName: %q,\nInput: %s,\nExpected: %s,\n},\n", "no properties set", goValue(map[string]interface{}{"id": oldId}), goValue(map[string]interface{}{"id": newId})),
+	}
+	if u.IdRewrite != nil {
+		testCases = append(testCases, fmt.Sprintf("{\nName: %q,\nInput: %s,\nExpected: nil,\n},\n", "invalid id", goValue(map[string]interface{}{"id": "not-a-resource-id"})))
+	}
+
+	var todo string
+	for _, change := range u.Changes {
+		if change.Kind == changeTypeChanged && conversionFor(change.Old, change.New) == conversionUnsupported {
+			todo += fmt.Sprintf("// TODO: add a test case for the conversion of `%s` from %s to %s\n", change.Path(), shape(change.Old), shape(change.New))
+		}
+	}
+
+	code := fmt.Sprintf(`// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package migration
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/stateupgrade"
+)
+
+func Test%[1]s(t *testing.T) {
+	%[2]sstateupgrade.RunTestCases(t, %[1]s{}, []stateupgrade.TestCase{
+		%[3]s
+	})
+}
+`, u.TypeName(), todo, strings.Join(testCases, ""))
+
+	return format.Source([]byte(code))
+}
+
+// resourceSource is the source file defining a resource
+type resourceSource struct {
+	FilePath string
+
+	// TypedResource is the name of the type implementing sdk.Resource for Typed Resources
+	TypedResource string
+
+	// Receiver is the name of the receiver used for the methods of the Typed Resource
+	Receiver string
+
+	// FuncName is the name of the function returning the *pluginsdk.Resource for Untyped Resources
+	FuncName string
+}
+
+// findResourceSource finds the source file defining the resource `resourceType` within the service packages
+func findResourceSource(rootDir, resourceType string) (*resourceSource, error) {
+	files, err := filepath.Glob(filepath.Join(rootDir, "internal", "services", "*", "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	untyped := regexp.MustCompile(fmt.Sprintf(`"%s":\s*(\w+)\(\)`, regexp.QuoteMeta(resourceType)))
+	typed := regexp.MustCompile(fmt.Sprintf(`func \((\w*)\s*(\w+)\) ResourceType\(\) string \{\s*return "%s"`, regexp.QuoteMeta(resourceType)))
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		if m := typed.FindSubmatch(data); m != nil {
+			receiver := string(m[1])
+			if receiver == "" {
+				receiver = "r"
+			}
+			return &resourceSource{FilePath: file, TypedResource: string(m[2]), Receiver: receiver}, nil
+		}
+
+		if filepath.Base(file) != "registration.go" {
+			continue
+		}
+		// only the Resources are registered within `SupportedResources`, rather than the Data Sources
+		idx := bytes.Index(data, []byte("SupportedResources() map[string]*pluginsdk.Resource {"))
+		if idx == -1 {
+			continue
+		}
+		m := untyped.FindSubmatch(data[idx:])
+		if m == nil {
+			continue
+		}
+		funcName := string(m[1])
+		siblings, err := filepath.Glob(filepath.Join(filepath.Dir(file), "*.go"))
+		if err != nil {
+			return nil, err
+		}
+		for _, sibling := range siblings {
+			contents, err := os.ReadFile(sibling)
+			if err != nil {
+				return nil, err
+			}
+			if bytes.Contains(contents, []byte("func "+funcName+"() *pluginsdk.Resource {")) {
+				return &resourceSource{FilePath: sibling, FuncName: funcName}, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("the source of %q was not found", resourceType)
+}
+
+var schemaVersionRegex = regexp.MustCompile(`SchemaVersion:\s*(\d+),`)
+
+// bumpSchemaVersion bumps the SchemaVersion of the resource from `version` and registers the State Upgrade `upgradeName` for `version`
+func (s resourceSource) bumpSchemaVersion(version int, upgradeName string) error {
+	data, err := os.ReadFile(s.FilePath)
+	if err != nil {
+		return err
+	}
+	contents := string(data)
+
+	start := -1
+	switch {
+	case s.FuncName != "":
+		start = strings.Index(contents, "func "+s.FuncName+"() *pluginsdk.Resource {")
+		if start == -1 {
+			return fmt.Errorf("the definition of the resource was not found")
+		}
+	case s.TypedResource != "":
+		if m := regexp.MustCompile(fmt.Sprintf(`func \(\w*\s*%s\) StateUpgraders\(\) sdk.StateUpgradeData \{`, s.TypedResource)).FindStringIndex(contents); m != nil {
+			start = m[0]
+		}
+	}
+	entry := fmt.Sprintf("%d: migration.%s{},\n", version, upgradeName)
+
+	// Typed Resources without any existing State Upgrades get a new `StateUpgraders` method appended
+	region := ""
+	end := start
+	if start != -1 {
+		end = start + strings.Index(contents[start:], "\n}\n")
+		region = contents[start:end]
+	} else {
+		start = len(contents)
+		end = len(contents)
+	}
+
+	if strings.Contains(region, "SchemaVersion:") && !schemaVersionRegex.MatchString(region) {
+		return fmt.Errorf("the existing `SchemaVersion` isn't a literal")
+	}
+	if m := schemaVersionRegex.FindStringSubmatchIndex(region); m != nil {
+		existing, _ := strconv.Atoi(region[m[2]:m[3]])
+		if existing != version {
+			return fmt.Errorf("expected the SchemaVersion to be %d but got %d", version, existing)
+		}
+		upgraders := strings.Index(region, "map[int]pluginsdk.StateUpgrade{")
+		if upgraders == -1 {
+			return fmt.Errorf("the existing State Upgrades were not found")
+		}
+		closing := matchingBrace(region, upgraders+len("map[int]pluginsdk.StateUpgrade{")-1)
+		if closing == -1 {
+			return fmt.Errorf("the end of the existing State Upgrades was not found")
+		}
+		region = region[:m[2]] + strconv.Itoa(version+1) + region[m[3]:closing] + entry + region[closing:]
+	} else if s.FuncName != "" {
+		resource := strings.Index(region, "&pluginsdk.Resource{\n")
+		if resource == -1 {
+			return fmt.Errorf("the `&pluginsdk.Resource{` was not found")
+		}
+		insertAt := resource + len("&pluginsdk.Resource{\n")
+		region = region[:insertAt] + fmt.Sprintf("SchemaVersion: %d,\nStateUpgraders: pluginsdk.StateUpgrades(map[int]pluginsdk.StateUpgrade{\n%s}),\n\n", version+1, entry) + region[insertAt:]
+	}
+	contents = contents[:start] + region + contents[end:]
+
+	if s.TypedResource != "" && region == "" {
+		contents += fmt.Sprintf(`
+func (%[4]s %[1]s) StateUpgraders() sdk.StateUpgradeData {
+	return sdk.StateUpgradeData{
+		SchemaVersion: %[2]d,
+		Upgraders: map[int]pluginsdk.StateUpgrade{
+			%[3]s
+		},
+	}
+}
+`, s.TypedResource, version+1, entry, s.Receiver)
+		assertion := fmt.Sprintf("var _ sdk.ResourceWithStateMigration = %s{}", s.TypedResource)
+		if !strings.Contains(contents, assertion) {
+			existing := fmt.Sprintf(`(?m)^var _ sdk\.\w+ = (%[1]s\{\}|\(\*%[1]s\)\(nil\))\n`, s.TypedResource)
+			if m := regexp.MustCompile(existing).FindStringIndex(contents); m != nil {
+				contents = contents[:m[1]] + "\n" + assertion + "\n" + contents[m[1]:]
+			}
+		}
+	}
+
+	// add the import for the migration package
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, s.FilePath, contents, parser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("parsing the updated source: %+v", err)
+	}
+	rel, err := filepath.Rel(filepath.Join(filepath.Dir(s.FilePath), "..", "..", ".."), filepath.Dir(s.FilePath))
+	if err != nil {
+		return err
+	}
+	astutil.AddImport(fset, file, modulePath+"/"+filepath.ToSlash(rel)+"/migration")
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return err
+	}
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.FilePath, formatted, 0o644)
+}
+
+// matchingBrace returns the index of the brace closing the brace at `open`
+func matchingBrace(input string, open int) int {
+	depth := 0
+	for i := open; i < len(input); i++ {
+		switch input[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,321 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func newWidgetSchema() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"capacity": {
+			Type:     pluginsdk.TypeInt,
+			Optional: true,
+		},
+		"enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+		},
+		"ip_range": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+		"network_acls": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"ip_rules": {
+						Type:     pluginsdk.TypeSet,
+						Optional: true,
+						Elem: &pluginsdk.Schema{
+							Type: pluginsdk.TypeString,
+						},
+					},
+					"bypass": {
+						Type:     pluginsdk.TypeString,
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestDiffSchemas(t *testing.T) {
+	oldSchema, err := schemaFromDump(filepath.Join("testdata", "schema.json"), "azurerm_widget")
+	if err != nil {
+		t.Fatalf("loading the schema dump: %+v", err)
+	}
+	if oldSchema["legacy_setting"].Default != false || oldSchema["network_rules"].MaxItems != 1 {
+		t.Fatalf("expected the Default and MaxItems to be loaded from the schema dump")
+	}
+	if _, ok := oldSchema["network_rules"].Elem.(*pluginsdk.Resource).Schema["allowed_ips"].Elem.(*pluginsdk.Schema); !ok {
+		t.Fatalf("expected the nested elements to be loaded from the schema dump")
+	}
+
+	if _, err := diffSchemas(nil, oldSchema, newWidgetSchema(), map[string]string{"network_rules": "network_rule"}); err == nil {
+		t.Fatalf("expected an error when renaming to a property which doesn't exist")
+	}
+
+	changes, err := diffSchemas(nil, oldSchema, newWidgetSchema(), map[string]string{"network_rules": "network_acls"})
+	if err != nil {
+		t.Fatalf("diffing the schemas: %+v", err)
+	}
+	actual := make([]string, 0)
+	for _, change := range changes {
+		actual = append(actual, change.String())
+	}
+	expected := []string{
+		"`network_rules.allowed_ips` has been renamed to `ip_rules`",
+		"`capacity` has changed from string to int",
+		"`enabled` has changed from string to bool",
+		"`ip_range` has changed from string to list(string)",
+		"`legacy_setting` has been removed",
+		"`network_rules` has been renamed to `network_acls`",
+	}
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("expected the changes:\n%s\n\nbut got:\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+}
+
+func TestConvert(t *testing.T) {
+	str := &pluginsdk.Schema{Type: pluginsdk.TypeString}
+	integer := &pluginsdk.Schema{Type: pluginsdk.TypeInt}
+	boolean := &pluginsdk.Schema{Type: pluginsdk.TypeBool}
+	list := &pluginsdk.Schema{Type: pluginsdk.TypeList, Elem: &pluginsdk.Schema{Type: pluginsdk.TypeString}}
+	set := &pluginsdk.Schema{Type: pluginsdk.TypeSet, Elem: &pluginsdk.Schema{Type: pluginsdk.TypeString}}
+
+	cases := []struct {
+		old      *pluginsdk.Schema
+		new      *pluginsdk.Schema
+		input    interface{}
+		expected interface{}
+	}{
+		{str, integer, "12", int64(12)},
+		{integer, str, 12, "12"},
+		{str, boolean, "true", true},
+		{str, list, "a", []interface{}{"a"}},
+		{list, str, []interface{}{"a", "b"}, "a"},
+		{list, str, []interface{}{}, nil},
+	}
+	for idx, c := range cases {
+		actual, err := convert(conversionFor(c.old, c.new), c.input)
+		if err != nil {
+			t.Fatalf("%d. converting %v: %+v", idx, c.input, err)
+		}
+		if goValue(actual) != goValue(c.expected) {
+			t.Fatalf("%d. expected %s but got %s", idx, goValue(c.expected), goValue(actual))
+		}
+	}
+
+	if conversionFor(list, set) != conversionNone {
+		t.Fatalf("expected no conversion between a List and a Set")
+	}
+	if conversionFor(boolean, integer) != conversionUnsupported {
+		t.Fatalf("expected the conversion from a bool to an int to be unsupported")
+	}
+	if _, err := convert(conversionToInt, "abc"); err == nil {
+		t.Fatalf("expected an error converting `abc` to an int")
+	}
+}
+
+func TestNewIdRewrite(t *testing.T) {
+	pkg := "github.com/hashicorp/terraform-provider-azurerm/ids"
+	if _, err := newIdRewrite("testdata", pkg+".LegacyWidgetId", pkg+".WidgetId", nil); err == nil {
+		t.Fatalf("expected an error when a renamed segment isn't mapped")
+	}
+
+	rewrite, err := newIdRewrite("testdata", pkg+".LegacyWidgetId", pkg+".WidgetId", map[string]string{"widgetName": "name"})
+	if err != nil {
+		t.Fatalf("building the Resource ID rewrite: %+v", err)
+	}
+	if expected := "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resource-group/providers/Microsoft.Widgets/Widgets/widgetValue"; rewrite.OldExample != expected {
+		t.Fatalf("expected the old example %q but got %q", expected, rewrite.OldExample)
+	}
+	if expected := "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resource-group/providers/Microsoft.Widgets/widgets/widgetValue"; rewrite.NewExample != expected {
+		t.Fatalf("expected the new example %q but got %q", expected, rewrite.NewExample)
+	}
+}
+
+func TestStateUpgradeCode(t *testing.T) {
+	oldSchema, err := schemaFromDump(filepath.Join("testdata", "schema.json"), "azurerm_widget")
+	if err != nil {
+		t.Fatalf("loading the schema dump: %+v", err)
+	}
+	changes, err := diffSchemas(nil, oldSchema, newWidgetSchema(), map[string]string{"network_rules": "network_acls"})
+	if err != nil {
+		t.Fatalf("diffing the schemas: %+v", err)
+	}
+	upgrade := stateUpgrade{
+		ResourceType: "azurerm_widget",
+		Version:      1,
+		OldSchema:    oldSchema,
+		Changes:      changes,
+	}
+	if upgrade.TypeName() != "WidgetV1ToV2" {
+		t.Fatalf("expected the type name `WidgetV1ToV2` but got %q", upgrade.TypeName())
+	}
+
+	code, err := upgrade.Code()
+	if err != nil {
+		t.Fatalf("generating the State Upgrade: %+v", err)
+	}
+	for _, expected := range []string{
+		"type WidgetV1ToV2 struct{}",
+		`"legacy_setting": {`,
+		`delete(rawState, "legacy_setting")`,
+		`converted, err := strconv.ParseInt(fmt.Sprint(v), 10, 64)`,
+		`rawState["ip_range"] = []interface{}{v}`,
+		`if blocks0, ok := rawState["network_rules"].([]interface{}); ok {`,
+		`block0["ip_rules"] = v`,
+		`rawState["network_acls"] = v`,
+	} {
+		if !strings.Contains(string(code), expected) {
+			t.Fatalf("expected the generated code to contain %q:\n%s", expected, code)
+		}
+	}
+	if strings.Contains(string(code), `"log"`) {
+		t.Fatalf("expected the unused import of `log` to be removed:\n%s", code)
+	}
+
+	testCode, err := upgrade.TestCode()
+	if err != nil {
+		t.Fatalf("generating the State Upgrade test: %+v", err)
+	}
+	for _, expected := range []string{
+		`"capacity": int64(1),`,
+		`"enabled":  true,`,
+		`"network_acls": []interface{}{`,
+		`"ip_rules": []interface{}{`,
+	} {
+		if !strings.Contains(string(testCode), expected) {
+			t.Fatalf("expected the generated test to contain %q:\n%s", expected, testCode)
+		}
+	}
+}
+
+func TestBumpSchemaVersion(t *testing.T) {
+	cases := []struct {
+		name     string
+		source   resourceSource
+		input    string
+		expected []string
+	}{
+		{
+			name:   "untyped without State Upgrades",
+			source: resourceSource{FuncName: "resourceWidget"},
+			input: `package widgets
+
+import "github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+
+func resourceWidget() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{},
+	}
+}
+`,
+			expected: []string{
+				"SchemaVersion: 1,",
+				"0: migration.WidgetV0ToV1{},",
+				`"github.com/hashicorp/terraform-provider-azurerm/internal/services/widgets/migration"`,
+			},
+		},
+		{
+			name:   "untyped with State Upgrades",
+			source: resourceSource{FuncName: "resourceWidget"},
+			input: `package widgets
+
+import (
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/widgets/migration"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func resourceWidget() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		SchemaVersion: 1,
+		StateUpgraders: pluginsdk.StateUpgrades(map[int]pluginsdk.StateUpgrade{
+			0: migration.WidgetV0ToV1{},
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{},
+	}
+}
+`,
+			expected: []string{
+				"SchemaVersion: 2,",
+				"0: migration.WidgetV0ToV1{},\n\t\t\t1: migration.WidgetV1ToV2{},",
+			},
+		},
+		{
+			name:   "typed",
+			source: resourceSource{TypedResource: "WidgetResource", Receiver: "r"},
+			input: `package widgets
+
+import "github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+
+var _ sdk.ResourceWithUpdate = WidgetResource{}
+
+type WidgetResource struct{}
+
+func (r WidgetResource) ResourceType() string {
+	return "azurerm_widget"
+}
+`,
+			expected: []string{
+				"var _ sdk.ResourceWithUpdate = WidgetResource{}\n\nvar _ sdk.ResourceWithStateMigration = WidgetResource{}",
+				"func (r WidgetResource) StateUpgraders() sdk.StateUpgradeData {",
+				"SchemaVersion: 1,",
+				"0: migration.WidgetV0ToV1{},",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		dir := filepath.Join(t.TempDir(), "internal", "services", "widgets")
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		c.source.FilePath = filepath.Join(dir, "widget_resource.go")
+		if err := os.WriteFile(c.source.FilePath, []byte(c.input), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		version := 0
+		if strings.Contains(c.input, "SchemaVersion: 1,") {
+			version = 1
+		}
+		if err := c.source.bumpSchemaVersion(version, upgradeNameForVersion(version)); err != nil {
+			t.Fatalf("%s: bumping the SchemaVersion: %+v", c.name, err)
+		}
+
+		data, err := os.ReadFile(c.source.FilePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, expected := range c.expected {
+			if !strings.Contains(string(data), expected) {
+				t.Fatalf("%s: expected the updated source to contain %q:\n%s", c.name, expected, data)
+			}
+		}
+	}
+}
+
+func upgradeNameForVersion(version int) string {
+	return stateUpgrade{ResourceType: "azurerm_widget", Version: version}.TypeName()
+}
@@ -43,6 +43,9 @@ func main() {
 		githubIssueLabelsGenerator{},
 		teamCityServicesListGenerator{},
 		websiteCategoriesGenerator{},
+		resourceIdsGenerator{
+			rootDirectory: *filePath,
+		},
 	}
 	for _, value := range generators {
 		outputFile := value.outputPath(*filePath)
@@ -284,7 +287,7 @@ func (githubIssueLabelsGenerator) run(outputFileName string, _ map[string]struct
 		}
 
 		var names []string
-		for _, resource := range service.Resources() {
+		for _, resource := range sdk.TypedResourcesForService(service) {
 			names = append(names, resource.ResourceType())
 		}
 
@@ -396,6 +399,62 @@ func (githubIssueLabelsGenerator) run(outputFileName string, _ map[string]struct
 	return writeToFile(outputFileName, output)
 }
 
+const resourceIdsTemplate = `// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+// NOTE: this file is generated via 'make generate' - manual changes will be overwritten
+
+// generatedResourceIds is a list of the example Resource IDs used to generate the
+// Resource ID Parsers within each Service Package (see 'internal/tools/generator-resource-id')
+var generatedResourceIds = []string{
+%s
+}
+`
+
+var resourceIdGeneratorExample = regexp.MustCompile(`generator-resource-id/main\.go.*\s-id=(\S+)`)
+
+type resourceIdsGenerator struct {
+	rootDirectory string
+}
+
+func (resourceIdsGenerator) outputPath(rootDirectory string) string {
+	return fmt.Sprintf("%s/internal/provider/function/resource_ids_gen.go", rootDirectory)
+}
+
+func (g resourceIdsGenerator) run(outputFileName string, _ map[string]struct{}) error {
+	files, err := filepath.Glob(filepath.Join(g.rootDirectory, "internal", "services", "*", "*.go"))
+	if err != nil {
+		return fmt.Errorf("finding the Service Packages: %+v", err)
+	}
+
+	ids := make(map[string]struct{})
+	for _, file := range files {
+		contents, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("reading %q: %+v", file, err)
+		}
+
+		for _, line := range strings.Split(string(contents), "\n") {
+			if !strings.HasPrefix(line, "//go:generate") {
+				continue
+			}
+			if match := resourceIdGeneratorExample.FindStringSubmatch(line); len(match) == 2 {
+				ids[match[1]] = struct{}{}
+			}
+		}
+	}
+
+	lines := make([]string, 0, len(ids))
+	for id := range ids {
+		lines = append(lines, fmt.Sprintf("\t%q,", id))
+	}
+	sort.Strings(lines)
+
+	return writeToFile(outputFileName, fmt.Sprintf(resourceIdsTemplate, strings.Join(lines, "\n")))
+}
+
 func writeToFile(filePath string, contents string) error {
 	outputPath, err := filepath.Abs(filePath)
 	if err != nil {
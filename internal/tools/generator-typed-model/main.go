@@ -6,24 +6,65 @@ package main
 import (
 	"flag"
 	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
 	"log"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"unicode"
 
 	. "github.com/dave/jennifer/jen"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+	"golang.org/x/tools/imports"
 )
 
+// NOTE: since we're using `go run` for these tools all of the code needs to live within the main.go
+
 func main() {
+	sdkPackage := flag.String("sdk-package", "", "The import path of a go-azure-sdk package to generate a Typed Resource from (e.g. github.com/hashicorp/go-azure-sdk/resource-manager/fluidrelay/2022-05-26/fluidrelayservers)")
+	resourceType := flag.String("name", "", "The type of the Resource which should be generated (e.g. azurerm_fluid_relay_server). Required when `-sdk-package` is set.")
+	servicePackagePath := flag.String("service-package-path", "", "The path to the service package relative to the project root (e.g. internal/services/fluidrelay). Required when `-sdk-package` is set.")
+	sdkModel := flag.String("model", "", "The SDK model the Resource is built from, defaults to the payload of the Create/CreateOrUpdate method")
+	clientPath := flag.String("client", "", "The path to the SDK client from `metadata.Client` (e.g. `FluidRelay.FluidRelayServers`), defaults to `<Service>.<SDK Client>`")
+	brandName := flag.String("brand-name", "", "The friendly/brand name of the Resource used in the documentation, defaults to the description of the Resource ID")
+	rootDir := flag.String("root-dir", "../../..", "The path to the project root")
+	website := flag.Bool("website", false, "Whether to scaffold the documentation via the `website-scaffold` tool once the Resource has been generated")
 	flag.Parse()
+
+	if *sdkPackage != "" {
+		opts := sdkResourceOptions{
+			SdkPackage:         *sdkPackage,
+			ResourceType:       *resourceType,
+			ServicePackagePath: *servicePackagePath,
+			Model:              *sdkModel,
+			ClientPath:         *clientPath,
+			BrandName:          *brandName,
+			RootDir:            *rootDir,
+			Website:            *website,
+		}
+		if err := runForSdkPackage(opts); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	if len(flag.Args()) != 1 {
 		fmt.Fprintln(os.Stderr, "Usage: generator-typed-model <resource_type>")
+		fmt.Fprintln(os.Stderr, "       generator-typed-model -sdk-package <import path> -name <resource_type> -service-package-path <path> [-website]")
 		os.Exit(1)
 	}
 	rt := flag.Args()[0]
-	resource, ok := provider.AzureProvider().ResourcesMap[rt]
+	resource, ok := provider.AzureProviderSchema().ResourcesMap[rt]
 	if !ok {
 		log.Fatalf("unknown resource type: %s", rt)
 	}
@@ -127,3 +168,1598 @@ func modelForSchemaMap(name string, sm map[string]*schema.Schema) []Statement {
 
 	return out
 }
+
+// sdkPackage is the information parsed from a go-azure-sdk package which is required to generate a Typed Resource
+type sdkPackage struct {
+	ImportPath  string
+	Name        string
+	Client      string
+	Models      map[string]*ast.StructType
+	Constants   map[string]bool
+	ResourceIds map[string]*sdkResourceId
+	// Methods maps the name of each method of the Client to its parameter types (excluding the context)
+	Methods map[string][]ast.Expr
+}
+
+type sdkResourceId struct {
+	Name        string
+	Description string
+	Fields      []string
+	ExampleId   string
+}
+
+func parseSdkPackage(dir, importPath string) (*sdkPackage, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading %q: %+v", dir, err)
+	}
+
+	pkg := &sdkPackage{
+		ImportPath:  importPath,
+		Models:      map[string]*ast.StructType{},
+		Constants:   map[string]bool{},
+		ResourceIds: map[string]*sdkResourceId{},
+		Methods:     map[string][]ast.Expr{},
+	}
+
+	fset := token.NewFileSet()
+	var funcs []*ast.FuncDecl
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, entry.Name()), nil, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %q: %+v", entry.Name(), err)
+		}
+		pkg.Name = file.Name.Name
+
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				if decl.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range decl.Specs {
+					spec := spec.(*ast.TypeSpec)
+					st, ok := spec.Type.(*ast.StructType)
+					if !ok {
+						continue
+					}
+					pkg.Models[spec.Name.Name] = st
+					if entry.Name() == "client.go" && strings.HasSuffix(spec.Name.Name, "Client") {
+						pkg.Client = spec.Name.Name
+					}
+					if strings.HasPrefix(entry.Name(), "id_") && strings.HasSuffix(spec.Name.Name, "Id") {
+						id := &sdkResourceId{
+							Name: spec.Name.Name,
+						}
+						if decl.Doc != nil {
+							if _, desc, ok := strings.Cut(decl.Doc.Text(), "the Resource ID for a "); ok {
+								id.Description = strings.TrimSpace(desc)
+							}
+						}
+						for _, field := range st.Fields.List {
+							for _, name := range field.Names {
+								id.Fields = append(id.Fields, name.Name)
+							}
+						}
+						pkg.ResourceIds[id.Name] = id
+					}
+				}
+			case *ast.FuncDecl:
+				funcs = append(funcs, decl)
+			}
+		}
+	}
+
+	for _, fn := range funcs {
+		if fn.Recv == nil {
+			if name, ok := strings.CutPrefix(fn.Name.Name, "PossibleValuesFor"); ok {
+				pkg.Constants[name] = true
+			}
+			continue
+		}
+		recv := types.ExprString(fn.Recv.List[0].Type)
+		switch {
+		case recv == pkg.Client && fn.Name.IsExported():
+			var params []ast.Expr
+			for _, param := range fn.Type.Params.List {
+				if types.ExprString(param.Type) == "context.Context" {
+					continue
+				}
+				for range param.Names {
+					params = append(params, param.Type)
+				}
+			}
+			pkg.Methods[fn.Name.Name] = params
+		case fn.Name.Name == "Segments" && pkg.ResourceIds[recv] != nil:
+			pkg.ResourceIds[recv].ExampleId = exampleIdFromSegments(fn)
+		}
+	}
+
+	if pkg.Client == "" {
+		return nil, fmt.Errorf("no SDK Client was found in %q", dir)
+	}
+
+	return pkg, nil
+}
+
+// exampleIdFromSegments builds an example Resource ID from the example values of each Segment, which is the last argument of each Segment
+func exampleIdFromSegments(fn *ast.FuncDecl) string {
+	var segments []string
+	ast.Inspect(fn.Body, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+		if lit, ok := call.Args[len(call.Args)-1].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			if v, err := strconv.Unquote(lit.Value); err == nil {
+				segments = append(segments, strings.Trim(v, "/"))
+			}
+		}
+		return false
+	})
+	return "/" + strings.Join(segments, "/")
+}
+
+type fieldKind int
+
+const (
+	fieldKindUnsupported fieldKind = iota
+	fieldKindString
+	fieldKindBool
+	fieldKindInt
+	fieldKindFloat
+	fieldKindEnum
+	fieldKindMap
+	fieldKindModel
+)
+
+// resourceField is a property of an SDK model which is exposed in the Schema of the generated Resource
+type resourceField struct {
+	SdkName    string
+	SchemaName string
+	Kind       fieldKind
+	List       bool
+	// TypeName is the name of the SDK constant or model for enum and model fields
+	TypeName string
+	Pointer  bool
+	Required bool
+	// InProperties is whether this (top-level) field lives within the `Properties` of the SDK model
+	InProperties bool
+}
+
+type nestedModel struct {
+	Name   string
+	Fields []resourceField
+	Single bool
+	List   bool
+}
+
+// identityType describes how an identity type from go-azure-helpers is exposed in the Schema
+type identityType struct {
+	Schema         string
+	Model          string
+	Expand         string
+	Flatten        string
+	FlattenPointer bool
+	FlattenError   bool
+}
+
+var identityTypes = map[string]identityType{
+	"identity.LegacySystemAndUserAssignedMap": {"SystemAssignedUserAssignedIdentityOptional", "ModelSystemAssignedUserAssigned", "ExpandLegacySystemAndUserAssignedMapFromModel", "FlattenLegacySystemAndUserAssignedMapToModel", false, true},
+	"identity.SystemAndUserAssignedList":      {"SystemAssignedUserAssignedIdentityOptional", "ModelSystemAssignedUserAssigned", "ExpandSystemAndUserAssignedListFromModel", "FlattenSystemAndUserAssignedListToModel", true, true},
+	"identity.SystemAndUserAssignedMap":       {"SystemAssignedUserAssignedIdentityOptional", "ModelSystemAssignedUserAssigned", "ExpandSystemAndUserAssignedMapFromModel", "FlattenSystemAndUserAssignedMapToModel", true, true},
+	"identity.SystemAssigned":                 {"SystemAssignedIdentityOptional", "ModelSystemAssigned", "ExpandSystemAssignedFromModel", "FlattenSystemAssignedToModel", false, false},
+	"identity.SystemOrUserAssignedList":       {"SystemOrUserAssignedIdentityOptional", "ModelSystemAssignedUserAssigned", "ExpandSystemOrUserAssignedListFromModel", "FlattenSystemAssignedOrUserAssignedListToModel", true, true},
+	"identity.SystemOrUserAssignedMap":        {"SystemOrUserAssignedIdentityOptional", "ModelSystemAssignedUserAssigned", "ExpandSystemOrUserAssignedMapFromModel", "FlattenSystemOrUserAssignedMapToModel", true, true},
+	"identity.UserAssignedList":               {"UserAssignedIdentityOptional", "ModelUserAssigned", "ExpandUserAssignedListFromModel", "FlattenUserAssignedListToModel", true, true},
+	"identity.UserAssignedMap":                {"UserAssignedIdentityOptional", "ModelUserAssigned", "ExpandUserAssignedMapFromModel", "FlattenUserAssignedMapToModel", true, true},
+}
+
+// skippedFields are the properties of SDK models which are either read-only or handled as part of the Resource ID
+var skippedFields = map[string]bool{
+	"Etag":              true,
+	"Id":                true,
+	"Name":              true,
+	"ProvisioningState": true,
+	"SystemData":        true,
+	"Type":              true,
+}
+
+// typedResource is the definition of the Typed Resource which is generated from an SDK package
+type typedResource struct {
+	Pkg          *sdkPackage
+	ResourceType string
+	StructName   string
+	ClientPath   string
+	Model        string
+	Id           *sdkResourceId
+	ParentId     *sdkResourceId
+
+	PropertiesModel   string
+	PropertiesPointer bool
+	HasLocation       bool
+	LocationPointer   bool
+	HasTags           bool
+	TagsPointer       bool
+	Identity          *identityType
+	IdentityPointer   bool
+
+	Fields      []resourceField
+	Models      []*nestedModel
+	Unsupported []string
+
+	CreateMethod string
+	DeleteMethod string
+
+	models map[string]*nestedModel
+}
+
+func newTypedResource(pkg *sdkPackage, resourceType, model, clientPath string) (*typedResource, error) {
+	r := &typedResource{
+		Pkg:          pkg,
+		ResourceType: resourceType,
+		StructName:   snake2Camel(strings.TrimPrefix(resourceType, "azurerm_")) + "Resource",
+		ClientPath:   clientPath,
+		Model:        model,
+		models:       map[string]*nestedModel{},
+	}
+
+	for _, method := range []string{"CreateOrUpdate", "Create"} {
+		if params, ok := pkg.Methods[method]; ok && len(params) == 2 {
+			r.CreateMethod = method
+			if _, ok := pkg.Methods[method+"ThenPoll"]; ok {
+				r.CreateMethod += "ThenPoll"
+			}
+			if r.Model == "" {
+				r.Model = types.ExprString(params[1])
+			}
+			break
+		}
+	}
+	if r.CreateMethod == "" {
+		return nil, fmt.Errorf("the SDK Client %q has no Create or CreateOrUpdate method", pkg.Client)
+	}
+
+	for _, method := range []string{"DeleteThenPoll", "Delete"} {
+		if _, ok := pkg.Methods[method]; ok {
+			r.DeleteMethod = method
+			break
+		}
+	}
+	if r.DeleteMethod == "" {
+		return nil, fmt.Errorf("the SDK Client %q has no Delete method", pkg.Client)
+	}
+
+	getParams, ok := pkg.Methods["Get"]
+	if !ok || len(getParams) != 1 {
+		return nil, fmt.Errorf("the SDK Client %q has no Get method", pkg.Client)
+	}
+	r.Id = pkg.ResourceIds[types.ExprString(getParams[0])]
+	if r.Id == nil {
+		return nil, fmt.Errorf("the Resource ID %q used by the Get method was not found", types.ExprString(getParams[0]))
+	}
+	// a parent Resource ID within the same package is exposed as `<parent>_id` rather than a field for each segment
+	for _, id := range pkg.ResourceIds {
+		if len(id.Fields) == len(r.Id.Fields)-1 && slices.Equal(id.Fields, r.Id.Fields[:len(id.Fields)]) {
+			r.ParentId = id
+		}
+	}
+
+	st, ok := pkg.Models[r.Model]
+	if !ok {
+		return nil, fmt.Errorf("the model %q was not found", r.Model)
+	}
+	used := map[string]bool{
+		"name": true,
+	}
+	for _, arg := range r.idArguments() {
+		used[arg.SchemaName] = true
+	}
+
+	var properties *ast.StructType
+	for _, field := range structFields(st) {
+		switch field.name {
+		case "Location":
+			r.HasLocation = true
+			r.LocationPointer = field.pointer
+			used["location"] = true
+			continue
+		case "Tags":
+			r.HasTags = true
+			r.TagsPointer = field.pointer
+			used["tags"] = true
+			continue
+		case "Identity":
+			if it, ok := identityTypes[types.ExprString(stripPointer(field.typ))]; ok {
+				r.Identity = &it
+				r.IdentityPointer = field.pointer
+				used["identity"] = true
+			} else {
+				r.Unsupported = append(r.Unsupported, fmt.Sprintf("identity (%s)", types.ExprString(field.typ)))
+			}
+			continue
+		case "Properties":
+			name := types.ExprString(stripPointer(field.typ))
+			if props, ok := pkg.Models[name]; ok {
+				r.PropertiesModel = name
+				r.PropertiesPointer = field.pointer
+				properties = props
+				continue
+			}
+		}
+	}
+
+	addFields := func(st *ast.StructType, inProperties bool) {
+		for _, field := range structFields(st) {
+			if skippedFields[field.name] || (!inProperties && (field.name == "Location" || field.name == "Tags" || field.name == "Identity" || field.name == "Properties")) {
+				continue
+			}
+			f := r.newResourceField(field, map[string]bool{r.Model: true})
+			f.InProperties = inProperties
+			if f.Kind == fieldKindUnsupported || used[f.SchemaName] {
+				r.Unsupported = append(r.Unsupported, fmt.Sprintf("%s (%s)", field.json, types.ExprString(field.typ)))
+				continue
+			}
+			used[f.SchemaName] = true
+			r.Fields = append(r.Fields, f)
+		}
+	}
+	addFields(st, false)
+	if properties != nil {
+		addFields(properties, true)
+	}
+	sortFields(r.Fields)
+
+	for _, name := range sortedKeys(r.models) {
+		r.Models = append(r.Models, r.models[name])
+	}
+
+	return r, nil
+}
+
+type sdkField struct {
+	name      string
+	json      string
+	typ       ast.Expr
+	pointer   bool
+	omitEmpty bool
+}
+
+func structFields(st *ast.StructType) (out []sdkField) {
+	for _, field := range st.Fields.List {
+		if field.Tag == nil || len(field.Names) == 0 {
+			continue
+		}
+		tag, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			continue
+		}
+		jsonTag, ok := reflect.StructTag(tag).Lookup("json")
+		if !ok || jsonTag == "-" {
+			continue
+		}
+		jsonName, opts, _ := strings.Cut(jsonTag, ",")
+		_, isPointer := field.Type.(*ast.StarExpr)
+		out = append(out, sdkField{
+			name:      field.Names[0].Name,
+			json:      jsonName,
+			typ:       field.Type,
+			pointer:   isPointer,
+			omitEmpty: strings.Contains(opts, "omitempty"),
+		})
+	}
+	return out
+}
+
+func stripPointer(expr ast.Expr) ast.Expr {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		return star.X
+	}
+	return expr
+}
+
+func (r *typedResource) newResourceField(field sdkField, visiting map[string]bool) resourceField {
+	f := resourceField{
+		SdkName:    field.name,
+		SchemaName: camel2Snake(field.json),
+		Pointer:    field.pointer,
+		Required:   !field.pointer && !field.omitEmpty,
+	}
+
+	typ := stripPointer(field.typ)
+	switch t := typ.(type) {
+	case *ast.ArrayType:
+		f.List = true
+		typ = stripPointer(t.Elt)
+	case *ast.MapType:
+		if types.ExprString(t.Key) == "string" && types.ExprString(t.Value) == "string" {
+			f.Kind = fieldKindMap
+		}
+		return f
+	}
+
+	ident, ok := typ.(*ast.Ident)
+	if !ok {
+		return f
+	}
+	switch name := ident.Name; {
+	case name == "string":
+		f.Kind = fieldKindString
+	case name == "bool":
+		f.Kind = fieldKindBool
+	case name == "int64":
+		f.Kind = fieldKindInt
+	case name == "float64":
+		f.Kind = fieldKindFloat
+	case r.Pkg.Constants[name]:
+		// lists of constants need a conversion for each item which is left to be written by hand
+		if !f.List {
+			f.Kind = fieldKindEnum
+			f.TypeName = name
+		}
+	case r.Pkg.Models[name] != nil && !visiting[name]:
+		if r.nestedModel(name, visiting) != nil {
+			f.Kind = fieldKindModel
+			f.TypeName = name
+			if f.List {
+				r.models[name].List = true
+			} else {
+				r.models[name].Single = true
+			}
+		}
+	}
+	return f
+}
+
+// nestedModel returns the nested model for the SDK model, or nil when none of its properties are supported
+func (r *typedResource) nestedModel(name string, visiting map[string]bool) *nestedModel {
+	if m, ok := r.models[name]; ok {
+		return m
+	}
+
+	v := map[string]bool{name: true}
+	for k := range visiting {
+		v[k] = true
+	}
+	m := &nestedModel{
+		Name: name,
+	}
+	for _, field := range structFields(r.Pkg.Models[name]) {
+		if skippedFields[field.name] && field.name != "Name" && field.name != "Id" && field.name != "Type" {
+			continue
+		}
+		f := r.newResourceField(field, v)
+		if f.Kind == fieldKindUnsupported {
+			r.Unsupported = append(r.Unsupported, fmt.Sprintf("%s.%s (%s)", name, field.json, types.ExprString(field.typ)))
+			continue
+		}
+		m.Fields = append(m.Fields, f)
+	}
+	if len(m.Fields) == 0 {
+		return nil
+	}
+	sortFields(m.Fields)
+	r.models[name] = m
+	return m
+}
+
+// sortFields sorts the Required fields ahead of the Optional fields, both alphabetically
+func sortFields(fields []resourceField) {
+	sort.SliceStable(fields, func(i, j int) bool {
+		if fields[i].Required != fields[j].Required {
+			return fields[i].Required
+		}
+		return fields[i].SchemaName < fields[j].SchemaName
+	})
+}
+
+func sortedKeys[T any](input map[string]T) []string {
+	keys := make([]string, 0, len(input))
+	for k := range input {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// idArgument is an argument of the Resource which makes up its Resource ID
+type idArgument struct {
+	SchemaName string
+	ModelName  string
+	Schema     string
+}
+
+func (r *typedResource) idArguments() (out []idArgument) {
+	if r.ParentId != nil {
+		name := strings.TrimSuffix(r.ParentId.Name, "Id")
+		return []idArgument{
+			{
+				SchemaName: camel2Snake(name) + "_id",
+				ModelName:  name + "Id",
+				Schema: fmt.Sprintf(`{
+	Type:         pluginsdk.TypeString,
+	Required:     true,
+	ForceNew:     true,
+	ValidateFunc: %s.Validate%sID,
+}`, r.Pkg.Name, name),
+			},
+		}
+	}
+
+	for _, field := range r.Id.Fields[:len(r.Id.Fields)-1] {
+		switch field {
+		case "SubscriptionId":
+			continue
+		case "ResourceGroup", "ResourceGroupName":
+			out = append(out, idArgument{
+				SchemaName: "resource_group_name",
+				ModelName:  "ResourceGroupName",
+				Schema:     "commonschema.ResourceGroupName()",
+			})
+		default:
+			out = append(out, idArgument{
+				SchemaName: camel2Snake(field),
+				ModelName:  field,
+				Schema: `{
+	Type:         pluginsdk.TypeString,
+	Required:     true,
+	ForceNew:     true,
+	ValidateFunc: validation.StringIsNotEmpty,
+}`,
+			})
+		}
+	}
+	return out
+}
+
+func (r *typedResource) idName() string {
+	return strings.TrimSuffix(r.Id.Name, "Id")
+}
+
+func (f resourceField) modelType() string {
+	var out string
+	switch f.Kind {
+	case fieldKindString, fieldKindEnum:
+		out = "string"
+	case fieldKindBool:
+		out = "bool"
+	case fieldKindInt:
+		out = "int64"
+	case fieldKindFloat:
+		out = "float64"
+	case fieldKindMap:
+		return "map[string]string"
+	case fieldKindModel:
+		return "[]" + f.TypeName + "Model"
+	}
+	if f.List {
+		out = "[]" + out
+	}
+	return out
+}
+
+func (f resourceField) schema(pkgName string) string {
+	var b strings.Builder
+	b.WriteString("{\n")
+	switch {
+	case f.Kind == fieldKindMap:
+		b.WriteString("Type: pluginsdk.TypeMap,\n")
+	case f.List || f.Kind == fieldKindModel:
+		b.WriteString("Type: pluginsdk.TypeList,\n")
+	default:
+		b.WriteString("Type: " + f.primitiveType() + ",\n")
+	}
+	if f.Required {
+		b.WriteString("Required: true,\n")
+	} else {
+		b.WriteString("Optional: true,\n")
+	}
+
+	switch {
+	case f.Kind == fieldKindModel:
+		if !f.List {
+			b.WriteString("MaxItems: 1,\n")
+		}
+	case f.Kind == fieldKindMap:
+		b.WriteString("Elem: &pluginsdk.Schema{\nType: pluginsdk.TypeString,\n},\n")
+	case f.List:
+		b.WriteString("Elem: &pluginsdk.Schema{\nType: " + f.primitiveType() + ",\n" + f.validateFunc(pkgName) + "},\n")
+	default:
+		b.WriteString(f.validateFunc(pkgName))
+	}
+	return b.String()
+}
+
+func (f resourceField) primitiveType() string {
+	switch f.Kind {
+	case fieldKindBool:
+		return "pluginsdk.TypeBool"
+	case fieldKindInt:
+		return "pluginsdk.TypeInt"
+	case fieldKindFloat:
+		return "pluginsdk.TypeFloat"
+	}
+	return "pluginsdk.TypeString"
+}
+
+func (f resourceField) validateFunc(pkgName string) string {
+	switch f.Kind {
+	case fieldKindString:
+		return "ValidateFunc: validation.StringIsNotEmpty,\n"
+	case fieldKindEnum:
+		return fmt.Sprintf("ValidateFunc: validation.StringInSlice(%s.PossibleValuesFor%s(), false),\n", pkgName, f.TypeName)
+	}
+	return ""
+}
+
+// expand returns the expression converting the value of the Typed Model into the value of the SDK field
+func (f resourceField) expand(pkgName, v string) string {
+	switch f.Kind {
+	case fieldKindEnum:
+		v = fmt.Sprintf("%s.%s(%s)", pkgName, f.TypeName, v)
+	case fieldKindModel:
+		fn := "expand" + f.TypeName
+		if f.List {
+			fn += "s"
+		}
+		if f.Pointer {
+			return fmt.Sprintf("%s(%s)", fn, v)
+		}
+		return fmt.Sprintf("pointer.From(%s(%s))", fn, v)
+	}
+	if f.Pointer {
+		return fmt.Sprintf("pointer.To(%s)", v)
+	}
+	return v
+}
+
+// flatten returns the expression converting the value of the SDK field into the value of the Typed Model
+func (f resourceField) flatten(v string) string {
+	switch f.Kind {
+	case fieldKindEnum:
+		if f.Pointer {
+			return fmt.Sprintf("string(pointer.From(%s))", v)
+		}
+		return fmt.Sprintf("string(%s)", v)
+	case fieldKindModel:
+		fn := "flatten" + f.TypeName
+		if f.List {
+			fn += "s"
+		}
+		if f.Pointer {
+			return fmt.Sprintf("%s(%s)", fn, v)
+		}
+		return fmt.Sprintf("%s(&%s)", fn, v)
+	}
+	if f.Pointer {
+		return fmt.Sprintf("pointer.From(%s)", v)
+	}
+	return v
+}
+
+func (r *typedResource) modelStruct(name string, fields []resourceField, top bool) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "type %s struct {\n", name)
+	if top {
+		b.WriteString("Name string `tfschema:\"name\"`\n")
+		for _, arg := range r.idArguments() {
+			fmt.Fprintf(&b, "%s string `tfschema:%q`\n", arg.ModelName, arg.SchemaName)
+		}
+		if r.HasLocation {
+			b.WriteString("Location string `tfschema:\"location\"`\n")
+		}
+	}
+	for _, f := range fields {
+		fmt.Fprintf(&b, "%s %s `tfschema:%q`\n", f.SdkName, f.modelType(), f.SchemaName)
+	}
+	if top {
+		if r.Identity != nil {
+			fmt.Fprintf(&b, "Identity []identity.%s `tfschema:\"identity\"`\n", r.Identity.Model)
+		}
+		if r.HasTags {
+			b.WriteString("Tags map[string]string `tfschema:\"tags\"`\n")
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func (r *typedResource) schemaMap(fields []resourceField) string {
+	var b strings.Builder
+	b.WriteString("map[string]*pluginsdk.Schema{\n")
+	for _, f := range fields {
+		fmt.Fprintf(&b, "%q: %s", f.SchemaName, f.schema(r.Pkg.Name))
+		if f.Kind == fieldKindModel {
+			fmt.Fprintf(&b, "Elem: &pluginsdk.Resource{\nSchema: %s,\n},\n", r.schemaMap(r.models[f.TypeName].Fields))
+		}
+		b.WriteString("},\n\n")
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+func (r *typedResource) arguments() string {
+	var b strings.Builder
+	b.WriteString("map[string]*pluginsdk.Schema{\n")
+	b.WriteString(`"name": {
+	Type:         pluginsdk.TypeString,
+	Required:     true,
+	ForceNew:     true,
+	ValidateFunc: validation.StringIsNotEmpty,
+},
+
+`)
+	for _, arg := range r.idArguments() {
+		fmt.Fprintf(&b, "%q: %s,\n\n", arg.SchemaName, arg.Schema)
+	}
+	if r.HasLocation {
+		b.WriteString("\"location\": commonschema.Location(),\n\n")
+	}
+	props := r.schemaMap(r.Fields)
+	b.WriteString(strings.TrimSuffix(strings.TrimPrefix(props, "map[string]*pluginsdk.Schema{\n"), "}"))
+	if r.Identity != nil {
+		fmt.Fprintf(&b, "\"identity\": commonschema.%s(),\n\n", r.Identity.Schema)
+	}
+	if r.HasTags {
+		b.WriteString("\"tags\": commonschema.Tags(),\n")
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// fieldAssignments returns the assignments of the SDK fields from the Typed Model `source` - either as the key/value pairs
+// of a composite literal, or when `update` is set as assignments to `prefix` which are guarded by `HasChange`
+func (r *typedResource) fieldAssignments(fields []resourceField, prefix, source string, update bool) string {
+	var b strings.Builder
+	for _, f := range fields {
+		target := prefix + f.SdkName
+		value := f.expand(r.Pkg.Name, source+"."+f.SdkName)
+		switch {
+		case update:
+			fmt.Fprintf(&b, "if metadata.ResourceData.HasChange(%q) {\n%s = %s\n}\n\n", f.SchemaName, target, value)
+		default:
+			fmt.Fprintf(&b, "%s: %s,\n", f.SdkName, value)
+		}
+	}
+	return b.String()
+}
+
+func (r *typedResource) fieldsIn(inProperties bool) (out []resourceField) {
+	for _, f := range r.Fields {
+		if f.InProperties == inProperties {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func (r *typedResource) payload() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "payload := %s.%s{\n", r.Pkg.Name, r.Model)
+	if r.HasLocation {
+		if r.LocationPointer {
+			b.WriteString("Location: pointer.To(location.Normalize(config.Location)),\n")
+		} else {
+			b.WriteString("Location: location.Normalize(config.Location),\n")
+		}
+	}
+	b.WriteString(r.fieldAssignments(r.fieldsIn(false), "", "config", false))
+	if r.PropertiesModel != "" {
+		amp := ""
+		if r.PropertiesPointer {
+			amp = "&"
+		}
+		fmt.Fprintf(&b, "Properties: %s%s.%s{\n%s},\n", amp, r.Pkg.Name, r.PropertiesModel, r.fieldAssignments(r.fieldsIn(true), "", "config", false))
+	}
+	if r.HasTags {
+		if r.TagsPointer {
+			b.WriteString("Tags: pointer.To(config.Tags),\n")
+		} else {
+			b.WriteString("Tags: config.Tags,\n")
+		}
+	}
+	b.WriteString("}\n")
+	if r.Identity != nil {
+		fmt.Fprintf(&b, `
+identityValue, err := identity.%s(config.Identity)
+if err != nil {
+	return fmt.Errorf("expanding `+"`identity`"+`: %%+v", err)
+}
+payload.Identity = %s
+`, r.Identity.Expand, r.identityValue())
+	}
+	return b.String()
+}
+
+func (r *typedResource) identityValue() string {
+	if r.IdentityPointer {
+		return "identityValue"
+	}
+	return "pointer.From(identityValue)"
+}
+
+func (r *typedResource) idFromConfig() string {
+	if r.ParentId != nil {
+		parent := strings.TrimSuffix(r.ParentId.Name, "Id")
+		args := make([]string, 0)
+		for _, field := range r.ParentId.Fields {
+			args = append(args, "parentId."+field)
+		}
+		args = append(args, "config.Name")
+		return fmt.Sprintf(`parentId, err := %[1]s.Parse%[2]sID(config.%[2]sId)
+if err != nil {
+	return err
+}
+
+id := %[1]s.New%[3]sID(%[4]s)`, r.Pkg.Name, parent, r.idName(), strings.Join(args, ", "))
+	}
+
+	args := make([]string, 0)
+	for _, field := range r.Id.Fields[:len(r.Id.Fields)-1] {
+		switch field {
+		case "SubscriptionId":
+			args = append(args, "subscriptionId")
+		case "ResourceGroup", "ResourceGroupName":
+			args = append(args, "config.ResourceGroupName")
+		default:
+			args = append(args, "config."+field)
+		}
+	}
+	args = append(args, "config.Name")
+
+	var b strings.Builder
+	if slices.Contains(r.Id.Fields, "SubscriptionId") {
+		b.WriteString("subscriptionId := metadata.Client.Account.SubscriptionId\n")
+	}
+	fmt.Fprintf(&b, "id := %s.New%sID(%s)", r.Pkg.Name, r.idName(), strings.Join(args, ", "))
+	return b.String()
+}
+
+func (r *typedResource) stateFromId() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "state := %sModel{\nName: id.%s,\n", r.StructName, r.Id.Fields[len(r.Id.Fields)-1])
+	if r.ParentId != nil {
+		parent := strings.TrimSuffix(r.ParentId.Name, "Id")
+		args := make([]string, 0)
+		for _, field := range r.ParentId.Fields {
+			args = append(args, "id."+field)
+		}
+		fmt.Fprintf(&b, "%sId: %s.New%sID(%s).ID(),\n", parent, r.Pkg.Name, parent, strings.Join(args, ", "))
+	} else {
+		for _, arg := range r.idArguments() {
+			field := arg.ModelName
+			if field == "ResourceGroupName" && slices.Contains(r.Id.Fields, "ResourceGroup") {
+				field = "ResourceGroup"
+			}
+			fmt.Fprintf(&b, "%s: id.%s,\n", arg.ModelName, field)
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func (r *typedResource) readModel() string {
+	var b strings.Builder
+	b.WriteString("if model := resp.Model; model != nil {\n")
+	if r.HasLocation {
+		if r.LocationPointer {
+			b.WriteString("state.Location = location.NormalizeNilable(model.Location)\n")
+		} else {
+			b.WriteString("state.Location = location.Normalize(model.Location)\n")
+		}
+	}
+	for _, f := range r.fieldsIn(false) {
+		fmt.Fprintf(&b, "state.%s = %s\n", f.SdkName, f.flatten("model."+f.SdkName))
+	}
+	if r.Identity != nil {
+		identitySource := "model.Identity"
+		if !r.IdentityPointer {
+			identitySource = "&model.Identity"
+		}
+		value := "identityValue"
+		if r.Identity.FlattenPointer {
+			value = "pointer.From(identityValue)"
+		}
+		if r.Identity.FlattenError {
+			fmt.Fprintf(&b, `
+identityValue, err := identity.%s(%s)
+if err != nil {
+	return fmt.Errorf("flattening `+"`identity`"+`: %%+v", err)
+}
+state.Identity = %s
+`, r.Identity.Flatten, identitySource, value)
+		} else {
+			fmt.Fprintf(&b, "state.Identity = identity.%s(%s)\n", r.Identity.Flatten, identitySource)
+		}
+	}
+	if r.HasTags {
+		if r.TagsPointer {
+			b.WriteString("state.Tags = pointer.From(model.Tags)\n")
+		} else {
+			b.WriteString("state.Tags = model.Tags\n")
+		}
+	}
+	if r.PropertiesModel != "" {
+		props := "model.Properties"
+		if !r.PropertiesPointer {
+			props = "&model.Properties"
+		}
+		fmt.Fprintf(&b, "\nif props := %s; props != nil {\n", props)
+		for _, f := range r.fieldsIn(true) {
+			fmt.Fprintf(&b, "state.%s = %s\n", f.SdkName, f.flatten("props."+f.SdkName))
+		}
+		b.WriteString("}\n")
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func (r *typedResource) updateModel() string {
+	var b strings.Builder
+	b.WriteString("payload := existing.Model\n\n")
+	b.WriteString(r.fieldAssignments(r.fieldsIn(false), "payload.", "config", true))
+	if r.PropertiesModel != "" {
+		if fields := r.fieldsIn(true); len(fields) > 0 {
+			if r.PropertiesPointer {
+				fmt.Fprintf(&b, "if payload.Properties == nil {\npayload.Properties = &%s.%s{}\n}\n\n", r.Pkg.Name, r.PropertiesModel)
+			}
+			b.WriteString(r.fieldAssignments(fields, "payload.Properties.", "config", true))
+		}
+	}
+	if r.Identity != nil {
+		fmt.Fprintf(&b, `if metadata.ResourceData.HasChange("identity") {
+	identityValue, err := identity.%s(config.Identity)
+	if err != nil {
+		return fmt.Errorf("expanding `+"`identity`"+`: %%+v", err)
+	}
+	payload.Identity = %s
+}
+
+`, r.Identity.Expand, r.identityValue())
+	}
+	if r.HasTags {
+		value := "config.Tags"
+		if r.TagsPointer {
+			value = "pointer.To(config.Tags)"
+		}
+		fmt.Fprintf(&b, "if metadata.ResourceData.HasChange(\"tags\") {\npayload.Tags = %s\n}\n\n", value)
+	}
+	return b.String()
+}
+
+func (r *typedResource) expandFlattenFuncs() string {
+	var b strings.Builder
+	for _, m := range r.Models {
+		var entries strings.Builder
+		fmt.Fprintf(&entries, "%s.%s{\n%s}", r.Pkg.Name, m.Name, r.fieldAssignments(m.Fields, "", "v", false))
+		var flattened strings.Builder
+		flattened.WriteString("{\n")
+		for _, f := range m.Fields {
+			fmt.Fprintf(&flattened, "%s: %s,\n", f.SdkName, f.flatten("v."+f.SdkName))
+		}
+		flattened.WriteString("}")
+
+		if m.Single {
+			fmt.Fprintf(&b, `
+func expand%[1]s(input []%[1]sModel) *%[2]s.%[1]s {
+	if len(input) == 0 {
+		return nil
+	}
+
+	v := input[0]
+	return &%[3]s
+}
+`, m.Name, r.Pkg.Name, entries.String())
+			fmt.Fprintf(&b, `
+func flatten%[1]s(input *%[2]s.%[1]s) []%[1]sModel {
+	if input == nil {
+		return []%[1]sModel{}
+	}
+
+	v := *input
+	return []%[1]sModel{
+		%[3]s,
+	}
+}
+`, m.Name, r.Pkg.Name, flattened.String())
+		}
+		if m.List {
+			fmt.Fprintf(&b, `
+func expand%[1]ss(input []%[1]sModel) *[]%[2]s.%[1]s {
+	result := make([]%[2]s.%[1]s, 0)
+	for _, v := range input {
+		result = append(result, %[3]s)
+	}
+
+	return &result
+}
+`, m.Name, r.Pkg.Name, entries.String())
+			fmt.Fprintf(&b, `
+func flatten%[1]ss(input *[]%[2]s.%[1]s) []%[1]sModel {
+	result := make([]%[1]sModel, 0)
+	if input == nil {
+		return result
+	}
+
+	for _, v := range *input {
+		result = append(result, %[1]sModel%[3]s)
+	}
+
+	return result
+}
+`, m.Name, r.Pkg.Name, flattened.String())
+		}
+	}
+	return b.String()
+}
+
+func (r *typedResource) Code(servicePackage string) string {
+	var models strings.Builder
+	models.WriteString(r.modelStruct(r.StructName+"Model", r.Fields, true))
+	for _, m := range r.Models {
+		models.WriteString("\n" + r.modelStruct(m.Name+"Model", m.Fields, false))
+	}
+
+	var unsupported string
+	if len(r.Unsupported) > 0 {
+		unsupported = "\n// TODO: the following properties of the SDK models need to be mapped by hand:\n"
+		for _, p := range r.Unsupported {
+			unsupported += fmt.Sprintf("// * %s\n", p)
+		}
+	}
+
+	return fmt.Sprintf(`// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package %[1]s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/identity"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"%[2]s"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+type %[3]s struct{}
+
+var _ sdk.ResourceWithUpdate = %[3]s{}
+
+%[4]s
+%[13]s
+func (r %[3]s) Arguments() map[string]*pluginsdk.Schema {
+	return %[5]s
+}
+
+func (r %[3]s) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r %[3]s) ModelObject() interface{} {
+	return &%[3]sModel{}
+}
+
+func (r %[3]s) ResourceType() string {
+	return %[6]q
+}
+
+func (r %[3]s) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return %[7]s.Validate%[8]sID
+}
+
+func (r %[3]s) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.%[9]s
+
+			var config %[3]sModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %%+v", err)
+			}
+
+			%[10]s
+
+			existing, err := client.Get(ctx, id)
+			if err != nil {
+				if !response.WasNotFound(existing.HttpResponse) {
+					return fmt.Errorf("checking for presence of existing %%s: %%+v", id, err)
+				}
+			}
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			%[11]s
+
+			if %[14]s(ctx, id, payload); err != nil {
+				return fmt.Errorf("creating %%s: %%+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r %[3]s) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.%[9]s
+
+			id, err := %[7]s.Parse%[8]sID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %%s: %%+v", *id, err)
+			}
+
+			%[12]s
+			%[15]s
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r %[3]s) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.%[9]s
+
+			id, err := %[7]s.Parse%[8]sID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var config %[3]sModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %%+v", err)
+			}
+
+			existing, err := client.Get(ctx, *id)
+			if err != nil {
+				return fmt.Errorf("retrieving %%s: %%+v", *id, err)
+			}
+			if existing.Model == nil {
+				return fmt.Errorf("retrieving %%s: `+"`model`"+` was nil", *id)
+			}
+
+			%[16]s
+			if %[14]s(ctx, *id, *payload); err != nil {
+				return fmt.Errorf("updating %%s: %%+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r %[3]s) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.%[9]s
+
+			id, err := %[7]s.Parse%[8]sID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if %[17]s {
+				return fmt.Errorf("deleting %%s: %%+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+%[18]s`, servicePackage, r.Pkg.ImportPath, r.StructName, models.String(), r.arguments(), r.ResourceType, r.Pkg.Name, r.idName(),
+		r.ClientPath, r.idFromConfig(), r.payload(), r.stateFromId(), unsupported, r.createCall(), r.readModel(), r.updateModel(), r.deleteCall(), r.expandFlattenFuncs())
+}
+
+func (r *typedResource) createCall() string {
+	if r.CreateMethod == "CreateOrUpdateThenPoll" || r.CreateMethod == "CreateThenPoll" {
+		return "err := client." + r.CreateMethod
+	}
+	return "_, err := client." + r.CreateMethod
+}
+
+func (r *typedResource) deleteCall() string {
+	if r.DeleteMethod == "DeleteThenPoll" {
+		return "err := client.DeleteThenPoll(ctx, *id); err != nil"
+	}
+	return "_, err := client.Delete(ctx, *id); err != nil"
+}
+
+// hclAttributes renders the attributes aligned in the same way as `terraform fmt`
+func hclAttributes(indent string, attrs [][2]string) string {
+	width := 0
+	for _, attr := range attrs {
+		width = max(width, len(attr[0]))
+	}
+	var b strings.Builder
+	for _, attr := range attrs {
+		fmt.Fprintf(&b, "%s%-*s = %s\n", indent, width, attr[0], attr[1])
+	}
+	return b.String()
+}
+
+func (r *typedResource) testConfigAttributes(complete bool) string {
+	attrs := [][2]string{
+		{"name", `"acctest-%[2]d"`},
+	}
+	var todo []string
+	for _, arg := range r.idArguments() {
+		if arg.SchemaName == "resource_group_name" {
+			attrs = append(attrs, [2]string{arg.SchemaName, "azurerm_resource_group.test.name"})
+			continue
+		}
+		todo = append(todo, arg.SchemaName)
+	}
+	if r.HasLocation {
+		if r.hasResourceGroup() {
+			attrs = append(attrs, [2]string{"location", "azurerm_resource_group.test.location"})
+		} else {
+			attrs = append(attrs, [2]string{"location", `"%[3]s"`})
+		}
+	}
+
+	out := hclAttributes("  ", attrs)
+	for _, f := range r.Fields {
+		if f.Required || complete {
+			todo = append(todo, f.SchemaName)
+		}
+	}
+	for _, name := range todo {
+		out += fmt.Sprintf("  # TODO: %s\n", name)
+	}
+	if complete && r.HasTags {
+		out += "\n  tags = {\n    ENV = \"Test\"\n  }\n"
+	}
+	return out
+}
+
+func (r *typedResource) hasResourceGroup() bool {
+	return slices.ContainsFunc(r.idArguments(), func(arg idArgument) bool {
+		return arg.SchemaName == "resource_group_name"
+	})
+}
+
+func (r *typedResource) TestCode(servicePackage, clientPath string) string {
+	testName := strings.TrimSuffix(r.StructName, "Resource")
+
+	template := "  # TODO: the dependencies of the Resource\n"
+	if r.hasResourceGroup() {
+		template = fmt.Sprintf(`resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%s-%%[1]d"
+  location = "%%[2]s"
+}
+`, servicePackage)
+	}
+
+	var requiresImport [][2]string
+	requiresImport = append(requiresImport, [2]string{"name", r.ResourceType + ".test.name"})
+	for _, arg := range r.idArguments() {
+		requiresImport = append(requiresImport, [2]string{arg.SchemaName, fmt.Sprintf("%s.test.%s", r.ResourceType, arg.SchemaName)})
+	}
+	if r.HasLocation {
+		requiresImport = append(requiresImport, [2]string{"location", r.ResourceType + ".test.location"})
+	}
+
+	return fmt.Sprintf(`// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package %[1]s_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"%[2]s"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type %[3]s struct{}
+
+func TestAcc%[4]s_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, %[5]q, "test")
+	r := %[3]s{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAcc%[4]s_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, %[5]q, "test")
+	r := %[3]s{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAcc%[4]s_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, %[5]q, "test")
+	r := %[3]s{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAcc%[4]s_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, %[5]q, "test")
+	r := %[3]s{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r %[3]s) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := %[6]s.Parse%[7]sID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.%[8]s.Get(ctx, *id)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %%s: %%+v", *id, err)
+	}
+
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (r %[3]s) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`+"`"+`
+provider "azurerm" {
+  features {}
+}
+
+%[9]s`+"`"+`, data.RandomInteger, data.Locations.Primary)
+}
+
+func (r %[3]s) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`+"`"+`
+%%[1]s
+
+resource %[5]q "test" {
+%[10]s}
+`+"`"+`, r.template(data), data.RandomInteger, data.Locations.Primary)
+}
+
+func (r %[3]s) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`+"`"+`
+%%s
+
+resource %[5]q "import" {
+%[11]s}
+`+"`"+`, r.basic(data))
+}
+
+func (r %[3]s) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`+"`"+`
+%%[1]s
+
+resource %[5]q "test" {
+%[12]s}
+`+"`"+`, r.template(data), data.RandomInteger, data.Locations.Primary)
+}
+`, servicePackage, r.Pkg.ImportPath, r.StructName, testName, r.ResourceType, r.Pkg.Name, r.idName(), clientPath,
+		template, r.testConfigAttributes(false), hclAttributes("  ", requiresImport), r.testConfigAttributes(true))
+}
+
+type sdkResourceOptions struct {
+	SdkPackage         string
+	ResourceType       string
+	ServicePackagePath string
+	Model              string
+	ClientPath         string
+	BrandName          string
+	RootDir            string
+	Website            bool
+}
+
+func runForSdkPackage(opts sdkResourceOptions) error {
+	if opts.ResourceType == "" || !strings.HasPrefix(opts.ResourceType, "azurerm_") {
+		return fmt.Errorf("the type of the Resource must be specified via `-name`, e.g. `azurerm_fluid_relay_server`")
+	}
+	if opts.ServicePackagePath == "" {
+		return fmt.Errorf("the path to the service package must be specified via `-service-package-path`")
+	}
+
+	pkg, err := parseSdkPackage(filepath.Join(opts.RootDir, "vendor", opts.SdkPackage), opts.SdkPackage)
+	if err != nil {
+		return fmt.Errorf("parsing the SDK package %q: %+v", opts.SdkPackage, err)
+	}
+
+	servicePackage := filepath.Base(opts.ServicePackagePath)
+	clientPath := opts.ClientPath
+	if clientPath == "" {
+		clientPath = snake2Camel(servicePackage) + "." + pkg.Client
+	}
+
+	r, err := newTypedResource(pkg, opts.ResourceType, opts.Model, clientPath)
+	if err != nil {
+		return err
+	}
+	for _, p := range r.Unsupported {
+		log.Printf("[WARN] the property %s is not supported and needs to be mapped by hand", p)
+	}
+
+	serviceDir := filepath.Join(opts.RootDir, opts.ServicePackagePath)
+	fileName := strings.TrimPrefix(opts.ResourceType, "azurerm_") + "_resource"
+	files := map[string]string{
+		fileName + ".go":      r.Code(servicePackage),
+		fileName + "_test.go": r.TestCode(servicePackage, clientPath),
+	}
+	for _, name := range sortedKeys(files) {
+		filePath := filepath.Join(serviceDir, name)
+		if _, err := os.Stat(filePath); err == nil {
+			return fmt.Errorf("%q already exists", filePath)
+		}
+		if err := goImportsAndWriteToFile(filePath, files[name]); err != nil {
+			return fmt.Errorf("generating %q: %+v", filePath, err)
+		}
+	}
+
+	if err := registerTypedResource(filepath.Join(serviceDir, "registration.go"), r.StructName); err != nil {
+		log.Printf("[WARN] %+v - `%s{}` needs to be registered by hand", err, r.StructName)
+	}
+
+	if opts.Website {
+		brandName := opts.BrandName
+		if brandName == "" {
+			brandName = r.Id.Description
+		}
+		cmd := exec.Command("go", "run", "./internal/tools/website-scaffold",
+			"-name", opts.ResourceType,
+			"-brand-name", brandName,
+			"-type", "resource",
+			"-resource-id", r.Id.ExampleId,
+			"-website-path", "website/")
+		cmd.Dir = opts.RootDir
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("scaffolding the documentation (the generated Resource must compile first): %+v", err)
+		}
+	}
+
+	return nil
+}
+
+// registerTypedResource appends the Resource to the typed Resources of the service registration
+func registerTypedResource(filePath, structName string) error {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	contents := string(data)
+
+	fn := "func (r Registration) Resources() []sdk.Resource {"
+	start := strings.Index(contents, fn)
+	if start == -1 {
+		return fmt.Errorf("no typed Resources were found in %q", filePath)
+	}
+	list := "[]sdk.Resource{"
+	idx := strings.Index(contents[start+len(fn):], list)
+	if idx == -1 {
+		return fmt.Errorf("no typed Resources were found in %q", filePath)
+	}
+	insertAt := start + len(fn) + idx + len(list)
+	end := strings.Index(contents[insertAt:], "}\n")
+	if end == -1 {
+		return fmt.Errorf("no typed Resources were found in %q", filePath)
+	}
+	existing := contents[insertAt : insertAt+end]
+	if strings.Contains(existing, structName+"{}") {
+		return nil
+	}
+
+	// the Resource is appended to the end of the list
+	entry := structName + "{},\n"
+	if strings.TrimSpace(existing) == "" {
+		entry = "\n" + entry
+	}
+	contents = contents[:insertAt+end] + entry + contents[insertAt+end:]
+	formatted, err := format.Source([]byte(contents))
+	if err != nil {
+		return fmt.Errorf("formatting %q: %+v", filePath, err)
+	}
+	return os.WriteFile(filePath, formatted, 0o644)
+}
+
+// goImportsAndWriteToFile formats the code and removes the imports which are unused by the generated code
+func goImportsAndWriteToFile(filePath, contents string) error {
+	formatted, err := imports.Process(filePath, []byte(contents), nil)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, formatted, 0o644)
+}
+
+func camel2Snake(input string) string {
+	runes := []rune(input)
+	var out []rune
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			continue
+		}
+		if unicode.IsUpper(r) {
+			if i > 0 && len(out) > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				out = append(out, '_')
+			}
+			r = unicode.ToLower(r)
+		}
+		out = append(out, r)
+	}
+	return string(out)
+}
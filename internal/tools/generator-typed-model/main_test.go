@@ -0,0 +1,165 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCamel2Snake(t *testing.T) {
+	cases := []struct {
+		in  string
+		out string
+	}{
+		{
+			"name",
+			"name",
+		},
+		{
+			"frsTenantId",
+			"frs_tenant_id",
+		},
+		{
+			"ipv4Address",
+			"ipv4_address",
+		},
+		{
+			"enableHTTPSTraffic",
+			"enable_https_traffic",
+		},
+		{
+			"@odata.type",
+			"odatatype",
+		},
+	}
+
+	for idx, c := range cases {
+		out := camel2Snake(c.in)
+		if c.out != out {
+			t.Fatalf("%d. %q (expect) != %q (actual)", idx, c.out, out)
+		}
+	}
+}
+
+func TestTypedResourceFromSdkPackage(t *testing.T) {
+	pkg, err := parseSdkPackage(filepath.Join("testdata", "widgets"), "example.com/widgets")
+	if err != nil {
+		t.Fatalf("parsing the SDK package: %+v", err)
+	}
+	if pkg.Client != "WidgetsClient" {
+		t.Fatalf("expected the client `WidgetsClient` but got %q", pkg.Client)
+	}
+	if id := pkg.ResourceIds["WidgetId"]; id == nil || id.Description != "Widget" {
+		t.Fatalf("expected the Resource ID `WidgetId` to be described as `Widget` but got %+v", id)
+	}
+	expectedId := "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resource-group/providers/Microsoft.Widgets/factories/factoryValue/widgets/widgetValue"
+	if id := pkg.ResourceIds["WidgetId"].ExampleId; id != expectedId {
+		t.Fatalf("expected the example Resource ID %q but got %q", expectedId, id)
+	}
+
+	r, err := newTypedResource(pkg, "azurerm_widget", "", "Widgets.WidgetsClient")
+	if err != nil {
+		t.Fatalf("building the Typed Resource: %+v", err)
+	}
+	if r.Model != "Widget" || r.CreateMethod != "CreateOrUpdateThenPoll" || r.DeleteMethod != "Delete" {
+		t.Fatalf("unexpected model %q or methods %q/%q", r.Model, r.CreateMethod, r.DeleteMethod)
+	}
+	if r.ParentId == nil || r.ParentId.Name != "FactoryId" {
+		t.Fatalf("expected the parent Resource ID to be `FactoryId` but got %+v", r.ParentId)
+	}
+
+	var fields []string
+	for _, f := range r.Fields {
+		fields = append(fields, f.SchemaName)
+	}
+	if actual := strings.Join(fields, ","); actual != "display_name,capacity,settings,sku" {
+		t.Fatalf("unexpected fields %q", actual)
+	}
+	if !r.Fields[0].Required || r.Fields[1].Required {
+		t.Fatalf("expected only `display_name` to be Required")
+	}
+	if len(r.Models) != 1 || !r.Models[0].List || r.Models[0].Single {
+		t.Fatalf("expected a single nested model used as a list but got %+v", r.Models)
+	}
+	if len(r.Unsupported) != 2 {
+		t.Fatalf("expected `metadata` and `WidgetSetting.parent` to be unsupported but got %+v", r.Unsupported)
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"widget_resource.go":      r.Code("widgets"),
+		"widget_resource_test.go": r.TestCode("widgets", r.ClientPath),
+	}
+	for name, code := range files {
+		if err := goImportsAndWriteToFile(filepath.Join(dir, name), code); err != nil {
+			t.Fatalf("formatting %s: %+v\n%s", name, err, code)
+		}
+	}
+
+	code, err := os.ReadFile(filepath.Join(dir, "widget_resource.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"id := widgets.NewWidgetID(parentId.SubscriptionId, parentId.ResourceGroupName, parentId.FactoryName, config.Name)",
+		`"factory_id": {`,
+		"ValidateFunc: widgets.ValidateFactoryID,",
+		"FactoryId: widgets.NewFactoryID(id.SubscriptionId, id.ResourceGroupName, id.FactoryName).ID(),",
+		`"identity": commonschema.SystemAssignedIdentityOptional(),`,
+		"state.Identity = identity.FlattenSystemAssignedToModel(model.Identity)",
+		"Sku:         pointer.To(widgets.SkuName(config.Sku)),",
+		"func expandWidgetSettings(input []WidgetSettingModel) *[]widgets.WidgetSetting {",
+		"func flattenWidgetSettings(input *[]widgets.WidgetSetting) []WidgetSettingModel {",
+		"// * metadata (*interface{})",
+	} {
+		if !strings.Contains(string(code), expected) {
+			t.Fatalf("expected the generated code to contain %q:\n%s", expected, code)
+		}
+	}
+	if strings.Contains(string(code), "go-azure-helpers/resourcemanager/location") {
+		t.Fatalf("expected the unused import of `location` to be removed")
+	}
+}
+
+func TestRegisterTypedResource(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "registration.go")
+	registration := `package widgets
+
+import "github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+
+type Registration struct{}
+
+func (r Registration) DataSources() []sdk.DataSource {
+	return []sdk.DataSource{}
+}
+
+func (r Registration) Resources() []sdk.Resource {
+	return []sdk.Resource{
+		FactoryResource{},
+	}
+}
+`
+	if err := os.WriteFile(filePath, []byte(registration), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// registering twice should be a no-op
+	for i := 0; i < 2; i++ {
+		if err := registerTypedResource(filePath, "WidgetResource"); err != nil {
+			t.Fatalf("registering the Resource: %+v", err)
+		}
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := strings.Replace(registration, "FactoryResource{},\n", "FactoryResource{},\n\t\tWidgetResource{},\n", 1)
+	if string(data) != expected {
+		t.Fatalf("unexpected registration:\n%s", data)
+	}
+}
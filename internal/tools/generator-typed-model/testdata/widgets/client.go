@@ -0,0 +1,9 @@
+package widgets
+
+import (
+	"github.com/hashicorp/go-azure-sdk/sdk/client/resourcemanager"
+)
+
+type WidgetsClient struct {
+	Client *resourcemanager.Client
+}
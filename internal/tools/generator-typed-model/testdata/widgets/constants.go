@@ -0,0 +1,15 @@
+package widgets
+
+type SkuName string
+
+const (
+	SkuNameBasic    SkuName = "Basic"
+	SkuNameStandard SkuName = "Standard"
+)
+
+func PossibleValuesForSkuName() []string {
+	return []string{
+		string(SkuNameBasic),
+		string(SkuNameStandard),
+	}
+}
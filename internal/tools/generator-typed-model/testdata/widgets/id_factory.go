@@ -0,0 +1,26 @@
+package widgets
+
+import (
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+)
+
+// FactoryId is a struct representing the Resource ID for a Factory
+type FactoryId struct {
+	SubscriptionId    string
+	ResourceGroupName string
+	FactoryName       string
+}
+
+// Segments returns a slice of Resource ID Segments which comprise this Factory ID
+func (id FactoryId) Segments() []resourceids.Segment {
+	return []resourceids.Segment{
+		resourceids.StaticSegment("staticSubscriptions", "subscriptions", "subscriptions"),
+		resourceids.SubscriptionIdSegment("subscriptionId", "12345678-1234-9876-4563-123456789012"),
+		resourceids.StaticSegment("staticResourceGroups", "resourceGroups", "resourceGroups"),
+		resourceids.ResourceGroupSegment("resourceGroupName", "example-resource-group"),
+		resourceids.StaticSegment("staticProviders", "providers", "providers"),
+		resourceids.ResourceProviderSegment("staticMicrosoftWidgets", "Microsoft.Widgets", "Microsoft.Widgets"),
+		resourceids.StaticSegment("staticFactories", "factories", "factories"),
+		resourceids.UserSpecifiedSegment("factoryName", "factoryValue"),
+	}
+}
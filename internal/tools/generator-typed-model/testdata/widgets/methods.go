@@ -0,0 +1,21 @@
+package widgets
+
+import (
+	"context"
+)
+
+func (c WidgetsClient) CreateOrUpdate(ctx context.Context, id WidgetId, input Widget) (result CreateOrUpdateOperationResponse, err error) {
+	return
+}
+
+func (c WidgetsClient) CreateOrUpdateThenPoll(ctx context.Context, id WidgetId, input Widget) error {
+	return nil
+}
+
+func (c WidgetsClient) Delete(ctx context.Context, id WidgetId) (result DeleteOperationResponse, err error) {
+	return
+}
+
+func (c WidgetsClient) Get(ctx context.Context, id WidgetId) (result GetOperationResponse, err error) {
+	return
+}
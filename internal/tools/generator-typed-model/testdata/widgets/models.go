@@ -0,0 +1,31 @@
+package widgets
+
+import (
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/identity"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/systemdata"
+)
+
+type Widget struct {
+	Id         *string                  `json:"id,omitempty"`
+	Identity   *identity.SystemAssigned `json:"identity,omitempty"`
+	Name       *string                  `json:"name,omitempty"`
+	Properties *WidgetProperties        `json:"properties,omitempty"`
+	SystemData *systemdata.SystemData   `json:"systemData,omitempty"`
+	Tags       *map[string]string       `json:"tags,omitempty"`
+	Type       *string                  `json:"type,omitempty"`
+}
+
+type WidgetProperties struct {
+	Capacity          *int64           `json:"capacity,omitempty"`
+	DisplayName       string           `json:"displayName"`
+	Metadata          *interface{}     `json:"metadata,omitempty"`
+	ProvisioningState *string          `json:"provisioningState,omitempty"`
+	Settings          *[]WidgetSetting `json:"settings,omitempty"`
+	Sku               *SkuName         `json:"sku,omitempty"`
+}
+
+type WidgetSetting struct {
+	Key    string         `json:"key"`
+	Parent *WidgetSetting `json:"parent,omitempty"`
+	Value  *string        `json:"value,omitempty"`
+}
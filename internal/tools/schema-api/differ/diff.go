@@ -5,114 +5,192 @@ package differ
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
 	schema_rules "github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/schema-rules"
 )
 
+const (
+	KindResource   = "resource"
+	KindDataSource = "data source"
+
+	ruleResourceRemoved   = "resource_removed"
+	ruleDataSourceRemoved = "data_source_removed"
+)
+
+// validationSchemaVersion is the first version of the schema dump which includes the validation for each property
+const validationSchemaVersion = 2
+
+// Violation is a breaking change detected between the base (released) schema and the current schema
+type Violation struct {
+	// Rule is the name of the Rule which was violated
+	Rule string `json:"rule"`
+
+	// Kind is either `resource` or `data source`
+	Kind string `json:"kind"`
+
+	// Name is the name of the Resource or Data Source, e.g. `azurerm_resource_group`
+	Name string `json:"name"`
+
+	// Path is the path to the property within the Resource or Data Source, e.g. `network_rules.ip_rules` - which
+	// is empty when the whole Resource or Data Source is affected
+	Path string `json:"path,omitempty"`
+
+	// Message describes the breaking change
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+	if v.Path == "" {
+		return fmt.Sprintf("%s %q: %s", v.Kind, v.Name, v.Message)
+	}
+	return fmt.Sprintf("%s %q property %q: %s", v.Kind, v.Name, v.Path, v.Message)
+}
+
 type Differ struct {
 	base    *providerjson.ProviderWrapper
 	current *providerjson.ProviderWrapper
 }
 
-func (d *Differ) Diff(fileName string, providerName string) []string {
+func (d *Differ) Diff(fileName string, providerName string) ([]Violation, error) {
 	if err := d.loadFromProvider(providerjson.LoadData(), providerName); err != nil {
-		return []string{err.Error()}
+		return nil, err
 	}
 
 	if err := d.loadFromFile(fileName); err != nil {
-		return []string{err.Error()}
+		return nil, err
 	}
 
 	if d.base.ProviderName != d.current.ProviderName {
-		return []string{fmt.Sprintf("provider name mismatch, expected %q, got %q", d.base.ProviderName, d.current.ProviderName)}
+		return nil, fmt.Errorf("provider name mismatch, expected %q, got %q", d.base.ProviderName, d.current.ProviderName)
 	}
 
-	violations := make([]string, 0)
+	return d.compare(), nil
+}
 
-	for resource, rs := range d.current.ProviderSchema.ResourcesMap {
-		_, ok := d.base.ProviderSchema.ResourcesMap[resource]
-		if !ok {
-			// New resource, no breaking changes to worry about
-			continue
+func (d *Differ) compare() []Violation {
+	return Compare(d.base, d.current)
+}
+
+// Compare returns the breaking changes between the base and current schemas
+func Compare(base *providerjson.ProviderWrapper, current *providerjson.ProviderWrapper) []Violation {
+	// dumps from prior versions don't include the validation, so this can't be compared
+	compareValidation := true
+	if v, err := strconv.Atoi(base.SchemaVersion); err != nil || v < validationSchemaVersion {
+		compareValidation = false
+	}
+
+	violations := make([]Violation, 0)
+	violations = append(violations, compareResources(KindResource, base.ProviderSchema.ResourcesMap, current.ProviderSchema.ResourcesMap, schema_rules.BreakingChangeRules, compareValidation)...)
+	violations = append(violations, compareResources(KindDataSource, base.ProviderSchema.DataSourcesMap, current.ProviderSchema.DataSourcesMap, schema_rules.BreakingChangeRulesDataSource, compareValidation)...)
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].Kind != violations[j].Kind {
+			return violations[i].Kind > violations[j].Kind
 		}
-		for propertyName, propertySchema := range rs.Schema {
-			// Get the same from the base (released) json
-			baseItem, ok := d.base.ProviderSchema.ResourcesMap[resource].Schema[propertyName]
-			if !ok {
-				// New property, could be breaking - Required etc
-				baseItem = providerjson.SchemaJSON{}
-			}
-			if errs := compareNodeResource(baseItem, propertySchema, propertyName); errs != nil {
-				violations = append(violations, errs...)
+		if violations[i].Name != violations[j].Name {
+			return violations[i].Name < violations[j].Name
+		}
+		return violations[i].Path < violations[j].Path
+	})
+
+	return violations
+}
+
+func compareResources(kind string, base map[string]providerjson.ResourceJSON, current map[string]providerjson.ResourceJSON, rules []schema_rules.BreakingChangeRule, compareValidation bool) []Violation {
+	violations := make([]Violation, 0)
+
+	for name := range base {
+		if _, ok := current[name]; !ok {
+			rule := ruleResourceRemoved
+			if kind == KindDataSource {
+				rule = ruleDataSourceRemoved
 			}
+			violations = append(violations, Violation{
+				Rule:    rule,
+				Kind:    kind,
+				Name:    name,
+				Message: fmt.Sprintf("the %s has been removed", kind),
+			})
 		}
 	}
 
-	for dataSource, ds := range d.current.ProviderSchema.DataSourcesMap {
-		_, ok := d.base.ProviderSchema.DataSourcesMap[dataSource]
+	for name, rs := range current {
+		baseResource, ok := base[name]
 		if !ok {
-			// New data source, no breaking changes to worry about
+			// New resource, no breaking changes to worry about
 			continue
 		}
-		for propertyName, propertySchema := range ds.Schema {
-			// Get the same from the base (released) json
-			baseItem, ok := d.base.ProviderSchema.DataSourcesMap[dataSource].Schema[propertyName]
-			if !ok {
-				// New property, could be breaking - Required etc
-				baseItem = providerjson.SchemaJSON{}
-			}
-			if errs := compareNodeDataSource(baseItem, propertySchema, propertyName); errs != nil {
-				violations = append(violations, errs...)
-			}
+
+		for _, v := range compareNodes(baseResource.Schema, rs.Schema, "", rules, compareValidation) {
+			v.Kind = kind
+			v.Name = name
+			violations = append(violations, v)
 		}
 	}
 
 	return violations
 }
 
-func compareNodeResource(base providerjson.SchemaJSON, current providerjson.SchemaJSON, nodeName string) (errs []string) {
-	if nodeIsBlock(base) {
-		newBaseRaw := base.Elem.(providerjson.ResourceJSON).Schema
-		newCurrent := current.Elem.(*providerjson.ResourceJSON).Schema
-		for k, newBase := range newBaseRaw {
-			errs = append(errs, compareNodeResource(newBase, newCurrent[k], k)...)
-		}
+// compareNodes compares each property within the base and current schemas (including those which have been added
+// or removed) - recursing into nested blocks
+func compareNodes(base map[string]providerjson.SchemaJSON, current map[string]providerjson.SchemaJSON, parentPath string, rules []schema_rules.BreakingChangeRule, compareValidation bool) (violations []Violation) {
+	propertyNames := make(map[string]struct{})
+	for k := range base {
+		propertyNames[k] = struct{}{}
+	}
+	for k := range current {
+		propertyNames[k] = struct{}{}
 	}
 
-	for _, v := range schema_rules.BreakingChangeRules {
-		if err := v.Check(base, current, nodeName); err != nil {
-			errs = append(errs, *err)
+	for propertyName := range propertyNames {
+		// a missing property in the base (released) schema is a new property, which could be breaking - Required etc
+		// whereas a missing property in the current schema has been removed
+		baseItem := base[propertyName]
+		currentItem := current[propertyName]
+		if !compareValidation {
+			baseItem.Validation = nil
+			currentItem.Validation = nil
 		}
-	}
 
-	return
-}
+		path := propertyName
+		if parentPath != "" {
+			path = fmt.Sprintf("%s.%s", parentPath, propertyName)
+		}
 
-func compareNodeDataSource(base providerjson.SchemaJSON, current providerjson.SchemaJSON, nodeName string) (errs []string) {
-	if nodeIsBlock(base) {
-		newBaseRaw := base.Elem.(providerjson.ResourceJSON).Schema
-		newCurrent := current.Elem.(*providerjson.ResourceJSON).Schema
-		for k, newBase := range newBaseRaw {
-			errs = append(errs, compareNodeDataSource(newBase, newCurrent[k], k)...)
+		if baseBlock, currentBlock := nodeAsBlock(baseItem), nodeAsBlock(currentItem); baseBlock != nil && currentBlock != nil {
+			violations = append(violations, compareNodes(baseBlock.Schema, currentBlock.Schema, path, rules, compareValidation)...)
 		}
-	}
 
-	for _, v := range schema_rules.BreakingChangeRulesDataSource {
-		if err := v.Check(base, current, nodeName); err != nil {
-			errs = append(errs, *err)
+		for _, rule := range rules {
+			if err := rule.Check(baseItem, currentItem, propertyName); err != nil {
+				violations = append(violations, Violation{
+					Rule:    rule.Name(),
+					Path:    path,
+					Message: *err,
+				})
+			}
 		}
 	}
 
 	return
 }
 
-func nodeIsBlock(input providerjson.SchemaJSON) bool {
-	if input.Type == providerjson.SchemaTypeList || input.Type == providerjson.SchemaTypeSet {
-		if _, ok := input.Elem.(providerjson.ResourceJSON); ok {
-			return true
-		}
+// nodeAsBlock returns the nested schema for a block - noting that the Elem is a value when loaded from a file
+// and a pointer when loaded from the provider
+func nodeAsBlock(input providerjson.SchemaJSON) *providerjson.ResourceJSON {
+	if input.Type != providerjson.SchemaTypeList && input.Type != providerjson.SchemaTypeSet {
+		return nil
+	}
+
+	switch v := input.Elem.(type) {
+	case providerjson.ResourceJSON:
+		return &v
+	case *providerjson.ResourceJSON:
+		return v
 	}
 
-	return false
+	return nil
 }
@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package differ
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+func TestDifferCompare(t *testing.T) {
+	base := &providerjson.ProviderWrapper{
+		ProviderName:  "azurerm",
+		SchemaVersion: "2",
+		ProviderSchema: &providerjson.ProviderSchemaJSON{
+			ResourcesMap: map[string]providerjson.ResourceJSON{
+				"azurerm_example": {
+					Schema: map[string]providerjson.SchemaJSON{
+						"name": {
+							Type:     "TypeString",
+							Required: true,
+							ForceNew: true,
+						},
+						"removed": {
+							Type:     "TypeString",
+							Optional: true,
+						},
+						"network_rules": {
+							Type:     providerjson.SchemaTypeList,
+							Optional: true,
+							Elem: providerjson.ResourceJSON{
+								Schema: map[string]providerjson.SchemaJSON{
+									"ip_rules": {
+										Type:     providerjson.SchemaTypeSet,
+										Optional: true,
+									},
+								},
+							},
+						},
+					},
+				},
+				"azurerm_removed": {
+					Schema: map[string]providerjson.SchemaJSON{},
+				},
+			},
+			DataSourcesMap: map[string]providerjson.ResourceJSON{},
+		},
+	}
+	current := &providerjson.ProviderWrapper{
+		ProviderName: "azurerm",
+		ProviderSchema: &providerjson.ProviderSchemaJSON{
+			ResourcesMap: map[string]providerjson.ResourceJSON{
+				"azurerm_example": {
+					Schema: map[string]providerjson.SchemaJSON{
+						"name": {
+							Type:     "TypeString",
+							Required: true,
+							ForceNew: true,
+						},
+						"network_rules": {
+							Type:     providerjson.SchemaTypeList,
+							Optional: true,
+							Elem: &providerjson.ResourceJSON{
+								Schema: map[string]providerjson.SchemaJSON{
+									"ip_rules": {
+										Type:     providerjson.SchemaTypeSet,
+										Optional: true,
+										ForceNew: true,
+										MaxItems: 10,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			DataSourcesMap: map[string]providerjson.ResourceJSON{},
+		},
+	}
+
+	d := Differ{
+		base:    base,
+		current: current,
+	}
+	actual := d.compare()
+
+	expected := []Violation{
+		{Rule: ruleResourceRemoved, Kind: KindResource, Name: "azurerm_removed"},
+		{Rule: "max_items_reduced", Kind: KindResource, Name: "azurerm_example", Path: "network_rules.ip_rules"},
+		{Rule: "new_force_new", Kind: KindResource, Name: "azurerm_example", Path: "network_rules.ip_rules"},
+		{Rule: "property_removed", Kind: KindResource, Name: "azurerm_example", Path: "removed"},
+	}
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %d violations but got %d: %+v", len(expected), len(actual), actual)
+	}
+	for _, e := range expected {
+		found := false
+		for _, a := range actual {
+			if a.Rule == e.Rule && a.Kind == e.Kind && a.Name == e.Name && a.Path == e.Path {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf("expected a violation %+v but didn't get one: %+v", e, actual)
+		}
+	}
+
+	buf := bytes.Buffer{}
+	if err := WriteReport(&buf, OutputFormatSARIF, actual); err != nil {
+		t.Fatalf("writing SARIF report: %+v", err)
+	}
+	var report sarifReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("parsing SARIF report: %+v", err)
+	}
+	if len(report.Runs) != 1 || len(report.Runs[0].Results) != len(expected) {
+		t.Fatalf("expected a single run with %d results, got %+v", len(expected), report)
+	}
+}
@@ -11,19 +11,29 @@ import (
 )
 
 func (d *Differ) loadFromFile(fileName string) error {
-	f, err := os.Open(fileName)
+	buf, err := LoadSchemaDump(fileName)
 	if err != nil {
 		return err
 	}
+	d.base = buf
+
+	return nil
+}
+
+// LoadSchemaDump loads a schema which was previously dumped/exported from the provider
+func LoadSchemaDump(fileName string) (*providerjson.ProviderWrapper, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
 	defer f.Close()
 	buf := &providerjson.ProviderWrapper{}
 	// TODO - Custom marshalling to fix the type assertions later? meh, works for now...
 	if err := json.NewDecoder(f).Decode(buf); err != nil {
-		return err
+		return nil, err
 	}
-	d.base = buf
 
-	return nil
+	return buf, nil
 }
 
 func (d *Differ) loadFromProvider(data *providerjson.ProviderJSON, providerName string) error {
@@ -0,0 +1,149 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package differ
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const (
+	OutputFormatText  = "text"
+	OutputFormatJSON  = "json"
+	OutputFormatSARIF = "sarif"
+)
+
+// WriteReport writes the violations to the writer in the specified format
+func WriteReport(w io.Writer, format string, violations []Violation) error {
+	switch strings.ToLower(format) {
+	case OutputFormatText:
+		for _, v := range violations {
+			if _, err := fmt.Fprintln(w, v.String()); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case OutputFormatJSON:
+		return writeJSON(w, violations)
+
+	case OutputFormatSARIF:
+		return writeJSON(w, sarifReportFor(violations))
+	}
+
+	return fmt.Errorf("unsupported output format %q - supported values are %q", format, []string{OutputFormatText, OutputFormatJSON, OutputFormatSARIF})
+}
+
+func writeJSON(w io.Writer, input interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(input)
+}
+
+// the subset of SARIF 2.1.0 needed to report violations
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type sarifReport struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	Id string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleId    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+func sarifReportFor(violations []Violation) sarifReport {
+	ruleIds := make(map[string]struct{})
+	results := make([]sarifResult, 0)
+	for _, v := range violations {
+		ruleIds[v.Rule] = struct{}{}
+
+		location := sarifLogicalLocation{
+			Name:               v.Name,
+			FullyQualifiedName: v.Name,
+			Kind:               "type",
+		}
+		if v.Path != "" {
+			segments := strings.Split(v.Path, ".")
+			location.Name = segments[len(segments)-1]
+			location.FullyQualifiedName = fmt.Sprintf("%s.%s", v.Name, v.Path)
+			location.Kind = "member"
+		}
+
+		results = append(results, sarifResult{
+			RuleId: v.Rule,
+			Level:  "error",
+			Message: sarifMessage{
+				Text: v.String(),
+			},
+			Locations: []sarifLocation{
+				{
+					LogicalLocations: []sarifLogicalLocation{location},
+				},
+			},
+		})
+	}
+
+	rules := make([]sarifRule, 0)
+	for id := range ruleIds {
+		rules = append(rules, sarifRule{Id: id})
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Id < rules[j].Id
+	})
+
+	return sarifReport{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:  "schema-api",
+						Rules: rules,
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+}
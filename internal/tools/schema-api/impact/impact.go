@@ -0,0 +1,190 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package impact
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/differ"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+const (
+	// CategoryBreakingChange is a breaking change between the baseline and current schemas
+	CategoryBreakingChange = "breaking_change"
+
+	// CategoryDeprecation is a Resource, Data Source or property which is deprecated in the current schema
+	CategoryDeprecation = "deprecation"
+
+	// CategoryFourPointOh is a change in behaviour when the Provider is running in 4.0 mode
+	CategoryFourPointOh = "four_point_oh"
+)
+
+// Finding is a change which impacts a Resource or Data Source used within the Terraform Configuration or State
+type Finding struct {
+	Category string `json:"category"`
+	Rule     string `json:"rule,omitempty"`
+	Address  string `json:"address"`
+	Type     string `json:"type"`
+	Source   string `json:"source"`
+	Path     string `json:"path,omitempty"`
+	Message  string `json:"message"`
+}
+
+func (f Finding) String() string {
+	if f.Path == "" {
+		return fmt.Sprintf("[%s] %s (%s): %s", f.Category, f.Address, f.Source, f.Message)
+	}
+	return fmt.Sprintf("[%s] %s (%s) %q: %s", f.Category, f.Address, f.Source, f.Path, f.Message)
+}
+
+// Input are the schemas and changes which the Usages are compared against
+type Input struct {
+	// BreakingChanges are the breaking changes between the baseline and current schemas
+	BreakingChanges []differ.Violation
+
+	// Current is the current schema, which is used to find deprecations
+	Current *providerjson.ProviderSchemaJSON
+
+	// FourPointOhChanges are the breaking changes between the current schema and the schema in 4.0 mode
+	FourPointOhChanges []differ.Violation
+
+	// FourPointOh is the current schema in 4.0 mode, which is used to find deprecations in 4.0
+	FourPointOh *providerjson.ProviderSchemaJSON
+}
+
+// Analyse returns the Findings which impact each of the Usages
+func Analyse(usages []Usage, input Input) []Finding {
+	findings := make([]Finding, 0)
+
+	for _, usage := range usages {
+		newFinding := func(category string, rule string, path string, message string) Finding {
+			return Finding{
+				Category: category,
+				Rule:     rule,
+				Address:  usage.Address,
+				Type:     usage.Type,
+				Source:   usage.Source,
+				Path:     path,
+				Message:  message,
+			}
+		}
+
+		for _, v := range input.BreakingChanges {
+			if violationApplies(v, usage) {
+				findings = append(findings, newFinding(CategoryBreakingChange, v.Rule, v.Path, v.Message))
+			}
+		}
+
+		currentDeprecations := deprecationsFor(input.Current, usage)
+		for path, message := range currentDeprecations {
+			findings = append(findings, newFinding(CategoryDeprecation, "", path, message))
+		}
+
+		for _, v := range input.FourPointOhChanges {
+			if violationApplies(v, usage) {
+				findings = append(findings, newFinding(CategoryFourPointOh, v.Rule, v.Path, v.Message))
+			}
+		}
+
+		for path, message := range deprecationsFor(input.FourPointOh, usage) {
+			if _, alreadyDeprecated := currentDeprecations[path]; alreadyDeprecated {
+				continue
+			}
+			findings = append(findings, newFinding(CategoryFourPointOh, "deprecated", path, message))
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Address != findings[j].Address {
+			return findings[i].Address < findings[j].Address
+		}
+		if findings[i].Category != findings[j].Category {
+			return findings[i].Category < findings[j].Category
+		}
+		return findings[i].Path < findings[j].Path
+	})
+
+	return findings
+}
+
+// violationApplies returns whether the breaking change affects the Usage
+func violationApplies(v differ.Violation, usage Usage) bool {
+	if v.Kind != usage.Kind || v.Name != usage.Type {
+		return false
+	}
+
+	// the whole Resource/Data Source is affected
+	if v.Path == "" {
+		return true
+	}
+
+	// a new Required property affects those which don't specify it, when the parent block is specified
+	if v.Rule == "new_required_property" {
+		parentPath := ""
+		if i := strings.LastIndex(v.Path, "."); i != -1 {
+			parentPath = v.Path[:i]
+		}
+		return (parentPath == "" || usage.Uses(parentPath)) && !usage.Uses(v.Path)
+	}
+
+	return usage.Uses(v.Path)
+}
+
+// deprecationsFor returns a map of the path to the deprecation message, for the Resource/Data Source and
+// each of the properties used
+func deprecationsFor(schema *providerjson.ProviderSchemaJSON, usage Usage) map[string]string {
+	out := make(map[string]string)
+	if schema == nil {
+		return out
+	}
+
+	resources := schema.ResourcesMap
+	if usage.Kind == KindDataSource {
+		resources = schema.DataSourcesMap
+	}
+	resource, ok := resources[usage.Type]
+	if !ok {
+		return out
+	}
+
+	if resource.DeprecationMessage != "" {
+		out[""] = resource.DeprecationMessage
+	}
+
+	for path := range usage.Paths {
+		if property := propertyAtPath(resource, path); property != nil && property.Deprecated != "" {
+			out[path] = property.Deprecated
+		}
+	}
+
+	return out
+}
+
+func propertyAtPath(resource providerjson.ResourceJSON, path string) *providerjson.SchemaJSON {
+	segments := strings.Split(path, ".")
+	schema := resource.Schema
+	for i, segment := range segments {
+		property, ok := schema[segment]
+		if !ok {
+			return nil
+		}
+		if i == len(segments)-1 {
+			return &property
+		}
+
+		switch v := property.Elem.(type) {
+		case providerjson.ResourceJSON:
+			schema = v.Schema
+		case *providerjson.ResourceJSON:
+			schema = v.Schema
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
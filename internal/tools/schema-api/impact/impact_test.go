@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package impact
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/differ"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+func TestAnalyse(t *testing.T) {
+	usages := []Usage{
+		{
+			Address: "azurerm_example.test",
+			Kind:    KindResource,
+			Type:    "azurerm_example",
+			Source:  "main.tf:1",
+			Paths: map[string]struct{}{
+				"name":                   {},
+				"legacy":                 {},
+				"network_rules":          {},
+				"network_rules.ip_rules": {},
+			},
+		},
+	}
+
+	schema := &providerjson.ProviderSchemaJSON{
+		ResourcesMap: map[string]providerjson.ResourceJSON{
+			"azurerm_example": {
+				Schema: map[string]providerjson.SchemaJSON{
+					"name": {
+						Type: "TypeString",
+					},
+					"legacy": {
+						Type:       "TypeString",
+						Deprecated: "`legacy` has been superseded by `modern`",
+					},
+				},
+			},
+		},
+	}
+	fourPointOh := &providerjson.ProviderSchemaJSON{
+		ResourcesMap: map[string]providerjson.ResourceJSON{
+			"azurerm_example": {
+				DeprecationMessage: "`azurerm_example` will be removed in 5.0",
+				Schema:             schema.ResourcesMap["azurerm_example"].Schema,
+			},
+		},
+	}
+
+	input := Input{
+		BreakingChanges: []differ.Violation{
+			// applies, since `network_rules.ip_rules` is used
+			{Rule: "new_force_new", Kind: differ.KindResource, Name: "azurerm_example", Path: "network_rules.ip_rules"},
+			// applies, since `sku` isn't specified
+			{Rule: "new_required_property", Kind: differ.KindResource, Name: "azurerm_example", Path: "sku"},
+			// doesn't apply, since `network_rules.subnet_ids` isn't specified
+			{Rule: "property_removed", Kind: differ.KindResource, Name: "azurerm_example", Path: "network_rules.subnet_ids"},
+			// doesn't apply, since `identity` isn't specified
+			{Rule: "new_required_property", Kind: differ.KindResource, Name: "azurerm_example", Path: "identity.type"},
+			// doesn't apply to a different resource
+			{Rule: "resource_removed", Kind: differ.KindResource, Name: "azurerm_other"},
+		},
+		Current: schema,
+		FourPointOhChanges: []differ.Violation{
+			{Rule: "property_removed", Kind: differ.KindResource, Name: "azurerm_example", Path: "legacy"},
+		},
+		FourPointOh: fourPointOh,
+	}
+
+	findings := Analyse(usages, input)
+
+	expected := map[string]string{
+		CategoryBreakingChange + ":network_rules.ip_rules": "new_force_new",
+		CategoryBreakingChange + ":sku":                    "new_required_property",
+		CategoryDeprecation + ":legacy":                    "",
+		CategoryFourPointOh + ":legacy":                    "property_removed",
+		CategoryFourPointOh + ":":                          "deprecated",
+	}
+	if len(findings) != len(expected) {
+		t.Fatalf("expected %d findings but got %d: %+v", len(expected), len(findings), findings)
+	}
+	for _, f := range findings {
+		rule, ok := expected[f.Category+":"+f.Path]
+		if !ok || rule != f.Rule {
+			t.Fatalf("unexpected finding %+v", f)
+		}
+		if f.Address != "azurerm_example.test" || f.Source != "main.tf:1" {
+			t.Fatalf("unexpected address/source for finding %+v", f)
+		}
+	}
+}
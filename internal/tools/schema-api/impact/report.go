@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package impact
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/differ"
+)
+
+// WriteReport writes the findings to the writer in the specified format, either `text` or `json`
+func WriteReport(w io.Writer, format string, findings []Finding) error {
+	switch strings.ToLower(format) {
+	case differ.OutputFormatText:
+		if len(findings) == 0 {
+			_, err := fmt.Fprintln(w, "No Resources or Data Sources are impacted")
+			return err
+		}
+
+		counts := make(map[string]int)
+		for _, f := range findings {
+			counts[f.Category]++
+			if _, err := fmt.Fprintln(w, f.String()); err != nil {
+				return err
+			}
+		}
+		_, err := fmt.Fprintf(w, "\n%d breaking changes, %d deprecations and %d 4.0 behaviour changes\n", counts[CategoryBreakingChange], counts[CategoryDeprecation], counts[CategoryFourPointOh])
+		return err
+
+	case differ.OutputFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(findings)
+	}
+
+	return fmt.Errorf("unsupported output format %q - supported values are %q", format, []string{differ.OutputFormatText, differ.OutputFormatJSON})
+}
@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package impact
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/differ"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+type Options struct {
+	// BaselineFileName is the path to the schema dump for the version of the provider currently in use
+	BaselineFileName string
+
+	// ConfigurationDirectory is the path to a directory containing Terraform Configuration (`.tf` files)
+	ConfigurationDirectory string
+
+	// StateFileName is the path to a Terraform State file
+	StateFileName string
+
+	// FourPointOhFileName is the path to a schema dump for the current version of the provider built in 4.0 mode,
+	// using the `fourpointoh` build tag (e.g. `go run -tags fourpointoh internal/tools/schema-api/main.go -export {path}`)
+	FourPointOhFileName string
+
+	ProviderName string
+}
+
+// Run determines the impact of upgrading from the baseline schema to the current schema (and 4.0 mode) on
+// the Resources and Data Sources used within the Terraform Configuration and/or State
+func Run(opts Options) ([]Finding, error) {
+	if opts.ConfigurationDirectory == "" && opts.StateFileName == "" {
+		return nil, fmt.Errorf("at least one of a Configuration Directory or a State File must be specified")
+	}
+	if features.FourPointOh() {
+		return nil, fmt.Errorf("the current schema must be loaded from a provider built without the `fourpointoh` build tag - the schema in 4.0 mode is loaded from the `FourPointOhFileName`")
+	}
+
+	usages := make([]Usage, 0)
+	if opts.ConfigurationDirectory != "" {
+		v, err := LoadConfiguration(opts.ConfigurationDirectory, opts.ProviderName)
+		if err != nil {
+			return nil, fmt.Errorf("loading the Terraform Configuration: %+v", err)
+		}
+		usages = append(usages, v...)
+	}
+	if opts.StateFileName != "" {
+		v, err := LoadState(opts.StateFileName, opts.ProviderName)
+		if err != nil {
+			return nil, fmt.Errorf("loading the Terraform State: %+v", err)
+		}
+		usages = append(usages, v...)
+	}
+
+	current, err := loadFromProvider(opts.ProviderName)
+	if err != nil {
+		return nil, fmt.Errorf("loading the current schema: %+v", err)
+	}
+
+	input := Input{
+		Current: current.ProviderSchema,
+	}
+
+	if opts.BaselineFileName != "" {
+		baseline, err := differ.LoadSchemaDump(opts.BaselineFileName)
+		if err != nil {
+			return nil, fmt.Errorf("loading the baseline schema from %q: %+v", opts.BaselineFileName, err)
+		}
+		if baseline.ProviderName != current.ProviderName {
+			return nil, fmt.Errorf("provider name mismatch, expected %q, got %q", baseline.ProviderName, current.ProviderName)
+		}
+		input.BreakingChanges = differ.Compare(baseline, current)
+	}
+
+	if opts.FourPointOhFileName != "" {
+		fourPointOh, err := differ.LoadSchemaDump(opts.FourPointOhFileName)
+		if err != nil {
+			return nil, fmt.Errorf("loading the schema in 4.0 mode from %q: %+v", opts.FourPointOhFileName, err)
+		}
+		if fourPointOh.ProviderName != current.ProviderName {
+			return nil, fmt.Errorf("provider name mismatch, expected %q, got %q", current.ProviderName, fourPointOh.ProviderName)
+		}
+		input.FourPointOh = fourPointOh.ProviderSchema
+		input.FourPointOhChanges = differ.Compare(current, fourPointOh)
+	}
+
+	return Analyse(usages, input), nil
+}
+
+func loadFromProvider(providerName string) (*providerjson.ProviderWrapper, error) {
+	s, err := providerjson.ProviderFromRaw(providerjson.LoadData())
+	if err != nil {
+		return nil, err
+	}
+
+	return &providerjson.ProviderWrapper{
+		ProviderName:   providerName,
+		SchemaVersion:  providerjson.SchemaVersion,
+		ProviderSchema: s,
+	}, nil
+}
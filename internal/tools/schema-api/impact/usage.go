@@ -0,0 +1,280 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package impact
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+const (
+	KindResource   = "resource"
+	KindDataSource = "data source"
+)
+
+// Usage describes a Resource or Data Source used within a Terraform Configuration or State
+type Usage struct {
+	// Address is the address of the Resource or Data Source, e.g. `module.example.azurerm_resource_group.test`
+	Address string
+
+	// Kind is either `resource` or `data source`
+	Kind string
+
+	// Type is the type of the Resource or Data Source, e.g. `azurerm_resource_group`
+	Type string
+
+	// Source is where this was found, e.g. `main.tf:12` or the path to the State file
+	Source string
+
+	// Paths are the properties (and nested blocks) which are used, e.g. `network_rules` and `network_rules.ip_rules`
+	Paths map[string]struct{}
+}
+
+// Uses returns whether the property at the path (or anything nested within it) is used
+func (u Usage) Uses(path string) bool {
+	if _, ok := u.Paths[path]; ok {
+		return true
+	}
+
+	for k := range u.Paths {
+		if strings.HasPrefix(k, path+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// meta-arguments which can be specified within any Resource or Data Source block
+var metaArguments = map[string]struct{}{
+	"count":       {},
+	"depends_on":  {},
+	"for_each":    {},
+	"lifecycle":   {},
+	"provider":    {},
+	"provisioner": {},
+	"connection":  {},
+	"timeouts":    {},
+}
+
+// LoadConfiguration parses each of the `.tf` files within the directory (and any subdirectories, excluding
+// `.terraform`) returning the Resources and Data Sources for this Provider
+func LoadConfiguration(directory string, providerName string) ([]Usage, error) {
+	files := make([]string, 0)
+	err := filepath.WalkDir(directory, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".terraform" {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() && strings.HasSuffix(path, ".tf") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("finding the Terraform Configuration files within %q: %+v", directory, err)
+	}
+	sort.Strings(files)
+
+	usages := make([]Usage, 0)
+	for _, fileName := range files {
+		src, err := os.ReadFile(fileName)
+		if err != nil {
+			return nil, fmt.Errorf("reading %q: %+v", fileName, err)
+		}
+
+		file, diags := hclsyntax.ParseConfig(src, fileName, hcl.InitialPos)
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("parsing %q: %s", fileName, diags.Error())
+		}
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		relativePath, err := filepath.Rel(directory, fileName)
+		if err != nil {
+			relativePath = fileName
+		}
+
+		for _, block := range body.Blocks {
+			if len(block.Labels) != 2 || !strings.HasPrefix(block.Labels[0], providerName+"_") {
+				continue
+			}
+
+			usage := Usage{
+				Type:   block.Labels[0],
+				Source: fmt.Sprintf("%s:%d", relativePath, block.DefRange().Start.Line),
+				Paths:  make(map[string]struct{}),
+			}
+			switch block.Type {
+			case "resource":
+				usage.Kind = KindResource
+				usage.Address = fmt.Sprintf("%s.%s", block.Labels[0], block.Labels[1])
+			case "data":
+				usage.Kind = KindDataSource
+				usage.Address = fmt.Sprintf("data.%s.%s", block.Labels[0], block.Labels[1])
+			default:
+				continue
+			}
+
+			pathsFromBody(block.Body, "", usage.Paths)
+			usages = append(usages, usage)
+		}
+	}
+
+	return usages, nil
+}
+
+func pathsFromBody(body *hclsyntax.Body, parentPath string, paths map[string]struct{}) {
+	pathFor := func(name string) string {
+		if parentPath == "" {
+			return name
+		}
+		return fmt.Sprintf("%s.%s", parentPath, name)
+	}
+
+	for name := range body.Attributes {
+		if _, isMetaArgument := metaArguments[name]; isMetaArgument && parentPath == "" {
+			continue
+		}
+		paths[pathFor(name)] = struct{}{}
+	}
+
+	for _, block := range body.Blocks {
+		if _, isMetaArgument := metaArguments[block.Type]; isMetaArgument && parentPath == "" {
+			continue
+		}
+
+		if block.Type == "dynamic" && len(block.Labels) == 1 {
+			path := pathFor(block.Labels[0])
+			paths[path] = struct{}{}
+			for _, nested := range block.Body.Blocks {
+				if nested.Type == "content" {
+					pathsFromBody(nested.Body, path, paths)
+				}
+			}
+			continue
+		}
+
+		path := pathFor(block.Type)
+		paths[path] = struct{}{}
+		pathsFromBody(block.Body, path, paths)
+	}
+}
+
+type stateFile struct {
+	Resources []stateResource `json:"resources"`
+}
+
+type stateResource struct {
+	Module    string          `json:"module,omitempty"`
+	Mode      string          `json:"mode"`
+	Type      string          `json:"type"`
+	Name      string          `json:"name"`
+	Instances []stateInstance `json:"instances"`
+}
+
+type stateInstance struct {
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+// LoadState parses the Terraform State (as returned from `terraform state pull`) returning the Resources and
+// Data Sources for this Provider. Since the State includes Computed values, only properties with a non-empty
+// value are considered to be used.
+func LoadState(fileName string, providerName string) ([]Usage, error) {
+	src, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("reading %q: %+v", fileName, err)
+	}
+
+	var state stateFile
+	if err := json.Unmarshal(src, &state); err != nil {
+		return nil, fmt.Errorf("parsing %q: %+v", fileName, err)
+	}
+
+	usages := make([]Usage, 0)
+	for _, resource := range state.Resources {
+		if !strings.HasPrefix(resource.Type, providerName+"_") {
+			continue
+		}
+
+		usage := Usage{
+			Type:   resource.Type,
+			Source: fileName,
+			Paths:  make(map[string]struct{}),
+		}
+		switch resource.Mode {
+		case "managed":
+			usage.Kind = KindResource
+			usage.Address = fmt.Sprintf("%s.%s", resource.Type, resource.Name)
+		case "data":
+			usage.Kind = KindDataSource
+			usage.Address = fmt.Sprintf("data.%s.%s", resource.Type, resource.Name)
+		default:
+			continue
+		}
+		if resource.Module != "" {
+			usage.Address = fmt.Sprintf("%s.%s", resource.Module, usage.Address)
+		}
+
+		for _, instance := range resource.Instances {
+			pathsFromAttributes(instance.Attributes, "", usage.Paths)
+		}
+		usages = append(usages, usage)
+	}
+
+	return usages, nil
+}
+
+func pathsFromAttributes(attributes map[string]interface{}, parentPath string, paths map[string]struct{}) {
+	for name, value := range attributes {
+		if isEmptyValue(value) {
+			continue
+		}
+
+		path := name
+		if parentPath != "" {
+			path = fmt.Sprintf("%s.%s", parentPath, name)
+		}
+		paths[path] = struct{}{}
+
+		// nested blocks are stored as a list of objects
+		if items, ok := value.([]interface{}); ok {
+			for _, item := range items {
+				if nested, ok := item.(map[string]interface{}); ok {
+					pathsFromAttributes(nested, path, paths)
+				}
+			}
+		}
+	}
+}
+
+func isEmptyValue(input interface{}) bool {
+	switch v := input.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package impact
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestLoadConfiguration(t *testing.T) {
+	directory := t.TempDir()
+	config := `
+resource "azurerm_storage_account" "example" {
+  name     = "example"
+  count    = 2
+  location = "West Europe"
+
+  network_rules {
+    default_action = "Deny"
+  }
+
+  dynamic "blob_properties" {
+    for_each = [1]
+    content {
+      versioning_enabled = true
+    }
+  }
+
+  lifecycle {
+    ignore_changes = [tags]
+  }
+}
+
+data "azurerm_client_config" "current" {}
+
+resource "random_string" "example" {
+  length = 8
+}
+`
+	if err := os.WriteFile(filepath.Join(directory, "main.tf"), []byte(config), 0o600); err != nil {
+		t.Fatalf("writing config: %+v", err)
+	}
+
+	usages, err := LoadConfiguration(directory, "azurerm")
+	if err != nil {
+		t.Fatalf("loading configuration: %+v", err)
+	}
+	if len(usages) != 2 {
+		t.Fatalf("expected 2 usages but got %d: %+v", len(usages), usages)
+	}
+
+	if usages[0].Address != "azurerm_storage_account.example" || usages[0].Kind != KindResource || usages[0].Source != "main.tf:2" {
+		t.Fatalf("unexpected usage: %+v", usages[0])
+	}
+	expected := []string{
+		"blob_properties",
+		"blob_properties.versioning_enabled",
+		"location",
+		"name",
+		"network_rules",
+		"network_rules.default_action",
+	}
+	if actual := sortedPaths(usages[0]); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected the paths %+v but got %+v", expected, actual)
+	}
+
+	if usages[1].Address != "data.azurerm_client_config.current" || usages[1].Kind != KindDataSource {
+		t.Fatalf("unexpected usage: %+v", usages[1])
+	}
+}
+
+func TestLoadState(t *testing.T) {
+	state := `{
+  "version": 4,
+  "resources": [
+    {
+      "module": "module.storage",
+      "mode": "managed",
+      "type": "azurerm_storage_account",
+      "name": "example",
+      "instances": [
+        {
+          "attributes": {
+            "name": "example",
+            "is_hns_enabled": false,
+            "tags": {},
+            "network_rules": [
+              {
+                "default_action": "Deny",
+                "ip_rules": []
+              }
+            ]
+          }
+        }
+      ]
+    },
+    {
+      "mode": "managed",
+      "type": "random_string",
+      "name": "example",
+      "instances": []
+    }
+  ]
+}`
+	fileName := filepath.Join(t.TempDir(), "terraform.tfstate")
+	if err := os.WriteFile(fileName, []byte(state), 0o600); err != nil {
+		t.Fatalf("writing state: %+v", err)
+	}
+
+	usages, err := LoadState(fileName, "azurerm")
+	if err != nil {
+		t.Fatalf("loading state: %+v", err)
+	}
+	if len(usages) != 1 {
+		t.Fatalf("expected 1 usage but got %d: %+v", len(usages), usages)
+	}
+	if usages[0].Address != "module.storage.azurerm_storage_account.example" {
+		t.Fatalf("unexpected address %q", usages[0].Address)
+	}
+
+	expected := []string{
+		"name",
+		"network_rules",
+		"network_rules.default_action",
+	}
+	if actual := sortedPaths(usages[0]); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected the paths %+v but got %+v", expected, actual)
+	}
+}
+
+func sortedPaths(input Usage) []string {
+	out := make([]string, 0)
+	for k := range input.Paths {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
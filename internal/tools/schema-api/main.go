@@ -14,6 +14,7 @@ import (
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/differ"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/impact"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
 )
 
@@ -26,6 +27,13 @@ func main() {
 	exportSchema := f.String("export", "", "export the schema to the given path/filename. Intended for use in the release process")
 	detectBreakingChanges := f.String("detect", "", "compare current schema to named dump.")
 	errorOnBreakingChange := f.Bool("error-on-violation", false, "should the detect mode exit with a non-zero error code. Defaults to `false`")
+	outputFormat := f.String("output-format", differ.OutputFormatText, "the format of the report from the detect (`text`, `json` or `sarif`) or impact (`text` or `json`) modes. Defaults to `text`")
+	outputPath := f.String("output", "", "the path to write the report from the detect or impact modes to. Defaults to stdout")
+	upgradeImpact := f.Bool("impact", false, "report the impact of upgrading to the current schema on the Terraform Configuration and/or State")
+	impactBaseline := f.String("baseline", "", "the schema dump for the version of the provider currently in use, for the impact mode")
+	impactConfig := f.String("config", "", "the directory containing the Terraform Configuration, for the impact mode")
+	impactState := f.String("state", "", "the Terraform State (e.g. from `terraform state pull`), for the impact mode")
+	impactFourPointOh := f.String("four-point-oh", "", "the schema dump for the current version of the provider built in 4.0 mode (using the `fourpointoh` build tag), for the impact mode")
 
 	if err := f.Parse(os.Args[1:]); err != nil {
 		fmt.Printf("error parsing args: %+v", err)
@@ -41,7 +49,7 @@ func main() {
 			log.Printf("dumping schema for '%s'", *providerName)
 			wrappedProvider := &providerjson.ProviderWrapper{
 				ProviderName:  *providerName,
-				SchemaVersion: "1",
+				SchemaVersion: providerjson.SchemaVersion,
 			}
 			if err := providerjson.DumpWithWrapper(wrappedProvider, data); err != nil {
 				log.Fatalf("error dumping provider: %+v", err)
@@ -53,14 +61,58 @@ func main() {
 	case pointer.From(detectBreakingChanges) != "":
 		{
 			d := differ.Differ{}
-			if violations := d.Diff(*detectBreakingChanges, *providerName); violations != nil {
-				for _, v := range violations {
-					log.Println(v)
+			violations, err := d.Diff(*detectBreakingChanges, *providerName)
+			if err != nil {
+				log.Fatalf("error detecting breaking changes: %+v", err)
+			}
+
+			output := os.Stdout
+			if path := pointer.From(outputPath); path != "" {
+				output, err = os.Create(path)
+				if err != nil {
+					log.Fatalf("error creating %q: %+v", path, err)
 				}
-				if pointer.From(errorOnBreakingChange) {
-					os.Exit(1)
+			}
+			if err := differ.WriteReport(output, *outputFormat, violations); err != nil {
+				log.Fatalf("error writing report: %+v", err)
+			}
+			output.Close()
+
+			if len(violations) > 0 && pointer.From(errorOnBreakingChange) {
+				os.Exit(1)
+			}
+
+			os.Exit(0)
+		}
+
+	case pointer.From(upgradeImpact):
+		{
+			findings, err := impact.Run(impact.Options{
+				BaselineFileName:       pointer.From(impactBaseline),
+				ConfigurationDirectory: pointer.From(impactConfig),
+				StateFileName:          pointer.From(impactState),
+				FourPointOhFileName:    pointer.From(impactFourPointOh),
+				ProviderName:           *providerName,
+			})
+			if err != nil {
+				log.Fatalf("error determining the upgrade impact: %+v", err)
+			}
+
+			output := os.Stdout
+			if path := pointer.From(outputPath); path != "" {
+				output, err = os.Create(path)
+				if err != nil {
+					log.Fatalf("error creating %q: %+v", path, err)
 				}
 			}
+			if err := impact.WriteReport(output, *outputFormat, findings); err != nil {
+				log.Fatalf("error writing report: %+v", err)
+			}
+			output.Close()
+
+			if len(findings) > 0 && pointer.From(errorOnBreakingChange) {
+				os.Exit(1)
+			}
 
 			os.Exit(0)
 		}
@@ -70,7 +122,7 @@ func main() {
 			log.Printf("dumping schema for '%s'", *providerName)
 			wrappedProvider := &providerjson.ProviderWrapper{
 				ProviderName:  *providerName,
-				SchemaVersion: "1",
+				SchemaVersion: providerjson.SchemaVersion,
 			}
 			if err := providerjson.WriteWithWrapper(wrappedProvider, data, *exportSchema); err != nil {
 				log.Fatalf("error writing provider schema for %q to %q: %+v", *providerName, *exportSchema, err)
@@ -19,6 +19,9 @@ const (
 	SchemaTypeFloat  = "Float"
 )
 
+// SchemaVersion is the version of the schema dump - version 2 includes the validation for each property
+const SchemaVersion = "2"
+
 type ProviderJSON schema.Provider
 
 type SchemaJSON struct {
@@ -33,6 +36,9 @@ type SchemaJSON struct {
 	Elem        interface{} `json:"elem,omitempty"`
 	MaxItems    int         `json:"maxItems,omitempty"`
 	MinItems    int         `json:"minItems,omitempty"`
+	Deprecated  string      `json:"deprecated,omitempty"`
+
+	Validation *ValidationJSON `json:"validation,omitempty"`
 }
 
 func (b *SchemaJSON) UnmarshalJSON(body []byte) error {
@@ -48,11 +54,15 @@ func (b *SchemaJSON) UnmarshalJSON(body []byte) error {
 	b.Description, _ = m["description"].(string)
 	b.Computed, _ = m["computed"].(bool)
 	b.ForceNew, _ = m["forceNew"].(bool)
+	b.Deprecated, _ = m["deprecated"].(string)
 	if max, ok := m["maxItems"].(float64); ok {
 		b.MaxItems = int(max)
 	}
 	if min, ok := m["minItems"].(float64); ok {
-		b.MaxItems = int(min)
+		b.MinItems = int(min)
+	}
+	if v, ok := m["validation"].(map[string]interface{}); ok {
+		b.Validation = validationFromMap(v)
 	}
 
 	if def, ok := m["default"]; ok && def != nil {
@@ -84,8 +94,9 @@ func (b *SchemaJSON) UnmarshalJSON(body []byte) error {
 }
 
 type ResourceJSON struct {
-	Schema   map[string]SchemaJSON `json:"schema"`
-	Timeouts *ResourceTimeoutJSON  `json:"timeouts,omitempty"`
+	Schema             map[string]SchemaJSON `json:"schema"`
+	Timeouts           *ResourceTimeoutJSON  `json:"timeouts,omitempty"`
+	DeprecationMessage string                `json:"deprecationMessage,omitempty"`
 }
 
 type ResourceTimeoutJSON struct {
@@ -96,7 +107,7 @@ type ResourceTimeoutJSON struct {
 }
 
 func LoadData() *ProviderJSON {
-	p := provider.AzureProvider()
+	p := provider.AzureProviderSchema()
 	return (*ProviderJSON)(p)
 }
 
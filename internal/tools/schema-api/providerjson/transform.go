@@ -21,6 +21,7 @@ func resourceFromRaw(input *schema.Resource) (*ResourceJSON, error) {
 		translatedSchema[k] = schemaFromRaw(s)
 	}
 	result.Schema = translatedSchema
+	result.DeprecationMessage = input.DeprecationMessage
 
 	if input.Timeouts != nil {
 		timeouts := &ResourceTimeoutJSON{}
@@ -57,6 +58,8 @@ func schemaFromRaw(input *schema.Schema) SchemaJSON {
 		Elem:        decodeElem(input.Elem),
 		MaxItems:    input.MaxItems,
 		MinItems:    input.MinItems,
+		Deprecated:  input.Deprecated,
+		Validation:  validationFromRaw(input),
 	}
 }
 
@@ -110,6 +113,14 @@ func SchemaFromMap(input map[string]interface{}) SchemaJSON {
 		result.MaxItems = int(t.(float64))
 	}
 
+	if t, ok := input["deprecated"]; ok {
+		result.Deprecated = t.(string)
+	}
+
+	if t, ok := input["validation"]; ok {
+		result.Validation = validationFromMap(t.(map[string]interface{}))
+	}
+
 	return result
 }
 
@@ -150,6 +161,14 @@ func decodeElem(input interface{}) interface{} {
 	case *schema.Resource:
 		r, _ := resourceFromRaw(t)
 		return r
+	case map[string]interface{}:
+		// nested elements loaded from a schema dump, see SchemaJSON.UnmarshalJSON
+		if s, ok := t["schema"].(map[string]interface{}); ok {
+			return ResourceFromMap(s)
+		}
+		if v, ok := t["type"].(string); ok {
+			return v
+		}
 	}
 	return nil
 }
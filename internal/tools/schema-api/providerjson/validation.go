@@ -0,0 +1,172 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providerjson
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ValidationJSON describes the validation applied to a property. Since validation functions can't be
+// serialised, the constraints are determined by probing the validation function with values which are
+// expected to be invalid, and parsing the errors returned from the validation functions within the
+// Plugin SDK - as such these are only populated where they can be determined.
+type ValidationJSON struct {
+	Func          string   `json:"func,omitempty"`
+	AllowedValues []string `json:"allowedValues,omitempty"`
+	MinValue      *int     `json:"minValue,omitempty"`
+	MaxValue      *int     `json:"maxValue,omitempty"`
+	MinLength     *int     `json:"minLength,omitempty"`
+	MaxLength     *int     `json:"maxLength,omitempty"`
+	Pattern       string   `json:"pattern,omitempty"`
+}
+
+var (
+	validationAllowedValuesRegex = regexp.MustCompile(`to be one of (\[.*\]), got`)
+	validationQuotedStringRegex  = regexp.MustCompile(`"(?:[^"\\]|\\.)*"`)
+	validationIntRangeRegex      = regexp.MustCompile(`to be in the range \((-?\d+) - (-?\d+)\), got`)
+	validationIntAtLeastRegex    = regexp.MustCompile(`to be at least \((-?\d+)\), got`)
+	validationIntAtMostRegex     = regexp.MustCompile(`to be at most \((-?\d+)\), got`)
+	validationLengthRegex        = regexp.MustCompile(`expected length of .* to be in the range \((\d+) - (\d+)\), got`)
+	validationPatternRegex       = regexp.MustCompile(`to match regular expression ("(?:[^"\\]|\\.)*"), got`)
+)
+
+// validationProbeLength is the length of the string used to probe for a maximum length
+const validationProbeLength = 65537
+
+func validationFromRaw(input *schema.Schema) *ValidationJSON {
+	if input.ValidateFunc == nil && input.ValidateDiagFunc == nil {
+		return nil
+	}
+
+	result := &ValidationJSON{}
+	if input.ValidateFunc == nil {
+		result.Func = funcName(input.ValidateDiagFunc)
+		return result
+	}
+
+	result.Func = funcName(input.ValidateFunc)
+	probes := make([]interface{}, 0)
+	switch input.Type {
+	case schema.TypeString:
+		probes = append(probes, "", "\x00schema-api-probe\x00", strings.Repeat("a", validationProbeLength))
+	case schema.TypeInt:
+		probes = append(probes, math.MinInt32, math.MaxInt32)
+	}
+
+	for _, probe := range probes {
+		for _, err := range probeValidateFunc(input.ValidateFunc, probe) {
+			parseValidationError(result, err.Error())
+		}
+	}
+
+	return result
+}
+
+// probeValidateFunc calls the validation function with the probe value - recovering from any panic, since
+// custom validation functions may not expect the value
+func probeValidateFunc(f schema.SchemaValidateFunc, probe interface{}) (errs []error) {
+	defer func() {
+		if r := recover(); r != nil {
+			errs = nil
+		}
+	}()
+
+	_, errs = f(probe, "probe")
+	return errs
+}
+
+func parseValidationError(result *ValidationJSON, message string) {
+	if m := validationAllowedValuesRegex.FindStringSubmatch(message); m != nil {
+		values := make([]string, 0)
+		for _, raw := range validationQuotedStringRegex.FindAllString(m[1], -1) {
+			if v, err := strconv.Unquote(raw); err == nil {
+				values = append(values, v)
+			}
+		}
+		result.AllowedValues = values
+		return
+	}
+
+	if m := validationLengthRegex.FindStringSubmatch(message); m != nil {
+		result.MinLength = parseValidationInt(m[1])
+		result.MaxLength = parseValidationInt(m[2])
+		return
+	}
+
+	if m := validationIntRangeRegex.FindStringSubmatch(message); m != nil {
+		result.MinValue = parseValidationInt(m[1])
+		result.MaxValue = parseValidationInt(m[2])
+		return
+	}
+
+	if m := validationIntAtLeastRegex.FindStringSubmatch(message); m != nil {
+		result.MinValue = parseValidationInt(m[1])
+		return
+	}
+
+	if m := validationIntAtMostRegex.FindStringSubmatch(message); m != nil {
+		result.MaxValue = parseValidationInt(m[1])
+		return
+	}
+
+	if m := validationPatternRegex.FindStringSubmatch(message); m != nil {
+		if v, err := strconv.Unquote(m[1]); err == nil {
+			result.Pattern = v
+		}
+	}
+}
+
+func parseValidationInt(input string) *int {
+	v, err := strconv.Atoi(input)
+	if err != nil {
+		return nil
+	}
+	return pointer.To(v)
+}
+
+func funcName(input interface{}) string {
+	f := runtime.FuncForPC(reflect.ValueOf(input).Pointer())
+	if f == nil {
+		return fmt.Sprintf("%T", input)
+	}
+	return f.Name()
+}
+
+func validationFromMap(input map[string]interface{}) *ValidationJSON {
+	result := &ValidationJSON{}
+	result.Func, _ = input["func"].(string)
+	result.Pattern, _ = input["pattern"].(string)
+
+	if v, ok := input["allowedValues"].([]interface{}); ok {
+		values := make([]string, 0)
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		result.AllowedValues = values
+	}
+
+	intFromMap := func(key string) *int {
+		if v, ok := input[key].(float64); ok {
+			return pointer.To(int(v))
+		}
+		return nil
+	}
+	result.MinValue = intFromMap("minValue")
+	result.MaxValue = intFromMap("maxValue")
+	result.MinLength = intFromMap("minLength")
+	result.MaxLength = intFromMap("maxLength")
+
+	return result
+}
@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providerjson
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func TestValidationFromRaw(t *testing.T) {
+	if v := validationFromRaw(&schema.Schema{Type: schema.TypeString}); v != nil {
+		t.Fatalf("expected no validation, got %+v", v)
+	}
+
+	v := validationFromRaw(&schema.Schema{
+		Type:         schema.TypeString,
+		ValidateFunc: validation.StringInSlice([]string{"Basic", "Premium"}, false),
+	})
+	if expected := []string{"Basic", "Premium"}; !reflect.DeepEqual(v.AllowedValues, expected) {
+		t.Fatalf("expected allowed values %+v, got %+v", expected, v.AllowedValues)
+	}
+
+	v = validationFromRaw(&schema.Schema{
+		Type:         schema.TypeInt,
+		ValidateFunc: validation.IntBetween(1, 30),
+	})
+	if v.MinValue == nil || *v.MinValue != 1 || v.MaxValue == nil || *v.MaxValue != 30 {
+		t.Fatalf("expected the range (1 - 30), got %+v", v)
+	}
+
+	v = validationFromRaw(&schema.Schema{
+		Type:         schema.TypeString,
+		ValidateFunc: validation.StringLenBetween(3, 24),
+	})
+	if v.MinLength == nil || *v.MinLength != 3 || v.MaxLength == nil || *v.MaxLength != 24 {
+		t.Fatalf("expected the length (3 - 24), got %+v", v)
+	}
+}
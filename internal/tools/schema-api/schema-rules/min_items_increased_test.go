@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var minItemsIncreasedBaseNode = providerjson.SchemaJSON{
+	Type:     providerjson.SchemaTypeList,
+	Optional: true,
+	MinItems: 1,
+}
+
+var minItemsIncreasedPasses = providerjson.SchemaJSON{
+	Type:     providerjson.SchemaTypeList,
+	Optional: true,
+	MinItems: 1,
+}
+
+var minItemsIncreasedViolates = providerjson.SchemaJSON{
+	Type:     providerjson.SchemaTypeList,
+	Optional: true,
+	MinItems: 2, // violation
+}
+
+func TestMinItemsIncreased_Check(t *testing.T) {
+	data := minItemsIncreased{}
+	if res := data.Check(minItemsIncreasedBaseNode, minItemsIncreasedPasses, ""); res != nil {
+		t.Errorf("expected no violation, got %+v", res)
+	}
+	if res := data.Check(minItemsIncreasedBaseNode, minItemsIncreasedViolates, ""); res == nil {
+		t.Errorf("expected violation, but didn't get one")
+	}
+}
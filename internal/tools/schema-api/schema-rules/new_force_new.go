@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var _ BreakingChangeRule = newForceNew{}
+
+type newForceNew struct{}
+
+func (newForceNew) Name() string {
+	return "new_force_new"
+}
+
+// Check - Checks that an existing property isn't updated to become ForceNew, since changing it would then replace the resource
+func (newForceNew) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	if base.Type != "" && !base.ForceNew && current.ForceNew {
+		return pointer.To(fmt.Sprintf("cannot make the existing property %q ForceNew, as changing it would replace the resource", propertyName))
+	}
+
+	return nil
+}
@@ -6,17 +6,27 @@ package schema_rules
 import "github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
 
 type BreakingChangeRule interface {
+	// Name is the identifier for this Rule, which is used in the machine-readable reports
+	Name() string
+
 	Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string
 }
 
 var BreakingChangeRules = []BreakingChangeRule{
 	becomeComputedOnly{},
+	maxItemsReduced{},
+	minItemsIncreased{},
+	newForceNew{},
 	newRequiredPropertyExistingResource{},
 	optionalRemoveComputed{},
 	optionalToRequired{},
+	propertyRemoved{},
 	propertyType{},
+	validationNarrowed{},
 }
 
 var BreakingChangeRulesDataSource = []BreakingChangeRule{
+	propertyRemoved{},
 	propertyType{},
+	validationNarrowed{},
 }
@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var _ BreakingChangeRule = validationNarrowed{}
+
+type validationNarrowed struct{}
+
+func (validationNarrowed) Name() string {
+	return "validation_narrowed"
+}
+
+// Check - Checks that the validation for an existing (user-specifiable) property hasn't been tightened, such that
+// values which were previously valid would now be rejected
+func (validationNarrowed) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	if base.Type == "" || current.Type == "" || !(current.Optional || current.Required) || current.Validation == nil {
+		return nil
+	}
+
+	if base.Validation == nil {
+		return pointer.To(fmt.Sprintf("cannot add validation (%s) to the existing property %q", current.Validation.Func, propertyName))
+	}
+
+	b := *base.Validation
+	c := *current.Validation
+	reasons := make([]string, 0)
+
+	if len(c.AllowedValues) > 0 {
+		if len(b.AllowedValues) == 0 {
+			reasons = append(reasons, fmt.Sprintf("the values are now limited to %q", c.AllowedValues))
+		} else {
+			removed := make([]string, 0)
+			for _, v := range b.AllowedValues {
+				if !containsString(c.AllowedValues, v) {
+					removed = append(removed, v)
+				}
+			}
+			if len(removed) > 0 {
+				reasons = append(reasons, fmt.Sprintf("the values %q are no longer allowed", removed))
+			}
+		}
+	}
+
+	if narrowedMinimum(b.MinValue, c.MinValue) || narrowedMaximum(b.MaxValue, c.MaxValue) {
+		reasons = append(reasons, fmt.Sprintf("the allowed range has changed from %s to %s", formatRange(b.MinValue, b.MaxValue), formatRange(c.MinValue, c.MaxValue)))
+	}
+
+	if narrowedMinimum(b.MinLength, c.MinLength) || narrowedMaximum(b.MaxLength, c.MaxLength) {
+		reasons = append(reasons, fmt.Sprintf("the allowed length has changed from %s to %s", formatRange(b.MinLength, b.MaxLength), formatRange(c.MinLength, c.MaxLength)))
+	}
+
+	if c.Pattern != "" && c.Pattern != b.Pattern {
+		reasons = append(reasons, fmt.Sprintf("the regular expression has changed from %q to %q", b.Pattern, c.Pattern))
+	}
+
+	if len(reasons) > 0 {
+		return pointer.To(fmt.Sprintf("validation for property %q has been narrowed: %s", propertyName, strings.Join(reasons, ", ")))
+	}
+
+	return nil
+}
+
+func containsString(input []string, value string) bool {
+	for _, v := range input {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func narrowedMinimum(base *int, current *int) bool {
+	if current == nil {
+		return false
+	}
+	return base == nil || *current > *base
+}
+
+func narrowedMaximum(base *int, current *int) bool {
+	if current == nil {
+		return false
+	}
+	return base == nil || *current < *base
+}
+
+func formatRange(min *int, max *int) string {
+	format := func(input *int) string {
+		if input == nil {
+			return "unbounded"
+		}
+		return fmt.Sprintf("%d", *input)
+	}
+	return fmt.Sprintf("(%s - %s)", format(min), format(max))
+}
@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+func TestValidationNarrowed_Check(t *testing.T) {
+	node := func(validation *providerjson.ValidationJSON) providerjson.SchemaJSON {
+		return providerjson.SchemaJSON{
+			Type:       providerjson.SchemaTypeString,
+			Optional:   true,
+			Validation: validation,
+		}
+	}
+
+	testData := []struct {
+		name      string
+		base      providerjson.SchemaJSON
+		current   providerjson.SchemaJSON
+		violation bool
+	}{
+		{
+			name:    "no validation",
+			base:    node(nil),
+			current: node(nil),
+		},
+		{
+			name:      "validation added",
+			base:      node(nil),
+			current:   node(&providerjson.ValidationJSON{Func: "validation.StringIsNotEmpty"}),
+			violation: true,
+		},
+		{
+			name:    "value added",
+			base:    node(&providerjson.ValidationJSON{AllowedValues: []string{"Basic"}}),
+			current: node(&providerjson.ValidationJSON{AllowedValues: []string{"Basic", "Premium"}}),
+		},
+		{
+			name:      "value removed",
+			base:      node(&providerjson.ValidationJSON{AllowedValues: []string{"Basic", "Premium"}}),
+			current:   node(&providerjson.ValidationJSON{AllowedValues: []string{"Premium"}}),
+			violation: true,
+		},
+		{
+			name:      "values introduced",
+			base:      node(&providerjson.ValidationJSON{Func: "validation.StringIsNotEmpty"}),
+			current:   node(&providerjson.ValidationJSON{AllowedValues: []string{"Premium"}}),
+			violation: true,
+		},
+		{
+			name:    "range widened",
+			base:    node(&providerjson.ValidationJSON{MinValue: pointer.To(1), MaxValue: pointer.To(10)}),
+			current: node(&providerjson.ValidationJSON{MinValue: pointer.To(0), MaxValue: pointer.To(100)}),
+		},
+		{
+			name:      "range narrowed",
+			base:      node(&providerjson.ValidationJSON{MinValue: pointer.To(1), MaxValue: pointer.To(10)}),
+			current:   node(&providerjson.ValidationJSON{MinValue: pointer.To(1), MaxValue: pointer.To(5)}),
+			violation: true,
+		},
+		{
+			name:      "length narrowed",
+			base:      node(&providerjson.ValidationJSON{MaxLength: pointer.To(64)}),
+			current:   node(&providerjson.ValidationJSON{MinLength: pointer.To(3), MaxLength: pointer.To(64)}),
+			violation: true,
+		},
+		{
+			name:      "pattern changed",
+			base:      node(&providerjson.ValidationJSON{Pattern: "^[a-z]+$"}),
+			current:   node(&providerjson.ValidationJSON{Pattern: "^[a-z]{3,}$"}),
+			violation: true,
+		},
+		{
+			name: "computed only",
+			base: node(nil),
+			current: providerjson.SchemaJSON{
+				Type:       providerjson.SchemaTypeString,
+				Computed:   true,
+				Validation: &providerjson.ValidationJSON{Func: "validation.StringIsNotEmpty"},
+			},
+		},
+	}
+
+	data := validationNarrowed{}
+	for _, v := range testData {
+		res := data.Check(v.base, v.current, "example")
+		if v.violation && res == nil {
+			t.Errorf("%s: expected violation, but didn't get one", v.name)
+		}
+		if !v.violation && res != nil {
+			t.Errorf("%s: expected no violation, got %+v", v.name, *res)
+		}
+	}
+}